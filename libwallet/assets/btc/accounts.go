@@ -104,6 +104,13 @@ func (asset *Asset) GetAccountBalance(accountNumber int32) (*sharedW.Balance, er
 		return nil, utils.ErrBTCNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		if accountNumber != sharedW.WatchedAccountNumber {
+			return &sharedW.Balance{}, nil
+		}
+		return asset.watchedBalance()
+	}
+
 	balance, err := asset.Internal().BTC.CalculateAccountBalances(uint32(accountNumber), asset.RequiredConfirmations())
 	if err != nil {
 		return nil, err
@@ -122,6 +129,17 @@ func (asset *Asset) SpendableForAccount(account int32) (int64, error) {
 		return -1, utils.ErrBTCNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		if account != sharedW.WatchedAccountNumber {
+			return 0, nil
+		}
+		balance, err := asset.watchedBalance()
+		if err != nil {
+			return 0, err
+		}
+		return balance.Spendable.ToInt(), nil
+	}
+
 	bals, err := asset.Internal().BTC.CalculateAccountBalances(uint32(account), asset.RequiredConfirmations())
 	if err != nil {
 		return 0, utils.TranslateError(err)
@@ -136,6 +154,13 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 		return nil, utils.ErrBTCNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		if account != sharedW.WatchedAccountNumber {
+			return []*sharedW.UnspentOutput{}, nil
+		}
+		return asset.watchedUnspentOutputs()
+	}
+
 	accountName, err := asset.AccountName(account)
 	if err != nil {
 		return nil, err
@@ -31,6 +31,10 @@ func (asset *Asset) HaveAddress(address string) bool {
 		return false
 	}
 
+	if asset.IsAddressWatchWallet() {
+		return asset.HasWatchedAddress(address)
+	}
+
 	addr, err := btcutil.DecodeAddress(address, asset.chainParams)
 	if err != nil {
 		return false
@@ -95,6 +99,10 @@ func (asset *Asset) CurrentAddress(account int32) (string, error) {
 		return "", utils.ErrBTCNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		return asset.WatchedAddresses[0], nil
+	}
+
 	addr, err := asset.Internal().BTC.CurrentAddress(uint32(account), GetScope())
 	if err != nil {
 		log.Errorf("CurrentAddress error: %v", err)
@@ -115,6 +123,10 @@ func (asset *Asset) NextAddress(account int32) (string, error) {
 		return "", utils.ErrBTCNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		return "", errors.New(utils.ErrAddressWatchWallet)
+	}
+
 	// NewAddress returns the next external chained address for a wallet.
 	address, err := asset.Internal().BTC.NewAddress(uint32(account), GetScope())
 	if err != nil {
@@ -0,0 +1,173 @@
+package btc
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/descriptor"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// AccountDescriptors returns the BIP380 output descriptors of the receive and
+// change addresses of account. The descriptors include the key origin if the
+// fingerprint of the wallet's master key is known.
+func (asset *Asset) AccountDescriptors(account int32) ([]string, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrBTCNotInitialized
+	}
+
+	props, err := asset.Internal().BTC.AccountProperties(GetScope(), uint32(account))
+	if err != nil {
+		return nil, err
+	}
+	if props.AccountPubKey == nil {
+		return nil, errors.New(utils.ErrInvalid)
+	}
+	// The wallet keys use the BIP84 versions, descriptors use the standard
+	// ones.
+	key, err := props.AccountPubKey.CloneWithVersion(asset.chainParams.HDPublicKeyID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := props.MasterKeyFingerprint
+	if fingerprint == 0 {
+		fingerprint = uint32(asset.ReadLongConfigValueForKey(sharedW.MasterKeyFingerprintConfigKey, 0))
+	}
+	var origin *descriptor.Origin
+	if fingerprint != 0 {
+		origin = &descriptor.Origin{
+			Fingerprint: fingerprint,
+			Path: []uint32{
+				GetScope().Purpose + hdkeychain.HardenedKeyStart,
+				GetScope().Coin + hdkeychain.HardenedKeyStart,
+				key.ChildIndex(),
+			},
+		}
+	}
+
+	descriptors := make([]string, 0, 2)
+	for _, branch := range []uint32{0, 1} {
+		desc := &descriptor.Descriptor{
+			Script:   descriptor.ScriptWPKH,
+			Origin:   origin,
+			Key:      key.String(),
+			Branches: []uint32{branch},
+		}
+		descriptors = append(descriptors, desc.String())
+	}
+	return descriptors, nil
+}
+
+// parseWatchOnlyKey returns the account extended public key of a watch only
+// wallet and the fingerprint of its master key, if known. s is either the
+// extended public key or the whitespace separated wpkh descriptors of the
+// account.
+func parseWatchOnlyKey(s string, params *chaincfg.Params) (*hdkeychain.ExtendedKey, uint32, error) {
+	if !descriptor.IsDescriptor(s) {
+		key, err := hdkeychain.NewKeyFromString(strings.TrimSpace(s))
+		if err != nil {
+			return nil, 0, err
+		}
+		return key, 0, nil
+	}
+
+	var keyStr string
+	var origin *descriptor.Origin
+	for i, desc := range strings.Fields(s) {
+		d, err := descriptor.Parse(desc)
+		if err != nil {
+			return nil, 0, err
+		}
+		// The wallet only derives the native segwit addresses of the
+		// receive and change branches.
+		if d.Script != descriptor.ScriptWPKH {
+			return nil, 0, descriptor.ErrUnsupported
+		}
+		for _, branch := range d.Branches {
+			if branch > 1 {
+				return nil, 0, descriptor.ErrUnsupported
+			}
+		}
+		if i == 0 {
+			keyStr, origin = d.Key, d.Origin
+			continue
+		}
+		if d.Key != keyStr || (d.Origin == nil) != (origin == nil) ||
+			(origin != nil && d.Origin.Fingerprint != origin.Fingerprint) {
+			return nil, 0, errors.New(utils.ErrInvalid)
+		}
+	}
+
+	key, err := hdkeychain.NewKeyFromString(keyStr)
+	if err != nil {
+		return nil, 0, err
+	}
+	if key.IsPrivate() || !key.IsForNet(params) {
+		return nil, 0, errors.New(utils.ErrInvalid)
+	}
+	var fingerprint uint32
+	if origin != nil {
+		fingerprint = origin.Fingerprint
+	}
+	return key, fingerprint, nil
+}
+
+// AccountKeyMatches checks if the extended public key of account is the key
+// of s, an extended public key or the descriptors of an account. The key
+// versions are ignored, so a standard key matches the BIP84 key of the
+// account.
+func (asset *Asset) AccountKeyMatches(account uint32, s string) (bool, error) {
+	key, _, err := parseWatchOnlyKey(s, asset.chainParams)
+	if err != nil {
+		return false, err
+	}
+	props, err := asset.Internal().BTC.AccountProperties(GetScope(), account)
+	if err != nil {
+		return false, err
+	}
+	if props.AccountPubKey == nil {
+		return false, nil
+	}
+
+	version := asset.chainParams.HDPublicKeyID[:]
+	accountKey, err := props.AccountPubKey.CloneWithVersion(version)
+	if err != nil {
+		return false, err
+	}
+	key, err = key.CloneWithVersion(version)
+	if err != nil {
+		return false, err
+	}
+	return accountKey.String() == key.String(), nil
+}
+
+// saveMasterKeyFingerprint saves the fingerprint of the master key of the
+// wallet seed, which btcwallet does not record for the accounts derived from
+// the seed. The fingerprint is only used in the exported descriptors, so
+// failures are logged.
+func (asset *Asset) saveMasterKeyFingerprint(seedMnemonic, seedPassphrase string) {
+	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, seedPassphrase, asset.Type)
+	if err != nil {
+		log.Errorf("Error decoding the wallet seed: %v", err)
+		return
+	}
+	master, err := hdkeychain.NewMaster(seed, asset.chainParams)
+	if err != nil {
+		log.Errorf("Error deriving the wallet master key: %v", err)
+		return
+	}
+	pubKey, err := master.ECPubKey()
+	if err != nil {
+		log.Errorf("Error deriving the wallet master key: %v", err)
+		return
+	}
+
+	fingerprint := binary.LittleEndian.Uint32(btcutil.Hash160(pubKey.SerializeCompressed())[:4])
+	asset.SaveUserConfigValue(sharedW.MasterKeyFingerprintConfigKey, int64(fingerprint))
+}
@@ -0,0 +1,499 @@
+package btc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/gcs/builder"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/walletdb"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// errProbeRollback rolls back the accounts created to derive the keys of the
+// accounts probed by a deep recovery.
+var errProbeRollback = errors.New("probe rollback")
+
+// recoveryBranch is a branch of an account scanned by a deep recovery.
+type recoveryBranch struct {
+	account uint32
+	branch  uint32
+	key     *hdkeychain.ExtendedKey
+	// known is the number of addresses of the branch tracked by the wallet
+	// before the recovery, and derived the number of addresses scanned.
+	known    uint32
+	derived  uint32
+	lastUsed int64
+}
+
+// recoveryAddress is an address scanned by a deep recovery.
+type recoveryAddress struct {
+	branch *recoveryBranch
+	index  uint32
+}
+
+// recoveryScan is the state of a deep recovery.
+type recoveryScan struct {
+	asset           *Asset
+	gapLimit        uint32
+	accountGapLimit uint32
+	branches        []*recoveryBranch
+	names           map[uint32]string
+	scripts         map[string]*recoveryAddress
+	// added are the scripts added since the start of the current pass.
+	added [][]byte
+	// lastAccount is the last account of the wallet, and lastProbed the
+	// last account probed if the accounts are discovered.
+	lastAccount uint32
+	lastProbed  uint32
+	probing     bool
+}
+
+// DeepRecovery discovers the used accounts and addresses of the wallet with
+// the gap limits provided, scanning the compact filters of the blocks from the
+// wallet birthday. The accounts are only discovered if the private passphrase
+// is provided. The addresses found are added to the wallet and their
+// transactions rescanned. The progress of every pass of the scan is published
+// to the sync progress listeners as address discovery progress.
+func (asset *Asset) DeepRecovery(ctx context.Context, gapLimit, accountGapLimit uint32, privatePassphrase string) (*sharedW.DeepRecoveryReport, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrBTCNotInitialized
+	}
+	if asset.IsAddressWatchWallet() {
+		return nil, errors.New(utils.ErrAddressWatchWallet)
+	}
+	if gapLimit == 0 {
+		return nil, errors.New(utils.ErrInvalid)
+	}
+	if !asset.IsSynced() {
+		return nil, errors.E(utils.ErrNotSynced)
+	}
+	if asset.IsRescanning() || !atomic.CompareAndSwapUint32(&asset.recovering, 0, 1) {
+		return nil, errors.E(utils.ErrSyncAlreadyInProgress)
+	}
+	defer atomic.StoreUint32(&asset.recovering, 0)
+
+	// New accounts can only be derived by an unlocked wallet.
+	probing := accountGapLimit > 0 && privatePassphrase != "" && !asset.IsWatchingOnlyWallet()
+	if probing {
+		if err := asset.UnlockWallet(privatePassphrase); err != nil {
+			return nil, err
+		}
+		defer asset.LockWallet()
+	}
+
+	startHeight, err := asset.recoveryStartHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &recoveryScan{
+		asset:           asset,
+		gapLimit:        gapLimit,
+		accountGapLimit: accountGapLimit,
+		names:           make(map[uint32]string),
+		scripts:         make(map[string]*recoveryAddress),
+		probing:         probing,
+	}
+	if err := rs.loadAccounts(); err != nil {
+		return nil, err
+	}
+	if probing {
+		if err := rs.probeAccounts(rs.lastAccount + accountGapLimit); err != nil {
+			return nil, err
+		}
+	}
+
+	startTime := time.Now()
+	if err := asset.scanRecovery(ctx, rs, startHeight, startTime); err != nil {
+		return nil, err
+	}
+
+	report, addrs, err := asset.applyRecovery(rs)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) > 0 {
+		if err := asset.rescanBlocks(startHeight, addrs); err != nil {
+			return nil, err
+		}
+	}
+	asset.publishRecoveryProgress(startTime, 100)
+
+	asset.SetInt32ConfigValueForKey(sharedW.GapLimitConfigKey, int32(gapLimit))
+	asset.SetInt32ConfigValueForKey(sharedW.AccountGapLimitConfigKey, int32(accountGapLimit))
+
+	report.GapLimit = gapLimit
+	report.AccountGapLimit = accountGapLimit
+	report.StartHeight = startHeight
+	return report, nil
+}
+
+// recoveryStartHeight returns the height of the wallet birthday block, or 0 if
+// it is not set.
+func (asset *Asset) recoveryStartHeight() (int32, error) {
+	var height int32
+	err := walletdb.View(asset.Internal().BTC.Database(), func(dbtx walletdb.ReadTx) error {
+		ns := dbtx.ReadBucket(wAddrMgrBkt)
+		birthday, _, err := asset.Internal().BTC.Manager.BirthdayBlock(ns)
+		if err != nil {
+			if waddrmgr.IsError(err, waddrmgr.ErrBirthdayBlockNotSet) {
+				return nil
+			}
+			return err
+		}
+		height = birthday.Height
+		return nil
+	})
+	return height, err
+}
+
+// loadAccounts adds the branches of the accounts of the wallet to the scan.
+func (rs *recoveryScan) loadAccounts() error {
+	scopedKM, err := rs.asset.Internal().BTC.Manager.FetchScopedKeyManager(GetScope())
+	if err != nil {
+		return err
+	}
+	return walletdb.View(rs.asset.Internal().BTC.Database(), func(dbtx walletdb.ReadTx) error {
+		ns := dbtx.ReadBucket(wAddrMgrBkt)
+		lastAccount, err := scopedKM.LastAccount(ns)
+		if err != nil {
+			return err
+		}
+		for account := uint32(0); account <= lastAccount; account++ {
+			props, err := scopedKM.AccountProperties(ns, account)
+			if err != nil {
+				return err
+			}
+			rs.names[account] = props.AccountName
+			err = rs.addAccount(account, props.AccountPubKey, props.ExternalKeyCount, props.InternalKeyCount)
+			if err != nil {
+				return err
+			}
+		}
+		rs.lastAccount = lastAccount
+		rs.lastProbed = lastAccount
+		return nil
+	})
+}
+
+// probeAccounts adds the branches of the accounts after the last probed
+// account up to lastAccount to the scan.
+func (rs *recoveryScan) probeAccounts(lastAccount uint32) error {
+	if lastAccount > waddrmgr.MaxAccountNum {
+		lastAccount = waddrmgr.MaxAccountNum
+	}
+	for ; rs.lastProbed < lastAccount; rs.lastProbed++ {
+		key, err := rs.asset.probeAccountKey(rs.lastProbed + 1)
+		if err != nil {
+			return err
+		}
+		if err := rs.addAccount(rs.lastProbed+1, key, 0, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// probeAccountKey returns the extended public key of an account not created
+// yet. The account is created to derive the key and rolled back.
+func (asset *Asset) probeAccountKey(account uint32) (*hdkeychain.ExtendedKey, error) {
+	scopedKM, err := asset.Internal().BTC.Manager.FetchScopedKeyManager(GetScope())
+	if err != nil {
+		return nil, err
+	}
+
+	var key *hdkeychain.ExtendedKey
+	err = walletdb.Update(asset.Internal().BTC.Database(), func(dbtx walletdb.ReadWriteTx) error {
+		ns := dbtx.ReadWriteBucket(wAddrMgrBkt)
+		if err := scopedKM.NewRawAccount(ns, account); err != nil {
+			return err
+		}
+		props, err := scopedKM.AccountProperties(ns, account)
+		if err != nil {
+			return err
+		}
+		key = props.AccountPubKey
+		return errProbeRollback
+	})
+	// The account info was cached by the rolled back transaction.
+	scopedKM.InvalidateAccountCache(account)
+	if err != errProbeRollback {
+		return nil, err
+	}
+	return key, nil
+}
+
+// addAccount adds the branches of an account to the scan, with the number of
+// addresses of the branches tracked by the wallet.
+func (rs *recoveryScan) addAccount(account uint32, key *hdkeychain.ExtendedKey, externalCount, internalCount uint32) error {
+	for branch, known := range []uint32{externalCount, internalCount} {
+		branchKey, err := key.Derive(uint32(branch))
+		if err != nil {
+			return err
+		}
+		rb := &recoveryBranch{
+			account:  account,
+			branch:   uint32(branch),
+			key:      branchKey,
+			known:    known,
+			lastUsed: -1,
+		}
+		rs.branches = append(rs.branches, rb)
+		if err := rs.extend(rb, known+rs.gapLimit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extend adds the scripts of the addresses of a branch up to count to the
+// scan.
+func (rs *recoveryScan) extend(rb *recoveryBranch, count uint32) error {
+	for ; rb.derived < count; rb.derived++ {
+		addr, err := rs.asset.branchAddress(rb.key, rb.derived)
+		if err == hdkeychain.ErrInvalidChild {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return err
+		}
+		rs.scripts[string(pkScript)] = &recoveryAddress{branch: rb, index: rb.derived}
+		rs.added = append(rs.added, pkScript)
+	}
+	return nil
+}
+
+// markUsed records the use of an address, extending the scanned addresses of
+// its branch and the probed accounts to keep the gap limits after it.
+func (rs *recoveryScan) markUsed(address *recoveryAddress) error {
+	rb := address.branch
+	if int64(address.index) <= rb.lastUsed {
+		return nil
+	}
+	rb.lastUsed = int64(address.index)
+	if err := rs.extend(rb, address.index+1+rs.gapLimit); err != nil {
+		return err
+	}
+	if rs.probing && rb.account > rs.lastAccount {
+		return rs.probeAccounts(rb.account + rs.accountGapLimit)
+	}
+	return nil
+}
+
+// branchAddress returns the P2WPKH address of the child of a branch key.
+func (asset *Asset) branchAddress(branchKey *hdkeychain.ExtendedKey, index uint32) (btcutil.Address, error) {
+	child, err := branchKey.Derive(index)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := child.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	return btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(pubKey.SerializeCompressed()), asset.chainParams)
+}
+
+// scanRecovery matches the compact filters of the blocks from startHeight
+// against the scanned scripts. The scripts added while scanning are matched
+// from the next block, and matched against the previous blocks by another
+// pass, until a pass adds no script.
+func (asset *Asset) scanRecovery(ctx context.Context, rs *recoveryScan, startHeight int32, startTime time.Time) error {
+	cs := asset.chainClient.CS
+	for len(rs.added) > 0 {
+		watched := rs.added
+		rs.added = nil
+		var matched int
+
+		endHeight := asset.GetBestBlockHeight()
+		var progress int32 = -1
+		for height := startHeight; height <= endHeight; height++ {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if p := int32(int64(height-startHeight) * 100 / int64(endHeight-startHeight+1)); p != progress {
+				progress = p
+				asset.publishRecoveryProgress(startTime, progress)
+			}
+
+			// Match the scripts added in this pass from the current block.
+			watched = append(watched, rs.added[matched:]...)
+			matched = len(rs.added)
+
+			blockHash, err := cs.GetBlockHash(int64(height))
+			if err != nil {
+				return err
+			}
+			filter, err := cs.GetCFilter(*blockHash, wire.GCSFilterRegular)
+			if err != nil {
+				return err
+			}
+			found, err := filter.MatchAny(builder.DeriveKey(blockHash), watched)
+			if err != nil {
+				return err
+			}
+			if !found {
+				continue
+			}
+
+			block, err := cs.GetBlock(*blockHash)
+			if err != nil {
+				return err
+			}
+			for _, tx := range block.Transactions() {
+				for _, txOut := range tx.MsgTx().TxOut {
+					address, ok := rs.scripts[string(txOut.PkScript)]
+					if !ok {
+						continue
+					}
+					if err := rs.markUsed(address); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		log.Debugf("[%d] Deep recovery pass matched %d scripts up to block %d", asset.ID, len(watched), endHeight)
+	}
+	return nil
+}
+
+// applyRecovery creates the accounts and adds the addresses found by a deep
+// recovery to the wallet. The report of the recovery and the addresses added
+// are returned.
+func (asset *Asset) applyRecovery(rs *recoveryScan) (*sharedW.DeepRecoveryReport, []btcutil.Address, error) {
+	lastUsedAccount := rs.lastAccount
+	for _, rb := range rs.branches {
+		if rb.lastUsed >= 0 && rb.account > lastUsedAccount {
+			lastUsedAccount = rb.account
+		}
+	}
+	for account := rs.lastAccount + 1; account <= lastUsedAccount; account++ {
+		name := fmt.Sprintf("account-%d", account)
+		number, err := asset.NextAccount(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if uint32(number) != account {
+			return nil, nil, fmt.Errorf("account %d created instead of account %d", number, account)
+		}
+		rs.names[account] = name
+	}
+
+	scopedKM, err := asset.Internal().BTC.Manager.FetchScopedKeyManager(GetScope())
+	if err != nil {
+		return nil, nil, err
+	}
+	err = walletdb.Update(asset.Internal().BTC.Database(), func(dbtx walletdb.ReadWriteTx) error {
+		ns := dbtx.ReadWriteBucket(wAddrMgrBkt)
+		for _, rb := range rs.branches {
+			if rb.lastUsed < int64(rb.known) {
+				continue
+			}
+			var err error
+			if rb.branch == waddrmgr.ExternalBranch {
+				err = scopedKM.ExtendExternalAddresses(ns, rb.account, uint32(rb.lastUsed))
+			} else {
+				err = scopedKM.ExtendInternalAddresses(ns, rb.account, uint32(rb.lastUsed))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var addrs []btcutil.Address
+	accounts := make(map[uint32]*sharedW.DiscoveredAccount)
+	// found is set for the accounts with addresses added to the wallet.
+	found := make(map[uint32]bool)
+	for _, rb := range rs.branches {
+		if rb.account > lastUsedAccount {
+			continue
+		}
+		account, ok := accounts[rb.account]
+		if !ok {
+			account = &sharedW.DiscoveredAccount{
+				Number:                int32(rb.account),
+				Name:                  rs.names[rb.account],
+				New:                   rb.account > rs.lastAccount,
+				LastUsedExternalIndex: -1,
+				LastUsedInternalIndex: -1,
+			}
+			accounts[rb.account] = account
+		}
+		if rb.branch == waddrmgr.ExternalBranch {
+			account.LastUsedExternalIndex = int32(rb.lastUsed)
+		} else {
+			account.LastUsedInternalIndex = int32(rb.lastUsed)
+		}
+		if rb.lastUsed >= int64(rb.known) {
+			found[rb.account] = true
+		}
+
+		for index := int64(rb.known); index <= rb.lastUsed; index++ {
+			addr, err := asset.branchAddress(rb.key, uint32(index))
+			if err == hdkeychain.ErrInvalidChild {
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			addrs = append(addrs, addr)
+			if rb.branch == waddrmgr.ExternalBranch {
+				account.Addresses = append(account.Addresses, addr.String())
+			}
+		}
+	}
+
+	report := &sharedW.DeepRecoveryReport{}
+	for _, account := range accounts {
+		if account.New || found[uint32(account.Number)] {
+			report.Accounts = append(report.Accounts, account)
+		}
+	}
+	sort.Slice(report.Accounts, func(i, j int) bool {
+		return report.Accounts[i].Number < report.Accounts[j].Number
+	})
+	return report, addrs, nil
+}
+
+// publishRecoveryProgress publishes the progress of a deep recovery to the
+// sync progress listeners.
+func (asset *Asset) publishRecoveryProgress(startTime time.Time, progress int32) {
+	elapsed := int64(time.Since(startTime).Seconds())
+	var remaining int64
+	if progress > 0 {
+		remaining = elapsed*100/int64(progress) - elapsed
+	}
+	report := &sharedW.AddressDiscoveryProgressReport{
+		GeneralSyncProgress: &sharedW.GeneralSyncProgress{
+			TotalSyncProgress:         progress,
+			TotalTimeRemainingSeconds: remaining,
+		},
+		AddressDiscoveryStartTime: startTime.Unix(),
+		TotalDiscoveryTimeSpent:   elapsed,
+		AddressDiscoveryProgress:  progress,
+	}
+
+	asset.syncData.mu.RLock()
+	defer asset.syncData.mu.RUnlock()
+	for _, listener := range asset.syncData.syncProgressListeners {
+		listener.OnAddressDiscoveryProgress(report)
+	}
+}
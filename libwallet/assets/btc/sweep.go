@@ -0,0 +1,309 @@
+package btc
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/gcs/builder"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
+	"github.com/btcsuite/btcwallet/wallet/txsizes"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// uncompressedPubKeyExtraSize is the size added to a P2PKH signature script
+// by an uncompressed public key.
+const uncompressedPubKeyExtraSize = 65 - 33
+
+// sweepKey is a private key being swept with one of its output scripts.
+type sweepKey struct {
+	wif *btcutil.WIF
+	// witnessScript is the P2WPKH script of the key if the output script
+	// is a P2WPKH or a nested P2SH-P2WPKH script.
+	witnessScript []byte
+	nested        bool
+}
+
+// decodeSweepKeys decodes the WIF encoded private keys and returns them
+// indexed by the output scripts they can spend: P2PKH, and for compressed
+// keys P2WPKH and P2SH-P2WPKH.
+func (asset *Asset) decodeSweepKeys(wifs []string) (map[string]*sweepKey, error) {
+	keys := make(map[string]*sweepKey)
+	for _, encoded := range wifs {
+		wif, err := btcutil.DecodeWIF(strings.TrimSpace(encoded))
+		if err != nil || !wif.IsForNet(asset.chainParams) {
+			return nil, errors.New(utils.ErrInvalidPrivateKey)
+		}
+
+		pkHash := btcutil.Hash160(wif.SerializePubKey())
+		p2pkh, err := btcutil.NewAddressPubKeyHash(pkHash, asset.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		pkScript, err := txscript.PayToAddrScript(p2pkh)
+		if err != nil {
+			return nil, err
+		}
+		keys[string(pkScript)] = &sweepKey{wif: wif}
+
+		// Segwit outputs can only pay to compressed keys.
+		if !wif.CompressPubKey {
+			continue
+		}
+		p2wpkh, err := btcutil.NewAddressWitnessPubKeyHash(pkHash, asset.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		witnessScript, err := txscript.PayToAddrScript(p2wpkh)
+		if err != nil {
+			return nil, err
+		}
+		keys[string(witnessScript)] = &sweepKey{wif: wif, witnessScript: witnessScript}
+
+		nested, err := btcutil.NewAddressScriptHash(witnessScript, asset.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		pkScript, err = txscript.PayToAddrScript(nested)
+		if err != nil {
+			return nil, err
+		}
+		keys[string(pkScript)] = &sweepKey{wif: wif, witnessScript: witnessScript, nested: true}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New(utils.ErrInvalidPrivateKey)
+	}
+	return keys, nil
+}
+
+// ScanSweepKeys scans the compact filters of the blocks from startHeight to
+// the best block for the unspent outputs of the WIF encoded private keys.
+// progress, if set, is called with the height of every scanned block. The
+// keys are not imported into the wallet.
+func (asset *Asset) ScanSweepKeys(ctx context.Context, wifs []string, startHeight int32, progress func(height int32)) (*sharedW.SweepScan, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrBTCNotInitialized
+	}
+	if !asset.IsSynced() {
+		return nil, errors.E(utils.ErrNotSynced)
+	}
+
+	keys, err := asset.decodeSweepKeys(wifs)
+	if err != nil {
+		return nil, err
+	}
+	watched := make([][]byte, 0, len(keys))
+	for pkScript := range keys {
+		watched = append(watched, []byte(pkScript))
+	}
+
+	endHeight := asset.GetBestBlockHeight()
+	if startHeight < 0 || startHeight > endHeight {
+		return nil, errors.New(utils.ErrInvalid)
+	}
+
+	cs := asset.chainClient.CS
+	unspent := make(map[wire.OutPoint]*sharedW.SweepOutput)
+	for height := startHeight; height <= endHeight; height++ {
+		if ctx.Err() != nil {
+			return nil, errors.New(utils.ErrContextCanceled)
+		}
+		if progress != nil {
+			progress(height)
+		}
+
+		blockHash, err := cs.GetBlockHash(int64(height))
+		if err != nil {
+			return nil, err
+		}
+		filter, err := cs.GetCFilter(*blockHash, wire.GCSFilterRegular)
+		if err != nil {
+			return nil, err
+		}
+		// The filters commit to the scripts of the spent outputs too, so
+		// the spends of the found outputs also match.
+		matched, err := filter.MatchAny(builder.DeriveKey(blockHash), watched)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		block, err := cs.GetBlock(*blockHash)
+		if err != nil {
+			return nil, err
+		}
+		for i, tx := range block.Transactions() {
+			for _, txIn := range tx.MsgTx().TxIn {
+				delete(unspent, txIn.PreviousOutPoint)
+			}
+			// Skip the immature coinbase outputs.
+			if i == 0 && endHeight-height+1 < int32(asset.chainParams.CoinbaseMaturity) {
+				continue
+			}
+			for vout, txOut := range tx.MsgTx().TxOut {
+				if _, ok := keys[string(txOut.PkScript)]; !ok {
+					continue
+				}
+				unspent[*wire.NewOutPoint(tx.Hash(), uint32(vout))] = &sharedW.SweepOutput{
+					TxHash:   tx.Hash().String(),
+					Index:    uint32(vout),
+					Amount:   txOut.Value,
+					Height:   height,
+					PkScript: txOut.PkScript,
+				}
+			}
+		}
+	}
+
+	scan := &sharedW.SweepScan{StartHeight: startHeight, EndHeight: endHeight}
+	for _, output := range unspent {
+		scan.Outputs = append(scan.Outputs, output)
+		scan.Total += output.Amount
+	}
+	if len(scan.Outputs) == 0 {
+		return nil, errors.New(utils.ErrNothingToSweep)
+	}
+	sort.Slice(scan.Outputs, func(i, j int) bool {
+		a, b := scan.Outputs[i], scan.Outputs[j]
+		if a.Height != b.Height {
+			return a.Height < b.Height
+		}
+		if a.TxHash != b.TxHash {
+			return a.TxHash < b.TxHash
+		}
+		return a.Index < b.Index
+	})
+
+	// Estimate the fee with a placeholder of the P2WPKH address the outputs
+	// are swept to.
+	if _, scan.Fee, err = asset.sweepTx(keys, scan, make([]byte, txsizes.P2WPKHPkScriptSize)); err != nil {
+		return nil, err
+	}
+	return scan, nil
+}
+
+// SweepKeys broadcasts a transaction sweeping the outputs found by scan to a
+// new address of account. The transaction is signed with the WIF encoded
+// private keys, which are not imported into the wallet.
+func (asset *Asset) SweepKeys(wifs []string, scan *sharedW.SweepScan, account int32) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrBTCNotInitialized
+	}
+
+	keys, err := asset.decodeSweepKeys(wifs)
+	if err != nil {
+		return "", err
+	}
+
+	address, err := asset.NextAddress(account)
+	if err != nil {
+		return "", err
+	}
+	addr, err := decodeAddress(address, asset.chainParams)
+	if err != nil {
+		return "", err
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return "", err
+	}
+
+	tx, _, err := asset.sweepTx(keys, scan, pkScript)
+	if err != nil {
+		return "", err
+	}
+	if err := signSweepTx(tx, keys, scan.Outputs); err != nil {
+		return "", err
+	}
+
+	if err := asset.Internal().BTC.PublishTransaction(tx, ""); err != nil {
+		return "", utils.TranslateError(err)
+	}
+	return tx.TxHash().String(), nil
+}
+
+// sweepTx returns the unsigned transaction spending the outputs of scan to
+// pkScript and its fee, which is deducted from the output.
+func (asset *Asset) sweepTx(keys map[string]*sweepKey, scan *sharedW.SweepScan, pkScript []byte) (*wire.MsgTx, int64, error) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	var numP2PKH, numP2WPKH, numNested, extraSize int
+	for _, output := range scan.Outputs {
+		key, ok := keys[string(output.PkScript)]
+		if !ok {
+			return nil, 0, errors.New(utils.ErrInvalidPrivateKey)
+		}
+		txHash, err := chainhash.NewHashFromStr(output.TxHash)
+		if err != nil {
+			return nil, 0, err
+		}
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(txHash, output.Index), nil, nil))
+
+		switch {
+		case key.nested:
+			numNested++
+		case key.witnessScript != nil:
+			numP2WPKH++
+		default:
+			numP2PKH++
+			if !key.wif.CompressPubKey {
+				extraSize += uncompressedPubKeyExtraSize
+			}
+		}
+	}
+	txOut := wire.NewTxOut(0, pkScript)
+	tx.AddTxOut(txOut)
+
+	feeRate := btcutil.Amount(asset.GetUserFeeRate().ToInt())
+	size := txsizes.EstimateVirtualSize(numP2PKH, 0, numP2WPKH, numNested, tx.TxOut, 0) + extraSize
+	fee := int64(txrules.FeeForSerializeSize(feeRate, size))
+	txOut.Value = scan.Total - fee
+	if txOut.Value <= 0 || txrules.IsDustOutput(txOut, feeRate) {
+		return nil, 0, errors.New(utils.ErrInsufficientBalance)
+	}
+	return tx, fee, nil
+}
+
+// signSweepTx signs the inputs of tx spending outputs with keys.
+func signSweepTx(tx *wire.MsgTx, keys map[string]*sweepKey, outputs []*sharedW.SweepOutput) error {
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut, len(outputs))
+	for i, output := range outputs {
+		prevOuts[tx.TxIn[i].PreviousOutPoint] = wire.NewTxOut(output.Amount, output.PkScript)
+	}
+	sigHashes := txscript.NewTxSigHashes(tx, txscript.NewMultiPrevOutFetcher(prevOuts))
+
+	for i, output := range outputs {
+		key := keys[string(output.PkScript)]
+		txIn := tx.TxIn[i]
+
+		var err error
+		if key.witnessScript == nil {
+			txIn.SignatureScript, err = txscript.SignatureScript(tx, i, output.PkScript,
+				txscript.SigHashAll, key.wif.PrivKey, key.wif.CompressPubKey)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		txIn.Witness, err = txscript.WitnessSignature(tx, sigHashes, i, output.Amount,
+			key.witnessScript, txscript.SigHashAll, key.wif.PrivKey, true)
+		if err != nil {
+			return err
+		}
+		if key.nested {
+			txIn.SignatureScript, err = txscript.NewScriptBuilder().AddData(key.witnessScript).Script()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -293,6 +293,10 @@ notificationsLoop:
 				// on startup when a wallet is syncing from scratch.
 				go asset.listenForTransactions()
 
+				// The transactions of the watched addresses are not known by
+				// the wallet, find them in the synced blocks.
+				asset.startWatchedAddressesIndexing()
+
 				// Since the initial run on a restored wallet, address discovery
 				// is complete, mark discovered accounts as true.
 				if asset.IsRestored && !asset.ContainsDiscoveredAccounts() {
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"sort"
 	"sync"
-	"time"
 
 	"decred.org/dcrwallet/v3/errors"
 	"github.com/btcsuite/btcd/btcutil"
@@ -250,10 +249,96 @@ func (asset *Asset) EstimateMaxSendAmount() (*sharedW.Amount, error) {
 
 // Broadcast broadcasts the transaction to the network.
 func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byte, error) {
+	return asset.SignAndBroadcast(privatePassphrase, transactionLabel, nil)
+}
+
+// SignAndBroadcast signs the transaction and broadcasts it to the network.
+// onSigned, if not nil, is called with the hash of the signed transaction
+// before it is broadcast, and the transaction is not broadcast if it returns
+// an error.
+func (asset *Asset) SignAndBroadcast(privatePassphrase, transactionLabel string, onSigned func(txHash string) error) ([]byte, error) {
 	if !asset.WalletOpened() {
 		return nil, utils.ErrBTCNotInitialized
 	}
 
+	relock, err := asset.UnlockForSigning(privatePassphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer relock()
+
+	reservation, err := asset.authorizeSpend()
+	if err != nil {
+		return nil, err
+	}
+	// The reserved amount no longer counts against the spending limit if the
+	// transaction is not published.
+	published := false
+	defer func() {
+		if !published {
+			asset.ReleaseSpend(reservation)
+		}
+	}()
+
+	msgTx, err := asset.signUnsignedTx()
+	if err != nil {
+		return nil, err
+	}
+
+	if onSigned != nil {
+		if err := onSigned(msgTx.TxHash().String()); err != nil {
+			return nil, err
+		}
+	}
+
+	err = asset.Internal().BTC.PublishTransaction(msgTx, transactionLabel)
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+	published = true
+	asset.RecordSpend(reservation)
+	return nil, nil
+}
+
+// authorizeSpend checks the unsigned transaction against the spending policy
+// of the wallet. It returns the reservation of the outputs and the fee of the
+// transaction against the policy.
+func (asset *Asset) authorizeSpend() (*sharedW.SpendReservation, error) {
+	asset.TxAuthoredInfo.mu.Lock()
+	unsignedTx, err := asset.unsignedTransaction()
+	if err != nil {
+		asset.TxAuthoredInfo.mu.Unlock()
+		return nil, utils.TranslateError(err)
+	}
+
+	outputs := make([]sharedW.TransactionDestination, 0, len(asset.TxAuthoredInfo.destinations))
+	for _, destination := range asset.TxAuthoredInfo.destinations {
+		// Sends between the accounts of the wallet are not restricted.
+		if asset.HaveAddress(destination.Address) {
+			continue
+		}
+		output := *destination
+		if output.SendMax {
+			// The send max destination receives the change output.
+			output.UnitAmount = 0
+			if unsignedTx.ChangeIndex >= 0 {
+				output.UnitAmount = unsignedTx.Tx.TxOut[unsignedTx.ChangeIndex].Value
+			}
+		}
+		outputs = append(outputs, output)
+	}
+	// The fee leaves the wallet too and counts against the spending limit.
+	fee := int64(unsignedTx.TotalInput)
+	for _, txOut := range unsignedTx.Tx.TxOut {
+		fee -= txOut.Value
+	}
+	asset.TxAuthoredInfo.mu.Unlock()
+
+	return asset.CheckSpendingPolicy(outputs, fee)
+}
+
+// signUnsignedTx signs the unsigned transaction. The wallet must be unlocked.
+func (asset *Asset) signUnsignedTx() (*wire.MsgTx, error) {
 	asset.TxAuthoredInfo.mu.Lock()
 	defer asset.TxAuthoredInfo.mu.Unlock()
 
@@ -270,17 +355,6 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 	// Test encode and decode the tx to check its validity after being signed.
 	msgTx := unsignedTx.Tx
 
-	lock := make(chan time.Time, 1)
-	defer func() {
-		lock <- time.Time{}
-	}()
-
-	err = asset.Internal().BTC.Unlock([]byte(privatePassphrase), lock)
-	if err != nil {
-		log.Errorf("unlocking the wallet failed: %v", err)
-		return nil, errors.New(utils.ErrInvalidPassphrase)
-	}
-
 	// To discourage fee sniping, LockTime is explicity set in the raw tx.
 	// More documentation on this:
 	// https://bitcoin.stackexchange.com/questions/48384/why-bitcoin-core-creates-time-locked-transactions-by-default
@@ -340,8 +414,7 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 		return nil, err
 	}
 
-	err = asset.Internal().BTC.PublishTransaction(msgTx, transactionLabel)
-	return nil, utils.TranslateError(err)
+	return msgTx, nil
 }
 
 func (asset *Asset) unsignedTransaction() (*txauthor.AuthoredTx, error) {
@@ -60,8 +60,8 @@ func hardenedKey(key uint32) uint32 {
 }
 
 // DeriveAccountXpub derives the xpub for the given account.
-func (asset *Asset) DeriveAccountXpub(seedMnemonic string, account uint32, params *chaincfg.Params) (xpub string, err error) {
-	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, asset.Type)
+func (asset *Asset) DeriveAccountXpub(seedMnemonic, seedPassphrase string, account uint32, params *chaincfg.Params) (xpub string, err error) {
+	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, seedPassphrase, asset.Type)
 	if err != nil {
 		return "", err
 	}
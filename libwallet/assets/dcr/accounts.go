@@ -110,6 +110,13 @@ func (asset *Asset) GetAccountBalance(accountNumber int32) (*sharedW.Balance, er
 		return nil, utils.ErrDCRNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		if accountNumber != sharedW.WatchedAccountNumber {
+			return &sharedW.Balance{}, nil
+		}
+		return asset.watchedBalance()
+	}
+
 	ctx, _ := asset.ShutdownContextWithCancel()
 	balance, err := asset.Internal().DCR.AccountBalance(ctx, uint32(accountNumber), asset.RequiredConfirmations())
 	if err != nil {
@@ -132,6 +139,17 @@ func (asset *Asset) SpendableForAccount(account int32) (int64, error) {
 		return -1, utils.ErrDCRNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		if account != sharedW.WatchedAccountNumber {
+			return 0, nil
+		}
+		balance, err := asset.watchedBalance()
+		if err != nil {
+			return 0, err
+		}
+		return balance.Spendable.ToInt(), nil
+	}
+
 	ctx, _ := asset.ShutdownContextWithCancel()
 	bals, err := asset.Internal().DCR.AccountBalance(ctx, uint32(account), asset.RequiredConfirmations())
 	if err != nil {
@@ -146,6 +164,13 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 		return nil, utils.ErrDCRNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		if account != sharedW.WatchedAccountNumber {
+			return []*sharedW.UnspentOutput{}, nil
+		}
+		return asset.watchedUnspentOutputs()
+	}
+
 	policy := w.OutputSelectionPolicy{
 		Account:               uint32(account),
 		RequiredConfirmations: asset.RequiredConfirmations(),
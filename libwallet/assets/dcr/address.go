@@ -24,6 +24,10 @@ func (asset *Asset) IsAddressValid(address string) bool {
 }
 
 func (asset *Asset) HaveAddress(address string) bool {
+	if asset.IsAddressWatchWallet() {
+		return asset.HasWatchedAddress(address)
+	}
+
 	addr, err := stdaddr.DecodeAddress(address, asset.chainParams)
 	if err != nil {
 		return false
@@ -86,6 +90,10 @@ func (asset *Asset) CurrentAddress(account int32) (string, error) {
 		return "", errors.E(utils.ErrAddressDiscoveryNotDone)
 	}
 
+	if asset.IsAddressWatchWallet() {
+		return asset.WatchedAddresses[0], nil
+	}
+
 	addr, err := asset.Internal().DCR.CurrentAddress(uint32(account))
 	if err != nil {
 		log.Errorf("CurrentAddress error: %v", err)
@@ -102,6 +110,10 @@ func (asset *Asset) NextAddress(account int32) (string, error) {
 		return "", errors.E(utils.ErrAddressDiscoveryNotDone)
 	}
 
+	if asset.IsAddressWatchWallet() {
+		return "", errors.New(utils.ErrAddressWatchWallet)
+	}
+
 	// NewExternalAddress increments the lastReturnedAddressIndex but does
 	// not return the address at the new index. The actual new address (at
 	// the newly incremented index) is returned below by CurrentAddress.
@@ -0,0 +1,420 @@
+package dcr
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	w "decred.org/dcrwallet/v3/wallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+// DecodeWatchedAddresses returns the addresses of s, separated by whitespace
+// or commas. An error is returned if one of them is not an address of the
+// network.
+func DecodeWatchedAddresses(s string, netType utils.NetworkType) ([]string, error) {
+	chainParams, err := utils.DCRChainParams(netType)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t' || r == '\r'
+	})
+	if len(addresses) == 0 {
+		return nil, errors.New(utils.ErrInvalidAddress)
+	}
+	for _, address := range addresses {
+		if _, err := watchedScript(address, chainParams); err != nil {
+			return nil, err
+		}
+	}
+	return addresses, nil
+}
+
+// watchedScript returns the payment script of a watched address. Only the
+// version 0 scripts are supported.
+func watchedScript(address string, chainParams *chaincfg.Params) ([]byte, error) {
+	addr, err := stdaddr.DecodeAddress(address, chainParams)
+	if err != nil {
+		return nil, errors.New(utils.ErrInvalidAddress)
+	}
+	version, pkScript := addr.PaymentScript()
+	if version != 0 {
+		return nil, errors.New(utils.ErrInvalidAddress)
+	}
+	return pkScript, nil
+}
+
+// CreateAddressWatchWallet creates a wallet watching the transactions of
+// addresses from the block at startHeight. The wallet cannot derive new
+// addresses.
+func CreateAddressWatchWallet(walletName string, addresses []string, startHeight int32, params *sharedW.InitParams) (sharedW.Asset, error) {
+	chainParams, err := utils.DCRChainParams(params.NetType)
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range addresses {
+		if _, err := watchedScript(address, chainParams); err != nil {
+			return nil, err
+		}
+	}
+
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen)
+	if err != nil {
+		return nil, err
+	}
+	master, err := hdkeychain.NewMaster(seed, chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver)
+	w, err := sharedW.CreateAddressWatchWallet(walletName, master.Neuter().String(),
+		addresses, ldr, params, utils.DCRWalletAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	dcrWallet := &Asset{
+		Wallet:      w,
+		chainParams: chainParams,
+		syncData: &SyncData{
+			syncProgressListeners: make(map[string]sharedW.SyncProgressListener),
+		},
+		txAndBlockNotificationListeners:  make(map[string]sharedW.TxAndBlockNotificationListener),
+		accountMixerNotificationListener: make(map[string]AccountMixerNotificationListener),
+	}
+
+	dcrWallet.SetInt32ConfigValueForKey(sharedW.WatchStartHeightConfigKey, startHeight)
+
+	dcrWallet.SetNetworkCancelCallback(dcrWallet.SafelyCancelSync)
+
+	return dcrWallet, nil
+}
+
+// indexWatchedAddresses saves the transactions of the watched addresses in
+// the blocks after the last indexed block, found with the compact filters of
+// the blocks. The unmined transactions are indexed by indexWatchedMempoolTxs.
+func (asset *Asset) indexWatchedAddresses() error {
+	if !atomic.CompareAndSwapUint32(&asset.indexingWatched, 0, 1) {
+		// Indexing in progress already.
+		return nil
+	}
+	defer atomic.StoreUint32(&asset.indexingWatched, 0)
+
+	scripts, err := asset.watchedScripts()
+	if err != nil {
+		return err
+	}
+	watched := make([][]byte, 0, len(scripts))
+	for pkScript := range scripts {
+		watched = append(watched, []byte(pkScript))
+	}
+
+	// The transactions of the blocks that are no longer in the main chain are
+	// deleted before the watched outputs are read.
+	ctx, _ := asset.ShutdownContextWithCancel()
+	wallet := asset.Internal().DCR
+	startHeight, err := asset.WatchIndexStart(asset.GetBestBlockHeight(), func(height int32) (string, error) {
+		info, err := wallet.BlockInfo(ctx, w.NewBlockIdentifierFromHeight(height))
+		if err != nil {
+			return "", err
+		}
+		return info.Hash.String(), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	amounts, err := asset.watchedAmounts()
+	if err != nil {
+		return err
+	}
+
+	n, err := wallet.NetworkBackend()
+	if err != nil {
+		return err
+	}
+
+	db := asset.GetWalletDataDb()
+	height := startHeight
+	var lastHash *chainhash.Hash
+	for ; height <= asset.GetBestBlockHeight(); height++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		info, err := wallet.BlockInfo(ctx, w.NewBlockIdentifierFromHeight(height))
+		if err != nil {
+			return err
+		}
+		lastHash = &info.Hash
+		key, filter, err := wallet.CFilterV2(ctx, &info.Hash)
+		if err != nil {
+			return err
+		}
+		// The filters commit to the scripts of the spent outputs too, so
+		// the spends of the watched outputs also match.
+		if !filter.MatchAny(key, watched) {
+			continue
+		}
+
+		blocks, err := n.Blocks(ctx, []*chainhash.Hash{&info.Hash})
+		if err != nil {
+			return err
+		}
+		block := blocks[0]
+		// Tickets may spend the watched outputs.
+		txs := make([]*wire.MsgTx, 0, len(block.Transactions)+len(block.STransactions))
+		txs = append(txs, block.Transactions...)
+		for _, tx := range append(txs, block.STransactions...) {
+			summary, err := watchedTxSummary(tx, scripts, amounts, info.Timestamp)
+			if err != nil {
+				return err
+			}
+			if summary == nil {
+				continue
+			}
+
+			decodedTx, err := asset.decodeTransactionWithTxSummary(summary, &info.Hash)
+			if err != nil {
+				return err
+			}
+			if _, err := db.SaveOrUpdate(&sharedW.Transaction{}, decodedTx); err != nil {
+				return err
+			}
+			asset.publishTransactionConfirmed(decodedTx.Hash, height)
+		}
+		if err := asset.SaveWatchIndexPoint(height, info.Hash.String()); err != nil {
+			return err
+		}
+	}
+
+	// The syncer reports the unmined transactions paying to the watched
+	// addresses or spending the watched outputs once they are in its filter.
+	addrs := make([]stdaddr.Address, 0, len(scripts))
+	for _, addr := range scripts {
+		addrs = append(addrs, addr)
+	}
+	outpoints := make([]wire.OutPoint, 0, len(amounts))
+	for outpoint := range amounts {
+		outpoints = append(outpoints, outpoint)
+	}
+	if err := n.LoadTxFilter(ctx, false, addrs, outpoints); err != nil {
+		return err
+	}
+
+	if lastHash == nil {
+		return nil
+	}
+	log.Debugf("[%d] Watched addresses indexed up to block %d", asset.ID, height-1)
+	return asset.SaveWatchIndexPoint(height-1, lastHash.String())
+}
+
+// watchedScripts returns the addresses of the watched addresses by their
+// payment scripts.
+func (asset *Asset) watchedScripts() (map[string]stdaddr.Address, error) {
+	scripts := make(map[string]stdaddr.Address, len(asset.WatchedAddresses))
+	for _, address := range asset.WatchedAddresses {
+		pkScript, err := watchedScript(address, asset.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		addr, _ := stdaddr.DecodeAddress(address, asset.chainParams)
+		scripts[string(pkScript)] = addr
+	}
+	return scripts, nil
+}
+
+// watchedAmounts returns the amounts of the saved watched outputs, which
+// identify the inputs spending them.
+func (asset *Asset) watchedAmounts() (map[wire.OutPoint]int64, error) {
+	outputs, err := asset.WatchedOutputs(true)
+	if err != nil {
+		return nil, err
+	}
+	amounts := make(map[wire.OutPoint]int64, len(outputs))
+	for _, output := range outputs {
+		txHash, err := chainhash.NewHashFromStr(output.TxHash)
+		if err != nil {
+			return nil, err
+		}
+		amounts[*wire.NewOutPoint(txHash, output.Index, wire.TxTreeRegular)] = output.Amount
+	}
+	return amounts, nil
+}
+
+// indexWatchedMempoolTxs saves the unmined transactions of the watched
+// addresses among txs, which are reported by the syncer.
+func (asset *Asset) indexWatchedMempoolTxs(txs []*wire.MsgTx) {
+	if !asset.IsAddressWatchWallet() {
+		return
+	}
+
+	scripts, err := asset.watchedScripts()
+	if err != nil {
+		log.Errorf("[%d] Error indexing the watched mempool txs: %v", asset.ID, err)
+		return
+	}
+	amounts, err := asset.watchedAmounts()
+	if err != nil {
+		log.Errorf("[%d] Error indexing the watched mempool txs: %v", asset.ID, err)
+		return
+	}
+
+	for _, tx := range txs {
+		summary, err := watchedTxSummary(tx, scripts, amounts, time.Now().Unix())
+		if err != nil {
+			log.Errorf("[%d] Error indexing the watched mempool tx %s: %v", asset.ID, tx.TxHash(), err)
+			continue
+		}
+		if summary == nil {
+			continue
+		}
+
+		decodedTx, err := asset.decodeTransactionWithTxSummary(summary, nil)
+		if err != nil {
+			log.Errorf("[%d] Error indexing the watched mempool tx %s: %v", asset.ID, tx.TxHash(), err)
+			continue
+		}
+		overwritten, err := asset.GetWalletDataDb().SaveOrUpdate(&sharedW.Transaction{}, decodedTx)
+		if err != nil {
+			log.Errorf("[%d] Error saving the watched mempool tx %s: %v", asset.ID, decodedTx.Hash, err)
+			continue
+		}
+		if overwritten {
+			continue
+		}
+
+		log.Infof("[%d] New Transaction %s", asset.ID, decodedTx.Hash)
+		result, err := json.Marshal(decodedTx)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		asset.mempoolTransactionNotification(string(result))
+	}
+}
+
+// watchedTxSummary returns the summary of tx if it spends or pays to the
+// watched outputs, or nil. The outputs of tx paying to the watched scripts are
+// added to amounts.
+func watchedTxSummary(tx *wire.MsgTx, scripts map[string]stdaddr.Address, amounts map[wire.OutPoint]int64, timestamp int64) (*w.TransactionSummary, error) {
+	summary := &w.TransactionSummary{
+		Timestamp: timestamp,
+		Type:      w.TxTransactionType(tx),
+	}
+	var inputsTotal int64
+	for i, txIn := range tx.TxIn {
+		amount, ok := amounts[txIn.PreviousOutPoint]
+		if !ok {
+			continue
+		}
+		summary.MyInputs = append(summary.MyInputs, w.TransactionSummaryInput{
+			Index:           uint32(i),
+			PreviousAccount: sharedW.WatchedAccountNumber,
+			PreviousAmount:  dcrutil.Amount(amount),
+		})
+		inputsTotal += amount
+	}
+
+	txHash := tx.TxHash()
+	var outputsTotal int64
+	for i, txOut := range tx.TxOut {
+		outputsTotal += txOut.Value
+		addr, ok := scripts[string(txOut.PkScript)]
+		if !ok || txOut.Version != 0 {
+			continue
+		}
+		summary.MyOutputs = append(summary.MyOutputs, w.TransactionSummaryOutput{
+			Index:        uint32(i),
+			Account:      sharedW.WatchedAccountNumber,
+			Amount:       dcrutil.Amount(txOut.Value),
+			Address:      addr,
+			OutputScript: txOut.PkScript,
+		})
+		amounts[*wire.NewOutPoint(&txHash, uint32(i), wire.TxTreeRegular)] = txOut.Value
+	}
+	if len(summary.MyInputs) == 0 && len(summary.MyOutputs) == 0 {
+		return nil, nil
+	}
+
+	// The fee is only known if all the inputs are watched.
+	if len(summary.MyInputs) == len(tx.TxIn) {
+		summary.Fee = dcrutil.Amount(inputsTotal - outputsTotal)
+	}
+
+	txBytes, err := tx.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	summary.Hash = &txHash
+	summary.Transaction = txBytes
+	return summary, nil
+}
+
+// startWatchedAddressesIndexing indexes the transactions of the watched
+// addresses of an address watch wallet in the background.
+func (asset *Asset) startWatchedAddressesIndexing() {
+	if !asset.IsAddressWatchWallet() {
+		return
+	}
+	go func() {
+		if err := asset.indexWatchedAddresses(); err != nil {
+			log.Errorf("[%d] Error indexing the watched addresses: %v", asset.ID, err)
+		}
+	}()
+}
+
+// watchedBalance returns the balance of the watched addresses.
+func (asset *Asset) watchedBalance() (*sharedW.Balance, error) {
+	total, spendable, immature, err := asset.WatchedBalance(asset.GetBestBlockHeight(),
+		asset.RequiredConfirmations(), int32(asset.chainParams.CoinbaseMaturity))
+	if err != nil {
+		return nil, err
+	}
+	return &sharedW.Balance{
+		Total:          Amount(total),
+		Spendable:      Amount(spendable),
+		ImmatureReward: Amount(immature),
+	}, nil
+}
+
+// watchedUnspentOutputs returns the unspent outputs of the watched addresses.
+func (asset *Asset) watchedUnspentOutputs() ([]*sharedW.UnspentOutput, error) {
+	outputs, err := asset.WatchedOutputs(false)
+	if err != nil {
+		return nil, err
+	}
+
+	bestHeight := asset.GetBestBlockHeight()
+	unspents := make([]*sharedW.UnspentOutput, 0, len(outputs))
+	for _, output := range outputs {
+		pkScript, err := watchedScript(output.Address, asset.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		unspents = append(unspents, &sharedW.UnspentOutput{
+			TxID:          output.TxHash,
+			Vout:          output.Index,
+			Address:       output.Address,
+			ScriptPubKey:  hex.EncodeToString(pkScript),
+			Amount:        Amount(output.Amount),
+			Confirmations: output.Confirmations(bestHeight),
+			ReceiveTime:   time.Unix(output.Timestamp, 0),
+			Tree:          wire.TxTreeRegular,
+		})
+	}
+	return unspents, nil
+}
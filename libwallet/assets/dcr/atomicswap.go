@@ -0,0 +1,462 @@
+package dcr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	w "decred.org/dcrwallet/v3/wallet"
+	"decred.org/dcrwallet/v3/wallet/txrules"
+	"github.com/crypto-power/cryptopower/libwallet/atomicswap"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+// SwapChain returns the script differences of the Decred chain.
+func (asset *Asset) SwapChain() atomicswap.Chain {
+	return atomicswap.DecredChain
+}
+
+// AddressHash returns the HASH160 of the public key of a P2PKH address.
+func (asset *Asset) AddressHash(address string) ([]byte, error) {
+	addr, err := stdaddr.DecodeAddress(address, asset.chainParams)
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+
+	pkhAddr, ok := addr.(*stdaddr.AddressPubKeyHashEcdsaSecp256k1V0)
+	if !ok {
+		return nil, fmt.Errorf("address %s is not a public key hash address", address)
+	}
+	return pkhAddr.Hash160()[:], nil
+}
+
+// FundContract broadcasts a transaction from account paying amount to a
+// contract with the provided terms, and signs the transaction refunding the
+// contract after its lock time.
+func (asset *Asset) FundContract(account int32, terms *atomicswap.Terms, amount int64, passphrase string) (*atomicswap.FundedContract, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	refundAddress, err := asset.NextAddress(account)
+	if err != nil {
+		return nil, err
+	}
+	if terms.RefundHash, err = asset.AddressHash(refundAddress); err != nil {
+		return nil, err
+	}
+
+	script, err := asset.SwapChain().BuildContract(terms)
+	if err != nil {
+		return nil, err
+	}
+	contractAddr, err := stdaddr.NewAddressScriptHashV0(script, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := asset.NewUnsignedTx(account, nil); err != nil {
+		return nil, err
+	}
+	if err := asset.AddSendDestination(contractAddr.String(), amount, false); err != nil {
+		return nil, err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	relock, err := asset.UnlockForSigning(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer relock()
+
+	reservation, err := asset.authorizeSpend()
+	if err != nil {
+		return nil, err
+	}
+	// The reserved amount no longer counts against the spending limit if the
+	// transaction is not published.
+	published := false
+	defer func() {
+		if !published {
+			asset.ReleaseSpend(reservation)
+		}
+	}()
+
+	msgTx, err := asset.signUnsignedTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := asset.contractOutput(script, msgTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The refund is signed before the contract is funded so that the funds
+	// can always be recovered.
+	refundTx, err := asset.spendContract(ctx, contract, refundAddress, uint32(terms.LockTime),
+		atomicswap.RefundSigScriptSize(script), func(sig, pubKey []byte) []byte {
+			return atomicswap.RefundSigScript(sig, pubKey, script)
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error signing the contract refund: %w", err)
+	}
+
+	if _, err := asset.Internal().DCR.PublishTransaction(ctx, msgTx, n); err != nil {
+		return nil, utils.TranslateError(err)
+	}
+	published = true
+	asset.RecordSpend(reservation)
+
+	refund, err := serializeTx(refundTx)
+	if err != nil {
+		return nil, err
+	}
+	return &atomicswap.FundedContract{
+		Contract:     *contract,
+		RefundTx:     refund,
+		RefundTxHash: refundTx.TxHash().String(),
+	}, nil
+}
+
+// AuditContract checks that tx pays to the contract script and returns the
+// contract and its terms.
+func (asset *Asset) AuditContract(script, tx []byte) (*atomicswap.Contract, *atomicswap.Terms, error) {
+	terms, err := asset.SwapChain().ExtractTerms(script)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var msgTx wire.MsgTx
+	if err := msgTx.Deserialize(bytes.NewReader(tx)); err != nil {
+		return nil, nil, fmt.Errorf("invalid contract transaction: %w", err)
+	}
+
+	contract, err := asset.contractOutput(script, &msgTx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return contract, terms, nil
+}
+
+// RedeemContract broadcasts a transaction redeeming contract with secret to a
+// new address of account.
+func (asset *Asset) RedeemContract(account int32, contract *atomicswap.Contract, secret []byte, passphrase string) ([]byte, string, error) {
+	redeem, err := asset.SignRedeem(account, contract, passphrase)
+	if err != nil {
+		return nil, "", err
+	}
+	return asset.PublishRedeem(redeem, contract, secret)
+}
+
+// SignRedeem signs a transaction redeeming contract to a new address of
+// account. The signature script of the contract input is completed with the
+// secret by PublishRedeem.
+func (asset *Asset) SignRedeem(account int32, contract *atomicswap.Contract, passphrase string) (*atomicswap.PresignedRedeem, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	address, err := asset.NextAddress(account)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	relock, err := asset.UnlockForSigning(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer relock()
+
+	redeem := new(atomicswap.PresignedRedeem)
+	redeemTx, err := asset.spendContract(ctx, contract, address, 0,
+		atomicswap.RedeemSigScriptSize(contract.Script), func(sig, pubKey []byte) []byte {
+			redeem.Sig, redeem.PubKey = sig, pubKey
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if redeem.Tx, err = serializeTx(redeemTx); err != nil {
+		return nil, err
+	}
+	return redeem, nil
+}
+
+// PublishRedeem broadcasts the presigned redeem of contract with secret.
+func (asset *Asset) PublishRedeem(redeem *atomicswap.PresignedRedeem, contract *atomicswap.Contract, secret []byte) ([]byte, string, error) {
+	if !asset.WalletOpened() {
+		return nil, "", utils.ErrDCRNotInitialized
+	}
+
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var redeemTx wire.MsgTx
+	if err := redeemTx.Deserialize(bytes.NewReader(redeem.Tx)); err != nil {
+		return nil, "", fmt.Errorf("invalid redeem transaction: %w", err)
+	}
+	if len(redeemTx.TxIn) != 1 {
+		return nil, "", errors.New("redeem transaction does not spend the contract")
+	}
+	redeemTx.TxIn[0].SignatureScript = atomicswap.RedeemSigScript(redeem.Sig, redeem.PubKey, secret, contract.Script)
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	txHash, err := asset.Internal().DCR.PublishTransaction(ctx, &redeemTx, n)
+	if err != nil {
+		return nil, "", utils.TranslateError(err)
+	}
+
+	tx, err := serializeTx(&redeemTx)
+	if err != nil {
+		return nil, "", err
+	}
+	return tx, txHash.String(), nil
+}
+
+// ContractStatus scans the compact filters of the blocks from startHeight to
+// the best block for the transactions funding and spending contract.
+func (asset *Asset) ContractStatus(ctx context.Context, contract *atomicswap.Contract, startHeight int32) (*atomicswap.ContractStatus, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+	if !asset.IsSynced() {
+		return nil, errors.New(utils.ErrNotSynced)
+	}
+
+	wallet := asset.Internal().DCR
+	n, err := wallet.NetworkBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddr, err := stdaddr.NewAddressScriptHashV0(contract.Script, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	_, pkScript := contractAddr.PaymentScript()
+	txHash, err := chainhash.NewHashFromStr(contract.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	outPoint := wire.NewOutPoint(txHash, contract.Vout, wire.TxTreeRegular)
+
+	endHeight := asset.GetBestBlockHeight()
+	if startHeight > endHeight {
+		startHeight = endHeight
+	}
+	if startHeight < 0 {
+		startHeight = 0
+	}
+
+	status := new(atomicswap.ContractStatus)
+	for height := startHeight; height <= endHeight && status.SpendTx == nil; height++ {
+		if ctx.Err() != nil {
+			return nil, errors.New(utils.ErrContextCanceled)
+		}
+
+		info, err := wallet.BlockInfo(ctx, w.NewBlockIdentifierFromHeight(height))
+		if err != nil {
+			return nil, err
+		}
+		key, filter, err := wallet.CFilterV2(ctx, &info.Hash)
+		if err != nil {
+			return nil, err
+		}
+		// The filters commit to the scripts of the spent outputs too, so
+		// the spends of the contract also match.
+		if !filter.Match(key, pkScript) {
+			continue
+		}
+
+		blocks, err := n.Blocks(ctx, []*chainhash.Hash{&info.Hash})
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range blocks[0].Transactions {
+			hash := tx.TxHash()
+			if hash == *txHash {
+				if err := checkContractOutput(tx, contract, pkScript); err != nil {
+					return nil, err
+				}
+				status.Height = height
+			}
+			for _, txIn := range tx.TxIn {
+				if txIn.PreviousOutPoint != *outPoint {
+					continue
+				}
+				if status.SpendTx, err = serializeTx(tx); err != nil {
+					return nil, err
+				}
+				status.SpendTxHash = hash.String()
+			}
+		}
+	}
+
+	if status.Height > 0 {
+		status.Confirmations = endHeight - status.Height + 1
+	}
+	return status, nil
+}
+
+// RedeemSigScript returns the signature script of the input of tx spending
+// the contract funded by contractTxHash.
+func (asset *Asset) RedeemSigScript(tx []byte, contractTxHash string) ([]byte, error) {
+	var msgTx wire.MsgTx
+	if err := msgTx.Deserialize(bytes.NewReader(tx)); err != nil {
+		return nil, fmt.Errorf("invalid redeem transaction: %w", err)
+	}
+
+	for _, txIn := range msgTx.TxIn {
+		if txIn.PreviousOutPoint.Hash.String() == contractTxHash {
+			return txIn.SignatureScript, nil
+		}
+	}
+	return nil, errors.New("transaction does not spend the contract")
+}
+
+// PublishRawTx broadcasts a serialized transaction.
+func (asset *Asset) PublishRawTx(tx []byte) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrDCRNotInitialized
+	}
+
+	var msgTx wire.MsgTx
+	if err := msgTx.Deserialize(bytes.NewReader(tx)); err != nil {
+		return "", fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	txHash, err := asset.Internal().DCR.PublishTransaction(ctx, &msgTx, n)
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+	return txHash.String(), nil
+}
+
+// contractOutput returns the output of tx paying to the contract script.
+func (asset *Asset) contractOutput(script []byte, tx *wire.MsgTx) (*atomicswap.Contract, error) {
+	contractAddr, err := stdaddr.NewAddressScriptHashV0(script, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	_, pkScript := contractAddr.PaymentScript()
+
+	for vout, txOut := range tx.TxOut {
+		if !bytes.Equal(txOut.PkScript, pkScript) {
+			continue
+		}
+
+		serializedTx, err := serializeTx(tx)
+		if err != nil {
+			return nil, err
+		}
+		return &atomicswap.Contract{
+			Script: script,
+			Tx:     serializedTx,
+			TxHash: tx.TxHash().String(),
+			Vout:   uint32(vout),
+			Amount: txOut.Value,
+		}, nil
+	}
+	return nil, errors.New("transaction does not pay to the contract")
+}
+
+// checkContractOutput checks that the contract output of tx pays the
+// contract's amount to pkScript.
+func checkContractOutput(tx *wire.MsgTx, contract *atomicswap.Contract, pkScript []byte) error {
+	if int(contract.Vout) >= len(tx.TxOut) {
+		return errors.New("transaction does not pay to the contract")
+	}
+	txOut := tx.TxOut[contract.Vout]
+	if !bytes.Equal(txOut.PkScript, pkScript) || txOut.Value != contract.Amount {
+		return errors.New("mined contract output does not match the contract")
+	}
+	return nil
+}
+
+// spendContract returns a transaction spending contract to address. The
+// contract input is signed by the key of the recipient or refund address of
+// the contract, depending on whether lockTime is set, and sigScript builds
+// its signature script. The wallet must be unlocked.
+func (asset *Asset) spendContract(ctx context.Context, contract *atomicswap.Contract, address string,
+	lockTime uint32, sigScriptSize int, sigScript func(sig, pubKey []byte) []byte,
+) (*wire.MsgTx, error) {
+	terms, err := asset.SwapChain().ExtractTerms(contract.Script)
+	if err != nil {
+		return nil, err
+	}
+	signerHash := terms.RecipientHash
+	if lockTime > 0 {
+		signerHash = terms.RefundHash
+	}
+	signer, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(signerHash, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := stdaddr.DecodeAddress(address, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	pkScriptVer, pkScript := addr.PaymentScript()
+
+	txHash, err := chainhash.NewHashFromStr(contract.TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := wire.NewMsgTx()
+	tx.LockTime = lockTime
+	txIn := wire.NewTxIn(wire.NewOutPoint(txHash, contract.Vout, wire.TxTreeRegular), contract.Amount, nil)
+	if lockTime > 0 {
+		// A final sequence would disable the lock time check.
+		txIn.Sequence = 0
+	}
+	tx.AddTxIn(txIn)
+	txOut := &wire.TxOut{Version: pkScriptVer, PkScript: pkScript}
+	tx.AddTxOut(txOut)
+
+	// Size the transaction with a placeholder of the largest signature script.
+	txIn.SignatureScript = make([]byte, sigScriptSize)
+	relayFee := asset.Internal().DCR.RelayFee()
+	txOut.Value = contract.Amount - int64(txrules.FeeForSerializeSize(relayFee, tx.SerializeSize()))
+	if txOut.Value <= 0 || txrules.IsDustOutput(txOut, relayFee) {
+		return nil, errors.New("contract amount is too small to cover the fee")
+	}
+
+	sig, pubKey, err := asset.Internal().DCR.CreateSignature(ctx, tx, 0, signer, txscript.SigHashAll, contract.Script)
+	if err != nil {
+		return nil, err
+	}
+	txIn.SignatureScript = sigScript(sig, pubKey)
+	return tx, nil
+}
+
+func serializeTx(tx *wire.MsgTx) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(tx.SerializeSize())
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
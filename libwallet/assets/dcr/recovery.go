@@ -0,0 +1,327 @@
+package dcr
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	w "decred.org/dcrwallet/v3/wallet"
+	"decred.org/dcrwallet/v3/wallet/udb"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+// noUsedIndex is the last used index of the branches without used addresses.
+const noUsedIndex = ^uint32(0)
+
+// DeepRecovery discovers the used accounts and addresses of the wallet with
+// the gap limits provided. The accounts after the last account of the wallet
+// are discovered if the private passphrase is provided, by scanning the compact
+// filters of the blocks for their first addresses, and the used addresses of
+// all the accounts are discovered by the wallet. The blocks are then rescanned
+// for the transactions of the addresses found. The progress of every pass of
+// the accounts scan is published to the sync progress listeners as address
+// discovery progress.
+func (asset *Asset) DeepRecovery(ctx context.Context, gapLimit, accountGapLimit uint32, privatePassphrase string) (*sharedW.DeepRecoveryReport, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+	if asset.IsAddressWatchWallet() {
+		return nil, errors.New(utils.ErrAddressWatchWallet)
+	}
+	if gapLimit == 0 {
+		return nil, errors.New(utils.ErrInvalid)
+	}
+
+	netBackend, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return nil, errors.E(utils.ErrNotConnected)
+	}
+	if !asset.IsSynced() {
+		return nil, errors.New(utils.ErrNotSynced)
+	}
+	if asset.IsSyncing() || asset.IsRescanning() || !atomic.CompareAndSwapUint32(&asset.recovering, 0, 1) {
+		return nil, errors.New(utils.ErrSyncAlreadyInProgress)
+	}
+	defer atomic.StoreUint32(&asset.recovering, 0)
+
+	// New accounts can only be derived by an unlocked wallet.
+	probing := accountGapLimit > 0 && privatePassphrase != "" && !asset.IsWatchingOnlyWallet()
+	if probing {
+		if err := asset.UnlockWallet(privatePassphrase); err != nil {
+			return nil, err
+		}
+		defer asset.LockWallet()
+	}
+
+	before, err := asset.recoveryAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	asset.publishRecoveryProgress(startTime, 0)
+	if probing {
+		err := asset.discoverRecoveryAccounts(ctx, netBackend, before, gapLimit, accountGapLimit, startTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	startBlock := asset.chainParams.GenesisHash
+	err = asset.Internal().DCR.DiscoverActiveAddresses(ctx, netBackend, &startBlock, false, gapLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := asset.recoveryReport(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	if len(report.Accounts) > 0 {
+		if err := asset.RescanBlocks(); err != nil {
+			return nil, err
+		}
+	}
+	asset.publishRecoveryProgress(startTime, 100)
+
+	asset.SetInt32ConfigValueForKey(sharedW.GapLimitConfigKey, int32(gapLimit))
+	asset.SetInt32ConfigValueForKey(sharedW.AccountGapLimitConfigKey, int32(accountGapLimit))
+
+	report.GapLimit = gapLimit
+	report.AccountGapLimit = accountGapLimit
+	return report, nil
+}
+
+// recoveryAccounts returns the properties of the BIP0044 accounts of the
+// wallet.
+func (asset *Asset) recoveryAccounts(ctx context.Context) (map[uint32]w.AccountResult, error) {
+	resp, err := asset.Internal().DCR.Accounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make(map[uint32]w.AccountResult, len(resp.Accounts))
+	for _, account := range resp.Accounts {
+		if account.AccountNumber < ImportedAccountNumber {
+			accounts[account.AccountNumber] = account
+		}
+	}
+	return accounts, nil
+}
+
+// discoverRecoveryAccounts creates the used accounts found after the last
+// account of the wallet. The accounts are used if one of their first gapLimit
+// addresses is used, and are searched for until accountGapLimit accounts in a
+// row are unused.
+func (asset *Asset) discoverRecoveryAccounts(ctx context.Context, netBackend w.NetworkBackend, accounts map[uint32]w.AccountResult, gapLimit, accountGapLimit uint32, startTime time.Time) error {
+	wallet := asset.Internal().DCR
+	coinTypeKey, err := wallet.CoinTypePrivKey(ctx)
+	if err != nil {
+		return err
+	}
+	defer coinTypeKey.Zero()
+
+	var lastAccount uint32
+	for account := range accounts {
+		if account > lastAccount {
+			lastAccount = account
+		}
+	}
+
+	for {
+		scripts := make(map[string]uint32)
+		watched := make([][]byte, 0, accountGapLimit*gapLimit*2)
+		for account := lastAccount + 1; account <= lastAccount+accountGapLimit && account < hdkeychain.HardenedKeyStart; account++ {
+			accountKey, err := coinTypeKey.Child(hdkeychain.HardenedKeyStart + account)
+			if err != nil {
+				return err
+			}
+			for branch := uint32(0); branch < 2; branch++ {
+				branchKey, err := accountKey.Neuter().Child(branch)
+				if err != nil {
+					return err
+				}
+				for index := uint32(0); index < gapLimit; index++ {
+					addr, err := asset.branchAddress(branchKey, index)
+					if err == hdkeychain.ErrInvalidChild {
+						continue
+					}
+					if err != nil {
+						return err
+					}
+					_, pkScript := addr.PaymentScript()
+					scripts[string(pkScript)] = account
+					watched = append(watched, pkScript)
+				}
+			}
+		}
+
+		lastUsed, err := asset.lastUsedRecoveryAccount(ctx, netBackend, scripts, watched, startTime)
+		if err != nil {
+			return err
+		}
+		if lastUsed <= lastAccount {
+			return nil
+		}
+		for account := lastAccount + 1; account <= lastUsed; account++ {
+			number, err := wallet.NextAccount(ctx, fmt.Sprintf("account-%d", account))
+			if err != nil {
+				return err
+			}
+			if number != account {
+				return fmt.Errorf("account %d created instead of account %d", number, account)
+			}
+		}
+		lastAccount = lastUsed
+	}
+}
+
+// lastUsedRecoveryAccount returns the last account of which a script is paid
+// to by the blocks, or 0 if none is.
+func (asset *Asset) lastUsedRecoveryAccount(ctx context.Context, netBackend w.NetworkBackend, scripts map[string]uint32, watched [][]byte, startTime time.Time) (uint32, error) {
+	wallet := asset.Internal().DCR
+	endHeight := asset.GetBestBlockHeight()
+	var lastUsed uint32
+	var progress int32 = -1
+	for height := int32(0); height <= endHeight; height++ {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		if p := int32(int64(height) * 100 / int64(endHeight+1)); p != progress {
+			progress = p
+			asset.publishRecoveryProgress(startTime, progress)
+		}
+
+		info, err := wallet.BlockInfo(ctx, w.NewBlockIdentifierFromHeight(height))
+		if err != nil {
+			return 0, err
+		}
+		key, filter, err := wallet.CFilterV2(ctx, &info.Hash)
+		if err != nil {
+			return 0, err
+		}
+		if !filter.MatchAny(key, watched) {
+			continue
+		}
+
+		blocks, err := netBackend.Blocks(ctx, []*chainhash.Hash{&info.Hash})
+		if err != nil {
+			return 0, err
+		}
+		for _, txs := range [][]*wire.MsgTx{blocks[0].Transactions, blocks[0].STransactions} {
+			for _, tx := range txs {
+				for _, txOut := range tx.TxOut {
+					account, ok := scripts[string(txOut.PkScript)]
+					if ok && txOut.Version == 0 && account > lastUsed {
+						lastUsed = account
+					}
+				}
+			}
+		}
+	}
+	return lastUsed, nil
+}
+
+// branchAddress returns the P2PKH address of the child of a branch key.
+func (asset *Asset) branchAddress(branchKey *hdkeychain.ExtendedKey, index uint32) (stdaddr.Address, error) {
+	child, err := branchKey.Child(index)
+	if err != nil {
+		return nil, err
+	}
+	return stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(stdaddr.Hash160(child.SerializedPubKey()), asset.chainParams)
+}
+
+// recoveryReport returns the report of the accounts created and the addresses
+// found by a deep recovery, from the accounts of the wallet before it.
+func (asset *Asset) recoveryReport(ctx context.Context, before map[uint32]w.AccountResult) (*sharedW.DeepRecoveryReport, error) {
+	after, err := asset.Internal().DCR.Accounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &sharedW.DeepRecoveryReport{}
+	for _, account := range after.Accounts {
+		if account.AccountNumber >= ImportedAccountNumber {
+			continue
+		}
+		prev, ok := before[account.AccountNumber]
+		if !ok {
+			prev.LastUsedExternalIndex = noUsedIndex
+			prev.LastUsedInternalIndex = noUsedIndex
+		}
+		// The indexes of the branches without used addresses wrap to 0.
+		firstExternal := prev.LastUsedExternalIndex + 1
+		extFound := account.LastUsedExternalIndex != noUsedIndex && account.LastUsedExternalIndex >= firstExternal
+		intFound := account.LastUsedInternalIndex != noUsedIndex &&
+			account.LastUsedInternalIndex >= prev.LastUsedInternalIndex+1
+		if ok && !extFound && !intFound {
+			continue
+		}
+
+		discovered := &sharedW.DiscoveredAccount{
+			Number:                int32(account.AccountNumber),
+			Name:                  account.AccountName,
+			New:                   !ok,
+			LastUsedExternalIndex: usedIndex(account.LastUsedExternalIndex),
+			LastUsedInternalIndex: usedIndex(account.LastUsedInternalIndex),
+		}
+		if extFound {
+			xpub, err := asset.Internal().DCR.AccountXpub(ctx, account.AccountNumber)
+			if err != nil {
+				return nil, err
+			}
+			branchKey, err := xpub.Child(udb.ExternalBranch)
+			if err != nil {
+				return nil, err
+			}
+			for index := firstExternal; index <= account.LastUsedExternalIndex; index++ {
+				addr, err := asset.branchAddress(branchKey, index)
+				if err == hdkeychain.ErrInvalidChild {
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				discovered.Addresses = append(discovered.Addresses, addr.String())
+			}
+		}
+		report.Accounts = append(report.Accounts, discovered)
+	}
+	return report, nil
+}
+
+// usedIndex returns the last used index of a branch, or -1 if none is used.
+func usedIndex(index uint32) int32 {
+	if index == noUsedIndex {
+		return -1
+	}
+	return int32(index)
+}
+
+// publishRecoveryProgress publishes the progress of a deep recovery to the
+// sync progress listeners.
+func (asset *Asset) publishRecoveryProgress(startTime time.Time, progress int32) {
+	elapsed := int64(time.Since(startTime).Seconds())
+	var remaining int64
+	if progress > 0 {
+		remaining = elapsed*100/int64(progress) - elapsed
+	}
+	report := &sharedW.AddressDiscoveryProgressReport{
+		GeneralSyncProgress: &sharedW.GeneralSyncProgress{
+			TotalSyncProgress:         progress,
+			TotalTimeRemainingSeconds: remaining,
+		},
+		AddressDiscoveryStartTime: startTime.Unix(),
+		TotalDiscoveryTimeSpent:   elapsed,
+		AddressDiscoveryProgress:  progress,
+	}
+	for _, listener := range asset.syncProgressListeners() {
+		listener.OnAddressDiscoveryProgress(report)
+	}
+}
@@ -100,7 +100,7 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 
 		var err error
 		if startHeight == 0 {
-			err = asset.reindexTransactions()
+			err = asset.ReindexTransactions()
 		} else {
 			err = asset.GetWalletDataDb().SaveLastIndexPoint(startHeight)
 			if err != nil {
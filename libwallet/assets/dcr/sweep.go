@@ -0,0 +1,239 @@
+package dcr
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"decred.org/dcrwallet/v3/errors"
+	w "decred.org/dcrwallet/v3/wallet"
+	"decred.org/dcrwallet/v3/wallet/txrules"
+	"decred.org/dcrwallet/v3/wallet/txsizes"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/sign"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+// decodeSweepKeys decodes the WIF encoded private keys and returns them
+// indexed by the P2PKH output scripts they can spend.
+func (asset *Asset) decodeSweepKeys(wifs []string) (map[string]*dcrutil.WIF, error) {
+	keys := make(map[string]*dcrutil.WIF)
+	for _, encoded := range wifs {
+		wif, err := dcrutil.DecodeWIF(strings.TrimSpace(encoded), asset.chainParams.PrivateKeyID)
+		if err != nil || wif.DSA() != dcrec.STEcdsaSecp256k1 {
+			return nil, errors.New(utils.ErrInvalidPrivateKey)
+		}
+
+		addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(stdaddr.Hash160(wif.PubKey()), asset.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		_, pkScript := addr.PaymentScript()
+		keys[string(pkScript)] = wif
+	}
+	if len(keys) == 0 {
+		return nil, errors.New(utils.ErrInvalidPrivateKey)
+	}
+	return keys, nil
+}
+
+// ScanSweepKeys scans the compact filters of the blocks from startHeight to
+// the best block for the unspent outputs of the WIF encoded private keys.
+// progress, if set, is called with the height of every scanned block. The
+// keys are not imported into the wallet.
+func (asset *Asset) ScanSweepKeys(ctx context.Context, wifs []string, startHeight int32, progress func(height int32)) (*sharedW.SweepScan, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+	if !asset.IsSynced() {
+		return nil, errors.New(utils.ErrNotSynced)
+	}
+
+	keys, err := asset.decodeSweepKeys(wifs)
+	if err != nil {
+		return nil, err
+	}
+	watched := make([][]byte, 0, len(keys))
+	for pkScript := range keys {
+		watched = append(watched, []byte(pkScript))
+	}
+
+	endHeight := asset.GetBestBlockHeight()
+	if startHeight < 0 || startHeight > endHeight {
+		return nil, errors.New(utils.ErrInvalid)
+	}
+
+	wallet := asset.Internal().DCR
+	n, err := wallet.NetworkBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	unspent := make(map[wire.OutPoint]*sharedW.SweepOutput)
+	for height := startHeight; height <= endHeight; height++ {
+		if ctx.Err() != nil {
+			return nil, errors.New(utils.ErrContextCanceled)
+		}
+		if progress != nil {
+			progress(height)
+		}
+
+		info, err := wallet.BlockInfo(ctx, w.NewBlockIdentifierFromHeight(height))
+		if err != nil {
+			return nil, err
+		}
+		key, filter, err := wallet.CFilterV2(ctx, &info.Hash)
+		if err != nil {
+			return nil, err
+		}
+		// The filters commit to the scripts of the spent outputs too, so
+		// the spends of the found outputs also match.
+		if !filter.MatchAny(key, watched) {
+			continue
+		}
+
+		blocks, err := n.Blocks(ctx, []*chainhash.Hash{&info.Hash})
+		if err != nil {
+			return nil, err
+		}
+		block := blocks[0]
+		// Tickets may spend the found outputs.
+		for _, tx := range block.STransactions {
+			for _, txIn := range tx.TxIn {
+				delete(unspent, txIn.PreviousOutPoint)
+			}
+		}
+		for i, tx := range block.Transactions {
+			for _, txIn := range tx.TxIn {
+				delete(unspent, txIn.PreviousOutPoint)
+			}
+			// Skip the immature coinbase outputs.
+			if i == 0 && endHeight-height+1 < int32(asset.chainParams.CoinbaseMaturity) {
+				continue
+			}
+			txHash := tx.TxHash()
+			for vout, txOut := range tx.TxOut {
+				if _, ok := keys[string(txOut.PkScript)]; !ok {
+					continue
+				}
+				unspent[*wire.NewOutPoint(&txHash, uint32(vout), wire.TxTreeRegular)] = &sharedW.SweepOutput{
+					TxHash:   txHash.String(),
+					Index:    uint32(vout),
+					Tree:     wire.TxTreeRegular,
+					Amount:   txOut.Value,
+					Height:   height,
+					PkScript: txOut.PkScript,
+				}
+			}
+		}
+	}
+
+	scan := &sharedW.SweepScan{StartHeight: startHeight, EndHeight: endHeight}
+	for _, output := range unspent {
+		scan.Outputs = append(scan.Outputs, output)
+		scan.Total += output.Amount
+	}
+	if len(scan.Outputs) == 0 {
+		return nil, errors.New(utils.ErrNothingToSweep)
+	}
+	sort.Slice(scan.Outputs, func(i, j int) bool {
+		a, b := scan.Outputs[i], scan.Outputs[j]
+		if a.Height != b.Height {
+			return a.Height < b.Height
+		}
+		if a.TxHash != b.TxHash {
+			return a.TxHash < b.TxHash
+		}
+		return a.Index < b.Index
+	})
+
+	// Estimate the fee with a placeholder of the P2PKH address the outputs
+	// are swept to.
+	if _, scan.Fee, err = asset.sweepTx(keys, scan, 0, make([]byte, txsizes.P2PKHPkScriptSize)); err != nil {
+		return nil, err
+	}
+	return scan, nil
+}
+
+// SweepKeys broadcasts a transaction sweeping the outputs found by scan to a
+// new address of account. The transaction is signed with the WIF encoded
+// private keys, which are not imported into the wallet.
+func (asset *Asset) SweepKeys(wifs []string, scan *sharedW.SweepScan, account int32) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrDCRNotInitialized
+	}
+
+	keys, err := asset.decodeSweepKeys(wifs)
+	if err != nil {
+		return "", err
+	}
+
+	address, err := asset.NextAddress(account)
+	if err != nil {
+		return "", err
+	}
+	addr, err := stdaddr.DecodeAddress(address, asset.chainParams)
+	if err != nil {
+		return "", err
+	}
+	pkScriptVer, pkScript := addr.PaymentScript()
+
+	tx, _, err := asset.sweepTx(keys, scan, pkScriptVer, pkScript)
+	if err != nil {
+		return "", err
+	}
+	for i, output := range scan.Outputs {
+		wif := keys[string(output.PkScript)]
+		tx.TxIn[i].SignatureScript, err = sign.SignatureScript(tx, i, output.PkScript,
+			txscript.SigHashAll, wif.PrivKey(), dcrec.STEcdsaSecp256k1, true)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return "", err
+	}
+	ctx, _ := asset.ShutdownContextWithCancel()
+	txHash, err := asset.Internal().DCR.PublishTransaction(ctx, tx, n)
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+	return txHash.String(), nil
+}
+
+// sweepTx returns the unsigned transaction spending the outputs of scan to
+// pkScript and its fee, which is deducted from the output.
+func (asset *Asset) sweepTx(keys map[string]*dcrutil.WIF, scan *sharedW.SweepScan, pkScriptVer uint16, pkScript []byte) (*wire.MsgTx, int64, error) {
+	tx := wire.NewMsgTx()
+	scriptSizes := make([]int, 0, len(scan.Outputs))
+	for _, output := range scan.Outputs {
+		if _, ok := keys[string(output.PkScript)]; !ok {
+			return nil, 0, errors.New(utils.ErrInvalidPrivateKey)
+		}
+		txHash, err := chainhash.NewHashFromStr(output.TxHash)
+		if err != nil {
+			return nil, 0, err
+		}
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(txHash, output.Index, output.Tree), output.Amount, nil))
+		scriptSizes = append(scriptSizes, txsizes.RedeemP2PKHSigScriptSize)
+	}
+	txOut := &wire.TxOut{Version: pkScriptVer, PkScript: pkScript}
+	tx.AddTxOut(txOut)
+
+	relayFee := asset.Internal().DCR.RelayFee()
+	size := txsizes.EstimateSerializeSize(scriptSizes, tx.TxOut, 0)
+	fee := int64(txrules.FeeForSerializeSize(relayFee, size))
+	txOut.Value = scan.Total - fee
+	if txOut.Value <= 0 || txrules.IsDustOutput(txOut, relayFee) {
+		return nil, 0, errors.New(utils.ErrInsufficientBalance)
+	}
+	return tx, fee, nil
+}
@@ -29,6 +29,7 @@ func (asset *Asset) spvSyncNotificationCallbacks() *spv.Notifications {
 		RescanStarted:                asset.rescanStarted,
 		RescanProgress:               asset.rescanProgress,
 		RescanFinished:               asset.rescanFinished,
+		MempoolTxs:                   asset.indexWatchedMempoolTxs,
 	}
 }
 
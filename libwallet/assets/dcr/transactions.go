@@ -99,6 +99,14 @@ func (asset *Asset) GetTransactionRaw(txHash string) (*sharedW.Transaction, erro
 	return asset.decodeTransactionWithTxSummary(txSummary, blockHash)
 }
 
+// SetTransactionLabel sets the label of the indexed transaction with txHash.
+func (asset *Asset) SetTransactionLabel(txHash, label string) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+	return asset.GetWalletDataDb().UpdateLabel(&sharedW.Transaction{}, txHash, label)
+}
+
 func (asset *Asset) GetTransactions(offset, limit, txFilter int32, newestFirst bool) (string, error) {
 	transactions, err := asset.GetTransactionsRaw(offset, limit, txFilter, newestFirst)
 	if err != nil {
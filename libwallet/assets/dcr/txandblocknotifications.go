@@ -18,7 +18,14 @@ func (asset *Asset) listenForTransactions() {
 				if v == nil {
 					return
 				}
+				// The transactions of an address watch wallet are saved
+				// with their watched inputs and outputs by the indexing of
+				// the watched addresses.
+				watching := asset.IsAddressWatchWallet()
 				for _, transaction := range v.UnminedTransactions {
+					if watching {
+						break
+					}
 					tempTransaction, err := asset.decodeTransactionWithTxSummary(&transaction, nil)
 					if err != nil {
 						log.Errorf("[%d] Error ntfn parse tx: %v", asset.ID, err)
@@ -46,6 +53,9 @@ func (asset *Asset) listenForTransactions() {
 				for _, block := range v.AttachedBlocks {
 					blockHash := block.Header.BlockHash()
 					for _, transaction := range block.Transactions {
+						if watching {
+							break
+						}
 						tempTransaction, err := asset.decodeTransactionWithTxSummary(&transaction, &blockHash)
 						if err != nil {
 							log.Errorf("[%d] Error ntfn parse tx: %v", asset.ID, err)
@@ -65,6 +75,7 @@ func (asset *Asset) listenForTransactions() {
 
 				if len(v.AttachedBlocks) > 0 {
 					asset.checkWalletMixers()
+					asset.startWatchedAddressesIndexing()
 				}
 
 			case <-asset.syncData.syncCanceled:
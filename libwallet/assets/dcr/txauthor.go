@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
-	"time"
 
 	"decred.org/dcrwallet/v3/errors"
 	w "decred.org/dcrwallet/v3/wallet"
@@ -208,6 +207,14 @@ func (asset *Asset) EstimateMaxSendAmount() (*sharedW.Amount, error) {
 }
 
 func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byte, error) {
+	return asset.SignAndBroadcast(privatePassphrase, transactionLabel, nil)
+}
+
+// SignAndBroadcast signs the transaction and broadcasts it to the network.
+// onSigned, if not nil, is called with the hash of the signed transaction
+// before it is broadcast, and the transaction is not broadcast if it returns
+// an error.
+func (asset *Asset) SignAndBroadcast(privatePassphrase, transactionLabel string, onSigned func(txHash string) error) ([]byte, error) {
 	if !asset.WalletOpened() {
 		return nil, utils.ErrDCRNotInitialized
 	}
@@ -218,6 +225,82 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 		return nil, err
 	}
 
+	ctx, _ := asset.ShutdownContextWithCancel()
+	relock, err := asset.UnlockForSigning(privatePassphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer relock()
+
+	reservation, err := asset.authorizeSpend()
+	if err != nil {
+		return nil, err
+	}
+	// The reserved amount no longer counts against the spending limit if the
+	// transaction is not published.
+	published := false
+	defer func() {
+		if !published {
+			asset.ReleaseSpend(reservation)
+		}
+	}()
+
+	msgTx, err := asset.signUnsignedTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if onSigned != nil {
+		if err := onSigned(msgTx.TxHash().String()); err != nil {
+			return nil, err
+		}
+	}
+
+	txHash, err := asset.Internal().DCR.PublishTransaction(ctx, msgTx, n)
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+	published = true
+	asset.RecordSpend(reservation)
+
+	return txHash[:], asset.updateTxLabel(txHash, transactionLabel)
+}
+
+// authorizeSpend checks the unsigned transaction against the spending policy
+// of the wallet. It returns the reservation of the outputs and the fee of the
+// transaction against the policy.
+func (asset *Asset) authorizeSpend() (*sharedW.SpendReservation, error) {
+	unsignedTx, err := asset.unsignedTransaction()
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+
+	outputs := make([]sharedW.TransactionDestination, 0, len(asset.TxAuthoredInfo.destinations))
+	for _, destination := range asset.TxAuthoredInfo.destinations {
+		// Sends between the accounts of the wallet are not restricted.
+		if asset.HaveAddress(destination.Address) {
+			continue
+		}
+		if destination.SendMax {
+			// The send max destination receives the change output.
+			destination.UnitAmount = 0
+			if unsignedTx.ChangeIndex >= 0 {
+				destination.UnitAmount = unsignedTx.Tx.TxOut[unsignedTx.ChangeIndex].Value
+			}
+		}
+		outputs = append(outputs, destination)
+	}
+	// The fee leaves the wallet too and counts against the spending limit.
+	fee := int64(unsignedTx.TotalInput)
+	for _, txOut := range unsignedTx.Tx.TxOut {
+		fee -= txOut.Value
+	}
+
+	return asset.CheckSpendingPolicy(outputs, fee)
+}
+
+// signUnsignedTx signs the unsigned transaction. The wallet must be unlocked.
+func (asset *Asset) signUnsignedTx(ctx context.Context) (*wire.MsgTx, error) {
 	unsignedTx, err := asset.unsignedTransaction()
 	if err != nil {
 		return nil, utils.TranslateError(err)
@@ -243,18 +326,6 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 		return nil, err
 	}
 
-	lock := make(chan time.Time, 1)
-	defer func() {
-		lock <- time.Time{}
-	}()
-
-	ctx, _ := asset.ShutdownContextWithCancel()
-	err = asset.Internal().DCR.Unlock(ctx, []byte(privatePassphrase), lock)
-	if err != nil {
-		log.Error(err)
-		return nil, errors.New(utils.ErrInvalidPassphrase)
-	}
-
 	var additionalPkScripts map[wire.OutPoint][]byte
 
 	invalidSigs, err := asset.Internal().DCR.SignTransaction(ctx, &msgTx, txscript.SigHashAll, additionalPkScripts, nil, nil)
@@ -283,15 +354,9 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 		return nil, err
 	}
 
-	txHash, err := asset.Internal().DCR.PublishTransaction(ctx, &msgTx, n)
-	if err != nil {
-		return nil, utils.TranslateError(err)
-	}
-
-	return txHash[:], asset.updateTxLabel(txHash, transactionLabel)
+	return &msgTx, nil
 }
 
-// updateTxLabel saves the tx label in the local instance.
 func (asset *Asset) updateTxLabel(hash *chainhash.Hash, txLabel string) error {
 	tx := &sharedW.Transaction{
 		Hash:  hash.String(),
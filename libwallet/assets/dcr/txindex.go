@@ -2,7 +2,10 @@ package dcr
 
 import (
 	w "decred.org/dcrwallet/v3/wallet"
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/diagnostics"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/decred/dcrd/chaincfg/chainhash"
 )
@@ -12,6 +15,10 @@ func (asset *Asset) IndexTransactions() error {
 		return utils.ErrDCRNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		return asset.indexWatchedAddresses()
+	}
+
 	ctx, _ := asset.ShutdownContextWithCancel()
 
 	var totalIndex int32
@@ -89,7 +96,9 @@ func (asset *Asset) IndexTransactions() error {
 	return asset.Internal().DCR.GetTransactions(ctx, rangeFn, startBlock, endBlock)
 }
 
-func (asset *Asset) reindexTransactions() error {
+// ReindexTransactions clears the transaction index and indexes the
+// transactions of the wallet again from the start of the chain.
+func (asset *Asset) ReindexTransactions() error {
 	err := asset.GetWalletDataDb().ClearSavedTransactions(&sharedW.Transaction{})
 	if err != nil {
 		return err
@@ -97,3 +106,76 @@ func (asset *Asset) reindexTransactions() error {
 
 	return asset.IndexTransactions()
 }
+
+// VerifyTxIndex compares the transaction index with the transactions of the
+// wallet's transaction store and adds the differences to report.
+func (asset *Asset) VerifyTxIndex(report *diagnostics.Report) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+
+	bestHeight := asset.GetBestBlockHeight()
+	lastIndexed, err := asset.GetWalletDataDb().LastIndexPoint()
+	if err != nil {
+		return err
+	}
+	if lastIndexed > bestHeight {
+		report.Add(diagnostics.CheckTxIndex, diagnostics.RepairReindex,
+			"transactions are indexed up to block %d, beyond the best block %d", lastIndexed, bestHeight)
+	}
+
+	// Unmined transactions are stored with height -1.
+	stored := make(map[string]int32)
+	rangeFn := func(block *w.Block) (bool, error) {
+		height := int32(-1)
+		if block.Header != nil {
+			height = int32(block.Header.Height)
+		}
+		for _, tx := range block.Transactions {
+			stored[tx.Hash.String()] = height
+		}
+
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		default:
+			return false, nil
+		}
+	}
+	if err := asset.Internal().DCR.GetTransactions(ctx, rangeFn, w.NewBlockIdentifierFromHeight(0), nil); err != nil {
+		report.Add(diagnostics.CheckTxStore, diagnostics.RepairRebuild, "transaction store can not be read: %v", err)
+		return nil
+	}
+
+	var indexed []*sharedW.Transaction
+	if err := asset.GetWalletDataDb().Find(q.True(), &indexed); err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	var unknown, moved int
+	for _, tx := range indexed {
+		height, ok := stored[tx.Hash]
+		switch {
+		case !ok:
+			unknown++
+		case height != tx.BlockHeight:
+			moved++
+		}
+		delete(stored, tx.Hash)
+	}
+	if len(stored) > 0 {
+		report.Add(diagnostics.CheckTxIndex, diagnostics.RepairReindex,
+			"%d stored transactions are not indexed", len(stored))
+	}
+	if unknown > 0 {
+		report.Add(diagnostics.CheckTxIndex, diagnostics.RepairReindex,
+			"%d indexed transactions are not in the transaction store", unknown)
+	}
+	if moved > 0 {
+		report.Add(diagnostics.CheckTxIndex, diagnostics.RepairReindex,
+			"%d indexed transactions have an outdated block height", moved)
+	}
+	return nil
+}
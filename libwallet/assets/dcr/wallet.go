@@ -30,6 +30,14 @@ type Asset struct {
 
 	TxAuthoredInfo *TxAuthor
 
+	// indexingWatched is set while the transactions of the watched addresses
+	// of an address watch wallet are indexed.
+	indexingWatched uint32 // atomic
+
+	// recovering is set while a deep recovery of the accounts and addresses
+	// of the wallet is running.
+	recovering uint32 // atomic
+
 	vspClientsMu sync.Mutex
 	vspClients   map[string]*vsp.Client
 	vspMu        sync.RWMutex
@@ -143,7 +151,7 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, params *sharedW
 	}
 
 	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver)
-	w, err := sharedW.CreateWatchOnlyWallet(walletName, extendedPublicKey,
+	w, err := sharedW.CreateWatchOnlyWallet(walletName, extendedPublicKey, 0,
 		ldr, params, utils.DCRWalletAsset)
 	if err != nil {
 		return nil, err
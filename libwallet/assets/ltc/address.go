@@ -31,6 +31,10 @@ func (asset *Asset) HaveAddress(address string) bool {
 		return false
 	}
 
+	if asset.IsAddressWatchWallet() {
+		return asset.HasWatchedAddress(address)
+	}
+
 	addr, err := ltcutil.DecodeAddress(address, asset.chainParams)
 	if err != nil {
 		log.Debugf("DecodeAddress failed: ", err)
@@ -97,6 +101,10 @@ func (asset *Asset) CurrentAddress(account int32) (string, error) {
 		return "", utils.ErrLTCNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		return asset.WatchedAddresses[0], nil
+	}
+
 	addr, err := asset.Internal().LTC.CurrentAddress(uint32(account), GetScope())
 	if err != nil {
 		log.Errorf("CurrentAddress error: %v", err)
@@ -117,6 +125,10 @@ func (asset *Asset) NextAddress(account int32) (string, error) {
 		return "", utils.ErrLTCNotInitialized
 	}
 
+	if asset.IsAddressWatchWallet() {
+		return "", errors.New(utils.ErrAddressWatchWallet)
+	}
+
 	// NewAddress returns the next external chained address for a wallet.
 	address, err := asset.Internal().LTC.NewAddress(uint32(account), GetScope())
 	if err != nil {
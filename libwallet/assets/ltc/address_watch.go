@@ -0,0 +1,345 @@
+package ltc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/ltcutil/gcs/builder"
+	"github.com/ltcsuite/ltcd/ltcutil/hdkeychain"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+	w "github.com/ltcsuite/ltcwallet/wallet"
+)
+
+// DecodeWatchedAddresses returns the addresses of s, separated by whitespace
+// or commas. An error is returned if one of them is not an address of the
+// network.
+func DecodeWatchedAddresses(s string, netType utils.NetworkType) ([]string, error) {
+	chainParams, err := utils.LTCChainParams(netType)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t' || r == '\r'
+	})
+	if len(addresses) == 0 {
+		return nil, errors.New(utils.ErrInvalidAddress)
+	}
+	for _, address := range addresses {
+		if _, err := decodeAddress(address, chainParams); err != nil {
+			return nil, errors.New(utils.ErrInvalidAddress)
+		}
+	}
+	return addresses, nil
+}
+
+// CreateAddressWatchWallet creates a wallet watching the transactions of
+// addresses from the block at startHeight. The wallet cannot derive new
+// addresses.
+func CreateAddressWatchWallet(walletName string, addresses []string, startHeight int32, params *sharedW.InitParams) (sharedW.Asset, error) {
+	chainParams, err := utils.LTCChainParams(params.NetType)
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range addresses {
+		if _, err := decodeAddress(address, chainParams); err != nil {
+			return nil, errors.New(utils.ErrInvalidAddress)
+		}
+	}
+
+	key, err := randomWatchKey(chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	ldr := initWalletLoader(chainParams, params.RootDir)
+	w, err := sharedW.CreateAddressWatchWallet(walletName, key, addresses,
+		ldr, params, utils.LTCWalletAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	ltcWallet := &Asset{
+		Wallet:      w,
+		chainParams: chainParams,
+		syncData: &SyncData{
+			syncProgressListeners: make(map[string]sharedW.SyncProgressListener),
+		},
+		txAndBlockNotificationListeners: make(map[string]sharedW.TxAndBlockNotificationListener),
+	}
+
+	if err := ltcWallet.prepareChain(); err != nil {
+		return nil, err
+	}
+
+	ltcWallet.SetInt32ConfigValueForKey(sharedW.WatchStartHeightConfigKey, startHeight)
+
+	ltcWallet.SetNetworkCancelCallback(ltcWallet.SafelyCancelSync)
+
+	return ltcWallet, nil
+}
+
+// randomWatchKey returns the extended public key of a new random master key.
+func randomWatchKey(chainParams *chaincfg.Params) (string, error) {
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen)
+	if err != nil {
+		return "", err
+	}
+	master, err := hdkeychain.NewMaster(seed, chainParams)
+	if err != nil {
+		return "", err
+	}
+	key, err := master.Neuter()
+	if err != nil {
+		return "", err
+	}
+	return key.String(), nil
+}
+
+// startWatchedAddressesIndexing indexes the transactions of the watched
+// addresses of an address watch wallet in the background.
+func (asset *Asset) startWatchedAddressesIndexing() {
+	if !asset.IsAddressWatchWallet() {
+		return
+	}
+	go func() {
+		if err := asset.indexWatchedAddresses(); err != nil {
+			log.Errorf("[%d] Error indexing the watched addresses: %v", asset.ID, err)
+		}
+	}()
+}
+
+// indexWatchedAddresses saves the transactions of the watched addresses in
+// the blocks after the last indexed block, found with the compact filters of
+// the blocks. Neutrino does not relay unconfirmed transactions, so the
+// transactions are only indexed once they are mined.
+func (asset *Asset) indexWatchedAddresses() error {
+	if !atomic.CompareAndSwapUint32(&asset.indexingWatched, 0, 1) {
+		// Indexing in progress already.
+		return nil
+	}
+	defer atomic.StoreUint32(&asset.indexingWatched, 0)
+
+	scripts := make(map[string]bool, len(asset.WatchedAddresses))
+	watched := make([][]byte, 0, len(asset.WatchedAddresses))
+	for _, address := range asset.WatchedAddresses {
+		addr, err := decodeAddress(address, asset.chainParams)
+		if err != nil {
+			return err
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return err
+		}
+		scripts[string(pkScript)] = true
+		watched = append(watched, pkScript)
+	}
+
+	// The transactions of the blocks that are no longer in the main chain are
+	// deleted before the watched outputs are read.
+	cs := asset.chainClient.CS
+	startHeight, err := asset.WatchIndexStart(asset.GetBestBlockHeight(), func(height int32) (string, error) {
+		hash, err := cs.GetBlockHash(int64(height))
+		if err != nil {
+			return "", err
+		}
+		return hash.String(), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The amounts of the watched outputs identify the inputs spending them.
+	outputs, err := asset.WatchedOutputs(true)
+	if err != nil {
+		return err
+	}
+	amounts := make(map[wire.OutPoint]int64, len(outputs))
+	for _, output := range outputs {
+		txHash, err := chainhash.NewHashFromStr(output.TxHash)
+		if err != nil {
+			return err
+		}
+		amounts[*wire.NewOutPoint(txHash, output.Index)] = output.Amount
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	db := asset.GetWalletDataDb()
+	height := startHeight
+	var lastHash *chainhash.Hash
+	for ; height <= asset.GetBestBlockHeight(); height++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		blockHash, err := cs.GetBlockHash(int64(height))
+		if err != nil {
+			return err
+		}
+		lastHash = blockHash
+		filter, err := cs.GetCFilter(*blockHash, wire.GCSFilterRegular)
+		if err != nil {
+			return err
+		}
+		// The filters commit to the scripts of the spent outputs too, so
+		// the spends of the watched outputs also match.
+		matched, err := filter.MatchAny(builder.DeriveKey(blockHash), watched)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+
+		block, err := cs.GetBlock(*blockHash)
+		if err != nil {
+			return err
+		}
+		timestamp := block.MsgBlock().Header.Timestamp.Unix()
+		for _, tx := range block.Transactions() {
+			summary, err := watchedTxSummary(tx.MsgTx(), scripts, amounts, timestamp)
+			if err != nil {
+				return err
+			}
+			if summary == nil {
+				continue
+			}
+
+			decodedTx := asset.decodeTransactionWithTxSummary(height, *summary)
+			if _, err := db.SaveOrUpdate(&sharedW.Transaction{}, decodedTx); err != nil {
+				return err
+			}
+			asset.publishTransactionConfirmed(decodedTx.Hash, height)
+		}
+		if err := asset.SaveWatchIndexPoint(height, blockHash.String()); err != nil {
+			return err
+		}
+	}
+	if lastHash == nil {
+		return nil
+	}
+
+	log.Debugf("[%d] Watched addresses indexed up to block %d", asset.ID, height-1)
+	return asset.SaveWatchIndexPoint(height-1, lastHash.String())
+}
+
+// watchedTxSummary returns the summary of tx if it spends or pays to the
+// watched outputs, or nil. The outputs of tx paying to the watched scripts are
+// added to amounts.
+func watchedTxSummary(tx *wire.MsgTx, scripts map[string]bool, amounts map[wire.OutPoint]int64, timestamp int64) (*w.TransactionSummary, error) {
+	summary := &w.TransactionSummary{Timestamp: timestamp}
+	var inputsTotal int64
+	for i, txIn := range tx.TxIn {
+		amount, ok := amounts[txIn.PreviousOutPoint]
+		if !ok {
+			continue
+		}
+		summary.MyInputs = append(summary.MyInputs, w.TransactionSummaryInput{
+			Index:           uint32(i),
+			PreviousAccount: sharedW.WatchedAccountNumber,
+			PreviousAmount:  ltcutil.Amount(amount),
+		})
+		inputsTotal += amount
+	}
+
+	txHash := tx.TxHash()
+	var outputsTotal int64
+	for i, txOut := range tx.TxOut {
+		outputsTotal += txOut.Value
+		if !scripts[string(txOut.PkScript)] {
+			continue
+		}
+		summary.MyOutputs = append(summary.MyOutputs, w.TransactionSummaryOutput{
+			Index:   uint32(i),
+			Account: sharedW.WatchedAccountNumber,
+		})
+		amounts[*wire.NewOutPoint(&txHash, uint32(i))] = txOut.Value
+	}
+	if len(summary.MyInputs) == 0 && len(summary.MyOutputs) == 0 {
+		return nil, nil
+	}
+
+	// The fee is only known if all the inputs are watched.
+	if len(summary.MyInputs) == len(tx.TxIn) {
+		summary.Fee = ltcutil.Amount(inputsTotal - outputsTotal)
+	}
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	summary.Hash = &txHash
+	summary.Transaction = buf.Bytes()
+	return summary, nil
+}
+
+// rescanWatchedAddresses drops the saved transactions of the watched addresses
+// and indexes them again from the start height of the wallet.
+func (asset *Asset) rescanWatchedAddresses() error {
+	if !asset.IsSynced() {
+		return errors.E(utils.ErrNotSynced)
+	}
+	if atomic.LoadUint32(&asset.indexingWatched) == 1 {
+		return errors.E(utils.ErrSyncAlreadyInProgress)
+	}
+	if err := asset.GetWalletDataDb().ClearSavedTransactions(&sharedW.Transaction{}); err != nil {
+		return err
+	}
+	asset.startWatchedAddressesIndexing()
+	return nil
+}
+
+// watchedBalance returns the balance of the watched addresses.
+func (asset *Asset) watchedBalance() (*sharedW.Balance, error) {
+	total, spendable, immature, err := asset.WatchedBalance(asset.GetBestBlockHeight(),
+		asset.RequiredConfirmations(), int32(asset.chainParams.CoinbaseMaturity))
+	if err != nil {
+		return nil, err
+	}
+	return &sharedW.Balance{
+		Total:          Amount(total),
+		Spendable:      Amount(spendable),
+		ImmatureReward: Amount(immature),
+	}, nil
+}
+
+// watchedUnspentOutputs returns the unspent outputs of the watched addresses.
+func (asset *Asset) watchedUnspentOutputs() ([]*sharedW.UnspentOutput, error) {
+	outputs, err := asset.WatchedOutputs(false)
+	if err != nil {
+		return nil, err
+	}
+
+	bestHeight := asset.GetBestBlockHeight()
+	unspents := make([]*sharedW.UnspentOutput, 0, len(outputs))
+	for _, output := range outputs {
+		addr, err := decodeAddress(output.Address, asset.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+		unspents = append(unspents, &sharedW.UnspentOutput{
+			TxID:          output.TxHash,
+			Vout:          output.Index,
+			Address:       output.Address,
+			ScriptPubKey:  hex.EncodeToString(pkScript),
+			Amount:        Amount(output.Amount),
+			Confirmations: output.Confirmations(bestHeight),
+			ReceiveTime:   time.Unix(output.Timestamp, 0),
+		})
+	}
+	return unspents, nil
+}
@@ -29,6 +29,9 @@ func (asset *Asset) RescanBlocks() error {
 // RescanBlocksFromHeight rescans the blockchain for all addresses in the wallet
 // starting from the provided block height.
 func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
+	if asset.IsAddressWatchWallet() {
+		return asset.rescanWatchedAddresses()
+	}
 	return asset.rescanBlocks(startHeight, nil)
 }
 
@@ -9,6 +9,7 @@ import (
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/txhelper"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcwallet/wallet"
 )
 
@@ -74,6 +75,31 @@ func (asset *Asset) GetTransactionRaw(txHash string) (*sharedW.Transaction, erro
 	return nil, err
 }
 
+// SetTransactionLabel sets the label of the wallet transaction with txHash.
+func (asset *Asset) SetTransactionLabel(txHash, label string) error {
+	if !asset.WalletOpened() {
+		return utils.ErrLTCNotInitialized
+	}
+
+	if asset.IsAddressWatchWallet() {
+		return asset.GetWalletDataDb().UpdateLabel(&sharedW.Transaction{}, txHash, label)
+	}
+
+	hash, err := chainhash.NewHashFromStr(txHash)
+	if err != nil {
+		return err
+	}
+	if err := asset.Internal().LTC.LabelTransaction(*hash, label, true); err != nil {
+		return err
+	}
+
+	// Reload the cached transactions to get the new label.
+	asset.txs.mu.Lock()
+	asset.txs.blockHeight = -1
+	asset.txs.mu.Unlock()
+	return nil
+}
+
 // TxMatchesFilter checks if the transaction matches the given filter.
 func (asset *Asset) TxMatchesFilter(_ *sharedW.Transaction, txFilter int32) bool {
 	return txhelper.TxDirectionInvalid != asset.ltcSupportedTxFilter(txFilter)
@@ -171,6 +197,10 @@ func (asset *Asset) filterTxs(offset, limit, txFilter int32, newestFirst bool) (
 // If startblock is less that the endblock the list return is in ascending order
 // (starts with the oldest) otherwise its in descending (starts with the newest) order.
 func (asset *Asset) getTransactionsRaw(offset, limit int32, newestFirst bool) ([]*sharedW.Transaction, error) {
+	if asset.IsAddressWatchWallet() {
+		return asset.WatchedTransactions(0, 0, utils.TxFilterAll, newestFirst)
+	}
+
 	asset.txs.mu.RLock()
 	allTxs := append(asset.txs.unminedTxs, asset.txs.minedTxs...)
 	txCacheHeight := asset.txs.blockHeight
@@ -69,6 +69,10 @@ notificationsLoop:
 				asset.publishBlockAttached(block.Height)
 			}
 
+			if len(n.AttachedBlocks) > 0 {
+				asset.startWatchedAddressesIndexing()
+			}
+
 		case <-asset.syncCtx.Done():
 			notify.Done()
 			break notificationsLoop
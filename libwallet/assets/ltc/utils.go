@@ -3,7 +3,6 @@ package ltc
 import (
 	"encoding/binary"
 
-	"decred.org/dcrwallet/v3/walletseed"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/ltcsuite/ltcd/chaincfg"
@@ -56,8 +55,8 @@ func (asset *Asset) ToAmount(v int64) sharedW.AssetAmount {
 }
 
 // DeriveAccountXpub derives the xpub for the given account.
-func (asset *Asset) DeriveAccountXpub(seedMnemonic string, account uint32, params *chaincfg.Params) (xpub string, err error) {
-	seed, err := walletseed.DecodeUserInput(seedMnemonic)
+func (asset *Asset) DeriveAccountXpub(seedMnemonic, seedPassphrase string, account uint32, params *chaincfg.Params) (xpub string, err error) {
+	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, seedPassphrase, asset.Type)
 	if err != nil {
 		return "", err
 	}
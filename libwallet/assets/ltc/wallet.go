@@ -64,6 +64,14 @@ type Asset struct {
 	// transactions from the wallet db.
 	rescanStarting uint32 // atomic
 
+	// indexingWatched is set while the transactions of the watched addresses
+	// of an address watch wallet are indexed.
+	indexingWatched uint32 // atomic
+
+	// recovering is set while a deep recovery of the accounts and addresses
+	// of the wallet is running.
+	recovering uint32 // atomic
+
 	notificationListenersMu sync.RWMutex
 
 	syncData                        *SyncData
@@ -116,6 +124,12 @@ func CreateNewWallet(pass *sharedW.AuthInfo, params *sharedW.InitParams) (shared
 		return nil, err
 	}
 
+	if seed, err := w.DecryptSeed(pass.PrivatePass); err == nil {
+		ltcWallet.saveMasterKeyFingerprint(seed, pass.SeedPassphrase)
+	} else {
+		log.Errorf("Error decrypting the wallet seed: %v", err)
+	}
+
 	ltcWallet.SetNetworkCancelCallback(ltcWallet.SafelyCancelSync)
 
 	return ltcWallet, nil
@@ -166,8 +180,15 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, params *sharedW
 		return nil, err
 	}
 
+	// Wallets imported from descriptors also know the fingerprint of the
+	// master key of the account.
+	key, fingerprint, err := parseWatchOnlyKey(extendedPublicKey, chainParams)
+	if err != nil {
+		return nil, err
+	}
+
 	ldr := initWalletLoader(chainParams, params.RootDir)
-	w, err := sharedW.CreateWatchOnlyWallet(walletName, extendedPublicKey,
+	w, err := sharedW.CreateWatchOnlyWallet(walletName, key.String(), fingerprint,
 		ldr, params, utils.LTCWalletAsset)
 	if err != nil {
 		return nil, err
@@ -223,6 +244,8 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 		return nil, err
 	}
 
+	ltcWallet.saveMasterKeyFingerprint(seedMnemonic, pass.SeedPassphrase)
+
 	ltcWallet.SetNetworkCancelCallback(ltcWallet.SafelyCancelSync)
 
 	return ltcWallet, nil
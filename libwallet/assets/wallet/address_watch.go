@@ -0,0 +1,265 @@
+package wallet
+
+import (
+	"sort"
+	"strconv"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/asdine/storm/q"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// WatchedAccountNumber is the account of the outputs paying to the
+	// addresses of an address watch wallet.
+	WatchedAccountNumber = 0
+
+	// watchedBlocksKept is the number of the last indexed blocks whose hashes
+	// are kept to find where a reorg forked from the indexed blocks.
+	watchedBlocksKept = 100
+)
+
+// WatchedOutput is an output paying to an address watched by an address watch
+// wallet.
+type WatchedOutput struct {
+	TxHash    string
+	Index     uint32
+	Address   string
+	Amount    int64
+	Height    int32
+	Timestamp int64
+	Coinbase  bool
+}
+
+// Confirmations returns the number of confirmations of the output at
+// bestHeight, which is 0 if it is not mined.
+func (output *WatchedOutput) Confirmations(bestHeight int32) int32 {
+	if output.Height == UnminedTxHeight {
+		return 0
+	}
+	return bestHeight - output.Height + 1
+}
+
+// CreateAddressWatchWallet creates a wallet watching the transactions of a
+// list of addresses. The asset wallet is a watch only wallet of
+// extendedPublicKey, a random key that keeps the wallet in sync with the
+// network and never receives funds. The transactions of the addresses are
+// found with the compact filters of the blocks and saved in the wallet data
+// db.
+func CreateAddressWatchWallet(walletName, extendedPublicKey string, addresses []string, loader loader.AssetLoader,
+	params *InitParams, assetType utils.AssetType,
+) (*Wallet, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New(utils.ErrInvalidAddress)
+	}
+
+	wallet := &Wallet{
+		Name:     walletName,
+		db:       params.DB,
+		dbDriver: params.DbDriver,
+		rootDir:  params.RootDir,
+		logDir:   params.LogDir,
+		// The random key has no history to discover.
+		HasDiscoveredAccounts: true,
+		WatchedAddresses:      addresses,
+		Type:                  assetType,
+		loader:                loader,
+		netType:               params.NetType,
+	}
+
+	return wallet.saveNewWallet(func() error {
+		err := wallet.prepare()
+		if err != nil {
+			return err
+		}
+		return wallet.createWatchingOnlyWallet(extendedPublicKey, 0)
+	})
+}
+
+// IsAddressWatchWallet returns true if the wallet watches a list of addresses
+// instead of the addresses of an extended key.
+func (wallet *Wallet) IsAddressWatchWallet() bool {
+	return len(wallet.WatchedAddresses) > 0
+}
+
+// HasWatchedAddress returns true if the wallet watches address.
+func (wallet *Wallet) HasWatchedAddress(address string) bool {
+	for _, watched := range wallet.WatchedAddresses {
+		if watched == address {
+			return true
+		}
+	}
+	return false
+}
+
+// WatchedOutputs returns the outputs of the saved transactions paying to the
+// watched addresses, oldest first. The spent outputs are only returned if
+// includeSpent is true.
+func (wallet *Wallet) WatchedOutputs(includeSpent bool) ([]*WatchedOutput, error) {
+	var txs []*Transaction
+	if err := wallet.GetWalletDataDb().Find(q.True(), &txs); err != nil {
+		return nil, err
+	}
+
+	spent := make(map[string]bool)
+	for _, tx := range txs {
+		for _, input := range tx.Inputs {
+			if input.AccountNumber == WatchedAccountNumber {
+				spent[input.PreviousOutpoint] = true
+			}
+		}
+	}
+
+	var outputs []*WatchedOutput
+	for _, tx := range txs {
+		for _, output := range tx.Outputs {
+			if output.AccountNumber != WatchedAccountNumber {
+				continue
+			}
+			if !includeSpent && spent[WatchedOutpoint(tx.Hash, uint32(output.Index))] {
+				continue
+			}
+			outputs = append(outputs, &WatchedOutput{
+				TxHash:    tx.Hash,
+				Index:     uint32(output.Index),
+				Address:   output.Address,
+				Amount:    output.Amount,
+				Height:    tx.BlockHeight,
+				Timestamp: tx.Timestamp,
+				Coinbase:  tx.Type == txhelper.TxTypeCoinBase,
+			})
+		}
+	}
+	sort.Slice(outputs, func(i, j int) bool {
+		if outputs[i].Height != outputs[j].Height {
+			return outputs[i].Height < outputs[j].Height
+		}
+		if outputs[i].TxHash != outputs[j].TxHash {
+			return outputs[i].TxHash < outputs[j].TxHash
+		}
+		return outputs[i].Index < outputs[j].Index
+	})
+	return outputs, nil
+}
+
+// WatchedBalance returns the total, spendable and immature coinbase balances
+// of the unspent outputs paying to the watched addresses.
+func (wallet *Wallet) WatchedBalance(bestHeight, requiredConfirmations, coinbaseMaturity int32) (total, spendable, immature int64, err error) {
+	outputs, err := wallet.WatchedOutputs(false)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	for _, output := range outputs {
+		total += output.Amount
+		confirmations := output.Confirmations(bestHeight)
+		switch {
+		case confirmations == 0:
+			// The unconfirmed outputs are never spendable, even if no
+			// confirmations are required.
+		case output.Coinbase && confirmations < coinbaseMaturity:
+			immature += output.Amount
+		case confirmations >= requiredConfirmations:
+			spendable += output.Amount
+		}
+	}
+	return total, spendable, immature, nil
+}
+
+// WatchIndexStart returns the height of the first block to index the watched
+// addresses from. blockHash returns the hash of the main chain block at a
+// height up to bestHeight. If the last indexed blocks are no longer in the
+// main chain, the saved transactions mined above the fork are deleted and the
+// blocks are indexed again from the fork.
+func (wallet *Wallet) WatchIndexStart(bestHeight int32, blockHash func(height int32) (string, error)) (int32, error) {
+	db := wallet.GetWalletDataDb()
+	startHeight := wallet.ReadInt32ConfigValueForKey(WatchStartHeightConfigKey, 0)
+	blocks, err := db.IndexedBlocks()
+	if err != nil {
+		return 0, err
+	}
+	if len(blocks) == 0 {
+		// The hashes of the blocks indexed before they were saved are not
+		// known, the last blocks are indexed again instead.
+		height, err := db.ReadIndexingStartBlock()
+		if err != nil {
+			return 0, err
+		}
+		if height > startHeight {
+			startHeight = height
+		}
+		return startHeight, nil
+	}
+
+	if last := blocks[len(blocks)-1]; last.Height > bestHeight {
+		// The blocks are checked once the chain is past the last indexed
+		// block again.
+		return last.Height + 1, nil
+	}
+
+	fork := -1
+	for i := len(blocks) - 1; i >= 0; i-- {
+		hash, err := blockHash(blocks[i].Height)
+		if err != nil {
+			return 0, err
+		}
+		if hash == blocks[i].Hash {
+			fork = i
+			break
+		}
+	}
+	if fork == len(blocks)-1 {
+		return blocks[fork].Height + 1, nil
+	}
+
+	// Without a common block, everything is indexed again from the start.
+	forkHeight := startHeight - 1
+	if fork >= 0 {
+		forkHeight = blocks[fork].Height
+	}
+	log.Infof("[%d] Reorg of the watched addresses blocks from height %d, indexing again from block %d",
+		wallet.ID, blocks[len(blocks)-1].Height, forkHeight+1)
+	if err := db.DeleteTransactionsAbove(&Transaction{}, forkHeight); err != nil {
+		return 0, err
+	}
+	if err := db.SaveIndexedBlocks(blocks[:fork+1]); err != nil {
+		return 0, err
+	}
+	return forkHeight + 1, nil
+}
+
+// SaveWatchIndexPoint saves the block at height with hash as the last block
+// indexed for the watched addresses.
+func (wallet *Wallet) SaveWatchIndexPoint(height int32, hash string) error {
+	db := wallet.GetWalletDataDb()
+	blocks, err := db.IndexedBlocks()
+	if err != nil {
+		return err
+	}
+	// The blocks from height replace the blocks indexed before a reorg.
+	for len(blocks) > 0 && blocks[len(blocks)-1].Height >= height {
+		blocks = blocks[:len(blocks)-1]
+	}
+	blocks = append(blocks, walletdata.IndexedBlock{Height: height, Hash: hash})
+	if len(blocks) > watchedBlocksKept {
+		blocks = blocks[len(blocks)-watchedBlocksKept:]
+	}
+	return db.SaveIndexedBlocks(blocks)
+}
+
+// WatchedTransactions returns the saved transactions of the watched
+// addresses.
+func (wallet *Wallet) WatchedTransactions(offset, limit, txFilter int32, newestFirst bool) ([]*Transaction, error) {
+	var txs []*Transaction
+	err := wallet.GetWalletDataDb().Read(offset, limit, txFilter, newestFirst, 0, 0, &txs)
+	return txs, err
+}
+
+// WatchedOutpoint returns the previous outpoint of the inputs spending the
+// output index of the transaction txHash.
+func WatchedOutpoint(txHash string, index uint32) string {
+	return txHash + ":" + strconv.FormatUint(uint64(index), 10)
+}
@@ -0,0 +1,207 @@
+package wallet
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/asdine/storm/q"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+)
+
+// watchWallet returns a wallet with a config database and a wallet data
+// database holding txs.
+func watchWallet(t *testing.T, txs ...*Transaction) *Wallet {
+	wallet := testWallet(t)
+	db, err := walletdata.Initialize(filepath.Join(t.TempDir(), "tx.db"), &Transaction{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	wallet.walletDataDB = db
+
+	for _, tx := range txs {
+		if _, err := db.SaveOrUpdate(&Transaction{}, tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return wallet
+}
+
+// watchedTx returns a transaction at height paying amount to the watched
+// addresses and spending the watched outpoints.
+func watchedTx(hash string, height int32, amount int64, spends ...string) *Transaction {
+	tx := &Transaction{
+		Hash:        hash,
+		BlockHeight: height,
+		Outputs: []*TxOutput{
+			{Index: 0, Amount: amount, Address: "watched", AccountNumber: WatchedAccountNumber},
+			{Index: 1, Amount: 1, Address: "other", AccountNumber: -1},
+		},
+	}
+	for _, outpoint := range spends {
+		tx.Inputs = append(tx.Inputs, &TxInput{PreviousOutpoint: outpoint, AccountNumber: WatchedAccountNumber})
+	}
+	return tx
+}
+
+func TestSaveWatchIndexPoint(t *testing.T) {
+	wallet := watchWallet(t)
+	for height := int32(1); height <= watchedBlocksKept+10; height++ {
+		if err := wallet.SaveWatchIndexPoint(height, fmt.Sprint("main", height)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The blocks of a new branch replace the blocks from its first height.
+	if err := wallet.SaveWatchIndexPoint(watchedBlocksKept+5, "fork"); err != nil {
+		t.Fatal(err)
+	}
+
+	blocks, err := wallet.GetWalletDataDb().IndexedBlocks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != watchedBlocksKept-5 {
+		t.Fatalf("%d blocks kept, want %d", len(blocks), watchedBlocksKept-5)
+	}
+	if first := blocks[0]; first.Height != 11 || first.Hash != "main11" {
+		t.Errorf("first block %+v, want main11 at 11", first)
+	}
+	if last := blocks[len(blocks)-1]; last.Height != watchedBlocksKept+5 || last.Hash != "fork" {
+		t.Errorf("last block %+v, want fork at %d", last, watchedBlocksKept+5)
+	}
+}
+
+func TestWatchIndexStart(t *testing.T) {
+	tests := []struct {
+		name       string
+		bestHeight int32
+		// forkHeight is the height of the last indexed block still in the
+		// main chain.
+		forkHeight int32
+		start      int32
+		lastBlock  int32
+		txs        []string
+	}{
+		{"no reorg", 25, 19, 20, 19, []string{"a", "b", "unmined"}},
+		{"chain behind the indexed blocks", 18, 0, 20, 19, []string{"a", "b", "unmined"}},
+		{"fork inside the kept blocks", 25, 15, 16, 15, []string{"a", "unmined"}},
+		{"no common block", 25, 0, 10, 0, []string{"unmined"}},
+	}
+
+	for _, test := range tests {
+		wallet := watchWallet(t,
+			watchedTx("a", 12, 10),
+			watchedTx("b", 17, 10),
+			watchedTx("unmined", UnminedTxHeight, 10),
+		)
+		if err := wallet.walletConfigSave(false, WatchStartHeightConfigKey, int32(10)); err != nil {
+			t.Fatal(err)
+		}
+		for height := int32(10); height <= 19; height++ {
+			if err := wallet.SaveWatchIndexPoint(height, fmt.Sprint("main", height)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		blockHash := func(height int32) (string, error) {
+			if height > test.bestHeight {
+				t.Fatalf("%s: hash of block %d above the best block", test.name, height)
+			}
+			if height > test.forkHeight {
+				return fmt.Sprint("fork", height), nil
+			}
+			return fmt.Sprint("main", height), nil
+		}
+		start, err := wallet.WatchIndexStart(test.bestHeight, blockHash)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if start != test.start {
+			t.Errorf("%s: start %d, want %d", test.name, start, test.start)
+		}
+
+		db := wallet.GetWalletDataDb()
+		blocks, err := db.IndexedBlocks()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var lastBlock int32
+		if len(blocks) > 0 {
+			lastBlock = blocks[len(blocks)-1].Height
+		}
+		if lastBlock != test.lastBlock {
+			t.Errorf("%s: last indexed block %d, want %d", test.name, lastBlock, test.lastBlock)
+		}
+
+		var txs []*Transaction
+		if err := db.Find(q.True(), &txs); err != nil {
+			t.Fatal(err)
+		}
+		hashes := make([]string, 0, len(txs))
+		for _, tx := range txs {
+			hashes = append(hashes, tx.Hash)
+		}
+		sort.Strings(hashes)
+		if !reflect.DeepEqual(hashes, test.txs) {
+			t.Errorf("%s: txs %v, want %v", test.name, hashes, test.txs)
+		}
+	}
+}
+
+func TestWatchedOutputs(t *testing.T) {
+	coinbase := watchedTx("coinbase", 95, 500)
+	coinbase.Type = txhelper.TxTypeCoinBase
+	wallet := watchWallet(t,
+		watchedTx("a", 10, 100),
+		// b spends the watched output of a.
+		watchedTx("b", 20, 70, WatchedOutpoint("a", 0)),
+		coinbase,
+		watchedTx("unmined", UnminedTxHeight, 30),
+	)
+
+	outpoints := func(includeSpent bool) []string {
+		outputs, err := wallet.WatchedOutputs(includeSpent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		outpoints := make([]string, 0, len(outputs))
+		for _, output := range outputs {
+			outpoints = append(outpoints, WatchedOutpoint(output.TxHash, output.Index))
+		}
+		return outpoints
+	}
+	if got, want := outpoints(false), []string{"unmined:0", "b:0", "coinbase:0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unspent outputs %v, want %v", got, want)
+	}
+	if got, want := outpoints(true), []string{"unmined:0", "a:0", "b:0", "coinbase:0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("all outputs %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name                  string
+		bestHeight            int32
+		requiredConfirmations int32
+		spendable, immature   int64
+	}{
+		{"immature coinbase", 100, 6, 70, 500},
+		{"coinbase one block before maturity", 193, 6, 70, 500},
+		{"mature coinbase", 194, 6, 570, 0},
+		{"not enough confirmations", 100, 90, 0, 500},
+		{"no confirmations required", 100, 0, 70, 500},
+	}
+	for _, test := range tests {
+		total, spendable, immature, err := wallet.WatchedBalance(test.bestHeight, test.requiredConfirmations, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 600 || spendable != test.spendable || immature != test.immature {
+			t.Errorf("%s: balance %d/%d/%d, want 600/%d/%d", test.name, total, spendable, immature,
+				test.spendable, test.immature)
+		}
+	}
+}
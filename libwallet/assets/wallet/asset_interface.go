@@ -2,6 +2,8 @@ package wallet
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
@@ -31,16 +33,20 @@ type Asset interface {
 	GetWalletID() int
 	GetWalletName() string
 	IsWatchingOnlyWallet() bool
+	IsAddressWatchWallet() bool
 	UnlockWallet(string) error
 	DeleteWallet(privPass string) error
 	RenameWallet(newName string) error
 	DecryptSeed(privatePassphrase string) (string, error)
 	VerifySeedForWallet(seedMnemonic, privpass string) (bool, error)
+	SplitSeed(privatePassphrase string, threshold, count int) ([]string, error)
+	VerifySeedSharesForWallet(shares []string, privpass string) (bool, error)
 	ChangePrivatePassphraseForWallet(oldPrivatePassphrase, newPrivatePassphrase string, privatePassphraseType int32) error
 
 	RootDir() string
 	DataDir() string
 	GetEncryptedSeed() string
+	GetSeedFormat() SeedFormat
 	IsConnectedToNetwork() bool
 	NetType() utils.NetworkType
 	ToAmount(v int64) AssetAmount
@@ -50,12 +56,16 @@ type Asset interface {
 	RequiredConfirmations() int32
 	ShutdownContextWithCancel() (context.Context, context.CancelFunc)
 	LogFile() string
+	Databases() map[string]func(w io.Writer) error
+	ResealWalletData() error
+	SealWalletDataPlaintext() error
 
 	PublishUnminedTransactions() error
 	CountTransactions(txFilter int32) (int, error)
 	GetTransactionRaw(txHash string) (*Transaction, error)
 	TxMatchesFilter(tx *Transaction, txFilter int32) bool
 	GetTransactionsRaw(offset, limit, txFilter int32, newestFirst bool) ([]*Transaction, error)
+	SetTransactionLabel(txHash, label string) error
 
 	GetBestBlock() *BlockInfo
 	GetBestBlockHeight() int32
@@ -89,6 +99,8 @@ type Asset interface {
 
 	SaveUserConfigValue(key string, value interface{})
 	ReadUserConfigValue(key string, valueOut interface{}) error
+	UserConfigValues() (map[string]json.RawMessage, error)
+	DeleteUserConfigValueForKey(key string)
 
 	SetBoolConfigValueForKey(key string, value bool)
 	SetDoubleConfigValueForKey(key string, value float64)
@@ -108,6 +120,14 @@ type Asset interface {
 	AddSendDestination(address string, unitAmount int64, sendMax bool) error
 	ComputeTxSizeEstimation(dstAddress string, utxos []*UnspentOutput) (int, error)
 	Broadcast(passphrase, label string) ([]byte, error)
+	SignAndBroadcast(passphrase, label string, onSigned func(txHash string) error) ([]byte, error)
+	SpendingPolicy() (*SpendingPolicy, error)
+	SetSpendingPolicy(privatePassphrase string, policy *SpendingPolicy) error
 	EstimateFeeAndSize() (*TxFeeAndSize, error)
 	IsUnsignedTxExist() bool
+
+	ScanSweepKeys(ctx context.Context, wifs []string, startHeight int32, progress func(height int32)) (*SweepScan, error)
+	SweepKeys(wifs []string, scan *SweepScan, account int32) (string, error)
+
+	DeepRecovery(ctx context.Context, gapLimit, accountGapLimit uint32, privatePassphrase string) (*DeepRecoveryReport, error)
 }
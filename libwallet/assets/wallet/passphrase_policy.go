@@ -0,0 +1,58 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/passphrase"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// ReadPassphrasePolicy returns the policy of the passphrases of passType saved
+// in db, or the default policy of passType if none is saved or db is nil.
+func ReadPassphrasePolicy(db AssetsManagerDB, passType int32) *passphrase.Policy {
+	var policies map[int32]*passphrase.Policy
+	if db != nil {
+		_ = db.ReadWalletConfigValue(PassphrasePoliciesConfigKey, &policies)
+	}
+	if policy, ok := policies[passType]; ok && policy != nil {
+		return policy
+	}
+
+	policy := passphrase.DefaultPasswordPolicy
+	if passType == PassphraseTypePin {
+		policy = passphrase.DefaultPINPolicy
+	}
+	return &policy
+}
+
+// checkPassphrasePolicy returns an error if the new private passphrase of the
+// wallet does not comply with the policy of passType.
+func (wallet *Wallet) checkPassphrasePolicy(privatePassphrase string, passType int32) error {
+	return ReadPassphrasePolicy(wallet, passType).Check(privatePassphrase, wallet.Name)
+}
+
+// unlockWithBackoff unlocks the wallet with unlock, which returns the
+// translated unlock error. The failed attempts with a wrong passphrase are
+// rate limited with an exponential delay.
+func (wallet *Wallet) unlockWithBackoff(unlock func() error) error {
+	wallet.unlockMu.Lock()
+	defer wallet.unlockMu.Unlock()
+
+	var backoff passphrase.Backoff
+	_ = wallet.ReadUserConfigValue(UnlockBackoffConfigKey, &backoff)
+	now := time.Now()
+	if err := backoff.Check(now); err != nil {
+		return err
+	}
+
+	err := unlock()
+	switch {
+	case err != nil && err.Error() == utils.ErrInvalidPassphrase:
+		backoff.Fail(now)
+		wallet.SaveUserConfigValue(UnlockBackoffConfigKey, backoff)
+		log.Warnf("Wallet %d: failed unlock attempt %d", wallet.ID, backoff.Failures)
+	case err == nil && backoff.Failures > 0:
+		wallet.DeleteUserConfigValueForKey(UnlockBackoffConfigKey)
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/passphrase"
+)
+
+func TestUnlockForSigningBackoff(t *testing.T) {
+	wallet := testWallet(t)
+
+	// Fail until the next attempt must wait.
+	var backoff passphrase.Backoff
+	now := time.Now()
+	for backoff.Check(now) == nil {
+		backoff.Fail(now)
+	}
+	if err := wallet.walletConfigSave(false, UnlockBackoffConfigKey, backoff); err != nil {
+		t.Fatal(err)
+	}
+
+	// The wallet has no loader, the attempt must be refused before the
+	// passphrase is checked.
+	relock, err := wallet.UnlockForSigning("passphrase")
+	var retryErr *passphrase.RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("error %v, want a retry error", err)
+	}
+	if relock != nil {
+		t.Error("relock returned for a refused unlock")
+	}
+
+	// A refused attempt is not counted as a failure.
+	var saved passphrase.Backoff
+	if err := wallet.ReadUserConfigValue(UnlockBackoffConfigKey, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Failures != backoff.Failures {
+		t.Errorf("%d failures saved, want %d", saved.Failures, backoff.Failures)
+	}
+}
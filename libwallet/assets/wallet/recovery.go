@@ -0,0 +1,27 @@
+package wallet
+
+// DiscoveredAccount is an account of which a deep recovery found used
+// addresses that the wallet did not track before.
+type DiscoveredAccount struct {
+	Number int32
+	Name   string
+	// New is true if the account was created by the recovery.
+	New bool
+	// LastUsedExternalIndex and LastUsedInternalIndex are the indexes of the
+	// last used addresses of the branches, or -1 if none is used.
+	LastUsedExternalIndex int32
+	LastUsedInternalIndex int32
+	// Addresses are the external addresses of the account up to the last used
+	// one that the wallet did not track before the recovery.
+	Addresses []string
+}
+
+// DeepRecoveryReport is the result of a deep recovery of the accounts and
+// addresses of a wallet with the gap limits GapLimit and AccountGapLimit from
+// the block at StartHeight.
+type DeepRecoveryReport struct {
+	GapLimit        uint32
+	AccountGapLimit uint32
+	StartHeight     int32
+	Accounts        []*DiscoveredAccount
+}
@@ -0,0 +1,364 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/asdine/storm"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	SpendingPolicyConfigKey  = "spending_policy"
+	SpendingHistoryConfigKey = "spending_history"
+	DelayedSpendsConfigKey   = "delayed_spends"
+
+	// delayedSpendExpiry is how long a delayed send can be confirmed after
+	// its cooling-off delay before it has to be delayed again.
+	delayedSpendExpiry = 24 * time.Hour
+)
+
+// SpendingPeriod is the rolling period of the spending limit of a policy.
+type SpendingPeriod int
+
+const (
+	SpendingPeriodNone SpendingPeriod = iota
+	SpendingPeriodDaily
+	SpendingPeriodWeekly
+)
+
+// Duration returns the length of the period.
+func (p SpendingPeriod) Duration() time.Duration {
+	switch p {
+	case SpendingPeriodDaily:
+		return 24 * time.Hour
+	case SpendingPeriodWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// SpendingPolicy restricts the sends of a wallet. Amounts are in the smallest
+// unit of the wallet's asset.
+type SpendingPolicy struct {
+	// Limit is the maximum amount sent in a rolling Period. It is not
+	// enforced if it is zero or Period is SpendingPeriodNone.
+	Period SpendingPeriod
+	Limit  int64
+
+	// AllowedAddresses are the only addresses the wallet can send to if it
+	// is not empty. The addresses of the wallet are always allowed.
+	AllowedAddresses []string
+
+	// Sends above DelayThreshold must be confirmed again after Delay.
+	DelayThreshold int64
+	Delay          time.Duration
+}
+
+// IsEmpty returns true if the policy does not restrict any send.
+func (p *SpendingPolicy) IsEmpty() bool {
+	return (p.Limit == 0 || p.Period == SpendingPeriodNone) &&
+		len(p.AllowedAddresses) == 0 && p.Delay == 0
+}
+
+// validate checks that the values of the policy are consistent.
+func (p *SpendingPolicy) validate() error {
+	if p.Period.Duration() == 0 && p.Period != SpendingPeriodNone {
+		return fmt.Errorf("invalid spending period %d", p.Period)
+	}
+	if p.Limit < 0 || p.DelayThreshold < 0 || p.Delay < 0 {
+		return errors.E(errors.Invalid, "negative spending policy value")
+	}
+	for _, address := range p.AllowedAddresses {
+		if strings.TrimSpace(address) == "" {
+			return errors.E(errors.Invalid, "empty allowed address")
+		}
+	}
+	return nil
+}
+
+// SpendDelayedError is returned when a send above the delay threshold of the
+// spending policy must be confirmed again after its cooling-off delay.
+type SpendDelayedError struct {
+	ReadyAt time.Time
+}
+
+func (e *SpendDelayedError) Error() string {
+	return utils.ErrSpendDelayed
+}
+
+// spendRecord is a send counted against the spending limit. ID identifies
+// the reservation of the send.
+type spendRecord struct {
+	ID     string `json:",omitempty"`
+	Time   time.Time
+	Amount int64
+}
+
+// delayedSpend is a send waiting for the cooling-off delay of the policy.
+type delayedSpend struct {
+	ID          string
+	RequestedAt time.Time
+}
+
+// SpendingPolicy returns the spending policy of the wallet, or nil if it has
+// none.
+func (wallet *Wallet) SpendingPolicy() (*SpendingPolicy, error) {
+	var policy *SpendingPolicy
+	err := wallet.ReadUserConfigValue(SpendingPolicyConfigKey, &policy)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SetSpendingPolicy replaces the spending policy of the wallet after
+// verifying privatePassphrase. An empty or nil policy removes it.
+func (wallet *Wallet) SetSpendingPolicy(privatePassphrase string, policy *SpendingPolicy) error {
+	if policy != nil {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+	}
+	if err := wallet.verifyPrivatePassphrase(privatePassphrase); err != nil {
+		return err
+	}
+
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	wallet.DeleteUserConfigValueForKey(DelayedSpendsConfigKey)
+	if policy == nil || policy.IsEmpty() {
+		wallet.DeleteUserConfigValueForKey(SpendingPolicyConfigKey)
+		return nil
+	}
+	return wallet.walletConfigSave(false, SpendingPolicyConfigKey, policy)
+}
+
+// verifyPrivatePassphrase checks privatePassphrase by unlocking the wallet.
+// The wallet is locked again if it was locked.
+func (wallet *Wallet) verifyPrivatePassphrase(privatePassphrase string) error {
+	wasLocked := wallet.IsLocked()
+	if err := wallet.UnlockWallet(privatePassphrase); err != nil {
+		return err
+	}
+	if wasLocked {
+		wallet.LockWallet()
+	}
+	return nil
+}
+
+// SpendReservation is the amount of a send counted against the spending limit
+// of a wallet by CheckSpendingPolicy until the send is recorded or released.
+type SpendReservation struct {
+	id      string
+	outputs []TransactionDestination
+}
+
+// CheckSpendingPolicy returns an error if the spending policy of the wallet
+// does not allow sending to outputs with fee, which must not include the
+// addresses of the wallet. The first attempt of a send above the delay
+// threshold starts its cooling-off delay.
+//
+// An allowed send is reserved against the spending limit in the same check so
+// that concurrent sends can not exceed it together. The reservation must be
+// passed to RecordSpend once the send is published, or to ReleaseSpend if it
+// is not.
+func (wallet *Wallet) CheckSpendingPolicy(outputs []TransactionDestination, fee int64) (*SpendReservation, error) {
+	policy, err := wallet.SpendingPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, output := range outputs {
+		total += output.UnitAmount
+	}
+
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	now := time.Now()
+	var history []spendRecord
+	err = wallet.ReadUserConfigValue(SpendingHistoryConfigKey, &history)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	if policy != nil {
+		if err := policy.check(outputs, total+fee, history, now); err != nil {
+			return nil, err
+		}
+		if err := wallet.checkSpendDelay(policy, outputs, total, now); err != nil {
+			return nil, err
+		}
+	}
+
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	reservation := &SpendReservation{id: hex.EncodeToString(id[:]), outputs: outputs}
+
+	// Sends older than the longest period are no longer counted.
+	records := []spendRecord{{ID: reservation.id, Time: now, Amount: total + fee}}
+	for _, record := range history {
+		if now.Sub(record.Time) < SpendingPeriodWeekly.Duration() {
+			records = append(records, record)
+		}
+	}
+	if err := wallet.walletConfigSave(false, SpendingHistoryConfigKey, records); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// check returns an error if the policy does not allow sending amount to
+// outputs given the history of the sends.
+func (p *SpendingPolicy) check(outputs []TransactionDestination, amount int64, history []spendRecord, now time.Time) error {
+	if len(p.AllowedAddresses) > 0 {
+		allowed := make(map[string]bool, len(p.AllowedAddresses))
+		for _, address := range p.AllowedAddresses {
+			allowed[address] = true
+		}
+		for _, output := range outputs {
+			if !allowed[output.Address] {
+				return errors.New(utils.ErrDestinationNotAllowed)
+			}
+		}
+	}
+
+	if period := p.Period.Duration(); period > 0 && p.Limit > 0 {
+		spent := amount
+		for _, record := range history {
+			if now.Sub(record.Time) < period {
+				spent += record.Amount
+			}
+		}
+		if spent > p.Limit {
+			return errors.New(utils.ErrSpendingLimitExceeded)
+		}
+	}
+	return nil
+}
+
+// checkSpendDelay returns a SpendDelayedError if the send of total to outputs
+// is above the delay threshold of the policy and its cooling-off delay has
+// not passed yet. The first attempt of the send starts the delay. It must be
+// called with spendingMu held.
+func (wallet *Wallet) checkSpendDelay(policy *SpendingPolicy, outputs []TransactionDestination, total int64, now time.Time) error {
+	if policy.Delay == 0 || total <= policy.DelayThreshold {
+		return nil
+	}
+
+	delayed, err := wallet.delayedSpends(now, policy.Delay)
+	if err != nil {
+		return err
+	}
+	id := spendID(outputs)
+	for _, spend := range delayed {
+		if spend.ID != id {
+			continue
+		}
+		readyAt := spend.RequestedAt.Add(policy.Delay)
+		if now.Before(readyAt) {
+			return &SpendDelayedError{ReadyAt: readyAt}
+		}
+		return nil
+	}
+
+	delayed = append(delayed, delayedSpend{ID: id, RequestedAt: now})
+	if err := wallet.walletConfigSave(false, DelayedSpendsConfigKey, delayed); err != nil {
+		return err
+	}
+	log.Infof("Send of %d delayed by the spending policy of wallet %d", total, wallet.ID)
+	return &SpendDelayedError{ReadyAt: now.Add(policy.Delay)}
+}
+
+// RecordSpend keeps the reservation of a published send counted against the
+// spending limit of the wallet and clears its cooling-off delay.
+func (wallet *Wallet) RecordSpend(reservation *SpendReservation) {
+	if reservation == nil {
+		return
+	}
+
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	var delayed []delayedSpend
+	if err := wallet.ReadUserConfigValue(DelayedSpendsConfigKey, &delayed); err != nil {
+		return
+	}
+	id := spendID(reservation.outputs)
+	for i, spend := range delayed {
+		if spend.ID == id {
+			delayed = append(delayed[:i], delayed[i+1:]...)
+			wallet.SaveUserConfigValue(DelayedSpendsConfigKey, delayed)
+			break
+		}
+	}
+}
+
+// ReleaseSpend removes the reservation of a send that was not published from
+// the spending limit of the wallet.
+func (wallet *Wallet) ReleaseSpend(reservation *SpendReservation) {
+	if reservation == nil {
+		return
+	}
+
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	var history []spendRecord
+	if err := wallet.ReadUserConfigValue(SpendingHistoryConfigKey, &history); err != nil {
+		return
+	}
+	for i, record := range history {
+		if record.ID == reservation.id {
+			history = append(history[:i], history[i+1:]...)
+			wallet.SaveUserConfigValue(SpendingHistoryConfigKey, history)
+			break
+		}
+	}
+}
+
+// delayedSpends returns the delayed sends that have not expired.
+func (wallet *Wallet) delayedSpends(now time.Time, delay time.Duration) ([]delayedSpend, error) {
+	var delayed []delayedSpend
+	err := wallet.ReadUserConfigValue(DelayedSpendsConfigKey, &delayed)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	pending := delayed[:0]
+	for _, spend := range delayed {
+		if now.Sub(spend.RequestedAt) < delay+delayedSpendExpiry {
+			pending = append(pending, spend)
+		}
+	}
+	return pending, nil
+}
+
+// spendID identifies a send by its destinations. The amount of a send max
+// destination is left out as it changes with the fee.
+func spendID(outputs []TransactionDestination) string {
+	parts := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		amount := fmt.Sprint(output.UnitAmount)
+		if output.SendMax {
+			amount = "max"
+		}
+		parts = append(parts, output.Address+":"+amount)
+	}
+	sort.Strings(parts)
+	hash := sha256.Sum256([]byte(strings.Join(parts, ",")))
+	return hex.EncodeToString(hash[:])
+}
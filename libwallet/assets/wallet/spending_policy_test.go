@@ -0,0 +1,186 @@
+package wallet
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asdine/storm"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// testWallet returns a wallet with a config database and no asset wallet.
+func testWallet(t *testing.T) *Wallet {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "wallets.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &Wallet{ID: 1, db: db}
+}
+
+func TestSpendingPolicyCheck(t *testing.T) {
+	now := time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC)
+	history := []spendRecord{
+		{Time: now.Add(-time.Hour), Amount: 30},
+		{Time: now.Add(-48 * time.Hour), Amount: 40},
+	}
+	to := func(addresses ...string) []TransactionDestination {
+		outputs := make([]TransactionDestination, 0, len(addresses))
+		for _, address := range addresses {
+			outputs = append(outputs, TransactionDestination{Address: address})
+		}
+		return outputs
+	}
+
+	tests := []struct {
+		name    string
+		policy  SpendingPolicy
+		outputs []TransactionDestination
+		amount  int64
+		err     string
+	}{{
+		name:    "no restriction",
+		outputs: to("a"),
+		amount:  1000,
+	}, {
+		name:    "allowed address",
+		policy:  SpendingPolicy{AllowedAddresses: []string{"a", "b"}},
+		outputs: to("a", "b"),
+	}, {
+		name:    "address not allowed",
+		policy:  SpendingPolicy{AllowedAddresses: []string{"a"}},
+		outputs: to("a", "c"),
+		err:     utils.ErrDestinationNotAllowed,
+	}, {
+		name:    "within the daily limit",
+		policy:  SpendingPolicy{Period: SpendingPeriodDaily, Limit: 100},
+		outputs: to("a"),
+		amount:  70,
+	}, {
+		name:    "above the daily limit",
+		policy:  SpendingPolicy{Period: SpendingPeriodDaily, Limit: 100},
+		outputs: to("a"),
+		amount:  71,
+		err:     utils.ErrSpendingLimitExceeded,
+	}, {
+		name:    "above the weekly limit",
+		policy:  SpendingPolicy{Period: SpendingPeriodWeekly, Limit: 100},
+		outputs: to("a"),
+		amount:  31,
+		err:     utils.ErrSpendingLimitExceeded,
+	}, {
+		name:    "limit without a period",
+		policy:  SpendingPolicy{Limit: 1},
+		outputs: to("a"),
+		amount:  1000,
+	}}
+
+	for _, test := range tests {
+		err := test.policy.check(test.outputs, test.amount, history, now)
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: error %v", test.name, err)
+		case test.err != "" && (err == nil || err.Error() != test.err):
+			t.Errorf("%s: error %v, want %s", test.name, err, test.err)
+		}
+	}
+}
+
+func TestCheckSpendDelay(t *testing.T) {
+	wallet := testWallet(t)
+	policy := &SpendingPolicy{DelayThreshold: 100, Delay: time.Hour}
+	outputs := []TransactionDestination{{Address: "a", UnitAmount: 150}}
+	requestedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// The steps run in order against the same delayed sends.
+	tests := []struct {
+		name    string
+		total   int64
+		now     time.Time
+		readyAt time.Time
+	}{
+		{"below the threshold", 100, requestedAt, time.Time{}},
+		{"first attempt", 150, requestedAt, requestedAt.Add(time.Hour)},
+		{"before the delay", 150, requestedAt.Add(59 * time.Minute), requestedAt.Add(time.Hour)},
+		{"after the delay", 150, requestedAt.Add(time.Hour), time.Time{}},
+		{"after the expiry", 150, requestedAt.Add(time.Hour + delayedSpendExpiry), requestedAt.Add(2*time.Hour + delayedSpendExpiry)},
+	}
+
+	for _, test := range tests {
+		err := wallet.checkSpendDelay(policy, outputs, test.total, test.now)
+		var delayedErr *SpendDelayedError
+		switch {
+		case test.readyAt.IsZero() && err != nil:
+			t.Errorf("%s: error %v", test.name, err)
+		case !test.readyAt.IsZero() && !errors.As(err, &delayedErr):
+			t.Errorf("%s: error %v, want a delay", test.name, err)
+		case !test.readyAt.IsZero() && !delayedErr.ReadyAt.Equal(test.readyAt):
+			t.Errorf("%s: ready at %v, want %v", test.name, delayedErr.ReadyAt, test.readyAt)
+		}
+	}
+}
+
+func TestSpendReservations(t *testing.T) {
+	wallet := testWallet(t)
+	policy := &SpendingPolicy{Period: SpendingPeriodDaily, Limit: 100}
+	if err := wallet.walletConfigSave(false, SpendingPolicyConfigKey, policy); err != nil {
+		t.Fatal(err)
+	}
+	outputs := []TransactionDestination{{Address: "a", UnitAmount: 30}}
+
+	// Only two sends of 30 with a fee of 5 fit in the limit together.
+	var (
+		wg           sync.WaitGroup
+		mu           sync.Mutex
+		reservations []*SpendReservation
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reservation, err := wallet.CheckSpendingPolicy(outputs, 5)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			reservations = append(reservations, reservation)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if len(reservations) != 2 {
+		t.Fatalf("%d sends reserved, want 2", len(reservations))
+	}
+
+	for _, reservation := range reservations {
+		wg.Add(1)
+		go func(reservation *SpendReservation) {
+			defer wg.Done()
+			wallet.ReleaseSpend(reservation)
+		}(reservation)
+	}
+	wg.Wait()
+
+	var history []spendRecord
+	if err := wallet.ReadUserConfigValue(SpendingHistoryConfigKey, &history); err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 0 {
+		t.Fatalf("%d sends left after the release", len(history))
+	}
+
+	// The released sends no longer count against the limit.
+	full := []TransactionDestination{{Address: "a", UnitAmount: 100}}
+	reservation, err := wallet.CheckSpendingPolicy(full, 0)
+	if err != nil {
+		t.Fatalf("send of the full limit refused: %v", err)
+	}
+	wallet.RecordSpend(reservation)
+	if _, err := wallet.CheckSpendingPolicy(outputs, 0); err == nil {
+		t.Error("send above the recorded limit allowed")
+	}
+}
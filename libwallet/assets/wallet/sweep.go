@@ -0,0 +1,23 @@
+package wallet
+
+// SweepOutput is an unspent output paying to one of the private keys being
+// swept.
+type SweepOutput struct {
+	TxHash   string
+	Index    uint32
+	Tree     int8 // Only set for DCR.
+	Amount   int64
+	Height   int32
+	PkScript []byte
+}
+
+// SweepScan is the result of a scan of the blocks from StartHeight to
+// EndHeight for the unspent outputs of the private keys being swept. Fee is
+// the estimated fee of the transaction sweeping the outputs.
+type SweepScan struct {
+	Outputs     []*SweepOutput
+	Total       int64
+	Fee         int64
+	StartHeight int32
+	EndHeight   int32
+}
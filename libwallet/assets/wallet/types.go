@@ -51,6 +51,36 @@ type AuthInfo struct {
 	Name            string
 	PrivatePass     string
 	PrivatePassType int32
+
+	// SeedFormat is the format of the seed generated for a new wallet.
+	SeedFormat SeedFormat
+	// SeedPassphrase is the optional BIP39 passphrase that protects the seed.
+	SeedPassphrase string
+}
+
+// SeedFormat is the encoding of a wallet seed mnemonic.
+type SeedFormat int32
+
+const (
+	// SeedFormatPGP is the 33 word PGP word list seed of Decred wallets. It
+	// is the zero value so that wallets created before seed formats were
+	// recorded keep their format.
+	SeedFormatPGP SeedFormat = iota
+	// SeedFormatBIP39 is a 12 to 24 word BIP39 mnemonic with an optional
+	// passphrase, supported by BTC and LTC wallets.
+	SeedFormatBIP39
+)
+
+// String returns the display name of the seed format.
+func (format SeedFormat) String() string {
+	switch format {
+	case SeedFormatPGP:
+		return "PGP"
+	case SeedFormatBIP39:
+		return "BIP39"
+	default:
+		return "unknown"
+	}
 }
 
 type BlockInfo struct {
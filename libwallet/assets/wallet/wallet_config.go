@@ -1,9 +1,15 @@
 package wallet
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/asdine/storm"
+	stormjson "github.com/asdine/storm/codec/json"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/crypto-power/cryptopower/libwallet/dbcrypt"
 )
 
 const (
@@ -19,7 +25,10 @@ const (
 	PrivacyModeConfigKey        = "privacy_mode"
 	SpendUnconfirmedConfigKey   = "spend_unconfirmed"
 	CurrencyConversionConfigKey = "currency_conversion_option"
+	FiatCurrencyConfigKey       = "fiat_currency"
 
+	StartupPassphraseConfigKey    = "startup-passphrase"
+	DBKeysConfigKey               = "db_keys"
 	IsStartupSecuritySetConfigKey = "startup_security_set"
 	StartupSecurityTypeConfigKey  = "startup_security_type"
 	UseBiometricConfigKey         = "use_biometric"
@@ -56,20 +65,62 @@ const (
 	LanguagePreferenceKey            = "app_language"
 	DarkModeConfigKey                = "dark_mode"
 	HideTotalBalanceConfigKey        = "hideTotalUSDBalance"
+	PendingMetadataConfigKey         = "pending_metadata"
+	DropTxHistoryConfigKey           = "drop_tx_history"
+	DBBackupEnabledConfigKey         = "db_backup_enabled"
+	DBBackupDirConfigKey             = "db_backup_dir"
+	DBBackupRetentionConfigKey       = "db_backup_retention"
+	LastDBBackupConfigKey            = "last_db_backup"
+	AutoLockTimeoutConfigKey         = "auto_lock_timeout"
+	LockOnSleepConfigKey             = "lock_on_sleep"
+	KeepRunningWhenLockedConfigKey   = "keep_running_when_locked"
+	PassphrasePoliciesConfigKey      = "passphrase_policies"
+	StartupPassphraseMemoryConfigKey = "startup_passphrase_memory"
+	StartupUnlockBackoffConfigKey    = "startup_unlock_backoff"
+	UnlockBackoffConfigKey           = "unlock_backoff"
+	MasterKeyFingerprintConfigKey    = "master_key_fingerprint"
+	WatchStartHeightConfigKey        = "watch_start_height"
+	GapLimitConfigKey                = "gap_limit"
+	AccountGapLimitConfigKey         = "account_gap_limit"
 
 	PassphraseTypePin  int32 = 0
 	PassphraseTypePass int32 = 1
 )
 
+// PlaintextConfigKeys are the assets manager level config values that are
+// read before the startup passphrase is entered. Unlike the other values,
+// they are not encrypted with the startup passphrase.
+var PlaintextConfigKeys = map[string]bool{
+	StartupPassphraseConfigKey:    true,
+	DBKeysConfigKey:               true,
+	IsStartupSecuritySetConfigKey: true,
+	StartupSecurityTypeConfigKey:  true,
+	UseBiometricConfigKey:         true,
+	LogLevelConfigKey:             true,
+	LanguagePreferenceKey:         true,
+	DarkModeConfigKey:             true,
+	PrivacyModeConfigKey:          true,
+	CurrencyConversionConfigKey:   true,
+	FiatCurrencyConfigKey:         true,
+	NetworkModeConfigKey:          true,
+	UserAgentConfigKey:            true,
+	// The startup passphrase hash is upgraded when it is verified and its
+	// failed attempts are rate limited before the databases are unlocked.
+	StartupPassphraseMemoryConfigKey: true,
+	StartupUnlockBackoffConfigKey:    true,
+}
+
 // AssetsManagerDB defines the main generic methods required to access and manage
 // the DB at the assets manager level.
 type AssetsManagerDB interface {
 	// DeleteWalletConfigValue deletes a generic value at the assets manager level.
 	DeleteWalletConfigValue(key string)
 	// SaveWalletConfigValue stores a generic value at the assets manager level.
-	SaveWalletConfigValue(key string, value interface{})
+	SaveWalletConfigValue(key string, value interface{}) error
 	// ReadWalletConfigValue reads a generic value at the assets manager level.
 	ReadWalletConfigValue(key string, valueOut interface{}) error
+	// WalletConfigValues returns all the values at the assets manager level.
+	WalletConfigValues() (map[string]json.RawMessage, error)
 }
 
 // walletConfigSave method manages all the write operations.
@@ -78,8 +129,11 @@ func (wallet *Wallet) walletConfigSave(isAssetsManager bool, key string, value i
 	if !isAssetsManager {
 		bucket = userConfigBucketName
 		key = fmt.Sprintf("%d%s", wallet.ID, key)
+	} else if PlaintextConfigKeys[key] {
+		return wallet.db.WithCodec(stormjson.Codec).Set(bucket, key, value)
 	}
-	return wallet.db.Set(bucket, key, value)
+	// The db codec only encrypts the values passed by pointer.
+	return wallet.db.Set(bucket, key, &value)
 }
 
 // walletConfigRead manages all the read operations.
@@ -102,13 +156,63 @@ func (wallet *Wallet) walletConfigDelete(isAssetsManager bool, key string) error
 	return wallet.db.Delete(bucket, key)
 }
 
+// walletConfigValues returns the encoded values of all the keys, without their
+// wallet ID prefix at the asset level.
+func (wallet *Wallet) walletConfigValues(isAssetsManager bool) (map[string]json.RawMessage, error) {
+	if isAssetsManager {
+		return configValues(wallet.db, walletsMetadataBucketName, "")
+	}
+	return configValues(wallet.db, userConfigBucketName, fmt.Sprintf("%d", wallet.ID))
+}
+
+// WalletUserConfigValues returns the encoded asset level config values of the
+// wallet with walletID, which does not have to be loaded.
+func WalletUserConfigValues(db *storm.DB, walletID int) (map[string]json.RawMessage, error) {
+	return configValues(db, userConfigBucketName, fmt.Sprintf("%d", walletID))
+}
+
+// configValues returns the encoded values of the keys of bucket that start
+// with prefix, without the prefix.
+func configValues(db *storm.DB, bucket, prefix string) (map[string]json.RawMessage, error) {
+	codec, _ := db.Codec().(*dbcrypt.Codec)
+	values := make(map[string]json.RawMessage)
+	err := db.Bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			key := string(k)
+			if !strings.HasPrefix(key, prefix) {
+				return nil
+			}
+			key = key[len(prefix):]
+			// Config keys never start with a digit, such keys belong to
+			// wallets whose ID starts with this wallet's ID.
+			if key == "" || (prefix != "" && key[0] >= '0' && key[0] <= '9') {
+				return nil
+			}
+			if codec != nil {
+				var err error
+				if v, err = codec.Open(v); err != nil {
+					return err
+				}
+			}
+			values[key] = append(json.RawMessage(nil), v...)
+			return nil
+		})
+	})
+	return values, err
+}
+
 // SaveWalletConfigValue stores a generic value against the provided key
 // at the assets manager level.
-func (wallet *Wallet) SaveWalletConfigValue(key string, value interface{}) {
+func (wallet *Wallet) SaveWalletConfigValue(key string, value interface{}) error {
 	err := wallet.walletConfigSave(true, key, value)
 	if err != nil {
 		log.Errorf("error setting wallet config value for key: %s, error: %v", key, err)
 	}
+	return err
 }
 
 // ReadWalletConfigValue reads a generic value stored against the provided key
@@ -130,6 +234,12 @@ func (wallet *Wallet) DeleteWalletConfigValue(key string) {
 	}
 }
 
+// WalletConfigValues returns the JSON encoded values of all the keys at the
+// assets manager level.
+func (wallet *Wallet) WalletConfigValues() (map[string]json.RawMessage, error) {
+	return wallet.walletConfigValues(true)
+}
+
 // SaveUserConfigValue stores the generic value against the provided key
 // at the asset level.
 func (wallet *Wallet) SaveUserConfigValue(key string, value interface{}) {
@@ -149,6 +259,12 @@ func (wallet *Wallet) ReadUserConfigValue(key string, valueOut interface{}) erro
 	return err
 }
 
+// UserConfigValues returns the JSON encoded values of all the keys at the
+// asset level.
+func (wallet *Wallet) UserConfigValues() (map[string]json.RawMessage, error) {
+	return wallet.walletConfigValues(false)
+}
+
 // DeleteUserConfigValueForKey method deletes the value stored against the provided
 // key at the asset level.
 func (wallet *Wallet) DeleteUserConfigValueForKey(key string) {
@@ -195,54 +311,60 @@ func (wallet *Wallet) SetStringConfigValueForKey(key, value string) {
 }
 
 // ReadBoolConfigValueForKey reads the boolean value stored against the provided
-// key at the asset level. Provided default value is returned if the key is not found.
+// key at the asset level. Provided default value is returned if the key is not
+// found or cannot be read, e.g. before the startup passphrase is entered.
 func (wallet *Wallet) ReadBoolConfigValueForKey(key string, defaultValue bool) (valueOut bool) {
-	if err := wallet.ReadUserConfigValue(key, &valueOut); err == storm.ErrNotFound {
+	if err := wallet.ReadUserConfigValue(key, &valueOut); err != nil {
 		valueOut = defaultValue
 	}
 	return
 }
 
 // ReadDoubleConfigValueForKey reads the float64 value stored against the provided
-// key at the asset level. Provided default value is returned if the key is not found.
+// key at the asset level. Provided default value is returned if the key is not
+// found or cannot be read, e.g. before the startup passphrase is entered.
 func (wallet *Wallet) ReadDoubleConfigValueForKey(key string, defaultValue float64) (valueOut float64) {
-	if err := wallet.ReadUserConfigValue(key, &valueOut); err == storm.ErrNotFound {
+	if err := wallet.ReadUserConfigValue(key, &valueOut); err != nil {
 		valueOut = defaultValue
 	}
 	return
 }
 
 // ReadIntConfigValueForKey reads the int value stored against the provided
-// key at the asset level. Provided default value is returned if the key is not found.
+// key at the asset level. Provided default value is returned if the key is not
+// found or cannot be read, e.g. before the startup passphrase is entered.
 func (wallet *Wallet) ReadIntConfigValueForKey(key string, defaultValue int) (valueOut int) {
-	if err := wallet.ReadUserConfigValue(key, &valueOut); err == storm.ErrNotFound {
+	if err := wallet.ReadUserConfigValue(key, &valueOut); err != nil {
 		valueOut = defaultValue
 	}
 	return
 }
 
 // ReadInt32ConfigValueForKey int32 the boolean value stored against the provided
-// key at the asset level. Provided default value is returned if the key is not found.
+// key at the asset level. Provided default value is returned if the key is not
+// found or cannot be read, e.g. before the startup passphrase is entered.
 func (wallet *Wallet) ReadInt32ConfigValueForKey(key string, defaultValue int32) (valueOut int32) {
-	if err := wallet.ReadUserConfigValue(key, &valueOut); err == storm.ErrNotFound {
+	if err := wallet.ReadUserConfigValue(key, &valueOut); err != nil {
 		valueOut = defaultValue
 	}
 	return
 }
 
 // ReadLongConfigValueForKey reads the int64 value stored against the provided
-// key at the asset level. Provided default value is returned if the key is not found.
+// key at the asset level. Provided default value is returned if the key is not
+// found or cannot be read, e.g. before the startup passphrase is entered.
 func (wallet *Wallet) ReadLongConfigValueForKey(key string, defaultValue int64) (valueOut int64) {
-	if err := wallet.ReadUserConfigValue(key, &valueOut); err == storm.ErrNotFound {
+	if err := wallet.ReadUserConfigValue(key, &valueOut); err != nil {
 		valueOut = defaultValue
 	}
 	return
 }
 
 // ReadStringConfigValueForKey reads the string value stored against the provided
-// key at the asset level. Provided default value is returned if the key is not found.
+// key at the asset level. Provided default value is returned if the key is not
+// found or cannot be read, e.g. before the startup passphrase is entered.
 func (wallet *Wallet) ReadStringConfigValueForKey(key string, defaultValue string) (valueOut string) {
-	if err := wallet.ReadUserConfigValue(key, &valueOut); err == storm.ErrNotFound {
+	if err := wallet.ReadUserConfigValue(key, &valueOut); err != nil {
 		valueOut = defaultValue
 	}
 	return
@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -13,6 +14,7 @@ import (
 	w "decred.org/dcrwallet/v3/wallet"
 	"github.com/asdine/storm"
 	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/dbcrypt"
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
@@ -28,9 +30,12 @@ type Wallet struct {
 	logDir    string
 
 	EncryptedSeed         []byte
+	SeedFormat            SeedFormat
 	IsRestored            bool
 	HasDiscoveredAccounts bool
 	PrivatePassphraseType int32
+	// WatchedAddresses are the addresses watched by an address watch wallet.
+	WatchedAddresses []string
 
 	netType      utils.NetworkType
 	chainsParams *utils.ChainsParams
@@ -53,6 +58,10 @@ type Wallet struct {
 	cancelFuncs  []context.CancelFunc
 
 	mu sync.RWMutex
+	// spendingMu guards the spending history and the delayed sends.
+	spendingMu sync.Mutex
+	// unlockMu serializes the unlock attempts to rate limit them.
+	unlockMu sync.Mutex
 }
 
 // prepare gets a wallet ready for use by opening the transactions index database
@@ -107,8 +116,10 @@ func (wallet *Wallet) prepare() (err error) {
 
 	walletDataDBPath := filepath.Join(wallet.dataDir(), dbName)
 
-	// Initialize the walletDataDb
-	walletDb, err := walletdata.Initialize(walletDataDBPath, &Transaction{})
+	// Initialize the walletDataDb, its values are encrypted like the assets
+	// manager's.
+	codec, _ := wallet.db.Codec().(*dbcrypt.Codec)
+	walletDb, err := walletdata.Initialize(walletDataDBPath, &Transaction{}, codec)
 	if err != nil {
 		log.Error(err.Error())
 		return err
@@ -253,6 +264,12 @@ func (wallet *Wallet) GetEncryptedSeed() string {
 	return string(wallet.EncryptedSeed)
 }
 
+func (wallet *Wallet) GetSeedFormat() SeedFormat {
+	wallet.mu.RLock()
+	defer wallet.mu.RUnlock()
+	return wallet.SeedFormat
+}
+
 func (wallet *Wallet) GetWalletID() int {
 	wallet.mu.RLock()
 	defer wallet.mu.RUnlock()
@@ -275,6 +292,30 @@ func (wallet *Wallet) SetNetworkCancelCallback(callback func()) {
 	wallet.networkCancel = callback
 }
 
+// ResealWalletData seals the values of the wallet data database with the
+// current seal key of the db codec, or opens them if it has none.
+func (wallet *Wallet) ResealWalletData() error {
+	wallet.mu.RLock()
+	defer wallet.mu.RUnlock()
+
+	if wallet.walletDataDB == nil {
+		return nil
+	}
+	return wallet.walletDataDB.Reseal()
+}
+
+// SealWalletDataPlaintext seals the values of the wallet data database that
+// are not sealed yet with the current seal key of the db codec.
+func (wallet *Wallet) SealWalletDataPlaintext() error {
+	wallet.mu.RLock()
+	defer wallet.mu.RUnlock()
+
+	if wallet.walletDataDB == nil {
+		return nil
+	}
+	return wallet.walletDataDB.SealPlaintext()
+}
+
 // GetWalletDataDb returns the walletdatadb instance. Its not exported via the
 // but nonetheless has been made thread safe.
 func (wallet *Wallet) GetWalletDataDb() *walletdata.DB {
@@ -283,6 +324,24 @@ func (wallet *Wallet) GetWalletDataDb() *walletdata.DB {
 	return wallet.walletDataDB
 }
 
+// Databases returns the functions that copy the databases of the loaded
+// wallet by the paths of the databases. The wallet data database of BTC and
+// LTC wallets is not returned since it holds the block headers index, which
+// must match the headers files stored next to it and is rebuilt on sync.
+func (wallet *Wallet) Databases() map[string]func(w io.Writer) error {
+	wallet.mu.RLock()
+	defer wallet.mu.RUnlock()
+
+	dbs := make(map[string]func(w io.Writer) error)
+	if _, loaded := wallet.loader.GetLoadedWallet(); loaded {
+		dbs[wallet.loader.WalletDBPath(strconv.Itoa(wallet.ID))] = wallet.loader.CopyWalletDB
+	}
+	if wallet.walletDataDB != nil && wallet.Type == utils.DCRWalletAsset {
+		dbs[wallet.walletDataDB.Path] = wallet.walletDataDB.Copy
+	}
+	return dbs
+}
+
 func (wallet *Wallet) WalletExists() (bool, error) {
 	wallet.mu.RLock()
 	defer wallet.mu.RUnlock()
@@ -316,7 +375,7 @@ func (wallet *Wallet) SetBirthday(birthday time.Time) {
 func CreateNewWallet(pass *AuthInfo, loader loader.AssetLoader,
 	params *InitParams, assetType utils.AssetType,
 ) (*Wallet, error) {
-	seed, err := generateSeed(assetType)
+	seed, err := generateSeed(assetType, pass.SeedFormat)
 	if err != nil {
 		return nil, err
 	}
@@ -334,6 +393,7 @@ func CreateNewWallet(pass *AuthInfo, loader loader.AssetLoader,
 		logDir:        params.LogDir,
 		CreatedAt:     time.Now(),
 		EncryptedSeed: encryptedSeed,
+		SeedFormat:    pass.SeedFormat,
 
 		PrivatePassphraseType: pass.PrivatePassType,
 		HasDiscoveredAccounts: true,
@@ -341,23 +401,26 @@ func CreateNewWallet(pass *AuthInfo, loader loader.AssetLoader,
 		loader:                loader,
 		netType:               params.NetType,
 	}
+	if err := wallet.checkPassphrasePolicy(pass.PrivatePass, pass.PrivatePassType); err != nil {
+		return nil, err
+	}
 
 	return wallet.saveNewWallet(func() error {
 		err := wallet.prepare()
 		if err != nil {
 			return err
 		}
-		return wallet.createWallet(pass.PrivatePass, seed)
+		return wallet.createWallet(pass.PrivatePass, seed, pass.SeedPassphrase)
 	})
 }
 
-func (wallet *Wallet) createWallet(privatePassphrase, seedMnemonic string) error {
+func (wallet *Wallet) createWallet(privatePassphrase, seedMnemonic, seedPassphrase string) error {
 	log.Info("Creating Wallet")
 	if len(seedMnemonic) == 0 {
 		return errors.New(utils.ErrEmptySeed)
 	}
 
-	seed, err := DecodeSeedMnemonic(seedMnemonic, wallet.Type)
+	seed, err := DecodeSeedMnemonic(seedMnemonic, seedPassphrase, wallet.Type)
 	if err != nil {
 		log.Error(err)
 		return err
@@ -381,7 +444,7 @@ func (wallet *Wallet) createWallet(privatePassphrase, seedMnemonic string) error
 	return nil
 }
 
-func CreateWatchOnlyWallet(walletName, extendedPublicKey string, loader loader.AssetLoader,
+func CreateWatchOnlyWallet(walletName, extendedPublicKey string, masterKeyFingerprint uint32, loader loader.AssetLoader,
 	params *InitParams, assetType utils.AssetType,
 ) (*Wallet, error) {
 	wallet := &Wallet{
@@ -405,15 +468,16 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, loader loader.A
 		if err != nil {
 			return err
 		}
-		return wallet.createWatchingOnlyWallet(extendedPublicKey)
+		return wallet.createWatchingOnlyWallet(extendedPublicKey, masterKeyFingerprint)
 	})
 }
 
-func (wallet *Wallet) createWatchingOnlyWallet(extendedPublicKey string) error {
+func (wallet *Wallet) createWatchingOnlyWallet(extendedPublicKey string, masterKeyFingerprint uint32) error {
 	params := &loader.WatchOnlyWalletParams{
-		WalletID:       strconv.Itoa(wallet.ID),
-		PubPassphrase:  []byte(w.InsecurePubPassphrase),
-		ExtendedPubKey: extendedPublicKey,
+		WalletID:             strconv.Itoa(wallet.ID),
+		PubPassphrase:        []byte(w.InsecurePubPassphrase),
+		ExtendedPubKey:       extendedPublicKey,
+		MasterKeyFingerprint: masterKeyFingerprint,
 	}
 
 	ctx, _ := wallet.ShutdownContextWithCancel()
@@ -440,6 +504,7 @@ func RestoreWallet(seedMnemonic string, pass *AuthInfo, loader loader.AssetLoade
 
 		IsRestored:            true,
 		HasDiscoveredAccounts: false,
+		SeedFormat:            SeedFormatOf(seedMnemonic, assetType),
 		Type:                  assetType,
 		loader:                loader,
 		netType:               params.NetType,
@@ -450,7 +515,7 @@ func RestoreWallet(seedMnemonic string, pass *AuthInfo, loader loader.AssetLoade
 		if err != nil {
 			return err
 		}
-		return wallet.createWallet(pass.PrivatePass, seedMnemonic)
+		return wallet.createWallet(pass.PrivatePass, seedMnemonic, pass.SeedPassphrase)
 	})
 }
 
@@ -586,27 +651,68 @@ func (wallet *Wallet) WalletOpened() bool {
 	}
 }
 
-func (wallet *Wallet) UnlockWallet(privPass string) (err error) {
+func (wallet *Wallet) UnlockWallet(privPass string) error {
 	loadedWallet, ok := wallet.loader.GetLoadedWallet()
 	if !ok {
 		return errors.New(utils.ErrWalletNotLoaded)
 	}
 
-	switch wallet.Type {
-	case utils.BTCWalletAsset:
-		err = loadedWallet.BTC.Unlock([]byte(privPass), nil)
-	case utils.DCRWalletAsset:
-		ctx, _ := wallet.ShutdownContextWithCancel()
-		err = loadedWallet.DCR.Unlock(ctx, []byte(privPass), nil)
-	case utils.LTCWalletAsset:
-		err = loadedWallet.LTC.Unlock([]byte(privPass), nil)
+	return wallet.unlockWithBackoff(func() (err error) {
+		switch wallet.Type {
+		case utils.BTCWalletAsset:
+			err = loadedWallet.BTC.Unlock([]byte(privPass), nil)
+		case utils.DCRWalletAsset:
+			ctx, _ := wallet.ShutdownContextWithCancel()
+			err = loadedWallet.DCR.Unlock(ctx, []byte(privPass), nil)
+		case utils.LTCWalletAsset:
+			err = loadedWallet.LTC.Unlock([]byte(privPass), nil)
+		}
+
+		if err != nil {
+			return utils.TranslateError(err)
+		}
+
+		return nil
+	})
+}
+
+// UnlockForSigning unlocks the wallet with privPass until the returned
+// function is called. Like UnlockWallet, repeated attempts with a wrong
+// passphrase are delayed.
+func (wallet *Wallet) UnlockForSigning(privPass string) (func(), error) {
+	lock := make(chan time.Time, 1)
+	relock := func() {
+		lock <- time.Time{}
 	}
 
+	err := wallet.unlockWithBackoff(func() (err error) {
+		loadedWallet, ok := wallet.loader.GetLoadedWallet()
+		if !ok {
+			return errors.New(utils.ErrWalletNotLoaded)
+		}
+
+		switch wallet.Type {
+		case utils.BTCWalletAsset:
+			err = loadedWallet.BTC.Unlock([]byte(privPass), lock)
+		case utils.DCRWalletAsset:
+			ctx, _ := wallet.ShutdownContextWithCancel()
+			err = loadedWallet.DCR.Unlock(ctx, []byte(privPass), lock)
+		case utils.LTCWalletAsset:
+			err = loadedWallet.LTC.Unlock([]byte(privPass), lock)
+		}
+
+		if err != nil {
+			log.Errorf("unlocking the wallet failed: %v", err)
+			relock()
+			return utils.TranslateError(err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return utils.TranslateError(err)
+		return nil, err
 	}
-
-	return nil
+	return relock, nil
 }
 
 func (wallet *Wallet) LockWallet() {
@@ -650,6 +756,9 @@ func (wallet *Wallet) ChangePrivatePassphraseForWallet(oldPrivatePassphrase, new
 	if privatePassphraseType != PassphraseTypePin && privatePassphraseType != PassphraseTypePass {
 		return errors.New(utils.ErrInvalid)
 	}
+	if err := wallet.checkPassphrasePolicy(newPrivatePassphrase, privatePassphraseType); err != nil {
+		return err
+	}
 
 	oldPassphrase := []byte(oldPrivatePassphrase)
 	newPassphrase := []byte(newPrivatePassphrase)
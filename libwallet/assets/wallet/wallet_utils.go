@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"decred.org/dcrwallet/v3/errors"
 	"decred.org/dcrwallet/v3/walletseed"
 	"github.com/asdine/storm"
 	btchdkeychain "github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/crypto-power/cryptopower/libwallet/bip39"
+	"github.com/crypto-power/cryptopower/libwallet/slip39"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	dcrhdkeychain "github.com/decred/dcrd/hdkeychain/v3"
 	"github.com/kevinburke/nacl"
@@ -137,6 +140,35 @@ func (wallet *Wallet) VerifySeedForWallet(seedMnemonic, privpass string) (bool,
 	return false, errors.New(utils.ErrInvalid)
 }
 
+// SplitSeed splits the decrypted wallet.EncryptedSeed into count SLIP-39
+// shares, any threshold of which restore the seed.
+func (wallet *Wallet) SplitSeed(privatePassphrase string, threshold, count int) ([]string, error) {
+	seedMnemonic, err := wallet.DecryptSeed(privatePassphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.SeedFormat != SeedFormatPGP {
+		return nil, fmt.Errorf("%v: %v seeds cannot be split into shares", utils.ErrUnusableSeed, wallet.SeedFormat)
+	}
+
+	seed, err := DecodeSeedMnemonic(seedMnemonic, "", wallet.Type)
+	if err != nil {
+		return nil, err
+	}
+	return slip39.Split(seed, nil, threshold, count)
+}
+
+// VerifySeedSharesForWallet combines the SLIP-39 shares and verifies the
+// resulting seed with VerifySeedForWallet.
+func (wallet *Wallet) VerifySeedSharesForWallet(shares []string, privpass string) (bool, error) {
+	seedMnemonic, err := CombineSeedShares(shares)
+	if err != nil {
+		return false, err
+	}
+	return wallet.VerifySeedForWallet(seedMnemonic, privpass)
+}
+
 // naclLoadFromPass derives a nacl.Key from pass using scrypt.Key.
 func naclLoadFromPass(pass []byte) (nacl.Key, error) {
 	const N, r, p = 1 << 15, 8, 1
@@ -174,7 +206,14 @@ func decryptWalletSeed(pass []byte, encryptedSeed []byte) (string, error) {
 
 // For use with gomobile bind,
 // doesn't support the alternative `GenerateSeed` function because it returns more than 2 types.
-func generateSeed(assetType utils.AssetType) (v string, err error) {
+func generateSeed(assetType utils.AssetType, format SeedFormat) (v string, err error) {
+	if format == SeedFormatBIP39 {
+		if !SupportsSeedFormat(assetType, format) {
+			return "", fmt.Errorf("%v: %v seeds are not supported for %v", utils.ErrInvalid, format, assetType)
+		}
+		return bip39.GenerateMnemonic(bip39.DefaultEntropyLength)
+	}
+
 	var seed []byte
 	switch assetType {
 	case utils.BTCWalletAsset:
@@ -205,13 +244,46 @@ func generateSeed(assetType utils.AssetType) (v string, err error) {
 }
 
 func VerifySeed(seedMnemonic string, assetType utils.AssetType) bool {
-	_, err := DecodeSeedMnemonic(seedMnemonic, assetType)
+	_, err := DecodeSeedMnemonic(seedMnemonic, "", assetType)
 	return err == nil
 }
 
-func DecodeSeedMnemonic(seedMnemonic string, assetType utils.AssetType) (hashedSeed []byte, err error) {
+// SupportsSeedFormat returns true if wallets of assetType can use seeds of
+// format.
+func SupportsSeedFormat(assetType utils.AssetType, format SeedFormat) bool {
+	switch format {
+	case SeedFormatPGP:
+		return true
+	case SeedFormatBIP39:
+		return assetType == utils.BTCWalletAsset || assetType == utils.LTCWalletAsset
+	default:
+		return false
+	}
+}
+
+// SeedFormatOf returns the format of seedMnemonic. Phrases of up to 24 words
+// are BIP39 mnemonics for the assets that support them, anything else is
+// decoded as a PGP word list seed or its hex encoding.
+func SeedFormatOf(seedMnemonic string, assetType utils.AssetType) SeedFormat {
+	if SupportsSeedFormat(assetType, SeedFormatBIP39) && len(strings.Fields(seedMnemonic)) > 1 &&
+		len(strings.Fields(seedMnemonic)) <= 24 {
+		return SeedFormatBIP39
+	}
+	return SeedFormatPGP
+}
+
+// DecodeSeedMnemonic returns the wallet seed of seedMnemonic. seedPassphrase
+// is the optional passphrase of BIP39 mnemonics and must be empty for other
+// seeds.
+func DecodeSeedMnemonic(seedMnemonic, seedPassphrase string, assetType utils.AssetType) (hashedSeed []byte, err error) {
 	switch assetType {
 	case utils.BTCWalletAsset, utils.DCRWalletAsset, utils.LTCWalletAsset:
+		if SeedFormatOf(seedMnemonic, assetType) == SeedFormatBIP39 {
+			return bip39.Seed(seedMnemonic, seedPassphrase)
+		}
+		if seedPassphrase != "" {
+			return nil, fmt.Errorf("%v: a seed passphrase requires a BIP39 seed", utils.ErrInvalid)
+		}
 		hashedSeed, err = walletseed.DecodeUserInput(seedMnemonic)
 	default:
 		err = fmt.Errorf("%v: (%v)", utils.ErrAssetUnknown, assetType)
@@ -219,6 +291,16 @@ func DecodeSeedMnemonic(seedMnemonic string, assetType utils.AssetType) (hashedS
 	return
 }
 
+// CombineSeedShares returns the seed mnemonic restored from the SLIP-39
+// shares.
+func CombineSeedShares(shares []string) (string, error) {
+	seed, err := slip39.Combine(shares, nil)
+	if err != nil {
+		return "", err
+	}
+	return walletseed.EncodeMnemonic(seed), nil
+}
+
 func fileExists(filePath string) (bool, error) {
 	_, err := os.Stat(filePath)
 	if err != nil {
@@ -2,10 +2,13 @@ package walletdata
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/asdine/storm"
 	bolt "go.etcd.io/bbolt"
+
+	"github.com/crypto-power/cryptopower/libwallet/dbcrypt"
 )
 
 const (
@@ -26,6 +29,7 @@ type DB struct {
 	BTC            *BTCDB
 	LTC            *LTCDB
 	walletDataDB   *storm.DB
+	codec          *dbcrypt.Codec
 	ticketMaturity int32
 	ticketExpiry   int32
 	Path           string
@@ -35,8 +39,9 @@ type DB struct {
 // and checks the database version for compatibility.
 // If there is a version mismatch or the db does not exist at `dbPath`,
 // a new db is created and the current db version number saved to the db.
-func Initialize(dbPath string, txData interface{}) (*DB, error) {
-	walletDataDB, err := openOrCreateDB(dbPath)
+// The values are encoded with codec if it is not nil.
+func Initialize(dbPath string, txData interface{}, codec *dbcrypt.Codec) (*DB, error) {
+	walletDataDB, err := openOrCreateDB(dbPath, codec)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +65,7 @@ func Initialize(dbPath string, txData interface{}) (*DB, error) {
 			Bolt: walletDataDB.Bolt,
 		},
 		walletDataDB: walletDataDB,
+		codec:        codec,
 		Path:         dbPath,
 	}, nil
 }
@@ -76,12 +82,39 @@ func (db *DB) SetTicketExpiry(val int32) *DB {
 	return db
 }
 
+// Copy writes a consistent copy of the wallet data database to w.
+func (db *DB) Copy(w io.Writer) error {
+	return db.walletDataDB.Bolt.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(w)
+		return err
+	})
+}
+
+// Reseal seals the values of the wallet data database with the seal key of
+// its codec, or opens them if it has none. The database version is never
+// sealed, it is read when the database is opened.
+func (db *DB) Reseal() error {
+	if db.codec == nil {
+		return nil
+	}
+	return db.codec.Reseal(db.walletDataDB.Bolt, map[string]bool{KeyDbVersion: true})
+}
+
+// SealPlaintext seals the values of the wallet data database that are not
+// sealed yet with the seal key of its codec.
+func (db *DB) SealPlaintext() error {
+	if db.codec == nil {
+		return nil
+	}
+	return db.codec.SealPlaintext(db.walletDataDB.Bolt, map[string]bool{KeyDbVersion: true})
+}
+
 // Close closes the wallet data database.
 func (db *DB) Close() error {
 	return db.walletDataDB.Close()
 }
 
-func openOrCreateDB(dbPath string) (*storm.DB, error) {
+func openOrCreateDB(dbPath string, codec *dbcrypt.Codec) (*storm.DB, error) {
 	var isNewDbFile bool
 
 	// first check if db file exists at dbPath, if not we'll need to create it and set the db version
@@ -93,7 +126,11 @@ func openOrCreateDB(dbPath string) (*storm.DB, error) {
 		}
 	}
 
-	walletDataDB, err := storm.Open(dbPath)
+	var options []func(*storm.Options) error
+	if codec != nil {
+		options = append(options, storm.Codec(codec))
+	}
+	walletDataDB, err := storm.Open(dbPath, options...)
 	if err != nil {
 		switch err {
 		case bolt.ErrTimeout:
@@ -6,9 +6,19 @@ import (
 
 	"decred.org/dcrwallet/v3/errors"
 	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
 )
 
-const KeyEndBlock = "EndBlock"
+const (
+	KeyEndBlock      = "EndBlock"
+	KeyIndexedBlocks = "IndexedBlocks"
+)
+
+// IndexedBlock is the height and hash of an indexed block.
+type IndexedBlock struct {
+	Height int32
+	Hash   string
+}
 
 // SaveOrUpdate saves a transaction to the database and would overwrite
 // if a transaction with same hash exists
@@ -42,6 +52,14 @@ func (db *DB) SaveOrUpdate(emptyTxPointer, record interface{}) (overwritten bool
 	return
 }
 
+// UpdateLabel sets the label of the saved transaction with txHash.
+func (db *DB) UpdateLabel(emptyTxPointer interface{}, txHash, label string) error {
+	if err := db.walletDataDB.One("Hash", txHash, emptyTxPointer); err != nil {
+		return err
+	}
+	return db.walletDataDB.UpdateField(emptyTxPointer, "Label", label)
+}
+
 func (db *DB) SaveOrUpdateVspdRecord(emptyTxPointer, record interface{}) (updated bool, err error) {
 	v := reflect.ValueOf(record)
 	txHash := reflect.Indirect(v).FieldByName("Hash").String()
@@ -78,11 +96,49 @@ func (db *DB) SaveLastIndexPoint(endBlockHeight int32) error {
 	return nil
 }
 
+// IndexedBlocks returns the last indexed blocks saved by SaveIndexedBlocks,
+// oldest first.
+func (db *DB) IndexedBlocks() ([]IndexedBlock, error) {
+	var blocks []IndexedBlock
+	err := db.walletDataDB.Get(TxBucketName, KeyIndexedBlocks, &blocks)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// SaveIndexedBlocks saves the last indexed blocks, oldest first. The height of
+// the last one is saved as the last index point.
+func (db *DB) SaveIndexedBlocks(blocks []IndexedBlock) error {
+	if err := db.walletDataDB.Set(TxBucketName, KeyIndexedBlocks, blocks); err != nil {
+		return fmt.Errorf("error saving the indexed blocks: %s", err.Error())
+	}
+	var endBlockHeight int32
+	if len(blocks) > 0 {
+		endBlockHeight = blocks[len(blocks)-1].Height
+	}
+	return db.SaveLastIndexPoint(endBlockHeight)
+}
+
+// DeleteTransactionsAbove deletes the saved transactions mined in the blocks
+// above height. The unmined transactions are kept.
+func (db *DB) DeleteTransactionsAbove(emptyTxPointer interface{}, height int32) error {
+	err := db.walletDataDB.Select(q.Gt("BlockHeight", height)).Delete(emptyTxPointer)
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
 func (db *DB) ClearSavedTransactions(emptyTxPointer interface{}) error {
 	err := db.walletDataDB.Drop(emptyTxPointer)
 	if err != nil {
 		return err
 	}
 
+	err = db.walletDataDB.Delete(TxBucketName, KeyIndexedBlocks)
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
 	return db.SaveLastIndexPoint(0)
 }
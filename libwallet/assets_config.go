@@ -2,20 +2,23 @@ package libwallet
 
 import (
 	"fmt"
+	"time"
 
 	"decred.org/dcrwallet/v3/errors"
 	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
-
-	"golang.org/x/crypto/bcrypt"
+	"github.com/crypto-power/cryptopower/libwallet/passphrase"
 )
 
 const (
 	logFileName   = "libwallet.log"
 	walletsDbName = "wallets.db"
+	// ratesFileName is an optional local rates file read by ext.NewFileRateProvider.
+	ratesFileName = "rates.json"
 
 	// Mainnet represents the main network.
 	Mainnet = utils.Mainnet
@@ -23,7 +26,7 @@ const (
 	Testnet = utils.Testnet
 
 	walletsMetadataBucketName    = "metadata"
-	walletstartupPassphraseField = "startup-passphrase"
+	walletstartupPassphraseField = sharedW.StartupPassphraseConfigKey
 )
 
 // setDBInterface extract the assets manager db interface that is available
@@ -55,12 +58,41 @@ func (mgr *AssetsManager) VerifyStartupPassphrase(startupPassphrase string) erro
 		return nil
 	}
 
-	// startup passphrase was set, verify
-	err = bcrypt.CompareHashAndPassword(startupPassphraseHash, []byte(startupPassphrase))
+	// startup passphrase was set, verify. The failed attempts are rate
+	// limited with an exponential delay.
+	var backoff passphrase.Backoff
+	mgr.db.ReadWalletConfigValue(sharedW.StartupUnlockBackoffConfigKey, &backoff)
+	now := time.Now()
+	if err := backoff.Check(now); err != nil {
+		return err
+	}
+
+	rehash, err := mgr.compareStartupPassphrase(startupPassphraseHash, startupPassphrase)
 	if err != nil {
+		backoff.Fail(now)
+		mgr.db.SaveWalletConfigValue(sharedW.StartupUnlockBackoffConfigKey, backoff)
+		log.Warnf("Failed startup passphrase attempt %d", backoff.Failures)
 		return errors.E(utils.ErrInvalidPassphrase)
 	}
+	if backoff.Failures > 0 {
+		mgr.db.DeleteWalletConfigValue(sharedW.StartupUnlockBackoffConfigKey)
+	}
 
+	if rehash {
+		// Upgrade the bcrypt hashes and the hashes with outdated costs.
+		if hash, err := mgr.hashStartupPassphrase(startupPassphrase); err != nil {
+			log.Errorf("Error upgrading the startup passphrase hash: %v", err)
+		} else {
+			startupPassphraseHash = hash
+			mgr.db.SaveWalletConfigValue(walletstartupPassphraseField, startupPassphraseHash)
+		}
+	}
+
+	if err := mgr.unlockDB(startupPassphrase, startupPassphraseHash); err != nil {
+		return fmt.Errorf("unable to unlock the databases: %v", err)
+	}
+
+	mgr.setDBBackupKey(startupPassphrase)
 	return nil
 }
 
@@ -70,19 +102,27 @@ func (mgr *AssetsManager) ChangeStartupPassphrase(oldPassphrase, newPassphrase s
 		return mgr.RemoveStartupPassphrase(oldPassphrase)
 	}
 
+	if err := mgr.PassphrasePolicy(passphraseType).Check(newPassphrase); err != nil {
+		return err
+	}
+
 	err := mgr.VerifyStartupPassphrase(oldPassphrase)
 	if err != nil {
 		return err
 	}
 
-	startupPassphraseHash, err := bcrypt.GenerateFromPassword([]byte(newPassphrase), bcrypt.DefaultCost)
+	startupPassphraseHash, err := mgr.hashStartupPassphrase(newPassphrase)
 	if err != nil {
 		return err
 	}
 
-	mgr.db.SaveWalletConfigValue(walletstartupPassphraseField, startupPassphraseHash)
+	// The passphrase hash is saved with the database keys it wraps.
+	if err := mgr.rekeyDB(newPassphrase, startupPassphraseHash); err != nil {
+		return err
+	}
 	mgr.db.SaveWalletConfigValue(sharedW.IsStartupSecuritySetConfigKey, true)
 	mgr.db.SaveWalletConfigValue(sharedW.StartupSecurityTypeConfigKey, passphraseType)
+	mgr.setDBBackupKey(newPassphrase)
 
 	return nil
 }
@@ -94,9 +134,17 @@ func (mgr *AssetsManager) RemoveStartupPassphrase(oldPassphrase string) error {
 		return err
 	}
 
+	// The databases can not stay encrypted without the startup passphrase.
+	if err := mgr.decryptDB(); err != nil {
+		return fmt.Errorf("unable to decrypt the databases: %v", err)
+	}
+
 	mgr.db.DeleteWalletConfigValue(walletstartupPassphraseField)
 	mgr.db.SaveWalletConfigValue(sharedW.IsStartupSecuritySetConfigKey, false)
 	mgr.db.DeleteWalletConfigValue(sharedW.StartupSecurityTypeConfigKey)
+	// Backups can not be encrypted without the startup passphrase.
+	mgr.db.SaveWalletConfigValue(sharedW.DBBackupEnabledConfigKey, false)
+	mgr.setDBBackupKey("")
 
 	return nil
 }
@@ -131,8 +179,34 @@ func (mgr *AssetsManager) GetDexServers() (map[string][]byte, error) {
 }
 
 // SaveDexServers saves the dex servers.
-func (mgr *AssetsManager) SaveDexServers(servers map[string][]byte) {
-	mgr.db.SaveWalletConfigValue(sharedW.KnownDexServersConfigKey, servers)
+func (mgr *AssetsManager) SaveDexServers(servers map[string][]byte) error {
+	return mgr.db.SaveWalletConfigValue(sharedW.KnownDexServersConfigKey, servers)
+}
+
+// GetFiatCurrency returns the fiat currency used to display asset values.
+func (mgr *AssetsManager) GetFiatCurrency() string {
+	var currency string
+	mgr.db.ReadWalletConfigValue(sharedW.FiatCurrencyConfigKey, &currency)
+	if currency == "" {
+		return values.USDCurrency // default fiat currency
+	}
+	return currency
+}
+
+// SetFiatCurrency sets the fiat currency used to display asset values.
+func (mgr *AssetsManager) SetFiatCurrency(currency string) {
+	mgr.db.SaveWalletConfigValue(sharedW.FiatCurrencyConfigKey, currency)
+	go mgr.RateSource.Refresh(false)
+}
+
+// FiatTicker returns the rate of the asset in the user's fiat currency. nil
+// is returned if the rate is not available.
+func (mgr *AssetsManager) FiatTicker(assetType utils.AssetType) *ext.Ticker {
+	market, ok := values.AssetExchangeMarketValue[assetType]
+	if !ok {
+		return nil
+	}
+	return mgr.RateSource.GetFiatTicker(market, mgr.GetFiatCurrency())
 }
 
 // GetCurrencyConversionExchange returns the currency conversion exchange.
@@ -6,14 +6,20 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 
 	"decred.org/dcrwallet/v3/errors"
 	"github.com/asdine/storm"
 	"github.com/asdine/storm/q"
+	"github.com/crypto-power/cryptopower/libwallet/atomicswap"
+	"github.com/crypto-power/cryptopower/libwallet/dbbackup"
+	"github.com/crypto-power/cryptopower/libwallet/dbcrypt"
 	"github.com/crypto-power/cryptopower/libwallet/ext"
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
 	"github.com/crypto-power/cryptopower/libwallet/internal/politeia"
+	"github.com/crypto-power/cryptopower/libwallet/pricealert"
+	"github.com/crypto-power/cryptopower/libwallet/pricehistory"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 	bolt "go.etcd.io/bbolt"
@@ -48,14 +54,44 @@ type AssetsManager struct {
 
 	db sharedW.AssetsManagerDB // Interface to manage db access at the ASM.
 
+	// dbCodec encodes the values of the databases, it encrypts them once the
+	// startup passphrase is set.
+	dbCodec *dbcrypt.Codec
+
+	// dataServicesCtx is the context of the services that wait for the
+	// startup passphrase to read the encrypted data.
+	dataServicesCtx  context.Context
+	dataServicesOnce sync.Once
+
 	shuttingDown chan bool
 	cancelFuncs  []context.CancelFunc
 	chainsParams utils.ChainsParams
 
+	// schedulerDepositMu serializes the deposits of the order schedulers,
+	// which share the wallets' unsigned transactions.
+	schedulerDepositMu sync.Mutex
+
+	// metadataMu protects the restored wallet metadata that is not applied
+	// yet.
+	metadataMu sync.Mutex
+
+	// dbBackupMu protects the database backup key and serializes the
+	// database backups.
+	dbBackupMu  sync.Mutex
+	dbBackupKey *dbbackup.Key
+
+	// schedulerKeys maps the IDs of the running order schedulers that hold
+	// the spending passphrase to the IDs of their source wallets.
+	schedulerKeysMu sync.Mutex
+	schedulerKeys   map[int]int
+
 	Politeia        *politeia.Politeia
 	InstantSwap     *instantswap.InstantSwap
 	ExternalService *ext.Service
 	RateSource      ext.RateSource
+	PriceHistory    *pricehistory.PriceHistory
+	PriceAlerts     *pricealert.PriceAlerts
+	AtomicSwaps     *atomicswap.Swaps
 }
 
 // initializeAssetsFields validate the network provided is valid for all assets before proceeding
@@ -87,8 +123,9 @@ func initializeAssetsFields(rootDir, dbDriver, logDir string, netType utils.Netw
 	}
 
 	mgr := &AssetsManager{
-		params: params,
-		Assets: new(Assets),
+		params:        params,
+		Assets:        new(Assets),
+		schedulerKeys: make(map[int]int),
 	}
 
 	mgr.Assets.BTC.Wallets = make(map[int]sharedW.Asset)
@@ -125,8 +162,17 @@ func NewAssetsManager(rootDir, dbDriver, politeiaHost, logDir string, netType ut
 		return nil, errors.Errorf("failed to init logRotator: %v", err.Error())
 	}
 
+	// Replace the databases with a restored backup before opening them.
+	if err := applyDBRestore(rootDir); err != nil {
+		return nil, errors.Errorf("failed to restore database backup: %v", err)
+	}
+
+	// The wallet records are read before the startup passphrase is entered
+	// and are not encrypted.
+	mgr.dbCodec = dbcrypt.NewCodec(&sharedW.Wallet{})
+
 	// Attempt to acquire lock on the wallets.db file.
-	mwDB, err := storm.Open(filepath.Join(rootDir, walletsDbName))
+	mwDB, err := storm.Open(filepath.Join(rootDir, walletsDbName), storm.Codec(mgr.dbCodec))
 	if err != nil {
 		log.Errorf("Error opening wallets database: %s", err.Error())
 		if err == bolt.ErrTimeout {
@@ -152,9 +198,33 @@ func NewAssetsManager(rootDir, dbDriver, politeiaHost, logDir string, netType ut
 		return nil, err
 	}
 
+	priceHistory, err := pricehistory.New(mwDB)
+	if err != nil {
+		return nil, err
+	}
+
+	priceAlerts, err := pricealert.New(mwDB)
+	if err != nil {
+		return nil, err
+	}
+
+	atomicSwaps, err := atomicswap.New(mwDB)
+	if err != nil {
+		return nil, err
+	}
+
 	mgr.params.DB = mwDB
 	mgr.Politeia = politeia
 	mgr.InstantSwap = instantSwap
+	mgr.PriceHistory = priceHistory
+	mgr.PriceAlerts = priceAlerts
+	mgr.AtomicSwaps = atomicSwaps
+
+	// The values sealed with the startup passphrase are not written until it
+	// is entered.
+	if mgr.hasDBKeys() {
+		mgr.dbCodec.Lock()
+	}
 
 	// initialize the ExternalService. ExternalService provides assetsManager
 	// with the functionalities to retrieve data from some 3rd party services.
@@ -175,6 +245,18 @@ func NewAssetsManager(rootDir, dbDriver, politeiaHost, logDir string, netType ut
 		return nil, err
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+	mgr.dataServicesCtx = ctx
+	// The services start when the startup passphrase unlocks the encrypted
+	// databases.
+	if !mgr.isDBLocked() {
+		if err := mgr.startDataServices(); err != nil {
+			return nil, err
+		}
+	}
+	mgr.startDBBackups(ctx)
+
 	// Attempt to set the log levels if a valid db interface was found.
 	if mgr.IsAssetManagerDB() {
 		mgr.GetLogLevels()
@@ -191,6 +273,16 @@ func (mgr *AssetsManager) initRateSource() (err error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
 
+	// A rates file placed in the root dir is made available as a rate source
+	// and is included when computing the aggregate rate.
+	ratesFile := filepath.Join(mgr.params.RootDir, ratesFileName)
+	if _, err := os.Stat(ratesFile); err == nil {
+		ext.UnregisterRateProvider(ext.FileRateSource)
+		if err := ext.RegisterRateProvider(ext.NewFileRateProvider(ratesFile)); err != nil {
+			log.Errorf("unable to register rates file: %v", err)
+		}
+	}
+
 	rateSource := values.DefaultExchangeValue
 	disabled := true
 	// Check if database has been initialized. ATM, new setups need a wallet
@@ -228,6 +320,10 @@ func (mgr *AssetsManager) initRateSource() (err error) {
 		}
 	}()
 
+	if err := mgr.startPriceAlerts(ctx); err != nil {
+		return fmt.Errorf("unable to start price alerts: %w", err)
+	}
+
 	return nil
 }
 
@@ -243,6 +339,16 @@ func (mgr *AssetsManager) prepareExistingWallets() error {
 		return err
 	}
 
+	// prepare the wallets loaded from db for use
+	for _, wallet := range wallets {
+		mgr.loadExistingWallet(wallet)
+	}
+	return nil
+}
+
+// loadExistingWallet loads the stored wallet into the wallets of its asset
+// type, or into the bad wallets of its asset type if it fails to load.
+func (mgr *AssetsManager) loadExistingWallet(wallet *sharedW.Wallet) {
 	isOK := func(val interface{}) bool {
 		var ok bool
 		if val != nil {
@@ -258,60 +364,56 @@ func (mgr *AssetsManager) prepareExistingWallets() error {
 		return ok
 	}
 
-	// prepare the wallets loaded from db for use
-	for _, wallet := range wallets {
-		// preset the network type so as to generate correct folder path
-		wallet.SetNetType(mgr.NetType())
-
-		path := filepath.Join(mgr.params.RootDir, wallet.DataDir())
-		log.Infof("loading properties of wallet=%v at location=%v", wallet.Name, path)
-
-		switch wallet.Type {
-		case utils.BTCWalletAsset:
-			w, err := btc.LoadExisting(wallet, mgr.params)
-			if err == nil && !isOK(w) {
-				err = fmt.Errorf("missing wallet database file: %v", path)
-				log.Warn(err)
-			}
-			if err != nil {
-				mgr.Assets.BTC.BadWallets[wallet.ID] = wallet
-				log.Warnf("Ignored btc wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
-			} else {
-				mgr.Assets.BTC.Wallets[wallet.ID] = w
-			}
+	// preset the network type so as to generate correct folder path
+	wallet.SetNetType(mgr.NetType())
 
-		case utils.DCRWalletAsset:
-			w, err := dcr.LoadExisting(wallet, mgr.params)
-			if err == nil && !isOK(w) {
-				err = fmt.Errorf("missing wallet database file: %v", path)
-				log.Debug(err)
-			}
-			if err != nil {
-				mgr.Assets.DCR.BadWallets[wallet.ID] = wallet
-				log.Warnf("Ignored dcr wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
-			} else {
-				mgr.Assets.DCR.Wallets[wallet.ID] = w
-			}
+	path := filepath.Join(mgr.params.RootDir, wallet.DataDir())
+	log.Infof("loading properties of wallet=%v at location=%v", wallet.Name, path)
 
-		case utils.LTCWalletAsset:
-			w, err := ltc.LoadExisting(wallet, mgr.params)
-			if err == nil && !isOK(w) {
-				err = fmt.Errorf("missing wallet database file: %v", path)
-				log.Debug(err)
-			}
-			if err != nil {
-				mgr.Assets.LTC.BadWallets[wallet.ID] = wallet
-				log.Warnf("Ignored ltc wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
-			} else {
-				mgr.Assets.LTC.Wallets[wallet.ID] = w
-			}
+	switch wallet.Type {
+	case utils.BTCWalletAsset:
+		w, err := btc.LoadExisting(wallet, mgr.params)
+		if err == nil && !isOK(w) {
+			err = fmt.Errorf("missing wallet database file: %v", path)
+			log.Warn(err)
+		}
+		if err != nil {
+			mgr.Assets.BTC.BadWallets[wallet.ID] = wallet
+			log.Warnf("Ignored btc wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
+		} else {
+			mgr.Assets.BTC.Wallets[wallet.ID] = w
+		}
 
-		default:
-			// Classify all wallets with missing AssetTypes as DCR badwallets.
+	case utils.DCRWalletAsset:
+		w, err := dcr.LoadExisting(wallet, mgr.params)
+		if err == nil && !isOK(w) {
+			err = fmt.Errorf("missing wallet database file: %v", path)
+			log.Debug(err)
+		}
+		if err != nil {
 			mgr.Assets.DCR.BadWallets[wallet.ID] = wallet
+			log.Warnf("Ignored dcr wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
+		} else {
+			mgr.Assets.DCR.Wallets[wallet.ID] = w
 		}
+
+	case utils.LTCWalletAsset:
+		w, err := ltc.LoadExisting(wallet, mgr.params)
+		if err == nil && !isOK(w) {
+			err = fmt.Errorf("missing wallet database file: %v", path)
+			log.Debug(err)
+		}
+		if err != nil {
+			mgr.Assets.LTC.BadWallets[wallet.ID] = wallet
+			log.Warnf("Ignored ltc wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
+		} else {
+			mgr.Assets.LTC.Wallets[wallet.ID] = w
+		}
+
+	default:
+		// Classify all wallets with missing AssetTypes as DCR badwallets.
+		mgr.Assets.DCR.BadWallets[wallet.ID] = wallet
 	}
-	return nil
 }
 
 func (mgr *AssetsManager) listenForShutdown() {
@@ -342,6 +444,9 @@ func (mgr *AssetsManager) Shutdown() {
 		mgr.InstantSwap.StopSync()
 	}
 
+	// Stop the order schedulers, they resume on the next start.
+	mgr.InstantSwap.CancelSchedulers()
+
 	for _, wallet := range mgr.AllWallets() {
 		wallet.Shutdown() // Cancels the wallet sync too.
 		wallet.CancelRescan()
@@ -402,9 +507,12 @@ func (mgr *AssetsManager) OpenWallets(startupPassphrase string) error {
 			// If shutdown protocol is detected, exit immediately.
 			return nil
 		default:
-			err := wallet.OpenWallet()
-			if err != nil {
-				return err
+			mgr.applyTxHistoryDrop(wallet)
+			if err := wallet.OpenWallet(); err != nil {
+				// The wallet can be diagnosed and repaired from the bad
+				// wallets, the other wallets are still opened.
+				log.Errorf("Error opening wallet %s: %v", wallet.GetWalletName(), err)
+				mgr.markBadWallet(wallet)
 			}
 		}
 	}
@@ -587,7 +695,7 @@ func (mgr *AssetsManager) DeleteBadWallet(walletID int) error {
 		return utils.TranslateError(err)
 	}
 
-	os.RemoveAll(wallet.DataDir())
+	os.RemoveAll(mgr.walletDataDir(wallet))
 
 	switch wallet.GetAssetType() {
 	case utils.BTCWalletAsset:
@@ -617,35 +725,60 @@ func (mgr *AssetsManager) RootDirFileSizeInBytes(dataDir string) (int64, error)
 	return size, err
 }
 
-// WalletWithSeed returns the ID of the wallet with the given seed. If a wallet
-// with the given seed does not exist, it returns -1.
-func (mgr *AssetsManager) WalletWithSeed(walletType utils.AssetType, seedMnemonic string) (int, error) {
+// WalletWithSeed returns the ID of the wallet with the given seed and the
+// seed passphrase of BIP39 seeds. If a wallet with the given seed does not
+// exist, it returns -1.
+func (mgr *AssetsManager) WalletWithSeed(walletType utils.AssetType, seedMnemonic, seedPassphrase string) (int, error) {
 	switch walletType {
 	case utils.BTCWalletAsset:
-		return mgr.BTCWalletWithSeed(seedMnemonic)
+		return mgr.BTCWalletWithSeed(seedMnemonic, seedPassphrase)
 	case utils.DCRWalletAsset:
 		return mgr.DCRWalletWithSeed(seedMnemonic)
 	case utils.LTCWalletAsset:
-		return mgr.LTCWalletWithSeed(seedMnemonic)
+		return mgr.LTCWalletWithSeed(seedMnemonic, seedPassphrase)
 	default:
 		return -1, utils.ErrAssetUnknown
 	}
 }
 
-// RestoreWallet restores a wallet from the given seed.
-func (mgr *AssetsManager) RestoreWallet(walletType utils.AssetType, walletName, seedMnemonic, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
+// RestoreWallet restores a wallet from the given seed. The format of the seed
+// is detected from the seed, seedPassphrase is the optional passphrase of
+// BIP39 seeds.
+func (mgr *AssetsManager) RestoreWallet(walletType utils.AssetType, walletName, seedMnemonic, seedPassphrase, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
+	if err := mgr.PassphrasePolicy(privatePassphraseType).Check(privatePassphrase, walletName); err != nil {
+		return nil, err
+	}
+	return mgr.restoreWallet(walletType, walletName, seedMnemonic, seedPassphrase, privatePassphrase, privatePassphraseType)
+}
+
+// restoreWallet restores a wallet from the given seed without checking the
+// private passphrase against its policy.
+func (mgr *AssetsManager) restoreWallet(walletType utils.AssetType, walletName, seedMnemonic, seedPassphrase, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
 	switch walletType {
 	case utils.BTCWalletAsset:
-		return mgr.RestoreBTCWallet(walletName, seedMnemonic, privatePassphrase, privatePassphraseType)
+		return mgr.RestoreBTCWallet(walletName, seedMnemonic, seedPassphrase, privatePassphrase, privatePassphraseType)
 	case utils.DCRWalletAsset:
+		if seedPassphrase != "" {
+			return nil, errors.New(utils.ErrInvalid)
+		}
 		return mgr.RestoreDCRWallet(walletName, seedMnemonic, privatePassphrase, privatePassphraseType)
 	case utils.LTCWalletAsset:
-		return mgr.RestoreLTCWallet(walletName, seedMnemonic, privatePassphrase, privatePassphraseType)
+		return mgr.RestoreLTCWallet(walletName, seedMnemonic, seedPassphrase, privatePassphrase, privatePassphraseType)
 	default:
 		return nil, utils.ErrAssetUnknown
 	}
 }
 
+// RestoreWalletFromShares restores a wallet from the seed split into the
+// SLIP-39 shares.
+func (mgr *AssetsManager) RestoreWalletFromShares(walletType utils.AssetType, walletName string, shares []string, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
+	seedMnemonic, err := sharedW.CombineSeedShares(shares)
+	if err != nil {
+		return nil, err
+	}
+	return mgr.RestoreWallet(walletType, walletName, seedMnemonic, "", privatePassphrase, privatePassphraseType)
+}
+
 // WalletWithXPub returns the ID of the wallet with the given xpub. If a wallet
 // with the given xpub does not exist, it returns -1.
 func (mgr *AssetsManager) WalletWithXPub(walletType utils.AssetType, xPub string) (int, error) {
@@ -661,6 +794,36 @@ func (mgr *AssetsManager) WalletWithXPub(walletType utils.AssetType, xPub string
 	}
 }
 
+// DecodeWatchedAddresses returns the addresses of s, separated by whitespace
+// or commas, if they are all addresses of walletType.
+func (mgr *AssetsManager) DecodeWatchedAddresses(walletType utils.AssetType, s string) ([]string, error) {
+	switch walletType {
+	case utils.DCRWalletAsset:
+		return dcr.DecodeWatchedAddresses(s, mgr.NetType())
+	case utils.BTCWalletAsset:
+		return btc.DecodeWatchedAddresses(s, mgr.NetType())
+	case utils.LTCWalletAsset:
+		return ltc.DecodeWatchedAddresses(s, mgr.NetType())
+	default:
+		return nil, utils.ErrAssetUnknown
+	}
+}
+
+// CreateAddressWatchWallet creates a new wallet of walletType watching the
+// transactions of addresses from the block at startHeight.
+func (mgr *AssetsManager) CreateAddressWatchWallet(walletType utils.AssetType, walletName string, addresses []string, startHeight int32) (sharedW.Asset, error) {
+	switch walletType {
+	case utils.DCRWalletAsset:
+		return mgr.CreateNewDCRAddressWatchWallet(walletName, addresses, startHeight)
+	case utils.BTCWalletAsset:
+		return mgr.CreateNewBTCAddressWatchWallet(walletName, addresses, startHeight)
+	case utils.LTCWalletAsset:
+		return mgr.CreateNewLTCAddressWatchWallet(walletName, addresses, startHeight)
+	default:
+		return nil, utils.ErrAssetUnknown
+	}
+}
+
 // on windows os after a wallet is deleted, the dir of deleted wallet still exists,
 // cleanDeletedWallets will check the data dir of all deleted wallets and remove them.
 func (mgr *AssetsManager) cleanDeletedWallets() {
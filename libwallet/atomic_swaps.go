@@ -0,0 +1,694 @@
+package libwallet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/atomicswap"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// swapWatchInterval is how often the contracts of the swaps are checked
+	// for spends and refunds.
+	swapWatchInterval = 10 * time.Minute
+
+	// swapMessagesDir is the directory of the root dir where the swap
+	// messages are saved.
+	swapMessagesDir = "atomicswaps"
+
+	// swapRefundDelay is how long after the lock time of a contract its
+	// refund is broadcast. The lock times are checked against the median time
+	// of the last blocks, which lags behind the wall clock.
+	swapRefundDelay = time.Hour
+)
+
+// swapWallet returns the wallet with walletID as an atomic swap wallet.
+func (mgr *AssetsManager) swapWallet(walletID int) (sharedW.Asset, atomicswap.Wallet, error) {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return nil, nil, errors.Errorf("wallet with id:%d not found", walletID)
+	}
+
+	swapWallet, ok := wallet.(atomicswap.Wallet)
+	if !ok {
+		return nil, nil, errors.Errorf("%s wallets do not support atomic swaps", wallet.GetAssetType())
+	}
+	return wallet, swapWallet, nil
+}
+
+// CreateSwapOffer creates a swap offering amount from the account of the
+// wallet with walletID in exchange for counterpartyAmount received to the
+// receive account of the wallet with receiveWalletID. The offer is sent to
+// the counterparty with SwapMessage.
+func (mgr *AssetsManager) CreateSwapOffer(walletID int, account int32, amount int64,
+	receiveWalletID int, receiveAccount int32, counterpartyAmount int64,
+) (*atomicswap.Swap, error) {
+	wallet, _, err := mgr.swapWallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	receiveWallet, _, err := mgr.swapWallet(receiveWalletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.GetAssetType() == receiveWallet.GetAssetType() {
+		return nil, errors.New("cannot swap between wallets of the same asset")
+	}
+	if amount <= 0 || counterpartyAmount <= 0 {
+		return nil, errors.E(errors.Invalid, "invalid amount")
+	}
+
+	receiveAddress, err := receiveWallet.NextAddress(receiveAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	swapID, err := atomicswap.NewSwapID()
+	if err != nil {
+		return nil, err
+	}
+	secret, secretHash, err := atomicswap.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	swap := &atomicswap.Swap{
+		SwapID:               swapID,
+		Role:                 atomicswap.Initiator,
+		State:                atomicswap.StateOffered,
+		Asset:                wallet.GetAssetType(),
+		WalletID:             walletID,
+		AccountNumber:        account,
+		Amount:               amount,
+		CounterpartyAsset:    receiveWallet.GetAssetType(),
+		CounterpartyAmount:   counterpartyAmount,
+		ReceiveWalletID:      receiveWalletID,
+		ReceiveAccountNumber: receiveAccount,
+		ReceiveAddress:       receiveAddress,
+		SecretHash:           secretHash,
+		Secret:               secret,
+	}
+	if err := mgr.AtomicSwaps.Save(swap); err != nil {
+		return nil, err
+	}
+	return swap, nil
+}
+
+// AcceptSwapOffer accepts a received swap offer. The offered amount is
+// received to the receive account of the wallet with receiveWalletID and the
+// requested amount is paid from the account of the wallet with walletID. The
+// acceptance is sent to the counterparty with SwapMessage.
+func (mgr *AssetsManager) AcceptSwapOffer(id, walletID int, account int32, receiveWalletID int, receiveAccount int32) (*atomicswap.Swap, error) {
+	swap, err := mgr.AtomicSwaps.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if swap.Role != atomicswap.Participant || swap.State != atomicswap.StateOffered {
+		return nil, errors.New("swap is not a received offer")
+	}
+
+	wallet, swapWallet, err := mgr.swapWallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	receiveWallet, _, err := mgr.swapWallet(receiveWalletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.GetAssetType() != swap.Asset || receiveWallet.GetAssetType() != swap.CounterpartyAsset {
+		return nil, errors.New("wallets do not match the assets of the offer")
+	}
+
+	// The contract funded by the user pays to the counterparty's address.
+	if _, err := swapWallet.AddressHash(swap.CounterpartyAddress); err != nil {
+		return nil, err
+	}
+
+	receiveAddress, err := receiveWallet.NextAddress(receiveAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	swap.WalletID = walletID
+	swap.AccountNumber = account
+	swap.ReceiveWalletID = receiveWalletID
+	swap.ReceiveAccountNumber = receiveAccount
+	swap.ReceiveAddress = receiveAddress
+	swap.State = atomicswap.StateAccepted
+	if err := mgr.AtomicSwaps.Save(swap); err != nil {
+		return nil, err
+	}
+	return swap, nil
+}
+
+// FundSwap funds the user's contract of a swap. The initiator funds its
+// contract once the offer is accepted and the participant once the
+// initiator's contract is audited and has the required confirmations. The
+// contract's refund is signed and broadcast automatically after its lock time.
+func (mgr *AssetsManager) FundSwap(id int, passphrase string) (*atomicswap.Swap, error) {
+	swap, err := mgr.AtomicSwaps.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	lockTime, nextState := atomicswap.InitiatorLockTime, atomicswap.StateInitiated
+	if swap.Role == atomicswap.Participant {
+		lockTime, nextState = atomicswap.ParticipantLockTime, atomicswap.StateParticipated
+	}
+	// The contract is funded from the state preceding nextState.
+	if swap.Contract != nil || (swap.Role == atomicswap.Initiator && swap.State != atomicswap.StateAccepted) ||
+		(swap.Role == atomicswap.Participant && swap.State != atomicswap.StateInitiated) {
+		return nil, errors.New("swap contract cannot be funded")
+	}
+
+	if swap.Role == atomicswap.Participant {
+		if err := mgr.confirmCounterpartyContract(swap); err != nil {
+			return nil, err
+		}
+	}
+
+	wallet, swapWallet, err := mgr.swapWallet(swap.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientHash, err := swapWallet.AddressHash(swap.CounterpartyAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := &atomicswap.Terms{
+		SecretHash:    swap.SecretHash,
+		RecipientHash: recipientHash,
+		LockTime:      time.Now().Add(lockTime).Unix(),
+	}
+	contractHeight := wallet.GetBestBlockHeight()
+	contract, err := swapWallet.FundContract(swap.AccountNumber, terms, swap.Amount, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Swap %s contract funded by %s", swap.SwapID, contract.TxHash)
+	swap.Contract = contract
+	swap.LockTime = terms.LockTime
+	swap.ContractHeight = contractHeight
+	swap.State = nextState
+	swap.LastError = ""
+	if err := mgr.AtomicSwaps.Save(swap); err != nil {
+		return nil, err
+	}
+	return swap, nil
+}
+
+// RedeemSwap redeems the counterparty's contract of a swap. The initiator
+// redeems once the participant's contract is audited and has the required
+// confirmations, revealing the secret to the participant, who redeems once
+// the secret is received or found on-chain.
+func (mgr *AssetsManager) RedeemSwap(id int, passphrase string) (*atomicswap.Swap, error) {
+	swap, err := mgr.AtomicSwaps.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if swap.State != atomicswap.StateParticipated || swap.CounterpartyContract == nil {
+		return nil, errors.New("swap contract cannot be redeemed")
+	}
+	if len(swap.Secret) == 0 {
+		return nil, errors.New("swap secret is not known yet")
+	}
+	if err := mgr.confirmCounterpartyContract(swap); err != nil {
+		return nil, err
+	}
+
+	_, receiveWallet, err := mgr.swapWallet(swap.ReceiveWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, txHash, err := receiveWallet.RedeemContract(swap.ReceiveAccountNumber, swap.CounterpartyContract, swap.Secret, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Swap %s contract redeemed by %s", swap.SwapID, txHash)
+	swap.RedeemTx = tx
+	swap.RedeemTxHash = txHash
+	swap.State = atomicswap.StateRedeemed
+	swap.LastError = ""
+	if err := mgr.AtomicSwaps.Save(swap); err != nil {
+		return nil, err
+	}
+	return swap, nil
+}
+
+// PresignSwapRedeem signs the participant's redeem of the counterparty's
+// contract of a swap, so that it is broadcast automatically once the
+// initiator reveals the secret.
+func (mgr *AssetsManager) PresignSwapRedeem(id int, passphrase string) (*atomicswap.Swap, error) {
+	swap, err := mgr.AtomicSwaps.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if swap.Role != atomicswap.Participant || swap.State.IsFinal() || swap.CounterpartyContract == nil {
+		return nil, errors.New("swap redeem cannot be signed")
+	}
+
+	_, receiveWallet, err := mgr.swapWallet(swap.ReceiveWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	redeem, err := receiveWallet.SignRedeem(swap.ReceiveAccountNumber, swap.CounterpartyContract, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	swap.PresignedRedeem = redeem
+	if len(swap.Secret) > 0 && swap.State == atomicswap.StateParticipated {
+		if err := mgr.redeemPresigned(swap); err != nil {
+			swap.LastError = err.Error()
+		}
+	}
+	if err := mgr.AtomicSwaps.Save(swap); err != nil {
+		return nil, err
+	}
+	return swap, nil
+}
+
+// redeemPresigned broadcasts the presigned redeem of the counterparty's
+// contract of swap with the swap secret. The swap is not saved.
+func (mgr *AssetsManager) redeemPresigned(swap *atomicswap.Swap) error {
+	_, receiveWallet, err := mgr.swapWallet(swap.ReceiveWalletID)
+	if err != nil {
+		return err
+	}
+
+	tx, txHash, err := receiveWallet.PublishRedeem(swap.PresignedRedeem, swap.CounterpartyContract, swap.Secret)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Swap %s contract redeemed by %s", swap.SwapID, txHash)
+	swap.RedeemTx = tx
+	swap.RedeemTxHash = txHash
+	swap.State = atomicswap.StateRedeemed
+	swap.LastError = ""
+	return nil
+}
+
+// RefundSwap broadcasts the refund of the user's contract of a swap. The
+// refund is only accepted by the network after the contract's lock time.
+func (mgr *AssetsManager) RefundSwap(id int) (*atomicswap.Swap, error) {
+	swap, err := mgr.AtomicSwaps.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !swap.Refundable() {
+		return nil, errors.New("swap contract cannot be refunded")
+	}
+	if time.Now().Unix() < swap.LockTime {
+		return nil, errors.Errorf("swap contract can be refunded after %s", time.Unix(swap.LockTime, 0))
+	}
+
+	_, swapWallet, err := mgr.swapWallet(swap.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := swapWallet.PublishRawTx(swap.Contract.RefundTx); err != nil {
+		swap.LastError = err.Error()
+		if saveErr := mgr.AtomicSwaps.Save(swap); saveErr != nil {
+			log.Errorf("Error saving swap %s: %v", swap.SwapID, saveErr)
+		}
+		return nil, err
+	}
+
+	log.Infof("Swap %s contract refunded by %s", swap.SwapID, swap.Contract.RefundTxHash)
+	swap.State = atomicswap.StateRefunded
+	swap.LastError = ""
+	if err := mgr.AtomicSwaps.Save(swap); err != nil {
+		return nil, err
+	}
+	return swap, nil
+}
+
+// CancelSwap cancels a swap whose user's contract is not funded.
+func (mgr *AssetsManager) CancelSwap(id int) error {
+	swap, err := mgr.AtomicSwaps.Get(id)
+	if err != nil {
+		return err
+	}
+	if swap.Contract != nil || swap.State.IsFinal() {
+		return errors.New("swap cannot be canceled")
+	}
+
+	swap.State = atomicswap.StateCanceled
+	return mgr.AtomicSwaps.Save(swap)
+}
+
+// SwapMessage returns the message to send to the counterparty of a swap in
+// its current state.
+func (mgr *AssetsManager) SwapMessage(id int) (*atomicswap.Message, error) {
+	swap, err := mgr.AtomicSwaps.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &atomicswap.Message{
+		Version: atomicswap.MessageVersion,
+		SwapID:  swap.SwapID,
+	}
+	switch {
+	case swap.Role == atomicswap.Initiator && swap.State == atomicswap.StateOffered:
+		msg.Kind = atomicswap.MsgOffer
+		msg.InitiatorAsset = swap.Asset
+		msg.InitiatorAmount = swap.Amount
+		msg.ParticipantAsset = swap.CounterpartyAsset
+		msg.ParticipantAmount = swap.CounterpartyAmount
+		msg.Address = swap.ReceiveAddress
+
+	case swap.Role == atomicswap.Participant && swap.State == atomicswap.StateAccepted:
+		msg.Kind = atomicswap.MsgAccept
+		msg.Address = swap.ReceiveAddress
+
+	case swap.Role == atomicswap.Initiator && swap.State == atomicswap.StateInitiated,
+		swap.Role == atomicswap.Participant && swap.State == atomicswap.StateParticipated:
+		msg.Kind = atomicswap.MsgInitiate
+		if swap.Role == atomicswap.Participant {
+			msg.Kind = atomicswap.MsgParticipate
+		}
+		msg.SecretHash = swap.SecretHash
+		msg.ContractScript = swap.Contract.Script
+		msg.ContractTx = swap.Contract.Tx
+
+	case swap.Role == atomicswap.Initiator && swap.State == atomicswap.StateRedeemed:
+		msg.Kind = atomicswap.MsgRedeem
+		msg.RedeemTx = swap.RedeemTx
+
+	default:
+		return nil, errors.New("swap has no message to send")
+	}
+	return msg, nil
+}
+
+// SaveSwapMessage saves the message to send to the counterparty of a swap to
+// a file and returns its path.
+func (mgr *AssetsManager) SaveSwapMessage(id int) (string, error) {
+	msg, err := mgr.SwapMessage(id)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(mgr.params.RootDir, swapMessagesDir)
+	if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.cpswap", msg.SwapID, msg.Kind))
+	if err := atomicswap.WriteMessageFile(msg, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ProcessSwapMessage moves the swap of a message received from the
+// counterparty to its next state. A received offer creates a new swap, to be
+// accepted with AcceptSwapOffer. The counterparty's contracts are audited
+// before they are accepted.
+func (mgr *AssetsManager) ProcessSwapMessage(msg *atomicswap.Message) (*atomicswap.Swap, error) {
+	if msg.Kind == atomicswap.MsgOffer {
+		return mgr.processSwapOffer(msg)
+	}
+
+	swap, err := mgr.AtomicSwaps.GetBySwapID(msg.SwapID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case msg.Kind == atomicswap.MsgAccept && swap.Role == atomicswap.Initiator && swap.State == atomicswap.StateOffered:
+		_, swapWallet, err := mgr.swapWallet(swap.WalletID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := swapWallet.AddressHash(msg.Address); err != nil {
+			return nil, err
+		}
+		swap.CounterpartyAddress = msg.Address
+		swap.State = atomicswap.StateAccepted
+
+	case msg.Kind == atomicswap.MsgInitiate && swap.Role == atomicswap.Participant && swap.State == atomicswap.StateAccepted:
+		// The initiator's contract must outlive the participant's, so that
+		// the participant can redeem it once the initiator reveals the secret.
+		minLockTime := time.Now().Add(atomicswap.ParticipantLockTime + atomicswap.MinLockTimeMargin)
+		swap.SecretHash = msg.SecretHash
+		if err := mgr.auditSwapContract(swap, msg, minLockTime); err != nil {
+			return nil, err
+		}
+		swap.State = atomicswap.StateInitiated
+
+	case msg.Kind == atomicswap.MsgParticipate && swap.Role == atomicswap.Initiator && swap.State == atomicswap.StateInitiated:
+		if !bytes.Equal(msg.SecretHash, swap.SecretHash) {
+			return nil, errors.New("contract does not use the swap secret")
+		}
+		minLockTime := time.Now().Add(atomicswap.MinRedeemTime)
+		if err := mgr.auditSwapContract(swap, msg, minLockTime); err != nil {
+			return nil, err
+		}
+		swap.State = atomicswap.StateParticipated
+
+	case msg.Kind == atomicswap.MsgRedeem && swap.Role == atomicswap.Participant && swap.State == atomicswap.StateParticipated:
+		if err := mgr.extractSwapSecret(swap, msg.RedeemTx); err != nil {
+			return nil, err
+		}
+		if swap.PresignedRedeem != nil {
+			if err := mgr.redeemPresigned(swap); err != nil {
+				swap.LastError = err.Error()
+			}
+		}
+
+	default:
+		return nil, errors.Errorf("unexpected %s message for a %s swap", msg.Kind, swap.State)
+	}
+
+	if err := mgr.AtomicSwaps.Save(swap); err != nil {
+		return nil, err
+	}
+	return swap, nil
+}
+
+// processSwapOffer creates the swap of a received offer.
+func (mgr *AssetsManager) processSwapOffer(msg *atomicswap.Message) (*atomicswap.Swap, error) {
+	if _, err := mgr.AtomicSwaps.GetBySwapID(msg.SwapID); err == nil {
+		return nil, errors.New("swap offer already received")
+	} else if err.Error() != utils.ErrNotExist {
+		return nil, err
+	}
+	if msg.InitiatorAmount <= 0 || msg.ParticipantAmount <= 0 || msg.InitiatorAsset == msg.ParticipantAsset {
+		return nil, errors.New("invalid swap offer")
+	}
+
+	swap := &atomicswap.Swap{
+		SwapID:              msg.SwapID,
+		Role:                atomicswap.Participant,
+		State:               atomicswap.StateOffered,
+		Asset:               msg.ParticipantAsset,
+		Amount:              msg.ParticipantAmount,
+		CounterpartyAddress: msg.Address,
+		CounterpartyAsset:   msg.InitiatorAsset,
+		CounterpartyAmount:  msg.InitiatorAmount,
+	}
+	if err := mgr.AtomicSwaps.Save(swap); err != nil {
+		return nil, err
+	}
+	return swap, nil
+}
+
+// extractSwapSecret sets the secret of swap from the initiator's redeem of the
+// participant's contract.
+func (mgr *AssetsManager) extractSwapSecret(swap *atomicswap.Swap, redeemTx []byte) error {
+	_, swapWallet, err := mgr.swapWallet(swap.WalletID)
+	if err != nil {
+		return err
+	}
+	sigScript, err := swapWallet.RedeemSigScript(redeemTx, swap.Contract.TxHash)
+	if err != nil {
+		return err
+	}
+	secret, err := atomicswap.ExtractSecret(sigScript, swap.SecretHash)
+	if err != nil {
+		return err
+	}
+	swap.Secret = secret
+	return nil
+}
+
+// auditSwapContract checks that the counterparty's contract of msg pays the
+// swap's amount to the user's receive address for the swap secret, and that
+// it cannot be refunded before minLockTime. The contract's transaction is
+// broadcast in case the counterparty did not. Its confirmations are checked by
+// confirmCounterpartyContract before the swap proceeds.
+func (mgr *AssetsManager) auditSwapContract(swap *atomicswap.Swap, msg *atomicswap.Message, minLockTime time.Time) error {
+	wallet, receiveWallet, err := mgr.swapWallet(swap.ReceiveWalletID)
+	if err != nil {
+		return err
+	}
+
+	contract, terms, err := receiveWallet.AuditContract(msg.ContractScript, msg.ContractTx)
+	if err != nil {
+		return err
+	}
+
+	recipientHash, err := receiveWallet.AddressHash(swap.ReceiveAddress)
+	if err != nil {
+		return err
+	}
+	switch {
+	case !bytes.Equal(terms.RecipientHash, recipientHash):
+		return errors.New("contract does not pay to the receive address")
+	case !bytes.Equal(terms.SecretHash, swap.SecretHash):
+		return errors.New("contract does not use the swap secret")
+	case contract.Amount < swap.CounterpartyAmount:
+		return errors.Errorf("contract pays %d instead of %d", contract.Amount, swap.CounterpartyAmount)
+	case terms.LockTime < minLockTime.Unix():
+		return errors.Errorf("contract can be refunded too early, at %s", time.Unix(terms.LockTime, 0))
+	}
+
+	if _, err := receiveWallet.PublishRawTx(contract.Tx); err != nil {
+		log.Debugf("Swap %s counterparty contract not broadcast: %v", swap.SwapID, err)
+	}
+
+	// The contract is searched for on-chain from the blocks mined since the
+	// longest contracts were funded.
+	lockTimeBlocks := int32(atomicswap.InitiatorLockTime.Minutes() / wallet.TargetTimePerBlockMinutes())
+	swap.CounterpartyHeight = wallet.GetBestBlockHeight() - lockTimeBlocks
+	if swap.CounterpartyHeight < 0 {
+		swap.CounterpartyHeight = 0
+	}
+
+	swap.CounterpartyContract = contract
+	swap.CounterpartyLockTime = terms.LockTime
+	return nil
+}
+
+// confirmCounterpartyContract checks that the counterparty's contract of swap
+// is mined with the receive wallet's required confirmations and is not spent.
+// The swap is not saved.
+func (mgr *AssetsManager) confirmCounterpartyContract(swap *atomicswap.Swap) error {
+	wallet, receiveWallet, err := mgr.swapWallet(swap.ReceiveWalletID)
+	if err != nil {
+		return err
+	}
+
+	ctx, _ := wallet.ShutdownContextWithCancel()
+	status, err := receiveWallet.ContractStatus(ctx, swap.CounterpartyContract, swap.CounterpartyHeight)
+	if err != nil {
+		return err
+	}
+	if status.SpendTxHash != "" {
+		return errors.Errorf("counterparty contract was spent by %s", status.SpendTxHash)
+	}
+	if required := wallet.RequiredConfirmations(); status.Confirmations < required {
+		return errors.Errorf("counterparty contract has %d of %d confirmations", status.Confirmations, required)
+	}
+
+	// Later searches start from the block mining the contract.
+	swap.CounterpartyHeight = status.Height
+	return nil
+}
+
+// startSwapWatcher checks the funded contracts of the swaps for spends and
+// refunds them once their lock time passes, until ctx is canceled.
+func (mgr *AssetsManager) startSwapWatcher(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(swapWatchInterval)
+		defer ticker.Stop()
+		for {
+			mgr.checkFundedSwaps(ctx)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// checkFundedSwaps checks the funded contracts of the swaps for spends, and
+// broadcasts the refunds of the unspent contracts whose lock time passed.
+func (mgr *AssetsManager) checkFundedSwaps(ctx context.Context) {
+	swaps, err := mgr.AtomicSwaps.Funded()
+	if err != nil {
+		log.Errorf("Error fetching swaps: %v", err)
+		return
+	}
+
+	for _, swap := range swaps {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := mgr.checkSwapContractSpend(ctx, swap); err != nil {
+			log.Errorf("Error checking swap %s contract: %v", swap.SwapID, err)
+			continue
+		}
+		if !swap.Refundable() || time.Since(time.Unix(swap.LockTime, 0)) < swapRefundDelay {
+			continue
+		}
+		if _, err := mgr.RefundSwap(swap.ID); err != nil {
+			log.Errorf("Error refunding swap %s: %v", swap.SwapID, err)
+		}
+	}
+}
+
+// checkSwapContractSpend looks up the spend of the user's contract of swap
+// on-chain. A contract redeemed by the initiator reveals the secret to the
+// participant, whose presigned redeem of the initiator's contract is then
+// broadcast. The swap is saved if it changed.
+func (mgr *AssetsManager) checkSwapContractSpend(ctx context.Context, swap *atomicswap.Swap) error {
+	changed := false
+	if swap.ContractSpendTxHash == "" {
+		_, swapWallet, err := mgr.swapWallet(swap.WalletID)
+		if err != nil {
+			return err
+		}
+		status, err := swapWallet.ContractStatus(ctx, &swap.Contract.Contract, swap.ContractHeight)
+		if err != nil {
+			return err
+		}
+		if status.SpendTxHash == "" {
+			return nil
+		}
+
+		log.Infof("Swap %s contract spent by %s", swap.SwapID, status.SpendTxHash)
+		changed = true
+		swap.ContractSpendTxHash = status.SpendTxHash
+		switch {
+		case status.SpendTxHash == swap.Contract.RefundTxHash:
+			swap.State = atomicswap.StateRefunded
+		case swap.Role == atomicswap.Participant && len(swap.Secret) == 0:
+			if err := mgr.extractSwapSecret(swap, status.SpendTx); err != nil {
+				swap.LastError = err.Error()
+			}
+		}
+	}
+
+	if swap.Role == atomicswap.Participant && swap.State == atomicswap.StateParticipated &&
+		len(swap.Secret) > 0 && swap.PresignedRedeem != nil {
+		changed = true
+		if err := mgr.redeemPresigned(swap); err != nil {
+			swap.LastError = err.Error()
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return mgr.AtomicSwaps.Save(swap)
+}
@@ -0,0 +1,324 @@
+package atomicswap
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// SecretSize is the size of a swap secret.
+	SecretSize = 32
+
+	// pubKeyHashSize is the size of the HASH160 of a public key.
+	pubKeyHashSize = 20
+	// pubKeySize is the size of a compressed public key.
+	pubKeySize = 33
+	// maxSigSize is the maximum size of a DER signature with its hash type.
+	maxSigSize = 73
+)
+
+// Opcodes used by the contract scripts. They have the same values on all the
+// supported chains, except for OP_SHA256, see Chain.
+const (
+	op0                   = 0x00
+	opPushData1           = 0x4c
+	opPushData2           = 0x4d
+	op1                   = 0x51
+	opIf                  = 0x63
+	opElse                = 0x67
+	opEndIf               = 0x68
+	opDrop                = 0x75
+	opDup                 = 0x76
+	opSize                = 0x82
+	opEqualVerify         = 0x88
+	opHash160             = 0xa9
+	opCheckSig            = 0xac
+	opCheckLockTimeVerify = 0xb1
+)
+
+// Chain holds the script differences of the chains that support swaps.
+type Chain struct {
+	// SHA256Opcode is the value of OP_SHA256.
+	SHA256Opcode byte
+}
+
+var (
+	// BitcoinChain describes the scripts of Bitcoin and Litecoin.
+	BitcoinChain = Chain{SHA256Opcode: 0xa8}
+	// DecredChain describes the scripts of Decred.
+	DecredChain = Chain{SHA256Opcode: 0xc0}
+)
+
+// ErrNotContract is returned when a script is not an atomic swap contract.
+var ErrNotContract = errors.New("script is not an atomic swap contract")
+
+// Terms are the terms of a contract.
+type Terms struct {
+	SecretHash    []byte `json:"secretHash"`
+	RecipientHash []byte `json:"recipientHash"` // HASH160 of the recipient's public key.
+	RefundHash    []byte `json:"refundHash"`    // HASH160 of the refund public key.
+	LockTime      int64  `json:"lockTime"`      // Unix time after which the contract can be refunded.
+}
+
+// NewSecret returns a random secret and its SHA256 hash.
+func NewSecret() (secret, secretHash []byte, err error) {
+	secret = make([]byte, SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, nil, err
+	}
+	hash := sha256.Sum256(secret)
+	return secret, hash[:], nil
+}
+
+// BuildContract returns a contract script paying to the owner of the public
+// key hashed to terms.RecipientHash once the secret of terms.SecretHash is
+// revealed. After terms.LockTime the contract pays to the owner of the public
+// key hashed to terms.RefundHash.
+func (c Chain) BuildContract(terms *Terms) ([]byte, error) {
+	if len(terms.SecretHash) != sha256.Size {
+		return nil, fmt.Errorf("invalid secret hash size %d", len(terms.SecretHash))
+	}
+	if len(terms.RecipientHash) != pubKeyHashSize || len(terms.RefundHash) != pubKeyHashSize {
+		return nil, errors.New("invalid public key hash size")
+	}
+	if terms.LockTime <= 0 {
+		return nil, errors.New("invalid lock time")
+	}
+
+	var b scriptBuilder
+	b.addOp(opIf) // Normal redeem path
+	// Require initiator's secret to be a known length that the redeeming
+	// party can audit. This is used to prevent fraud attacks between two
+	// currencies that have different maximum data sizes.
+	b.addOp(opSize)
+	b.addInt(SecretSize)
+	b.addOp(opEqualVerify)
+	// Require initiator's secret to be known to redeem the output.
+	b.addOp(c.SHA256Opcode)
+	b.addData(terms.SecretHash)
+	b.addOp(opEqualVerify)
+	// Verify their signature is being used to redeem the output.
+	b.addOp(opDup)
+	b.addOp(opHash160)
+	b.addData(terms.RecipientHash)
+	b.addOp(opElse) // Refund path
+	// Verify locktime and drop it off the stack (which is not done by CLTV).
+	b.addInt(terms.LockTime)
+	b.addOp(opCheckLockTimeVerify)
+	b.addOp(opDrop)
+	// Verify our signature is being used to redeem the output.
+	b.addOp(opDup)
+	b.addOp(opHash160)
+	b.addData(terms.RefundHash)
+	b.addOp(opEndIf)
+	// Complete the signature check.
+	b.addOp(opEqualVerify)
+	b.addOp(opCheckSig)
+	return b.script(), nil
+}
+
+// ExtractTerms returns the terms of contract. ErrNotContract is returned if
+// contract was not built by BuildContract.
+func (c Chain) ExtractTerms(contract []byte) (*Terms, error) {
+	tokens, err := tokenize(contract)
+	if err != nil || len(tokens) != 20 {
+		return nil, ErrNotContract
+	}
+
+	lockTime, err := decodeInt(tokens[11])
+	if err != nil {
+		return nil, ErrNotContract
+	}
+
+	terms := &Terms{
+		SecretHash:    tokens[5].data,
+		RecipientHash: tokens[9].data,
+		RefundHash:    tokens[16].data,
+		LockTime:      lockTime,
+	}
+
+	// Rebuilding the contract from its terms checks every opcode and push.
+	rebuilt, err := c.BuildContract(terms)
+	if err != nil || !bytes.Equal(rebuilt, contract) {
+		return nil, ErrNotContract
+	}
+	return terms, nil
+}
+
+// RedeemSigScript returns the signature script that redeems contract with
+// secret.
+func RedeemSigScript(sig, pubKey, secret, contract []byte) []byte {
+	var b scriptBuilder
+	b.addData(sig)
+	b.addData(pubKey)
+	b.addData(secret)
+	b.addOp(op1)
+	b.addData(contract)
+	return b.script()
+}
+
+// RefundSigScript returns the signature script that refunds contract.
+func RefundSigScript(sig, pubKey, contract []byte) []byte {
+	var b scriptBuilder
+	b.addData(sig)
+	b.addData(pubKey)
+	b.addOp(op0)
+	b.addData(contract)
+	return b.script()
+}
+
+// RedeemSigScriptSize returns the maximum size of the signature script that
+// redeems contract.
+func RedeemSigScriptSize(contract []byte) int {
+	return 1 + maxSigSize + 1 + pubKeySize + 1 + SecretSize + 1 + pushSize(contract)
+}
+
+// RefundSigScriptSize returns the maximum size of the signature script that
+// refunds contract.
+func RefundSigScriptSize(contract []byte) int {
+	return 1 + maxSigSize + 1 + pubKeySize + 1 + pushSize(contract)
+}
+
+// pushSize returns the size of the push of data.
+func pushSize(data []byte) int {
+	var b scriptBuilder
+	b.addData(data)
+	return len(b.script())
+}
+
+// ExtractSecret returns the secret of secretHash revealed by the signature
+// script of a contract redemption.
+func ExtractSecret(sigScript, secretHash []byte) ([]byte, error) {
+	tokens, err := tokenize(sigScript)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tokens {
+		if len(t.data) != SecretSize {
+			continue
+		}
+		if hash := sha256.Sum256(t.data); bytes.Equal(hash[:], secretHash) {
+			return t.data, nil
+		}
+	}
+	return nil, errors.New("signature script does not reveal the secret")
+}
+
+// scriptBuilder builds scripts using the minimal push encodings.
+type scriptBuilder struct {
+	buf bytes.Buffer
+}
+
+func (b *scriptBuilder) addOp(op byte) {
+	b.buf.WriteByte(op)
+}
+
+func (b *scriptBuilder) addData(data []byte) {
+	switch n := len(data); {
+	case n < opPushData1:
+		b.buf.WriteByte(byte(n))
+	case n <= 0xff:
+		b.buf.WriteByte(opPushData1)
+		b.buf.WriteByte(byte(n))
+	default:
+		b.buf.WriteByte(opPushData2)
+		var l [2]byte
+		binary.LittleEndian.PutUint16(l[:], uint16(n))
+		b.buf.Write(l[:])
+	}
+	b.buf.Write(data)
+}
+
+// addInt pushes n, which must not be negative, as a script number.
+func (b *scriptBuilder) addInt(n int64) {
+	switch {
+	case n == 0:
+		b.addOp(op0)
+	case n <= 16:
+		b.addOp(op1 + byte(n-1))
+	default:
+		b.addData(scriptNum(n))
+	}
+}
+
+func (b *scriptBuilder) script() []byte {
+	return b.buf.Bytes()
+}
+
+// scriptNum returns the minimal little endian encoding of the positive n.
+func scriptNum(n int64) []byte {
+	var num []byte
+	for n > 0 {
+		num = append(num, byte(n&0xff))
+		n >>= 8
+	}
+	// A set most significant bit would make the number negative.
+	if num[len(num)-1]&0x80 != 0 {
+		num = append(num, 0)
+	}
+	return num
+}
+
+// token is an opcode of a script, with the data it pushes.
+type token struct {
+	op   byte
+	data []byte
+}
+
+// tokenize splits script into its opcodes.
+func tokenize(script []byte) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(script); {
+		op := script[i]
+		i++
+
+		var n int
+		switch {
+		case op > op0 && op < opPushData1:
+			n = int(op)
+		case op == opPushData1:
+			if i+1 > len(script) {
+				return nil, errors.New("malformed push")
+			}
+			n = int(script[i])
+			i++
+		case op == opPushData2:
+			if i+2 > len(script) {
+				return nil, errors.New("malformed push")
+			}
+			n = int(binary.LittleEndian.Uint16(script[i:]))
+			i += 2
+		default:
+			tokens = append(tokens, token{op: op})
+			continue
+		}
+
+		if i+n > len(script) {
+			return nil, errors.New("malformed push")
+		}
+		tokens = append(tokens, token{op: op, data: script[i : i+n]})
+		i += n
+	}
+	return tokens, nil
+}
+
+// decodeInt returns the value of a token pushing a positive script number.
+func decodeInt(t token) (int64, error) {
+	if t.op >= op1 && t.op <= op1+15 {
+		return int64(t.op-op1) + 1, nil
+	}
+	if len(t.data) == 0 || len(t.data) > 5 || t.data[len(t.data)-1]&0x80 != 0 {
+		return 0, errors.New("invalid script number")
+	}
+
+	var n int64
+	for i := len(t.data) - 1; i >= 0; i-- {
+		n = n<<8 | int64(t.data[i])
+	}
+	return n, nil
+}
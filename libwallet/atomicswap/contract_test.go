@@ -0,0 +1,238 @@
+package atomicswap
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"reflect"
+	"testing"
+
+	btctxscript "github.com/btcsuite/btcd/txscript"
+	dcrtxscript "github.com/decred/dcrd/txscript/v4"
+)
+
+func testTerms(lockTime int64) *Terms {
+	secretHash := sha256.Sum256(bytes.Repeat([]byte{0x01}, SecretSize))
+	return &Terms{
+		SecretHash:    secretHash[:],
+		RecipientHash: bytes.Repeat([]byte{0x02}, pubKeyHashSize),
+		RefundHash:    bytes.Repeat([]byte{0x03}, pubKeyHashSize),
+		LockTime:      lockTime,
+	}
+}
+
+// btcContract builds the contract of terms with the btcd script builder, as
+// done by the reference atomic swap implementation.
+func btcContract(t *testing.T, terms *Terms) []byte {
+	script, err := btctxscript.NewScriptBuilder().
+		AddOp(btctxscript.OP_IF).
+		AddOp(btctxscript.OP_SIZE).
+		AddInt64(SecretSize).
+		AddOp(btctxscript.OP_EQUALVERIFY).
+		AddOp(btctxscript.OP_SHA256).
+		AddData(terms.SecretHash).
+		AddOp(btctxscript.OP_EQUALVERIFY).
+		AddOp(btctxscript.OP_DUP).
+		AddOp(btctxscript.OP_HASH160).
+		AddData(terms.RecipientHash).
+		AddOp(btctxscript.OP_ELSE).
+		AddInt64(terms.LockTime).
+		AddOp(btctxscript.OP_CHECKLOCKTIMEVERIFY).
+		AddOp(btctxscript.OP_DROP).
+		AddOp(btctxscript.OP_DUP).
+		AddOp(btctxscript.OP_HASH160).
+		AddData(terms.RefundHash).
+		AddOp(btctxscript.OP_ENDIF).
+		AddOp(btctxscript.OP_EQUALVERIFY).
+		AddOp(btctxscript.OP_CHECKSIG).
+		Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return script
+}
+
+// dcrContract builds the contract of terms with the dcrd script builder, as
+// done by the reference atomic swap implementation.
+func dcrContract(t *testing.T, terms *Terms) []byte {
+	script, err := dcrtxscript.NewScriptBuilder().
+		AddOp(dcrtxscript.OP_IF).
+		AddOp(dcrtxscript.OP_SIZE).
+		AddInt64(SecretSize).
+		AddOp(dcrtxscript.OP_EQUALVERIFY).
+		AddOp(dcrtxscript.OP_SHA256).
+		AddData(terms.SecretHash).
+		AddOp(dcrtxscript.OP_EQUALVERIFY).
+		AddOp(dcrtxscript.OP_DUP).
+		AddOp(dcrtxscript.OP_HASH160).
+		AddData(terms.RecipientHash).
+		AddOp(dcrtxscript.OP_ELSE).
+		AddInt64(terms.LockTime).
+		AddOp(dcrtxscript.OP_CHECKLOCKTIMEVERIFY).
+		AddOp(dcrtxscript.OP_DROP).
+		AddOp(dcrtxscript.OP_DUP).
+		AddOp(dcrtxscript.OP_HASH160).
+		AddData(terms.RefundHash).
+		AddOp(dcrtxscript.OP_ENDIF).
+		AddOp(dcrtxscript.OP_EQUALVERIFY).
+		AddOp(dcrtxscript.OP_CHECKSIG).
+		Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return script
+}
+
+func TestBuildContract(t *testing.T) {
+	tests := []struct {
+		name      string
+		chain     Chain
+		other     Chain
+		reference func(*testing.T, *Terms) []byte
+		lockTime  int64
+	}{
+		{"btc small int", BitcoinChain, DecredChain, btcContract, 16},
+		{"btc one byte", BitcoinChain, DecredChain, btcContract, 0x7f},
+		{"btc sign byte", BitcoinChain, DecredChain, btcContract, 0x80},
+		{"btc unix time", BitcoinChain, DecredChain, btcContract, 1700000000},
+		{"btc max lock time", BitcoinChain, DecredChain, btcContract, 0xffffffff},
+		{"dcr small int", DecredChain, BitcoinChain, dcrContract, 1},
+		{"dcr one byte", DecredChain, BitcoinChain, dcrContract, 0x7f},
+		{"dcr sign byte", DecredChain, BitcoinChain, dcrContract, 0x8000},
+		{"dcr unix time", DecredChain, BitcoinChain, dcrContract, 1700000000},
+		{"dcr max lock time", DecredChain, BitcoinChain, dcrContract, 0xffffffff},
+	}
+
+	for _, test := range tests {
+		terms := testTerms(test.lockTime)
+		contract, err := test.chain.BuildContract(terms)
+		if err != nil {
+			t.Fatalf("%s: BuildContract error: %v", test.name, err)
+		}
+		if want := test.reference(t, terms); !bytes.Equal(contract, want) {
+			t.Errorf("%s: contract %x, want %x", test.name, contract, want)
+		}
+
+		extracted, err := test.chain.ExtractTerms(contract)
+		if err != nil {
+			t.Fatalf("%s: ExtractTerms error: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(extracted, terms) {
+			t.Errorf("%s: extracted terms %+v, want %+v", test.name, extracted, terms)
+		}
+
+		// The contracts of the chains only differ by their OP_SHA256.
+		if _, err := test.other.ExtractTerms(contract); !errors.Is(err, ErrNotContract) {
+			t.Errorf("%s: ExtractTerms of the other chain error %v, want %v", test.name, err, ErrNotContract)
+		}
+	}
+}
+
+func TestBuildContractInvalidTerms(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Terms)
+	}{
+		{"short secret hash", func(terms *Terms) { terms.SecretHash = terms.SecretHash[1:] }},
+		{"short recipient hash", func(terms *Terms) { terms.RecipientHash = terms.RecipientHash[1:] }},
+		{"long refund hash", func(terms *Terms) { terms.RefundHash = append(terms.RefundHash, 0) }},
+		{"zero lock time", func(terms *Terms) { terms.LockTime = 0 }},
+		{"negative lock time", func(terms *Terms) { terms.LockTime = -1 }},
+	}
+
+	for _, test := range tests {
+		terms := testTerms(1700000000)
+		test.modify(terms)
+		if _, err := BitcoinChain.BuildContract(terms); err == nil {
+			t.Errorf("%s: BuildContract succeeded", test.name)
+		}
+	}
+}
+
+func TestExtractTermsNotContract(t *testing.T) {
+	contract, err := BitcoinChain.BuildContract(testTerms(1700000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		script []byte
+	}{
+		{"empty", nil},
+		{"truncated", contract[:len(contract)-1]},
+		{"extra opcode", append(append([]byte{}, contract...), opCheckSig)},
+		{"p2pkh", append([]byte{opDup, opHash160, pubKeyHashSize}, append(bytes.Repeat([]byte{0x02}, pubKeyHashSize), opEqualVerify, opCheckSig)...)},
+		{"malformed push", []byte{opPushData1}},
+	}
+
+	for _, test := range tests {
+		if _, err := BitcoinChain.ExtractTerms(test.script); !errors.Is(err, ErrNotContract) {
+			t.Errorf("%s: ExtractTerms error %v, want %v", test.name, err, ErrNotContract)
+		}
+	}
+}
+
+func TestSigScripts(t *testing.T) {
+	secret, secretHash, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := bytes.Repeat([]byte{0x30}, maxSigSize)
+	pubKey := bytes.Repeat([]byte{0x02}, pubKeySize)
+
+	for _, chain := range []Chain{BitcoinChain, DecredChain} {
+		terms := testTerms(1700000000)
+		terms.SecretHash = secretHash
+		contract, err := chain.BuildContract(terms)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			name      string
+			sigScript []byte
+			maxSize   int
+			pushes    [][]byte
+			revealed  bool
+		}{{
+			name:      "redeem",
+			sigScript: RedeemSigScript(sig, pubKey, secret, contract),
+			maxSize:   RedeemSigScriptSize(contract),
+			// PushedData skips the OP_1 selecting the redeem branch.
+			pushes:   [][]byte{sig, pubKey, secret, contract},
+			revealed: true,
+		}, {
+			name:      "refund",
+			sigScript: RefundSigScript(sig, pubKey, contract),
+			maxSize:   RefundSigScriptSize(contract),
+			pushes:    [][]byte{sig, pubKey, nil, contract},
+		}}
+
+		for _, test := range tests {
+			if len(test.sigScript) != test.maxSize {
+				t.Errorf("%x %s: size %d, want %d", chain.SHA256Opcode, test.name, len(test.sigScript), test.maxSize)
+			}
+
+			// The signature scripts only push data, which btcd parses the
+			// same way for both chains.
+			pushes, err := btctxscript.PushedData(test.sigScript)
+			if err != nil {
+				t.Fatalf("%x %s: PushedData error: %v", chain.SHA256Opcode, test.name, err)
+			}
+			if !reflect.DeepEqual(pushes, test.pushes) {
+				t.Errorf("%x %s: pushes %x, want %x", chain.SHA256Opcode, test.name, pushes, test.pushes)
+			}
+
+			revealed, err := ExtractSecret(test.sigScript, secretHash)
+			switch {
+			case test.revealed && err != nil:
+				t.Errorf("%x %s: ExtractSecret error: %v", chain.SHA256Opcode, test.name, err)
+			case test.revealed && !bytes.Equal(revealed, secret):
+				t.Errorf("%x %s: secret %x, want %x", chain.SHA256Opcode, test.name, revealed, secret)
+			case !test.revealed && err == nil:
+				t.Errorf("%x %s: ExtractSecret succeeded", chain.SHA256Opcode, test.name)
+			}
+		}
+	}
+}
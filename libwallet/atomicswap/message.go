@@ -0,0 +1,115 @@
+package atomicswap
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// MessageVersion is the version of the swap messages.
+	MessageVersion = 1
+
+	// messagePrefix starts the string encoding of a swap message.
+	messagePrefix = "cpswap:"
+)
+
+// MessageKind is the kind of message exchanged by the parties of a swap.
+type MessageKind string
+
+const (
+	// MsgOffer is sent by the initiator to propose a swap.
+	MsgOffer MessageKind = "offer"
+	// MsgAccept is sent by the participant to accept an offer.
+	MsgAccept MessageKind = "accept"
+	// MsgInitiate is sent by the initiator once its contract is funded.
+	MsgInitiate MessageKind = "initiate"
+	// MsgParticipate is sent by the participant once its contract is funded.
+	MsgParticipate MessageKind = "participate"
+	// MsgRedeem is sent by the initiator once it redeemed the participant's
+	// contract, revealing the secret.
+	MsgRedeem MessageKind = "redeem"
+)
+
+// Message is exchanged by the parties of a swap, as a string or a file, to
+// move the swap to its next step.
+type Message struct {
+	Version int         `json:"version"`
+	Kind    MessageKind `json:"kind"`
+	SwapID  string      `json:"swapID"`
+
+	// Offer fields. Amounts are in the assets' smallest unit.
+	InitiatorAsset    utils.AssetType `json:"initiatorAsset,omitempty"`
+	InitiatorAmount   int64           `json:"initiatorAmount,omitempty"`
+	ParticipantAsset  utils.AssetType `json:"participantAsset,omitempty"`
+	ParticipantAmount int64           `json:"participantAmount,omitempty"`
+
+	// Address is the sender's receiving address on the chain of the
+	// recipient's contract. Set by the offer and accept messages.
+	Address string `json:"address,omitempty"`
+
+	// Contract fields, set by the initiate and participate messages.
+	SecretHash     []byte `json:"secretHash,omitempty"`
+	ContractScript []byte `json:"contractScript,omitempty"`
+	ContractTx     []byte `json:"contractTx,omitempty"`
+
+	// RedeemTx is the initiator's redemption of the participant's contract.
+	RedeemTx []byte `json:"redeemTx,omitempty"`
+}
+
+// Encode returns the string encoding of m.
+func (m *Message) Encode() (string, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return messagePrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeMessage decodes a message encoded by Message.Encode.
+func DecodeMessage(s string) (*Message, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, messagePrefix) {
+		return nil, errors.New("not a swap message")
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(s, messagePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid swap message: %w", err)
+	}
+
+	m := new(Message)
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("invalid swap message: %w", err)
+	}
+	if m.Version != MessageVersion {
+		return nil, fmt.Errorf("unsupported swap message version %d", m.Version)
+	}
+	if m.SwapID == "" {
+		return nil, errors.New("swap message has no swap ID")
+	}
+	return m, nil
+}
+
+// WriteMessageFile writes the string encoding of m to the file at path.
+func WriteMessageFile(m *Message, path string) error {
+	s, err := m.Encode()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(s), utils.UserFilePerm)
+}
+
+// ReadMessageFile reads a message written by WriteMessageFile.
+func ReadMessageFile(path string) (*Message, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeMessage(string(b))
+}
@@ -0,0 +1,102 @@
+package atomicswap
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// New returns a Swaps that stores swaps in db.
+func New(db *storm.DB) (*Swaps, error) {
+	if err := db.Init(&Swap{}); err != nil {
+		log.Errorf("Error initializing atomic swaps database: %s", err.Error())
+		return nil, err
+	}
+
+	return &Swaps{db: db}, nil
+}
+
+// NewSwapID returns a random ID shared by both parties of a swap.
+func NewSwapID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// Save inserts or updates swap.
+func (s *Swaps) Save(swap *Swap) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := time.Now().Unix()
+	if swap.CreatedAt == 0 {
+		swap.CreatedAt = now
+	}
+	swap.UpdatedAt = now
+	if err := s.db.Save(swap); err != nil {
+		return fmt.Errorf("error saving swap: %w", err)
+	}
+	return nil
+}
+
+// Get returns the swap with the provided ID.
+func (s *Swaps) Get(id int) (*Swap, error) {
+	swap := new(Swap)
+	err := s.db.One("ID", id, swap)
+	if err == storm.ErrNotFound {
+		return nil, errors.New(utils.ErrNotExist)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching swap: %w", err)
+	}
+	return swap, nil
+}
+
+// GetBySwapID returns the swap with the provided shared swap ID.
+func (s *Swaps) GetBySwapID(swapID string) (*Swap, error) {
+	swap := new(Swap)
+	err := s.db.One("SwapID", swapID, swap)
+	if err == storm.ErrNotFound {
+		return nil, errors.New(utils.ErrNotExist)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching swap: %w", err)
+	}
+	return swap, nil
+}
+
+// All returns the stored swaps, newest first.
+func (s *Swaps) All() ([]*Swap, error) {
+	var swaps []*Swap
+	err := s.db.Select(q.True()).OrderBy("CreatedAt").Reverse().Find(&swaps)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching swaps: %w", err)
+	}
+	return swaps, nil
+}
+
+// Funded returns the swaps whose user's contract is funded and whose
+// counterparty's contract is not redeemed by the user.
+func (s *Swaps) Funded() ([]*Swap, error) {
+	var swaps []*Swap
+	err := s.db.Select(q.In("State", []State{StateInitiated, StateParticipated})).Find(&swaps)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching swaps: %w", err)
+	}
+
+	funded := swaps[:0]
+	for _, swap := range swaps {
+		if swap.Contract != nil {
+			funded = append(funded, swap)
+		}
+	}
+	return funded, nil
+}
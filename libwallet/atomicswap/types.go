@@ -0,0 +1,203 @@
+package atomicswap
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// InitiatorLockTime is how long the initiator's funds are locked in its
+	// contract before they can be refunded.
+	InitiatorLockTime = 48 * time.Hour
+	// ParticipantLockTime is how long the participant's funds are locked in
+	// its contract before they can be refunded. It is shorter than
+	// InitiatorLockTime so that the participant can still redeem the
+	// initiator's contract after the initiator redeemed theirs.
+	ParticipantLockTime = 24 * time.Hour
+	// MinLockTimeMargin is the minimum difference between the lock times of
+	// the initiator's and participant's contracts.
+	MinLockTimeMargin = 12 * time.Hour
+	// MinRedeemTime is the minimum time left before the lock time of the
+	// counterparty's contract for it to be accepted.
+	MinRedeemTime = 2 * time.Hour
+)
+
+// Role is the role of the user in a swap.
+type Role string
+
+const (
+	// Initiator is the party that creates the offer and the swap secret.
+	Initiator Role = "initiator"
+	// Participant is the party that accepts the offer.
+	Participant Role = "participant"
+)
+
+// State is the state of a swap.
+type State string
+
+const (
+	// StateOffered is the state of a swap whose offer was sent or received.
+	StateOffered State = "offered"
+	// StateAccepted is the state of a swap whose offer was accepted. Both
+	// parties know each other's receiving address.
+	StateAccepted State = "accepted"
+	// StateInitiated is the state of a swap whose initiator's contract is
+	// funded.
+	StateInitiated State = "initiated"
+	// StateParticipated is the state of a swap whose participant's contract
+	// is funded.
+	StateParticipated State = "participated"
+	// StateRedeemed is the state of a swap whose counterparty's contract was
+	// redeemed by the user.
+	StateRedeemed State = "redeemed"
+	// StateRefunded is the state of a swap whose user's contract was
+	// refunded.
+	StateRefunded State = "refunded"
+	// StateCanceled is the state of a swap canceled before the user's
+	// contract was funded.
+	StateCanceled State = "canceled"
+)
+
+// IsFinal returns true if the swap cannot progress anymore.
+func (s State) IsFinal() bool {
+	return s == StateRedeemed || s == StateRefunded || s == StateCanceled
+}
+
+// Contract is a funded contract.
+type Contract struct {
+	Script []byte `json:"script"`
+	Tx     []byte `json:"tx"` // The serialized funding transaction.
+	TxHash string `json:"txHash"`
+	Vout   uint32 `json:"vout"`
+	Amount int64  `json:"amount"`
+}
+
+// FundedContract is a contract funded by the user, with the signed
+// transaction that refunds it after its lock time.
+type FundedContract struct {
+	Contract
+	RefundTx     []byte `json:"refundTx"`
+	RefundTxHash string `json:"refundTxHash"`
+}
+
+// ContractStatus is the on-chain status of a contract.
+type ContractStatus struct {
+	// Height is the height of the block mining the contract's transaction,
+	// or 0 if it is not mined.
+	Height        int32
+	Confirmations int32
+	// SpendTx is the serialized mined transaction spending the contract, if
+	// any.
+	SpendTx     []byte
+	SpendTxHash string
+}
+
+// PresignedRedeem is a transaction redeeming a contract, signed before the
+// secret is known. The signature does not commit to the signature script of
+// the contract input, which is completed with the secret once it is revealed.
+type PresignedRedeem struct {
+	Tx     []byte `json:"tx"` // The serialized transaction, without signature script.
+	Sig    []byte `json:"sig"`
+	PubKey []byte `json:"pubKey"`
+}
+
+// Wallet is implemented by the asset wallets that support atomic swaps.
+type Wallet interface {
+	// SwapChain returns the script differences of the wallet's chain.
+	SwapChain() Chain
+	// AddressHash returns the HASH160 of the public key of address.
+	AddressHash(address string) ([]byte, error)
+	// FundContract broadcasts a transaction from account paying amount to
+	// a contract with the provided terms. The terms' RefundHash is set to
+	// the hash of a new address of account.
+	FundContract(account int32, terms *Terms, amount int64, passphrase string) (*FundedContract, error)
+	// AuditContract checks that tx pays to the contract script and returns
+	// the contract and its terms.
+	AuditContract(script, tx []byte) (*Contract, *Terms, error)
+	// RedeemContract broadcasts a transaction redeeming contract with secret
+	// to a new address of account. The serialized transaction is returned.
+	RedeemContract(account int32, contract *Contract, secret []byte, passphrase string) (tx []byte, txHash string, err error)
+	// SignRedeem signs a transaction redeeming contract to a new address of
+	// account, to be completed with the secret by PublishRedeem.
+	SignRedeem(account int32, contract *Contract, passphrase string) (*PresignedRedeem, error)
+	// PublishRedeem broadcasts the presigned redeem of contract with secret.
+	// The serialized transaction is returned.
+	PublishRedeem(redeem *PresignedRedeem, contract *Contract, secret []byte) (tx []byte, txHash string, err error)
+	// ContractStatus scans the blocks from startHeight to the best block for
+	// the transactions funding and spending contract.
+	ContractStatus(ctx context.Context, contract *Contract, startHeight int32) (*ContractStatus, error)
+	// RedeemSigScript returns the signature script of the input of tx
+	// spending the contract funded by contractTxHash.
+	RedeemSigScript(tx []byte, contractTxHash string) ([]byte, error)
+	// PublishRawTx broadcasts a serialized transaction.
+	PublishRawTx(tx []byte) (string, error)
+}
+
+// Swap is a persisted atomic swap.
+type Swap struct {
+	ID     int    `storm:"id,increment" json:"id"`
+	SwapID string `storm:"unique" json:"swapID"` // Shared by both parties.
+	Role   Role   `json:"role"`
+	State  State  `storm:"index" json:"state"`
+
+	// The user's side of the swap. The user's contract is funded from
+	// WalletID and pays to CounterpartyAddress.
+	Asset               utils.AssetType `json:"asset"`
+	WalletID            int             `json:"walletID"`
+	AccountNumber       int32           `json:"accountNumber"`
+	Amount              int64           `json:"amount"`
+	CounterpartyAddress string          `json:"counterpartyAddress"`
+
+	// The counterparty's side of the swap. The counterparty's contract pays
+	// to ReceiveAddress, which belongs to ReceiveWalletID.
+	CounterpartyAsset    utils.AssetType `json:"counterpartyAsset"`
+	CounterpartyAmount   int64           `json:"counterpartyAmount"`
+	ReceiveWalletID      int             `json:"receiveWalletID"`
+	ReceiveAccountNumber int32           `json:"receiveAccountNumber"`
+	ReceiveAddress       string          `json:"receiveAddress"`
+
+	SecretHash []byte `json:"secretHash"`
+	// Secret is known to the initiator from the start and to the
+	// participant once the initiator redeems its contract.
+	Secret []byte `json:"secret"`
+
+	Contract             *FundedContract `json:"contract"`
+	LockTime             int64           `json:"lockTime"`
+	CounterpartyContract *Contract       `json:"counterpartyContract"`
+	CounterpartyLockTime int64           `json:"counterpartyLockTime"`
+	// ContractHeight and CounterpartyHeight are the heights the contracts
+	// are searched for on-chain from.
+	ContractHeight     int32 `json:"contractHeight"`
+	CounterpartyHeight int32 `json:"counterpartyHeight"`
+	// ContractSpendTxHash is the hash of the mined transaction spending the
+	// user's contract.
+	ContractSpendTxHash string `json:"contractSpendTxHash"`
+	// PresignedRedeem redeems the counterparty's contract of the participant
+	// automatically once the initiator reveals the secret.
+	PresignedRedeem *PresignedRedeem `json:"presignedRedeem"`
+
+	RedeemTx     []byte `json:"redeemTx"`
+	RedeemTxHash string `json:"redeemTxHash"`
+
+	CreatedAt int64  `storm:"index" json:"createdAt"`
+	UpdatedAt int64  `json:"updatedAt"`
+	LastError string `json:"lastError"`
+}
+
+// Refundable returns true if the user's contract is funded and is refunded
+// automatically once its lock time passes. Contracts of swaps where the user
+// redeemed the counterparty's contract are left for the counterparty to
+// redeem, and spent contracts cannot be refunded.
+func (s *Swap) Refundable() bool {
+	return s.Contract != nil && s.ContractSpendTxHash == "" && (s.State == StateInitiated || s.State == StateParticipated)
+}
+
+// Swaps stores the user's atomic swaps.
+type Swaps struct {
+	db  *storm.DB
+	mtx sync.Mutex
+}
@@ -0,0 +1,130 @@
+// Package bip39 encodes wallet seeds as BIP39 mnemonics.
+// See https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki.
+package bip39
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	radixBits      = 11
+	seedIterations = 2048
+	seedLength     = 64
+
+	// DefaultEntropyLength is the entropy length in bytes of the 24 word
+	// mnemonics generated for new wallets.
+	DefaultEntropyLength = 32
+)
+
+var (
+	// ErrInvalidMnemonic is returned for a phrase that is not a BIP39
+	// mnemonic.
+	ErrInvalidMnemonic = errors.New("invalid BIP39 mnemonic")
+	// ErrInvalidChecksum is returned for a mnemonic with a wrong checksum.
+	ErrInvalidChecksum = errors.New("invalid BIP39 mnemonic checksum")
+)
+
+var wordIndexes = func() map[string]int {
+	indexes := make(map[string]int, 1<<radixBits)
+	for i, word := range WordList() {
+		indexes[word] = i
+	}
+	return indexes
+}()
+
+// validEntropyLength returns true for the 16, 20, 24, 28 and 32 byte entropy
+// lengths of 12 to 24 word mnemonics.
+func validEntropyLength(length int) bool {
+	return length >= 16 && length <= 32 && length%4 == 0
+}
+
+// GenerateMnemonic returns the mnemonic of entropyLength random bytes.
+func GenerateMnemonic(entropyLength int) (string, error) {
+	entropy := make([]byte, entropyLength)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return NewMnemonic(entropy)
+}
+
+// NewMnemonic returns the mnemonic that encodes entropy.
+func NewMnemonic(entropy []byte) (string, error) {
+	if !validEntropyLength(len(entropy)) {
+		return "", fmt.Errorf("invalid entropy length %d", len(entropy))
+	}
+
+	checksumBits := len(entropy) / 4
+	hash := sha256.Sum256(entropy)
+	n := new(big.Int).SetBytes(entropy)
+	n.Lsh(n, uint(checksumBits))
+	n.Or(n, big.NewInt(int64(hash[0]>>(8-checksumBits))))
+
+	wordCount := (len(entropy)*8 + checksumBits) / radixBits
+	wordList := WordList()
+	words := make([]string, wordCount)
+	mask := big.NewInt(1<<radixBits - 1)
+	for i := wordCount - 1; i >= 0; i-- {
+		words[i] = wordList[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, radixBits)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// EntropyFromMnemonic returns the entropy encoded by mnemonic after
+// validating its checksum.
+func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words)%3 != 0 || !validEntropyLength(len(words)*radixBits*32/33/8) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	n := new(big.Int)
+	for _, word := range words {
+		index, ok := wordIndexes[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, word)
+		}
+		n.Lsh(n, radixBits)
+		n.Or(n, big.NewInt(int64(index)))
+	}
+
+	checksumBits := len(words) * radixBits / 33
+	checksum := byte(new(big.Int).And(n, big.NewInt(1<<checksumBits-1)).Int64())
+	n.Rsh(n, uint(checksumBits))
+	entropy := n.FillBytes(make([]byte, checksumBits*4))
+
+	hash := sha256.Sum256(entropy)
+	if hash[0]>>(8-checksumBits) != checksum {
+		return nil, ErrInvalidChecksum
+	}
+	return entropy, nil
+}
+
+// IsMnemonicValid returns true if mnemonic is a BIP39 mnemonic with a valid
+// checksum.
+func IsMnemonicValid(mnemonic string) bool {
+	_, err := EntropyFromMnemonic(mnemonic)
+	return err == nil
+}
+
+// Seed returns the 64 byte wallet seed of mnemonic protected by passphrase.
+// The mnemonic is validated first.
+func Seed(mnemonic, passphrase string) ([]byte, error) {
+	if _, err := EntropyFromMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+
+	normalized := strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+	password := norm.NFKD.String(normalized)
+	salt := norm.NFKD.String("mnemonic" + passphrase)
+	return pbkdf2.Key([]byte(password), []byte(salt), seedIterations, seedLength, sha512.New), nil
+}
@@ -0,0 +1,103 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// The vectors are from https://github.com/trezor/python-mnemonic/blob/master/vectors.json,
+// all of which use the passphrase "TREZOR".
+func TestVectors(t *testing.T) {
+	tests := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{{
+		entropy:  "00000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	}, {
+		entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	}, {
+		entropy:  "80808080808080808080808080808080",
+		mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+		seed:     "d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+	}, {
+		entropy:  "ffffffffffffffffffffffffffffffff",
+		mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		seed:     "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	}, {
+		entropy:  "0000000000000000000000000000000000000000000000000000000000000000",
+		mnemonic: strings.Repeat("abandon ", 23) + "art",
+		seed:     "bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+	}, {
+		entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		mnemonic: strings.Repeat("zoo ", 23) + "vote",
+		seed:     "dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+	}}
+
+	for _, test := range tests {
+		entropy, _ := hex.DecodeString(test.entropy)
+		mnemonic, err := NewMnemonic(entropy)
+		if err != nil {
+			t.Fatalf("%s: NewMnemonic error: %v", test.entropy, err)
+		}
+		if mnemonic != test.mnemonic {
+			t.Errorf("%s: mnemonic %q, want %q", test.entropy, mnemonic, test.mnemonic)
+		}
+
+		decoded, err := EntropyFromMnemonic(test.mnemonic)
+		if err != nil {
+			t.Fatalf("%s: EntropyFromMnemonic error: %v", test.entropy, err)
+		}
+		if got := hex.EncodeToString(decoded); got != test.entropy {
+			t.Errorf("%s: entropy %s", test.entropy, got)
+		}
+
+		seed, err := Seed(test.mnemonic, "TREZOR")
+		if err != nil {
+			t.Fatalf("%s: Seed error: %v", test.entropy, err)
+		}
+		if got := hex.EncodeToString(seed); got != test.seed {
+			t.Errorf("%s: seed %s, want %s", test.entropy, got, test.seed)
+		}
+	}
+}
+
+func TestInvalidMnemonics(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		err      error
+	}{
+		{"wrong checksum", strings.Repeat("abandon ", 12), ErrInvalidChecksum},
+		{"unknown word", strings.Repeat("abandon ", 11) + "abut", ErrInvalidMnemonic},
+		{"short", strings.Repeat("abandon ", 8) + "about", ErrInvalidMnemonic},
+		{"not a multiple of 3 words", strings.Repeat("abandon ", 12) + "about", ErrInvalidMnemonic},
+		{"empty", "", ErrInvalidMnemonic},
+	}
+
+	for _, test := range tests {
+		if _, err := EntropyFromMnemonic(test.mnemonic); !errors.Is(err, test.err) {
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+		}
+		if IsMnemonicValid(test.mnemonic) {
+			t.Errorf("%s: mnemonic is valid", test.name)
+		}
+		if _, err := Seed(test.mnemonic, "TREZOR"); err == nil {
+			t.Errorf("%s: Seed succeeded", test.name)
+		}
+	}
+}
+
+func TestNewMnemonicInvalidEntropy(t *testing.T) {
+	for _, length := range []int{0, 12, 18, 36} {
+		if _, err := NewMnemonic(make([]byte, length)); err == nil {
+			t.Errorf("NewMnemonic of %d bytes succeeded", length)
+		}
+	}
+}
@@ -22,12 +22,14 @@ func initializeBTCWalletParameters(netType utils.NetworkType) (*chaincfg.Params,
 	return chainParams, nil
 }
 
-// CreateNewBTCWallet creates a new BTC wallet and returns it.
-func (mgr *AssetsManager) CreateNewBTCWallet(walletName, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
+// CreateNewBTCWallet creates a new BTC wallet with a seed of seedFormat and
+// returns it.
+func (mgr *AssetsManager) CreateNewBTCWallet(walletName, privatePassphrase string, privatePassphraseType int32, seedFormat sharedW.SeedFormat) (sharedW.Asset, error) {
 	pass := &sharedW.AuthInfo{
 		Name:            walletName,
 		PrivatePass:     privatePassphrase,
 		PrivatePassType: privatePassphraseType,
+		SeedFormat:      seedFormat,
 	}
 	wallet, err := btc.CreateNewWallet(pass, mgr.params)
 	if err != nil {
@@ -61,12 +63,32 @@ func (mgr *AssetsManager) CreateNewBTCWatchOnlyWallet(walletName, extendedPublic
 	return wallet, nil
 }
 
-// RestoreBTCWallet restores a BTC wallet from a seed and returns it.
-func (mgr *AssetsManager) RestoreBTCWallet(walletName, seedMnemonic, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
+// CreateNewBTCAddressWatchWallet creates a new BTC wallet watching the
+// transactions of addresses from the block at startHeight and returns it.
+func (mgr *AssetsManager) CreateNewBTCAddressWatchWallet(walletName string, addresses []string, startHeight int32) (sharedW.Asset, error) {
+	wallet, err := btc.CreateAddressWatchWallet(walletName, addresses, startHeight, mgr.params)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.Assets.BTC.Wallets[wallet.GetWalletID()] = wallet
+
+	// extract the db interface if it hasn't been set already.
+	if mgr.db == nil && wallet != nil {
+		mgr.setDBInterface(wallet.(sharedW.AssetsManagerDB))
+	}
+
+	return wallet, nil
+}
+
+// RestoreBTCWallet restores a BTC wallet from a seed and the seed passphrase
+// of BIP39 seeds and returns it.
+func (mgr *AssetsManager) RestoreBTCWallet(walletName, seedMnemonic, seedPassphrase, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
 	pass := &sharedW.AuthInfo{
 		Name:            walletName,
 		PrivatePass:     privatePassphrase,
 		PrivatePassType: privatePassphraseType,
+		SeedPassphrase:  seedPassphrase,
 	}
 	wallet, err := btc.RestoreWallet(seedMnemonic, pass, mgr.params)
 	if err != nil {
@@ -84,13 +106,18 @@ func (mgr *AssetsManager) RestoreBTCWallet(walletName, seedMnemonic, privatePass
 }
 
 // BTCWalletWithXPub returns the ID of the BTC wallet that has an account with the
-// provided xpub. Returns -1 if there is no such wallet.
+// provided xpub or output descriptors. Returns -1 if there is no such wallet.
 func (mgr *AssetsManager) BTCWalletWithXPub(xpub string) (int, error) {
 	for _, wallet := range mgr.Assets.BTC.Wallets {
 		if !wallet.WalletOpened() {
 			return -1, errors.Errorf("wallet %d is not open and cannot be checked", wallet.GetWalletID())
 		}
 
+		asset, ok := wallet.(*btc.Asset)
+		if !ok {
+			return -1, fmt.Errorf("invalid asset type")
+		}
+
 		wAccs, err := wallet.GetAccountsRaw()
 		if err != nil {
 			return -1, err
@@ -100,12 +127,11 @@ func (mgr *AssetsManager) BTCWalletWithXPub(xpub string) (int, error) {
 			if accs.AccountNumber == btc.ImportedAccountNumber {
 				continue
 			}
-			acctXPubKey, err := wallet.Internal().BTC.AccountProperties(btc.GetScope(), accs.AccountNumber)
+			matches, err := asset.AccountKeyMatches(accs.AccountNumber, xpub)
 			if err != nil {
 				return -1, err
 			}
-
-			if acctXPubKey.AccountPubKey.String() == xpub {
+			if matches {
 				return wallet.GetWalletID(), nil
 			}
 		}
@@ -114,9 +140,9 @@ func (mgr *AssetsManager) BTCWalletWithXPub(xpub string) (int, error) {
 }
 
 // BTCWalletWithSeed returns the ID of the BTC wallet that was created or restored
-// using the same seed as the one provided. Returns -1 if no wallet uses the
-// provided seed.
-func (mgr *AssetsManager) BTCWalletWithSeed(seedMnemonic string) (int, error) {
+// using the same seed and seed passphrase as the ones provided. Returns -1 if
+// no wallet uses the provided seed.
+func (mgr *AssetsManager) BTCWalletWithSeed(seedMnemonic, seedPassphrase string) (int, error) {
 	if len(seedMnemonic) == 0 {
 		return -1, errors.New(utils.ErrEmptySeed)
 	}
@@ -140,7 +166,7 @@ func (mgr *AssetsManager) BTCWalletWithSeed(seedMnemonic string) (int, error) {
 			if accs.AccountNumber == waddrmgr.ImportedAddrAccount {
 				continue
 			}
-			xpub, err := asset.DeriveAccountXpub(seedMnemonic,
+			xpub, err := asset.DeriveAccountXpub(seedMnemonic, seedPassphrase,
 				accs.AccountNumber, wallet.Internal().BTC.ChainParams())
 			if err != nil {
 				return -1, err
@@ -158,12 +184,14 @@ func (mgr *AssetsManager) BTCWalletWithSeed(seedMnemonic string) (int, error) {
 	return -1, nil
 }
 
-// RestoreLTCWallet restores a LTC wallet from a seed and returns it.
-func (mgr *AssetsManager) RestoreLTCWallet(walletName, seedMnemonic, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
+// RestoreLTCWallet restores a LTC wallet from a seed and the seed passphrase
+// of BIP39 seeds and returns it.
+func (mgr *AssetsManager) RestoreLTCWallet(walletName, seedMnemonic, seedPassphrase, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
 	pass := &sharedW.AuthInfo{
 		Name:            walletName,
 		PrivatePass:     privatePassphrase,
 		PrivatePassType: privatePassphraseType,
+		SeedPassphrase:  seedPassphrase,
 	}
 	wallet, err := ltc.RestoreWallet(seedMnemonic, pass, mgr.params)
 	if err != nil {
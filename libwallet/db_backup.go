@@ -0,0 +1,292 @@
+package libwallet
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	bolt "go.etcd.io/bbolt"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/dbbackup"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// dbBackupsDir is the directory of RootDir where the database backups
+	// are written unless another directory is configured.
+	dbBackupsDir = "db-backups"
+	// dbRestoreDir is the directory of RootDir where a restored backup is
+	// staged until it replaces the databases on the next start.
+	dbRestoreDir = "db-restore"
+	// dbRestoreReadyFile marks a completely staged restore.
+	dbRestoreReadyFile = ".ready"
+	// preRestoreDir is the directory of RootDir where the databases replaced
+	// by a restore are kept.
+	preRestoreDir = "pre-restore"
+
+	dbBackupInterval      = 24 * time.Hour
+	dbBackupCheckInterval = time.Hour
+
+	// DefaultDBBackupRetention is the number of database backups kept unless
+	// configured otherwise.
+	DefaultDBBackupRetention = 7
+)
+
+// setDBBackupKey derives the key of the database backups from the startup
+// passphrase. The key is only kept in memory, backups are not made until the
+// startup passphrase is entered.
+func (mgr *AssetsManager) setDBBackupKey(startupPassphrase string) {
+	var key *dbbackup.Key
+	if startupPassphrase != "" {
+		var err error
+		key, err = dbbackup.NewKey([]byte(startupPassphrase))
+		if err != nil {
+			log.Errorf("Error deriving the database backup key: %v", err)
+		}
+	}
+
+	mgr.dbBackupMu.Lock()
+	mgr.dbBackupKey = key
+	mgr.dbBackupMu.Unlock()
+}
+
+// IsDBBackupEnabled returns true if the scheduled database backups are
+// enabled.
+func (mgr *AssetsManager) IsDBBackupEnabled() bool {
+	var enabled bool
+	if mgr.IsAssetManagerDB() {
+		mgr.db.ReadWalletConfigValue(sharedW.DBBackupEnabledConfigKey, &enabled)
+	}
+	return enabled
+}
+
+// SetDBBackupEnabled enables or disables the scheduled database backups. The
+// backups are encrypted with the startup passphrase, which must be set to
+// enable them.
+func (mgr *AssetsManager) SetDBBackupEnabled(enabled bool) error {
+	if !mgr.IsAssetManagerDB() {
+		return errors.New(utils.ErrWalletNotFound)
+	}
+	if enabled && !mgr.IsStartupSecuritySet() {
+		return errors.New(utils.ErrPassphraseRequired)
+	}
+	mgr.db.SaveWalletConfigValue(sharedW.DBBackupEnabledConfigKey, enabled)
+	return nil
+}
+
+// DBBackupDir returns the directory of the database backups.
+func (mgr *AssetsManager) DBBackupDir() string {
+	var dir string
+	if mgr.IsAssetManagerDB() {
+		mgr.db.ReadWalletConfigValue(sharedW.DBBackupDirConfigKey, &dir)
+	}
+	if dir == "" {
+		dir = filepath.Join(mgr.params.RootDir, dbBackupsDir)
+	}
+	return dir
+}
+
+// SetDBBackupDir sets the directory of the database backups. An empty dir
+// restores the default directory.
+func (mgr *AssetsManager) SetDBBackupDir(dir string) error {
+	if !mgr.IsAssetManagerDB() {
+		return errors.New(utils.ErrWalletNotFound)
+	}
+	if dir != "" {
+		if !filepath.IsAbs(dir) {
+			return errors.Errorf("backup directory %q is not an absolute path", dir)
+		}
+		if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+			return err
+		}
+	}
+	mgr.db.SaveWalletConfigValue(sharedW.DBBackupDirConfigKey, dir)
+	return nil
+}
+
+// DBBackupRetention returns the number of database backups kept.
+func (mgr *AssetsManager) DBBackupRetention() int {
+	retention := DefaultDBBackupRetention
+	if mgr.IsAssetManagerDB() {
+		mgr.db.ReadWalletConfigValue(sharedW.DBBackupRetentionConfigKey, &retention)
+	}
+	return retention
+}
+
+// SetDBBackupRetention sets the number of database backups kept.
+func (mgr *AssetsManager) SetDBBackupRetention(retention int) error {
+	if !mgr.IsAssetManagerDB() {
+		return errors.New(utils.ErrWalletNotFound)
+	}
+	if retention < 1 {
+		return errors.Errorf("at least one backup must be kept")
+	}
+	mgr.db.SaveWalletConfigValue(sharedW.DBBackupRetentionConfigKey, retention)
+	return nil
+}
+
+// LastDBBackup returns the time of the last database backup or the zero time
+// if none was made.
+func (mgr *AssetsManager) LastDBBackup() time.Time {
+	var last int64
+	if mgr.IsAssetManagerDB() {
+		mgr.db.ReadWalletConfigValue(sharedW.LastDBBackupConfigKey, &last)
+	}
+	if last == 0 {
+		return time.Time{}
+	}
+	return time.Unix(last, 0)
+}
+
+// DBBackups returns the paths of the database backups, newest first.
+func (mgr *AssetsManager) DBBackups() ([]string, error) {
+	return dbbackup.List(mgr.DBBackupDir())
+}
+
+// startDBBackups starts the loop that backs up the databases once per
+// dbBackupInterval while the backups are enabled.
+func (mgr *AssetsManager) startDBBackups(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(dbBackupCheckInterval)
+		defer ticker.Stop()
+		for {
+			if mgr.IsDBBackupEnabled() && time.Since(mgr.LastDBBackup()) >= dbBackupInterval {
+				// Backups wait for the startup passphrase to be entered.
+				_, err := mgr.BackupDatabases()
+				if err != nil && err.Error() != utils.ErrPassphraseRequired {
+					log.Errorf("Error backing up databases: %v", err)
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// BackupDatabases writes a hot backup of the app database and of the
+// databases of the loaded wallets to the backup directory, verifies it and
+// removes the backups in excess of the retention. It returns the path of the
+// backup.
+func (mgr *AssetsManager) BackupDatabases() (string, error) {
+	mgr.dbBackupMu.Lock()
+	defer mgr.dbBackupMu.Unlock()
+
+	key := mgr.dbBackupKey
+	if key == nil {
+		return "", errors.New(utils.ErrPassphraseRequired)
+	}
+
+	rootDir := mgr.params.RootDir
+	sources := []dbbackup.Source{{
+		Name: walletsDbName,
+		Copy: func(w io.Writer) error {
+			return mgr.params.DB.Bolt.View(func(tx *bolt.Tx) error {
+				_, err := tx.WriteTo(w)
+				return err
+			})
+		},
+	}}
+	for _, wallet := range mgr.AllWallets() {
+		dbs := wallet.Databases()
+		if len(dbs) == 0 {
+			log.Warnf("Skipping the backup of unloaded wallet %s", wallet.GetWalletName())
+		}
+		for path, copyFn := range dbs {
+			name, err := filepath.Rel(rootDir, path)
+			if err != nil {
+				return "", err
+			}
+			sources = append(sources, dbbackup.Source{Name: name, Copy: copyFn})
+		}
+	}
+
+	dir := mgr.DBBackupDir()
+	if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	path := filepath.Join(dir, dbbackup.FileName(now))
+	if err := dbbackup.Write(path, key, sources); err != nil {
+		return "", err
+	}
+	if err := dbbackup.Verify(path, key); err != nil {
+		os.Remove(path)
+		return "", errors.Errorf("backup verification failed: %v", err)
+	}
+	mgr.db.SaveWalletConfigValue(sharedW.LastDBBackupConfigKey, now.Unix())
+
+	if err := dbbackup.Prune(dir, mgr.DBBackupRetention()); err != nil {
+		log.Errorf("Error removing old database backups: %v", err)
+	}
+
+	log.Infof("Databases backed up to %s", path)
+	return path, nil
+}
+
+// RestoreDBBackup decrypts the database backup at path with the startup
+// passphrase it was made with and stages its databases. They replace the
+// current databases when the app is restarted, before any of them is opened.
+func (mgr *AssetsManager) RestoreDBBackup(path, startupPassphrase string) error {
+	dir := filepath.Join(mgr.params.RootDir, dbRestoreDir)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	if _, err := dbbackup.Extract(path, []byte(startupPassphrase), dir); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, dbRestoreReadyFile), nil, 0o600)
+}
+
+// applyDBRestore replaces the databases of rootDir with the ones staged by
+// RestoreDBBackup. The replaced databases are moved to a new directory of
+// preRestoreDir. An incompletely staged restore is discarded.
+func applyDBRestore(rootDir string) error {
+	dir := filepath.Join(rootDir, dbRestoreDir)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := os.Stat(filepath.Join(dir, dbRestoreReadyFile)); err != nil {
+		log.Warnf("Discarding incomplete database restore")
+		return nil
+	}
+
+	replacedDir := filepath.Join(rootDir, preRestoreDir, time.Now().Format("20060102-150405"))
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || info.Name() == dbRestoreReadyFile {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(rootDir, name)
+		if err := os.MkdirAll(filepath.Dir(target), utils.UserFilePerm); err != nil {
+			return err
+		}
+
+		if _, err := os.Stat(target); err == nil {
+			replaced := filepath.Join(replacedDir, name)
+			if err := os.MkdirAll(filepath.Dir(replaced), utils.UserFilePerm); err != nil {
+				return err
+			}
+			if err := os.Rename(target, replaced); err != nil {
+				return err
+			}
+		}
+
+		log.Infof("Restoring database %s", name)
+		return os.Rename(path, target)
+	})
+}
@@ -0,0 +1,200 @@
+package libwallet
+
+import (
+	"fmt"
+
+	"github.com/asdine/storm"
+	stormjson "github.com/asdine/storm/codec/json"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/dbcrypt"
+)
+
+// isDBLocked returns true if the databases are encrypted with the startup
+// passphrase, which was not entered yet.
+func (mgr *AssetsManager) isDBLocked() bool {
+	return mgr.hasDBKeys() && len(mgr.dbCodec.Keys()) == 0
+}
+
+// hasDBKeys returns true if the data keys of the databases are saved.
+func (mgr *AssetsManager) hasDBKeys() bool {
+	var wrapped []byte
+	err := mgr.params.DB.Get(walletsMetadataBucketName, sharedW.DBKeysConfigKey, &wrapped)
+	return err == nil && len(wrapped) > 0
+}
+
+// saveDBKeys saves the wrapped data keys of the databases together with the
+// hash of the startup passphrase that wraps them, if it is not nil.
+func (mgr *AssetsManager) saveDBKeys(passphraseHash, wrapped []byte) error {
+	tx, err := mgr.params.DB.WithCodec(stormjson.Codec).Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if passphraseHash != nil {
+		err = tx.Set(walletsMetadataBucketName, sharedW.StartupPassphraseConfigKey, passphraseHash)
+		if err != nil {
+			return err
+		}
+	}
+	if wrapped == nil {
+		err = tx.Delete(walletsMetadataBucketName, sharedW.DBKeysConfigKey)
+	} else {
+		err = tx.Set(walletsMetadataBucketName, sharedW.DBKeysConfigKey, wrapped)
+	}
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	return tx.Commit()
+}
+
+// unlockDB unwraps the data keys of the databases with the verified startup
+// passphrase, which has the provided hash. The databases of the installs that
+// predate their encryption are encrypted on their first unlock.
+func (mgr *AssetsManager) unlockDB(startupPassphrase string, passphraseHash []byte) error {
+	if len(mgr.dbCodec.Keys()) > 0 {
+		return nil
+	}
+
+	var wrapped []byte
+	err := mgr.params.DB.Get(walletsMetadataBucketName, sharedW.DBKeysConfigKey, &wrapped)
+	switch {
+	case err == storm.ErrNotFound:
+		log.Info("Encrypting the databases with the startup passphrase")
+		return mgr.rekeyDB(startupPassphrase, passphraseHash)
+	case err != nil:
+		return err
+	}
+
+	keys, err := dbcrypt.Unwrap([]byte(startupPassphrase), wrapped)
+	if err != nil {
+		return err
+	}
+	mgr.dbCodec.SetKeys(keys[0], keys[1:]...)
+
+	if len(keys) > 1 {
+		// The values sealed with the old keys of an interrupted re-key are
+		// resealed with the new key.
+		mgr.completeRekey(startupPassphrase, keys[0])
+	} else if err := mgr.sealPlaintextDatabases(); err != nil {
+		// Older versions wrote the values saved before the startup
+		// passphrase was entered in plaintext.
+		log.Errorf("Error sealing the plaintext values of the databases: %v", err)
+	}
+
+	if err := mgr.startDataServices(); err != nil {
+		log.Errorf("Error starting the services: %v", err)
+	}
+	return nil
+}
+
+// rekeyDB seals the values of the databases with a new data key wrapped with
+// startupPassphrase, whose hash is saved with it. The current keys are kept
+// until all the values are resealed.
+func (mgr *AssetsManager) rekeyDB(startupPassphrase string, passphraseHash []byte) error {
+	key := dbcrypt.NewKey()
+	oldKeys := mgr.dbCodec.Keys()
+	wrapped, err := dbcrypt.Wrap([]byte(startupPassphrase), append([]*dbcrypt.Key{key}, oldKeys...)...)
+	if err != nil {
+		return err
+	}
+	if err := mgr.saveDBKeys(passphraseHash, wrapped); err != nil {
+		return err
+	}
+	mgr.dbCodec.SetKeys(key, oldKeys...)
+
+	mgr.completeRekey(startupPassphrase, key)
+	return nil
+}
+
+// completeRekey reseals the values of the databases with key and drops the
+// other data keys. A failed re-key is completed on the next unlock.
+func (mgr *AssetsManager) completeRekey(startupPassphrase string, key *dbcrypt.Key) {
+	if err := mgr.resealDatabases(); err != nil {
+		log.Errorf("Error re-keying the databases: %v", err)
+		return
+	}
+
+	wrapped, err := dbcrypt.Wrap([]byte(startupPassphrase), key)
+	if err == nil {
+		err = mgr.saveDBKeys(nil, wrapped)
+	}
+	if err != nil {
+		log.Errorf("Error saving the database key: %v", err)
+		return
+	}
+	mgr.dbCodec.SetKeys(key)
+}
+
+// decryptDB stores the values of the databases in plaintext and deletes their
+// data keys.
+func (mgr *AssetsManager) decryptDB() error {
+	keys := mgr.dbCodec.Keys()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	mgr.dbCodec.SetKeys(nil, keys...)
+	if err := mgr.resealDatabases(); err != nil {
+		mgr.dbCodec.SetKeys(keys[0], keys[1:]...)
+		return err
+	}
+	if err := mgr.saveDBKeys(nil, nil); err != nil {
+		return err
+	}
+	mgr.dbCodec.SetKeys(nil)
+	return nil
+}
+
+// resealDatabases reseals the values of the app database and of the wallet
+// data databases of the loaded wallets with the current seal key.
+func (mgr *AssetsManager) resealDatabases() error {
+	if err := mgr.dbCodec.Reseal(mgr.params.DB.Bolt, sharedW.PlaintextConfigKeys); err != nil {
+		return fmt.Errorf("%s: %v", walletsDbName, err)
+	}
+
+	for _, wallet := range mgr.AllWallets() {
+		if err := wallet.ResealWalletData(); err != nil {
+			return fmt.Errorf("wallet %s: %v", wallet.GetWalletName(), err)
+		}
+	}
+	return nil
+}
+
+// sealPlaintextDatabases seals the values of the app database and of the
+// wallet data databases of the loaded wallets that are not sealed yet.
+func (mgr *AssetsManager) sealPlaintextDatabases() error {
+	if err := mgr.dbCodec.SealPlaintext(mgr.params.DB.Bolt, sharedW.PlaintextConfigKeys); err != nil {
+		return fmt.Errorf("%s: %v", walletsDbName, err)
+	}
+
+	for _, wallet := range mgr.AllWallets() {
+		if err := wallet.SealWalletDataPlaintext(); err != nil {
+			return fmt.Errorf("wallet %s: %v", wallet.GetWalletName(), err)
+		}
+	}
+	return nil
+}
+
+// startDataServices loads the price alerts and starts the services that read
+// the swaps, the order schedulers and the pending metadata, which are
+// encrypted until the startup passphrase is entered. The services are started
+// once.
+func (mgr *AssetsManager) startDataServices() (err error) {
+	mgr.dataServicesOnce.Do(func() {
+		if err = mgr.PriceAlerts.Load(); err != nil {
+			return
+		}
+
+		ctx := mgr.dataServicesCtx
+		if err = mgr.startPayoutVerification(ctx); err != nil {
+			return
+		}
+
+		mgr.resumeSchedulers()
+		mgr.startSwapWatcher(ctx)
+		mgr.resumePendingMetadata()
+	})
+	return err
+}
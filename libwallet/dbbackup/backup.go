@@ -0,0 +1,287 @@
+package dbbackup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/kevinburke/nacl"
+	"github.com/kevinburke/nacl/secretbox"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// Ext is the file extension of the backups.
+	Ext = ".cpdb"
+
+	// magic starts every backup and identifies its format version.
+	magic    = "CPDBBAK1"
+	saltSize = 16
+
+	// fileTimeFormat is the format of the time in the name of a backup. It
+	// sorts the backups of a directory by their creation time.
+	fileTimeFormat = "20060102-150405"
+)
+
+// Key is the encryption key of backups derived from a passphrase.
+type Key struct {
+	salt []byte
+	key  nacl.Key
+}
+
+// NewKey derives a new key from pass with a random salt. The salt is stored in
+// the backups so they can be decrypted with pass only.
+func NewKey(pass []byte) (*Key, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return deriveKey(pass, salt)
+}
+
+// deriveKey derives the key of the provided salt from pass using scrypt.
+func deriveKey(pass, salt []byte) (*Key, error) {
+	const N, r, p = 1 << 15, 8, 1
+
+	hash, err := scrypt.Key(pass, salt, N, r, p, 32)
+	if err != nil {
+		return nil, err
+	}
+	key, err := nacl.Load(utils.EncodeHex(hash))
+	if err != nil {
+		return nil, err
+	}
+	return &Key{salt: salt, key: key}, nil
+}
+
+// Source is a database to back up.
+type Source struct {
+	// Name is the path of the database relative to the directory it is
+	// restored to.
+	Name string
+	// Copy writes a consistent copy of the database to w.
+	Copy func(w io.Writer) error
+}
+
+// FileName returns the name of a backup created at t.
+func FileName(t time.Time) string {
+	return "wallets-" + t.Format(fileTimeFormat) + Ext
+}
+
+// Write copies the sources into a new backup encrypted with key at path. The
+// backup is written to a temporary file that is renamed once complete.
+func Write(path string, key *Key, sources []Source) error {
+	var archive bytes.Buffer
+	zw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(zw)
+	for _, src := range sources {
+		var db bytes.Buffer
+		if err := src.Copy(&db); err != nil {
+			return fmt.Errorf("error copying %s: %w", src.Name, err)
+		}
+		err := tw.WriteHeader(&tar.Header{
+			Name:    filepath.ToSlash(src.Name),
+			Mode:    0o600,
+			Size:    int64(db.Len()),
+			ModTime: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, &db); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	data := make([]byte, 0, len(magic)+saltSize+archive.Len())
+	data = append(data, magic...)
+	data = append(data, key.salt...)
+	data = append(data, secretbox.EasySeal(archive.Bytes(), key.key)...)
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// open reads the backup at path and decrypts it with the key returned by
+// keyFn for the salt of the backup.
+func open(path string, keyFn func(salt []byte) (*Key, error)) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < len(magic)+saltSize || string(data[:len(magic)]) != magic {
+		return nil, fmt.Errorf("%s is not a database backup", filepath.Base(path))
+	}
+
+	key, err := keyFn(data[len(magic) : len(magic)+saltSize])
+	if err != nil {
+		return nil, err
+	}
+	archive, err := secretbox.EasyOpen(data[len(magic)+saltSize:], key.key)
+	if err != nil {
+		return nil, errors.New(utils.ErrInvalidPassphrase)
+	}
+	return archive, nil
+}
+
+// Extract decrypts the backup at path with pass, writes its databases under
+// dir and checks that they can be opened. It returns the names of the
+// extracted databases.
+func Extract(path string, pass []byte, dir string) ([]string, error) {
+	archive, err := open(path, func(salt []byte) (*Key, error) {
+		return deriveKey(pass, salt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return extract(archive, dir)
+}
+
+// Verify checks that the backup at path, written with key, can be decrypted
+// and that each of its databases can be opened.
+func Verify(path string, key *Key) error {
+	archive, err := open(path, func(salt []byte) (*Key, error) {
+		if !bytes.Equal(salt, key.salt) {
+			return nil, errors.New(utils.ErrInvalidPassphrase)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "cpdb-verify")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = extract(archive, dir)
+	return err
+}
+
+// extract writes the databases of the decrypted archive under dir and checks
+// them.
+func extract(archive []byte, dir string) ([]string, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var names []string
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("invalid database path %q in backup", hdr.Name)
+		}
+
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), utils.UserFilePerm); err != nil {
+			return nil, err
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(f, tr)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkDB(path); err != nil {
+			return nil, fmt.Errorf("database %s of the backup is invalid: %w", hdr.Name, err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// checkDB opens the bolt database at path and checks its consistency.
+func checkDB(path string) error {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) (firstErr error) {
+		// The channel is drained to let the check complete.
+		for err := range tx.Check() {
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	})
+}
+
+// List returns the paths of the backups in dir, newest first.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), Ext) {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	return paths, nil
+}
+
+// Prune removes the oldest backups in dir so that at most keep remain.
+func Prune(dir string, keep int) error {
+	paths, err := List(dir)
+	if err != nil {
+		return err
+	}
+	if len(paths) <= keep {
+		return nil
+	}
+	for _, path := range paths[keep:] {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package dbcrypt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/kevinburke/nacl/secretbox"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// magic starts the sealed values. It is followed by the ID of the data
+	// key that sealed the value and the secretbox of its JSON encoding.
+	magic = "CPDBENC1"
+
+	// stormMetadataBucket is the bucket storm creates in each of its buckets.
+	stormMetadataBucket = "__storm_metadata"
+)
+
+// ErrLocked is returned when a sealed value is read before the data key that
+// sealed it is set, or a value is written to a locked codec, i.e. before the
+// startup passphrase is entered.
+var ErrLocked = errors.New("the value is encrypted with the startup passphrase")
+
+// Codec is a storm codec that encodes the values in JSON and seals them with
+// a data key.
+//
+// Storm encodes the records, the values of the key/value pairs and the ids
+// and indexed fields that are not strings, []byte or integers with the codec.
+// Ids and indexed fields must encode the same way every time to be found, so
+// only the values passed by pointer are sealed, which storm always does for
+// records. The records of the plaintext types are never sealed.
+type Codec struct {
+	mu      sync.RWMutex
+	sealKey *Key
+	keys    map[[keyIDSize]byte]*Key
+	locked  bool
+
+	plaintextTypes   map[reflect.Type]bool
+	plaintextBuckets map[string]bool
+}
+
+// NewCodec returns a codec that does not seal the records of the types of
+// plaintext, which are pointers to structs. It does not seal any value until
+// a seal key is set.
+func NewCodec(plaintext ...interface{}) *Codec {
+	c := &Codec{
+		keys:             make(map[[keyIDSize]byte]*Key),
+		plaintextTypes:   make(map[reflect.Type]bool),
+		plaintextBuckets: make(map[string]bool),
+	}
+	for _, v := range plaintext {
+		t := reflect.TypeOf(v)
+		c.plaintextTypes[t] = true
+		c.plaintextBuckets[t.Elem().Name()] = true
+	}
+	return c
+}
+
+// SetKeys sets the key that seals the values and the other keys that open
+// them. No value is sealed if seal is nil.
+func (c *Codec) SetKeys(seal *Key, keys ...*Key) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sealKey = seal
+	c.locked = false
+	c.keys = make(map[[keyIDSize]byte]*Key, len(keys)+1)
+	for _, key := range append(keys, seal) {
+		if key != nil {
+			c.keys[key.id] = key
+		}
+	}
+}
+
+// Lock makes the codec refuse to encode the values it would seal until keys
+// are set, so that the values of a database sealed with keys that are not set
+// yet are neither written in plaintext nor overwritten with defaults.
+func (c *Codec) Lock() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.locked = true
+}
+
+// Keys returns the keys that open the values, starting with the seal key if
+// it is set.
+func (c *Codec) Keys() []*Key {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]*Key, 0, len(c.keys))
+	if c.sealKey != nil {
+		keys = append(keys, c.sealKey)
+	}
+	for _, key := range c.keys {
+		if key != c.sealKey {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Name returns the name of the codec. Storm refuses to open the buckets of
+// structs stored with a codec of another name, sealed values are still JSON
+// encoded.
+func (c *Codec) Name() string {
+	return "json"
+}
+
+// Marshal encodes v in JSON and seals it if it is passed by pointer.
+func (c *Codec) Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr || c.plaintextTypes[t] {
+		return data, nil
+	}
+
+	c.mu.RLock()
+	key, locked := c.sealKey, c.locked
+	c.mu.RUnlock()
+	if locked {
+		return nil, ErrLocked
+	}
+	if key == nil {
+		return data, nil
+	}
+	return seal(key, data), nil
+}
+
+// Unmarshal decodes the JSON encoded or sealed data into v.
+func (c *Codec) Unmarshal(data []byte, v interface{}) error {
+	data, err := c.Open(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Open returns the JSON encoding of a value encoded by the codec.
+func (c *Codec) Open(data []byte) ([]byte, error) {
+	if !IsSealed(data) {
+		return data, nil
+	}
+	if len(data) < len(magic)+keyIDSize {
+		return nil, errors.New("invalid sealed value")
+	}
+
+	var id [keyIDSize]byte
+	copy(id[:], data[len(magic):])
+	c.mu.RLock()
+	key := c.keys[id]
+	c.mu.RUnlock()
+	if key == nil {
+		return nil, ErrLocked
+	}
+
+	plain, err := secretbox.EasyOpen(data[len(magic)+keyIDSize:], key.key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open sealed value: %v", err)
+	}
+	return plain, nil
+}
+
+// IsSealed returns true if data is a value sealed by a codec.
+func IsSealed(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(magic))
+}
+
+func seal(key *Key, data []byte) []byte {
+	sealed := []byte(magic)
+	sealed = append(sealed, key.id[:]...)
+	return append(sealed, secretbox.EasySeal(data, key.key)...)
+}
+
+// Reseal seals the values of the storm buckets of db with the seal key, or
+// opens them if there is none. The records of the plaintext types and the
+// values of the plaintext keys are not sealed. The buckets that were not
+// created by storm are left untouched.
+func (c *Codec) Reseal(db *bolt.DB, plaintextKeys map[string]bool) error {
+	return c.reseal(db, plaintextKeys, true)
+}
+
+// SealPlaintext seals the values of the storm buckets of db that are not
+// sealed yet with the seal key, e.g. the values written in plaintext by older
+// versions while the codec was locked. Unlike Reseal, the sealed values are
+// left untouched.
+func (c *Codec) SealPlaintext(db *bolt.DB, plaintextKeys map[string]bool) error {
+	return c.reseal(db, plaintextKeys, false)
+}
+
+func (c *Codec) reseal(db *bolt.DB, plaintextKeys map[string]bool, sealed bool) error {
+	c.mu.RLock()
+	key := c.sealKey
+	c.mu.RUnlock()
+	if key == nil && !sealed {
+		return nil
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			if b.Bucket([]byte(stormMetadataBucket)) == nil || c.plaintextBuckets[string(name)] {
+				return nil
+			}
+
+			updates := make(map[string][]byte)
+			err := b.ForEach(func(k, v []byte) error {
+				// Nested buckets have nil values.
+				if len(v) == 0 || plaintextKeys[string(k)] || (!sealed && IsSealed(v)) {
+					return nil
+				}
+
+				data, err := c.Open(v)
+				if err != nil {
+					return fmt.Errorf("%s/%x: %v", name, k, err)
+				}
+				if key != nil {
+					data = seal(key, data)
+				}
+				if !bytes.Equal(data, v) {
+					updates[string(k)] = data
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			for k, v := range updates {
+				if err := b.Put([]byte(k), v); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,300 @@
+package dbcrypt
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+type record struct {
+	ID    int
+	Value string
+}
+
+type plainRecord struct {
+	ID int
+}
+
+func TestWrapUnwrap(t *testing.T) {
+	keys := []*Key{NewKey(), NewKey()}
+	wrapped, err := Wrap([]byte("pass"), keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		pass    []byte
+		wrapped []byte
+		err     error
+	}{
+		{name: "right passphrase", pass: []byte("pass"), wrapped: wrapped},
+		{name: "wrong passphrase", pass: []byte("Pass"), wrapped: wrapped, err: ErrWrongPassphrase},
+		{name: "empty passphrase", pass: nil, wrapped: wrapped, err: ErrWrongPassphrase},
+		{name: "tampered", pass: []byte("pass"), wrapped: append(append([]byte{}, wrapped[:len(wrapped)-1]...), wrapped[len(wrapped)-1]^1), err: ErrWrongPassphrase},
+	}
+
+	for _, test := range tests {
+		unwrapped, err := Unwrap(test.pass, test.wrapped)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(unwrapped, keys) {
+			t.Errorf("%s: unwrapped keys differ", test.name)
+		}
+	}
+
+	if _, err := Unwrap([]byte("pass"), wrapped[:saltSize-1]); err == nil {
+		t.Error("Unwrap of truncated keys succeeded")
+	}
+}
+
+func TestCodecSealOpen(t *testing.T) {
+	key := NewKey()
+	codec := NewCodec(&plainRecord{})
+	codec.SetKeys(key)
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		sealed bool
+	}{
+		{"record", &record{ID: 1, Value: "secret"}, true},
+		{"plaintext record", &plainRecord{ID: 1}, false},
+		{"value", record{ID: 1, Value: "id"}, false},
+		{"integer", 1, false},
+	}
+
+	for _, test := range tests {
+		data, err := codec.Marshal(test.value)
+		if err != nil {
+			t.Fatalf("%s: Marshal error: %v", test.name, err)
+		}
+		if IsSealed(data) != test.sealed {
+			t.Errorf("%s: sealed %v, want %v", test.name, IsSealed(data), test.sealed)
+		}
+		if test.sealed && bytes.Contains(data, []byte("secret")) {
+			t.Errorf("%s: sealed value holds the plaintext", test.name)
+		}
+
+		v := reflect.New(reflect.Indirect(reflect.ValueOf(test.value)).Type())
+		if err := codec.Unmarshal(data, v.Interface()); err != nil {
+			t.Fatalf("%s: Unmarshal error: %v", test.name, err)
+		}
+		if got := v.Elem().Interface(); !reflect.DeepEqual(got, reflect.Indirect(reflect.ValueOf(test.value)).Interface()) {
+			t.Errorf("%s: unmarshaled %v, want %v", test.name, got, test.value)
+		}
+	}
+
+	sealed, err := codec.Marshal(&record{ID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewCodec().Unmarshal(sealed, &record{}); !errors.Is(err, ErrLocked) {
+		t.Errorf("Unmarshal without keys error %v, want %v", err, ErrLocked)
+	}
+	other := NewCodec()
+	other.SetKeys(NewKey())
+	if err := other.Unmarshal(sealed, &record{}); !errors.Is(err, ErrLocked) {
+		t.Errorf("Unmarshal with another key error %v, want %v", err, ErrLocked)
+	}
+}
+
+// testDB returns a database with a storm bucket holding the values encoded by
+// codec and a bucket storm did not create.
+func testDB(t *testing.T, codec *Codec) *bolt.DB {
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucket([]byte("record"))
+		if err != nil {
+			return err
+		}
+		if _, err := b.CreateBucket([]byte(stormMetadataBucket)); err != nil {
+			return err
+		}
+		for _, k := range []string{"a", "b"} {
+			data, err := codec.Marshal(&record{Value: k})
+			if err != nil {
+				return err
+			}
+			if err := b.Put([]byte(k), data); err != nil {
+				return err
+			}
+		}
+		if err := b.Put([]byte("plain"), []byte(`"plain"`)); err != nil {
+			return err
+		}
+
+		raw, err := tx.CreateBucket([]byte("raw"))
+		if err != nil {
+			return err
+		}
+		return raw.Put([]byte("a"), []byte("raw"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestReseal(t *testing.T) {
+	oldKey, newKey := NewKey(), NewKey()
+
+	tests := []struct {
+		name   string
+		seal   *Key
+		sealed bool
+	}{
+		{"re-key", newKey, true},
+		{"decrypt", nil, false},
+	}
+
+	for _, test := range tests {
+		codec := NewCodec()
+		codec.SetKeys(oldKey)
+		db := testDB(t, codec)
+
+		codec.SetKeys(test.seal, oldKey)
+		if err := codec.Reseal(db, map[string]bool{"plain": true}); err != nil {
+			t.Fatalf("%s: Reseal error: %v", test.name, err)
+		}
+
+		// Only the seal key must be needed to open the resealed values.
+		reader := NewCodec()
+		reader.SetKeys(test.seal)
+		err := db.View(func(tx *bolt.Tx) error {
+			b := tx.Bucket([]byte("record"))
+			for _, k := range []string{"a", "b"} {
+				data := b.Get([]byte(k))
+				if IsSealed(data) != test.sealed {
+					t.Errorf("%s: %s sealed %v, want %v", test.name, k, IsSealed(data), test.sealed)
+				}
+				var r record
+				if err := reader.Unmarshal(data, &r); err != nil {
+					return err
+				}
+				if r.Value != k {
+					t.Errorf("%s: %s value %q", test.name, k, r.Value)
+				}
+			}
+			if data := b.Get([]byte("plain")); string(data) != `"plain"` {
+				t.Errorf("%s: plaintext key changed to %q", test.name, data)
+			}
+			if data := tx.Bucket([]byte("raw")).Get([]byte("a")); string(data) != "raw" {
+				t.Errorf("%s: non-storm bucket changed to %q", test.name, data)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+	}
+}
+
+func TestResealLocked(t *testing.T) {
+	codec := NewCodec()
+	codec.SetKeys(NewKey())
+	db := testDB(t, codec)
+
+	// Resealing without the key that sealed the values must fail.
+	codec.SetKeys(NewKey())
+	if err := codec.Reseal(db, nil); err == nil {
+		t.Fatal("Reseal without the old key succeeded")
+	}
+}
+
+func TestCodecLock(t *testing.T) {
+	codec := NewCodec(&plainRecord{})
+	codec.Lock()
+
+	tests := []struct {
+		name  string
+		value interface{}
+		err   error
+	}{
+		{"record", &record{ID: 1}, ErrLocked},
+		{"plaintext record", &plainRecord{ID: 1}, nil},
+		{"value", record{ID: 1}, nil},
+	}
+
+	for _, test := range tests {
+		if _, err := codec.Marshal(test.value); !errors.Is(err, test.err) {
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+		}
+	}
+
+	// Setting the keys unlocks the codec, even without a seal key.
+	codec.SetKeys(nil)
+	data, err := codec.Marshal(&record{ID: 1})
+	if err != nil {
+		t.Fatalf("Marshal after SetKeys error: %v", err)
+	}
+	if IsSealed(data) {
+		t.Error("value sealed without a seal key")
+	}
+}
+
+func TestSealPlaintext(t *testing.T) {
+	codec := NewCodec()
+	db := testDB(t, codec)
+
+	key := NewKey()
+	codec.SetKeys(key)
+	sealed, err := codec.Marshal(&record{Value: "sealed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte("record")).Put([]byte("sealed"), sealed)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := codec.SealPlaintext(db, map[string]bool{"plain": true}); err != nil {
+		t.Fatalf("SealPlaintext error: %v", err)
+	}
+
+	err = db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("record"))
+		for _, k := range []string{"a", "b"} {
+			data := b.Get([]byte(k))
+			if !IsSealed(data) {
+				t.Errorf("%s was not sealed", k)
+			}
+			var r record
+			if err := codec.Unmarshal(data, &r); err != nil {
+				return err
+			}
+			if r.Value != k {
+				t.Errorf("%s value %q", k, r.Value)
+			}
+		}
+		if data := b.Get([]byte("sealed")); !bytes.Equal(data, sealed) {
+			t.Error("sealed value was resealed")
+		}
+		if data := b.Get([]byte("plain")); string(data) != `"plain"` {
+			t.Errorf("plaintext key changed to %q", data)
+		}
+		if data := tx.Bucket([]byte("raw")).Get([]byte("a")); string(data) != "raw" {
+			t.Errorf("non-storm bucket changed to %q", data)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
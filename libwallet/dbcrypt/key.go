@@ -0,0 +1,102 @@
+// Package dbcrypt encrypts the values of the storm databases at rest.
+//
+// The values are sealed with random data keys. The data keys are stored in
+// the app database wrapped with a key derived from the startup passphrase, so
+// changing the passphrase only requires the data to be resealed with a new
+// data key.
+package dbcrypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/kevinburke/nacl"
+	"github.com/kevinburke/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	keyIDSize = 4
+	saltSize  = 16
+)
+
+// ErrWrongPassphrase is returned when the data keys can not be unwrapped with
+// the provided passphrase.
+var ErrWrongPassphrase = errors.New("the data keys can not be unwrapped with the passphrase")
+
+// Key is a data key that seals database values.
+type Key struct {
+	id  [keyIDSize]byte
+	key nacl.Key
+}
+
+// NewKey returns a new random data key.
+func NewKey() *Key {
+	return newKey(nacl.NewKey())
+}
+
+func newKey(key nacl.Key) *Key {
+	k := &Key{key: key}
+	hash := sha256.Sum256(key[:])
+	copy(k.id[:], hash[:])
+	return k
+}
+
+// deriveKey derives the key that wraps the data keys from pass using scrypt.
+func deriveKey(pass, salt []byte) (nacl.Key, error) {
+	const N, r, p = 1 << 15, 8, 1
+
+	hash, err := scrypt.Key(pass, salt, N, r, p, 32)
+	if err != nil {
+		return nil, err
+	}
+	return nacl.Load(utils.EncodeHex(hash))
+}
+
+// Wrap encrypts the keys with a key derived from pass.
+func Wrap(pass []byte, keys ...*Key) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	wrapKey, err := deriveKey(pass, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		buf.Write(key.key[:])
+	}
+	return append(salt, secretbox.EasySeal(buf.Bytes(), wrapKey)...), nil
+}
+
+// Unwrap decrypts the keys wrapped by Wrap with pass.
+func Unwrap(pass, wrapped []byte) ([]*Key, error) {
+	if len(wrapped) < saltSize {
+		return nil, errors.New("invalid wrapped keys")
+	}
+	wrapKey, err := deriveKey(pass, wrapped[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	data, err := secretbox.EasyOpen(wrapped[saltSize:], wrapKey)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	if len(data) == 0 || len(data)%nacl.KeySize != 0 {
+		return nil, errors.New("invalid wrapped keys")
+	}
+
+	keys := make([]*Key, 0, len(data)/nacl.KeySize)
+	for ; len(data) > 0; data = data[nacl.KeySize:] {
+		key := new([nacl.KeySize]byte)
+		copy(key[:], data)
+		keys = append(keys, newKey(key))
+	}
+	return keys, nil
+}
@@ -63,6 +63,24 @@ func (mgr *AssetsManager) CreateNewDCRWatchOnlyWallet(walletName, extendedPublic
 	return wallet, nil
 }
 
+// CreateNewDCRAddressWatchWallet creates a new DCR wallet watching the
+// transactions of addresses from the block at startHeight and returns it.
+func (mgr *AssetsManager) CreateNewDCRAddressWatchWallet(walletName string, addresses []string, startHeight int32) (sharedW.Asset, error) {
+	wallet, err := dcr.CreateAddressWatchWallet(walletName, addresses, startHeight, mgr.params)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.Assets.DCR.Wallets[wallet.GetWalletID()] = wallet
+
+	// extract the db interface if it hasn't been set already.
+	if mgr.db == nil && wallet != nil {
+		mgr.setDBInterface(wallet.(sharedW.AssetsManagerDB))
+	}
+
+	return wallet, nil
+}
+
 // RestoreDCRWallet restores a DCR wallet from a seed and returns it.
 func (mgr *AssetsManager) RestoreDCRWallet(walletName, seedMnemonic, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
 	pass := &sharedW.AuthInfo{
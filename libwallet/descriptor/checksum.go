@@ -0,0 +1,90 @@
+package descriptor
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	inputCharset    = "0123456789()[],'/*abcdefgh@:$%{}IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	checksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	checksumLen     = 8
+)
+
+var generator = [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+
+// ErrInvalidChecksum is returned when the checksum of a descriptor does not
+// match the descriptor.
+var ErrInvalidChecksum = errors.New("invalid descriptor checksum")
+
+func polymod(chk, value uint64) uint64 {
+	top := chk >> 35
+	chk = (chk&0x7ffffffff)<<5 ^ value
+	for i, g := range generator {
+		if (top>>i)&1 == 1 {
+			chk ^= g
+		}
+	}
+	return chk
+}
+
+// Checksum returns the BIP380 checksum of desc, which must not include a
+// checksum.
+func Checksum(desc string) (string, error) {
+	chk := uint64(1)
+	var class, classCount uint64
+	for _, c := range desc {
+		pos := strings.IndexRune(inputCharset, c)
+		if pos < 0 {
+			return "", errors.New("invalid character in descriptor")
+		}
+		// Every character contributes its position in its group of 32,
+		// and every 3 characters contribute the groups they belong to.
+		chk = polymod(chk, uint64(pos)&31)
+		class = class*3 + uint64(pos)>>5
+		classCount++
+		if classCount == 3 {
+			chk = polymod(chk, class)
+			class, classCount = 0, 0
+		}
+	}
+	if classCount > 0 {
+		chk = polymod(chk, class)
+	}
+	for i := 0; i < checksumLen; i++ {
+		chk = polymod(chk, 0)
+	}
+	chk ^= 1
+
+	checksum := make([]byte, checksumLen)
+	for i := range checksum {
+		checksum[i] = checksumCharset[(chk>>(5*(checksumLen-1-i)))&31]
+	}
+	return string(checksum), nil
+}
+
+// AddChecksum returns desc followed by its checksum.
+func AddChecksum(desc string) (string, error) {
+	checksum, err := Checksum(desc)
+	if err != nil {
+		return "", err
+	}
+	return desc + "#" + checksum, nil
+}
+
+// splitChecksum returns desc without its checksum. ErrInvalidChecksum is
+// returned if desc has a checksum that does not match.
+func splitChecksum(desc string) (string, error) {
+	body, checksum, found := strings.Cut(desc, "#")
+	if !found {
+		return desc, nil
+	}
+	expected, err := Checksum(body)
+	if err != nil {
+		return "", err
+	}
+	if checksum != expected {
+		return "", ErrInvalidChecksum
+	}
+	return body, nil
+}
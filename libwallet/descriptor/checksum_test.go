@@ -0,0 +1,66 @@
+package descriptor
+
+import (
+	"errors"
+	"testing"
+)
+
+// The descriptors are the checksum examples of BIP380.
+func TestSplitChecksum(t *testing.T) {
+	tests := []struct {
+		name  string
+		desc  string
+		body  string
+		valid bool
+		err   error
+	}{
+		{name: "valid checksum", desc: "raw(deadbeef)#89f8spxm", body: "raw(deadbeef)", valid: true},
+		{name: "no checksum", desc: "raw(deadbeef)", body: "raw(deadbeef)", valid: true},
+		{name: "missing checksum", desc: "raw(deadbeef)#", err: ErrInvalidChecksum},
+		{name: "too long checksum", desc: "raw(deadbeef)#89f8spxmx", err: ErrInvalidChecksum},
+		{name: "too short checksum", desc: "raw(deadbeef)#89f8spx", err: ErrInvalidChecksum},
+		{name: "error in payload", desc: "raw(dedbeef)#89f8spxm", err: ErrInvalidChecksum},
+		{name: "error in checksum", desc: "raw(deadbeef)##9f8spxm", err: ErrInvalidChecksum},
+		{name: "invalid character in payload", desc: "raw(Ü)#00000000"},
+	}
+
+	for _, test := range tests {
+		body, err := splitChecksum(test.desc)
+		switch {
+		case test.valid && err != nil:
+			t.Errorf("%s: error %v", test.name, err)
+		case test.valid && body != test.body:
+			t.Errorf("%s: body %q, want %q", test.name, body, test.body)
+		case !test.valid && err == nil:
+			t.Errorf("%s: checksum accepted", test.name)
+		case test.err != nil && !errors.Is(err, test.err):
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+		}
+	}
+}
+
+func TestAddChecksum(t *testing.T) {
+	tests := []struct {
+		desc string
+		want string
+	}{
+		{"raw(deadbeef)", "raw(deadbeef)#89f8spxm"},
+		{
+			"pkh([d34db33f/44'/0'/0']xpub6ERApfZwUNrhLCkDtcHTcxd75RbzS1ed54G1LkBUHQVHQKqhMkhgbmJbZRkrgZw4koxb5JaHWkY4ALHY2grBGRjaDMzQLcgJvLJuZZvRcEL/1/*)",
+			"pkh([d34db33f/44'/0'/0']xpub6ERApfZwUNrhLCkDtcHTcxd75RbzS1ed54G1LkBUHQVHQKqhMkhgbmJbZRkrgZw4koxb5JaHWkY4ALHY2grBGRjaDMzQLcgJvLJuZZvRcEL/1/*)#ml40v0wf",
+		},
+	}
+
+	for _, test := range tests {
+		got, err := AddChecksum(test.desc)
+		if err != nil {
+			t.Fatalf("%s: error %v", test.desc, err)
+		}
+		if got != test.want {
+			t.Errorf("%s: %s, want %s", test.desc, got, test.want)
+		}
+		if _, err := splitChecksum(got); err != nil {
+			t.Errorf("%s: checksum rejected: %v", test.desc, err)
+		}
+	}
+}
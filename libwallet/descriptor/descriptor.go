@@ -0,0 +1,233 @@
+// Package descriptor encodes and parses the BIP380 output descriptors of
+// single key HD accounts, to share the accounts with other wallet software.
+package descriptor
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HardenedKeyStart is the index of the first hardened child key.
+const HardenedKeyStart = 0x80000000
+
+// ScriptType is the script the keys of a descriptor pay to.
+type ScriptType string
+
+const (
+	ScriptPKH    ScriptType = "pkh"
+	ScriptWPKH   ScriptType = "wpkh"
+	ScriptSHWPKH ScriptType = "sh(wpkh)"
+	ScriptTR     ScriptType = "tr"
+)
+
+// ErrUnsupported is returned for the valid descriptors that do not describe
+// a single key HD account, such as multisig descriptors.
+var ErrUnsupported = errors.New("unsupported descriptor")
+
+// Origin is the key origin of a descriptor: the fingerprint of the master key
+// and the derivation path from it to the descriptor key.
+type Origin struct {
+	// Fingerprint is the first 4 bytes of the HASH160 of the master public
+	// key, read as a little-endian uint32 the way btcwallet stores it.
+	Fingerprint uint32
+	Path        []uint32
+}
+
+// Descriptor is the output descriptor of the external or internal addresses,
+// or both, of an HD account.
+type Descriptor struct {
+	Script ScriptType
+	Origin *Origin
+	// Key is the encoded extended public key of the account.
+	Key string
+	// Branches are the branches of the account the descriptor derives the
+	// addresses of. Two branches are encoded as a BIP389 multipath
+	// descriptor.
+	Branches []uint32
+}
+
+// IsDescriptor returns true if s looks like a descriptor rather than a bare
+// extended key.
+func IsDescriptor(s string) bool {
+	return strings.Contains(s, "(")
+}
+
+// String returns the descriptor with its checksum.
+func (d *Descriptor) String() string {
+	var b strings.Builder
+	if d.Origin != nil {
+		var fingerprint [4]byte
+		binary.LittleEndian.PutUint32(fingerprint[:], d.Origin.Fingerprint)
+		b.WriteString("[" + hex.EncodeToString(fingerprint[:]))
+		for _, index := range d.Origin.Path {
+			b.WriteString("/" + formatIndex(index))
+		}
+		b.WriteString("]")
+	}
+	b.WriteString(d.Key)
+	switch len(d.Branches) {
+	case 0:
+	case 1:
+		b.WriteString("/" + formatIndex(d.Branches[0]) + "/*")
+	default:
+		indexes := make([]string, len(d.Branches))
+		for i, branch := range d.Branches {
+			indexes[i] = formatIndex(branch)
+		}
+		b.WriteString("/<" + strings.Join(indexes, ";") + ">/*")
+	}
+
+	desc := b.String()
+	if d.Script == ScriptSHWPKH {
+		desc = "sh(wpkh(" + desc + "))"
+	} else {
+		desc = string(d.Script) + "(" + desc + ")"
+	}
+
+	// The checksum can only fail for characters that are not in the
+	// encoded keys.
+	withChecksum, err := AddChecksum(desc)
+	if err != nil {
+		return desc
+	}
+	return withChecksum
+}
+
+// Parse parses a single key descriptor of the addresses of an HD account.
+// The checksum is checked if desc has one.
+func Parse(desc string) (*Descriptor, error) {
+	body, err := splitChecksum(strings.TrimSpace(desc))
+	if err != nil {
+		return nil, err
+	}
+
+	d := new(Descriptor)
+	var ok bool
+	switch {
+	case strings.HasPrefix(body, "sh("):
+		d.Script = ScriptSHWPKH
+		body, ok = unwrap(body, "sh")
+		if ok {
+			body, ok = unwrap(body, string(ScriptWPKH))
+		}
+	case strings.HasPrefix(body, string(ScriptWPKH)+"("):
+		d.Script = ScriptWPKH
+		body, ok = unwrap(body, string(ScriptWPKH))
+	case strings.HasPrefix(body, string(ScriptPKH)+"("):
+		d.Script = ScriptPKH
+		body, ok = unwrap(body, string(ScriptPKH))
+	case strings.HasPrefix(body, string(ScriptTR)+"("):
+		d.Script = ScriptTR
+		body, ok = unwrap(body, string(ScriptTR))
+	}
+	if !ok || strings.ContainsAny(body, "(),") {
+		return nil, ErrUnsupported
+	}
+
+	if strings.HasPrefix(body, "[") {
+		end := strings.Index(body, "]")
+		if end < 0 {
+			return nil, errors.New("invalid key origin")
+		}
+		if d.Origin, err = parseOrigin(body[1:end]); err != nil {
+			return nil, err
+		}
+		body = body[end+1:]
+	}
+
+	parts := strings.Split(body, "/")
+	d.Key = parts[0]
+	if d.Key == "" {
+		return nil, errors.New("missing descriptor key")
+	}
+	// The key must be an account key with its addresses derived from the
+	// child keys of the branches: KEY/BRANCH/* or KEY/<BRANCH;...>/*.
+	if len(parts) != 3 || parts[2] != "*" {
+		return nil, ErrUnsupported
+	}
+	if d.Branches, err = parseBranches(parts[1]); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// unwrap returns the argument of the script expression name(arg).
+func unwrap(expr, name string) (string, bool) {
+	if !strings.HasPrefix(expr, name+"(") || !strings.HasSuffix(expr, ")") {
+		return "", false
+	}
+	return expr[len(name)+1 : len(expr)-1], true
+}
+
+func parseOrigin(origin string) (*Origin, error) {
+	parts := strings.Split(origin, "/")
+	fingerprint, err := hex.DecodeString(parts[0])
+	if err != nil || len(fingerprint) != 4 {
+		return nil, fmt.Errorf("invalid key origin fingerprint %q", parts[0])
+	}
+
+	o := &Origin{Fingerprint: binary.LittleEndian.Uint32(fingerprint)}
+	for _, part := range parts[1:] {
+		index, err := parseIndex(part)
+		if err != nil {
+			return nil, err
+		}
+		o.Path = append(o.Path, index)
+	}
+	return o, nil
+}
+
+func parseBranches(branches string) ([]uint32, error) {
+	if !strings.HasPrefix(branches, "<") {
+		branch, err := parseIndex(branches)
+		if err != nil {
+			return nil, err
+		}
+		return []uint32{branch}, nil
+	}
+
+	if !strings.HasSuffix(branches, ">") {
+		return nil, fmt.Errorf("invalid multipath %q", branches)
+	}
+	var indexes []uint32
+	for _, part := range strings.Split(branches[1:len(branches)-1], ";") {
+		index, err := parseIndex(part)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+	if len(indexes) < 2 {
+		return nil, fmt.Errorf("invalid multipath %q", branches)
+	}
+	return indexes, nil
+}
+
+// parseIndex parses a path element. Hardened elements end with ', h or H.
+func parseIndex(s string) (uint32, error) {
+	trimmed := strings.TrimRight(s, "'hH")
+	hardened := len(trimmed) == len(s)-1
+	if len(trimmed) < len(s)-1 {
+		return 0, fmt.Errorf("invalid path element %q", s)
+	}
+
+	index, err := strconv.ParseUint(trimmed, 10, 32)
+	if err != nil || index >= HardenedKeyStart {
+		return 0, fmt.Errorf("invalid path element %q", s)
+	}
+	if hardened {
+		index += HardenedKeyStart
+	}
+	return uint32(index), nil
+}
+
+func formatIndex(index uint32) string {
+	if index >= HardenedKeyStart {
+		return strconv.FormatUint(uint64(index-HardenedKeyStart), 10) + "h"
+	}
+	return strconv.FormatUint(uint64(index), 10)
+}
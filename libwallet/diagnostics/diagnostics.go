@@ -0,0 +1,180 @@
+package diagnostics
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// Repair is a fix for the problems found in a wallet. Repairs are ordered by
+// how much of the wallet they rebuild.
+type Repair int
+
+const (
+	// RepairNone is set on problems that need no repair.
+	RepairNone Repair = iota
+	// RepairReindex rebuilds the transaction index of the wallet from its
+	// transaction store.
+	RepairReindex
+	// RepairRescan drops the transaction history of the wallet and rescans
+	// the chain for it.
+	RepairRescan
+	// RepairRebuild recreates the wallet from its seed, keeping its
+	// settings, account names, transaction labels and exchange orders.
+	RepairRebuild
+)
+
+// String returns the name of the repair.
+func (r Repair) String() string {
+	switch r {
+	case RepairReindex:
+		return "reindex"
+	case RepairRescan:
+		return "rescan"
+	case RepairRebuild:
+		return "rebuild"
+	default:
+		return "none"
+	}
+}
+
+// Check names the part of the wallet a problem was found in.
+type Check string
+
+const (
+	CheckDatabase       Check = "database"
+	CheckAddressManager Check = "address_manager"
+	CheckTxStore        Check = "tx_store"
+	CheckTxIndex        Check = "tx_index"
+)
+
+// Problem is an inconsistency found in a wallet.
+type Problem struct {
+	Check       Check  `json:"check"`
+	Description string `json:"description"`
+	Repair      Repair `json:"repair"`
+}
+
+// Report is the result of the diagnosis of a wallet.
+type Report struct {
+	WalletID  int        `json:"walletID"`
+	CheckedAt int64      `json:"checkedAt"`
+	Problems  []*Problem `json:"problems"`
+}
+
+// NewReport returns an empty report for the wallet with walletID.
+func NewReport(walletID int) *Report {
+	return &Report{
+		WalletID:  walletID,
+		CheckedAt: time.Now().Unix(),
+	}
+}
+
+// Add records a problem found by check that is fixed by repair.
+func (r *Report) Add(check Check, repair Repair, format string, args ...interface{}) {
+	r.Problems = append(r.Problems, &Problem{
+		Check:       check,
+		Description: fmt.Sprintf(format, args...),
+		Repair:      repair,
+	})
+}
+
+// OK returns true if no problem was found.
+func (r *Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Repair returns the repair that fixes all the problems of the report, which
+// is the most thorough repair needed by any of them.
+func (r *Report) Repair() Repair {
+	repair := RepairNone
+	for _, p := range r.Problems {
+		if p.Repair > repair {
+			repair = p.Repair
+		}
+	}
+	return repair
+}
+
+// namespace is a top level bucket of a wallet database and the buckets it
+// must contain.
+type namespace struct {
+	name    string
+	check   Check
+	buckets []string
+	// repair fixes a missing or damaged namespace.
+	repair Repair
+}
+
+// walletNamespaces returns the namespaces of the wallet databases of
+// assetType. The transaction store of BTC and LTC wallets can be dropped and
+// recreated by a rescan while DCR wallets must be rebuilt.
+func walletNamespaces(assetType utils.AssetType) []namespace {
+	switch assetType {
+	case utils.DCRWalletAsset:
+		return []namespace{
+			{"waddrmgr", CheckAddressManager, []string{"main", "acct", "addr", "meta"}, RepairRebuild},
+			{"wtxmgr", CheckTxStore, []string{"b", "t", "u"}, RepairRebuild},
+			{"wstakemgr", CheckTxStore, nil, RepairRebuild},
+		}
+	case utils.BTCWalletAsset, utils.LTCWalletAsset:
+		return []namespace{
+			{"waddrmgr", CheckAddressManager, []string{"main", "sync"}, RepairRebuild},
+			{"wtxmgr", CheckTxStore, []string{"b", "t", "u"}, RepairRescan},
+		}
+	default:
+		return nil
+	}
+}
+
+// CheckWalletDB opens the wallet database of assetType at path read-only and
+// adds the problems of its pages and bucket structure to the report. The
+// database must not be opened by the wallet, which locks it.
+func CheckWalletDB(report *Report, path string, assetType utils.AssetType) {
+	if _, err := os.Stat(path); err != nil {
+		report.Add(CheckDatabase, RepairRebuild, "wallet database is missing: %v", err)
+		return
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		report.Add(CheckDatabase, RepairRebuild, "wallet database can not be opened: %v", err)
+		return
+	}
+	defer db.Close()
+
+	err = db.View(func(tx *bolt.Tx) error {
+		// The channel is drained to let the check complete.
+		var pageErrs int
+		for err := range tx.Check() {
+			if pageErrs == 0 {
+				report.Add(CheckDatabase, RepairRebuild, "wallet database is corrupted: %v", err)
+			}
+			pageErrs++
+		}
+		if pageErrs > 0 {
+			return nil
+		}
+
+		for _, ns := range walletNamespaces(assetType) {
+			b := tx.Bucket([]byte(ns.name))
+			if b == nil {
+				report.Add(ns.check, ns.repair, "%s namespace is missing", ns.name)
+				continue
+			}
+			for _, name := range ns.buckets {
+				if b.Bucket([]byte(name)) == nil {
+					report.Add(ns.check, ns.repair, "%s namespace is missing the %q bucket", ns.name, name)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		report.Add(CheckDatabase, RepairRebuild, "wallet database can not be read: %v", err)
+	}
+}
@@ -608,3 +608,70 @@ func TestGetAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateTickers(t *testing.T) {
+	now := time.Now()
+	change := func(c float64) *float64 { return &c }
+	tests := []struct {
+		name          string
+		tickers       []*Ticker
+		expectedPrice float64
+		expectNil     bool
+	}{
+		{
+			name: "median of live sources",
+			tickers: []*Ticker{
+				{LastTradePrice: 15.0, PriceChangePercent: change(1), lastUpdate: now},
+				{LastTradePrice: 15.2, PriceChangePercent: change(2), lastUpdate: now},
+				{LastTradePrice: 15.1, PriceChangePercent: change(3), lastUpdate: now},
+			},
+			expectedPrice: 15.1,
+		},
+		{
+			name: "outlier discarded",
+			tickers: []*Ticker{
+				{LastTradePrice: 15.0, lastUpdate: now},
+				{LastTradePrice: 15.2, lastUpdate: now},
+				{LastTradePrice: 15.1, lastUpdate: now},
+				{LastTradePrice: 30.0, lastUpdate: now},
+			},
+			expectedPrice: 15.1,
+		},
+		{
+			name: "stale quote discarded",
+			tickers: []*Ticker{
+				{LastTradePrice: 15.0, lastUpdate: now},
+				{LastTradePrice: 15.2, lastUpdate: now},
+				{LastTradePrice: 14.0, lastUpdate: now.Add(-2 * rateExpiry)},
+			},
+			expectedPrice: 15.1,
+		},
+		{
+			name: "no live source",
+			tickers: []*Ticker{
+				nil,
+				{LastTradePrice: 0, lastUpdate: now},
+				{LastTradePrice: 14.0, lastUpdate: now.Add(-2 * rateExpiry)},
+			},
+			expectNil: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ticker := aggregateTickers("DCR-USDT", tc.tickers, now)
+			if tc.expectNil {
+				if ticker != nil {
+					t.Errorf("(%v), expected nil ticker, got (%v)", tc.name, ticker)
+				}
+				return
+			}
+			if ticker == nil {
+				t.Fatalf("(%v), expected a ticker, got nil", tc.name)
+			}
+			if diff := ticker.LastTradePrice - tc.expectedPrice; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("(%v), expected price (%v), got (%v)", tc.name, tc.expectedPrice, ticker.LastTradePrice)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2023, The Cryptopower developers
+// See LICENSE for details.
+
+package ext
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+var (
+	// forexURL is used to fetch the USD exchange rates of fiat currencies.
+	forexURL = "https://open.er-api.com/v6/latest/USD"
+
+	// Fiat exchange rates move much slower than crypto rates, forex rates
+	// exceeding forexRateExpiry are refetched.
+	forexRateExpiry = 6 * time.Hour
+)
+
+// forexRates caches the USD exchange rates of fiat currencies. It is used to
+// convert the USD quotes of the rate sources to the user's local currency.
+type forexRates struct {
+	mtx        sync.RWMutex
+	rates      map[string]float64
+	lastUpdate time.Time
+	fetchRates func() (map[string]float64, error)
+}
+
+func newForexRates() *forexRates {
+	return &forexRates{
+		rates:      make(map[string]float64),
+		fetchRates: fetchForexRates,
+	}
+}
+
+// rate returns the amount of currency that 1 USD buys. Cached rates are
+// returned if they are still valid or if fetching new rates failed.
+func (fx *forexRates) rate(currency string) (float64, error) {
+	currency = strings.ToUpper(currency)
+	if currency == values.USDCurrency {
+		return 1, nil
+	}
+
+	fx.mtx.RLock()
+	rate, ok := fx.rates[currency]
+	expired := time.Since(fx.lastUpdate) > forexRateExpiry
+	fx.mtx.RUnlock()
+
+	if ok && !expired {
+		return rate, nil
+	}
+
+	if err := fx.refresh(); err != nil {
+		if ok {
+			log.Errorf("Using expired %s forex rate: %v", currency, err)
+			return rate, nil
+		}
+		return 0, err
+	}
+
+	fx.mtx.RLock()
+	defer fx.mtx.RUnlock()
+	rate, ok = fx.rates[currency]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("no forex rate for %s", currency)
+	}
+	return rate, nil
+}
+
+// refresh fetches the latest forex rates.
+func (fx *forexRates) refresh() error {
+	rates, err := fx.fetchRates()
+	if err != nil {
+		return err
+	}
+
+	fx.mtx.Lock()
+	fx.rates = rates
+	fx.lastUpdate = time.Now()
+	fx.mtx.Unlock()
+	return nil
+}
+
+func fetchForexRates() (map[string]float64, error) {
+	reqCfg := &utils.ReqConfig{
+		HTTPURL: forexURL,
+		Method:  "GET",
+	}
+
+	resp := new(ForexRatesResponse)
+	_, err := utils.HTTPRequest(reqCfg, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch forex rates: %w", err)
+	}
+
+	if resp.Result != "success" || len(resp.Rates) == 0 {
+		return nil, fmt.Errorf("failed to fetch forex rates: unexpected result %q", resp.Result)
+	}
+
+	return resp.Rates, nil
+}
+
+// ForexRate returns the amount of currency that 1 USD buys. Previously fetched
+// rates are returned if the rate source is disabled.
+func (cs *CommonRateSource) ForexRate(currency string) (float64, error) {
+	if cs.isDisabled() || cs.source == none {
+		cs.forex.mtx.RLock()
+		defer cs.forex.mtx.RUnlock()
+		if rate, ok := cs.forex.rates[strings.ToUpper(currency)]; ok {
+			return rate, nil
+		}
+		if strings.EqualFold(currency, values.USDCurrency) {
+			return 1, nil
+		}
+		return 0, fmt.Errorf("no forex rate for %s", currency)
+	}
+	return cs.forex.rate(currency)
+}
+
+// FiatMarket returns the name of the market for the provided USDT market in
+// currency e.g DCR-USDT in EUR is DCR-EUR.
+func FiatMarket(usdtMarket, currency string) string {
+	fromCur, _ := splitMarket(usdtMarket)
+	return fromCur + MktSep + strings.ToUpper(currency)
+}
+
+// GetFiatTicker returns ticker information for the provided USDT market with
+// the price converted to currency using the current forex rates. nil is
+// returned if either the USDT ticker or the forex rate is not available.
+func (cs *CommonRateSource) GetFiatTicker(usdtMarket, currency string) *Ticker {
+	ticker := cs.GetTicker(usdtMarket)
+	if ticker == nil || currency == "" || strings.EqualFold(currency, values.USDCurrency) {
+		return ticker
+	}
+
+	if cs.isDisabled() {
+		return nil
+	}
+
+	rate, err := cs.forex.rate(currency)
+	if err != nil {
+		cs.fail("Error fetching forex rate", err)
+		return nil
+	}
+
+	ticker.Market = FiatMarket(usdtMarket, currency)
+	ticker.LastTradePrice *= rate
+	return ticker
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2023, The Cryptopower developers
+// See LICENSE for details.
+
+package ext
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRateDeviation is the maximum fraction a source's price may deviate
+	// from the median of all the sources before it is considered an outlier.
+	maxRateDeviation = 0.05
+
+	// aggregateFetchTimeout is the maximum time spent waiting for each source
+	// to return a ticker.
+	aggregateFetchTimeout = 15 * time.Second
+)
+
+// AggregateRateProvider is a RateProvider that returns the median price across
+// all other registered rate providers that are live. Stale quotes and outliers
+// are discarded.
+type AggregateRateProvider struct {
+	// sources is an optional list of provider names to aggregate. All the
+	// registered providers are used if empty.
+	sources []string
+}
+
+// NewAggregateRateProvider returns a new AggregateRateProvider for the named
+// sources. If no source is provided, every registered rate provider is used.
+func NewAggregateRateProvider(sources ...string) *AggregateRateProvider {
+	return &AggregateRateProvider{sources: sources}
+}
+
+func (ap *AggregateRateProvider) Name() string {
+	return aggregate
+}
+
+// providers returns the rate providers to aggregate.
+func (ap *AggregateRateProvider) providers() []RateProvider {
+	names := ap.sources
+	if len(names) == 0 {
+		names = RateProviders()
+	}
+
+	var providers []RateProvider
+	for _, name := range names {
+		if name == ap.Name() {
+			continue
+		}
+		if p, ok := rateProvider(name); ok {
+			providers = append(providers, p)
+		}
+	}
+	return providers
+}
+
+// GetTicker concurrently fetches the market's ticker from every source and
+// returns a ticker that holds the median of the live quotes.
+func (ap *AggregateRateProvider) GetTicker(market string) (*Ticker, error) {
+	providers := ap.providers()
+	resChan := make(chan *Ticker, len(providers))
+
+	var wg sync.WaitGroup
+	for _, p := range providers {
+		wg.Add(1)
+		go func(p RateProvider) {
+			defer wg.Done()
+			ticker, err := p.GetTicker(market)
+			if err != nil {
+				log.Debugf("%s: %s rate source error: %v", aggregate, p.Name(), err)
+				return
+			}
+			resChan <- ticker
+		}(p)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resChan)
+	}()
+
+	var tickers []*Ticker
+	timeout := time.After(aggregateFetchTimeout)
+out:
+	for {
+		select {
+		case ticker, ok := <-resChan:
+			if !ok {
+				break out
+			}
+			tickers = append(tickers, ticker)
+		case <-timeout:
+			break out
+		}
+	}
+
+	ticker := aggregateTickers(market, tickers, time.Now())
+	if ticker == nil {
+		return nil, fmt.Errorf("%s: no live rate source for %s", aggregate, market)
+	}
+
+	return ticker, nil
+}
+
+// aggregateTickers returns a ticker with the median price and price change of
+// tickers after discarding stale quotes and outliers. nil is returned if no
+// ticker is usable.
+func aggregateTickers(market string, tickers []*Ticker, now time.Time) *Ticker {
+	var live []*Ticker
+	for _, t := range tickers {
+		if t == nil || t.LastTradePrice <= 0 {
+			continue
+		}
+		if !t.lastUpdate.IsZero() && now.Sub(t.lastUpdate) > rateExpiry {
+			continue // stale
+		}
+		live = append(live, t)
+	}
+
+	if len(live) == 0 {
+		return nil
+	}
+
+	prices := make([]float64, 0, len(live))
+	for _, t := range live {
+		prices = append(prices, t.LastTradePrice)
+	}
+	mid := median(prices)
+
+	// Discard outliers and recompute the median from the remaining quotes.
+	prices = prices[:0]
+	var changes []float64
+	for _, t := range live {
+		if math.Abs(t.LastTradePrice-mid)/mid > maxRateDeviation {
+			log.Debugf("%s: discarding outlier %s price %f, median is %f", aggregate, market, t.LastTradePrice, mid)
+			continue
+		}
+		prices = append(prices, t.LastTradePrice)
+		if t.PriceChangePercent != nil {
+			changes = append(changes, *t.PriceChangePercent)
+		}
+	}
+
+	if len(prices) == 0 {
+		return nil
+	}
+
+	ticker := &Ticker{
+		Market:         market,
+		LastTradePrice: median(prices),
+		lastUpdate:     now,
+	}
+	if len(changes) > 0 {
+		percentChange := median(changes)
+		ticker.PriceChangePercent = &percentChange
+	}
+
+	return ticker
+}
+
+// median returns the median of values. values is sorted in place.
+func median(values []float64) float64 {
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+
+	sort.Float64s(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
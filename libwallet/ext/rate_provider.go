@@ -0,0 +1,357 @@
+// Copyright (c) 2023, The Cryptopower developers
+// See LICENSE for details.
+
+package ext
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	// These are constants used to represent the additional rate sources
+	// supported.
+	kraken    = values.KrakenExchange
+	coinbase  = values.CoinbaseExchange
+	coingecko = values.CoinGeckoExchange
+	aggregate = values.AggregateExchange
+
+	// FileRateSource is the name of the rate provider that reads tickers from
+	// a local JSON file. See NewFileRateProvider.
+	FileRateSource = "file"
+)
+
+var (
+	// These are urls to fetch rate information from the Kraken exchange.
+	krakenURLs = sourceURLs{
+		price: "https://api.kraken.com/0/public/Ticker?pair=%s",
+	}
+
+	// These are urls to fetch rate information from the Coinbase exchange.
+	coinbaseURLs = sourceURLs{
+		stats: "https://api.exchange.coinbase.com/products/%s/stats",
+	}
+
+	// These are urls to fetch rate information from CoinGecko.
+	coingeckoURLs = sourceURLs{
+		price: "https://api.coingecko.com/api/v3/simple/price?ids=%s&vs_currencies=%s&include_24hr_change=true",
+	}
+
+	// krakenMarkets maps the repo's market format to the Kraken pair name.
+	krakenMarkets = map[string]string{
+		values.BTCUSDTMarket: "XBTUSDT",
+		values.DCRUSDTMarket: "DCRUSD",
+		values.LTCUSDTMarket: "LTCUSDT",
+		values.DCRBTCMarket:  "DCRXBT",
+		values.LTCBTCMarket:  "LTCXBT",
+	}
+
+	// coinbaseMarkets maps the repo's market format to the Coinbase product
+	// id.
+	coinbaseMarkets = map[string]string{
+		values.BTCUSDTMarket: "BTC-USDT",
+		values.DCRUSDTMarket: "DCR-USD",
+		values.LTCUSDTMarket: "LTC-USD",
+		values.LTCBTCMarket:  "LTC-BTC",
+	}
+
+	// coingeckoIDs maps the repo's currency symbols to CoinGecko coin ids and
+	// vs_currencies.
+	coingeckoIDs = map[string]string{
+		"BTC":  "bitcoin",
+		"DCR":  "decred",
+		"LTC":  "litecoin",
+		"USDT": "usd",
+	}
+
+	providersMtx  sync.RWMutex
+	rateProviders = make(map[string]RateProvider)
+)
+
+// RateProvider is the interface implemented by every exchange or service that
+// can be used as a rate source. Providers are registered by name using
+// RegisterRateProvider and can then be selected with
+// CommonRateSource.ToggleSource.
+type RateProvider interface {
+	// Name is the unique key identifying the provider e.g "kraken".
+	Name() string
+	// GetTicker fetches the current ticker for market. market is in the
+	// repo's format, e.g DCR-USDT, and is always one of the supported markets.
+	GetTicker(market string) (*Ticker, error)
+}
+
+// rateProviderFunc adapts an ordinary function to the RateProvider interface.
+type rateProviderFunc struct {
+	name      string
+	getTicker func(market string) (*Ticker, error)
+}
+
+func (p *rateProviderFunc) Name() string {
+	return p.name
+}
+
+func (p *rateProviderFunc) GetTicker(market string) (*Ticker, error) {
+	return p.getTicker(market)
+}
+
+// NewRateProvider returns a RateProvider that uses getTicker to fetch tickers.
+func NewRateProvider(name string, getTicker func(market string) (*Ticker, error)) RateProvider {
+	return &rateProviderFunc{name: name, getTicker: getTicker}
+}
+
+func init() {
+	for _, p := range []RateProvider{
+		NewRateProvider(binance, binanceGetTicker),
+		NewRateProvider(bittrex, bittrexGetTicker),
+		NewRateProvider(kraken, krakenGetTicker),
+		NewRateProvider(coinbase, coinbaseGetTicker),
+		NewRateProvider(coingecko, coingeckoGetTicker),
+		NewAggregateRateProvider(),
+	} {
+		if err := RegisterRateProvider(p); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterRateProvider makes a rate provider available for use as a rate
+// source. An error is returned if a provider with the same name already
+// exists.
+func RegisterRateProvider(p RateProvider) error {
+	name := p.Name()
+	if name == "" || name == none {
+		return fmt.Errorf("invalid rate provider name %q", name)
+	}
+
+	providersMtx.Lock()
+	defer providersMtx.Unlock()
+	if _, ok := rateProviders[name]; ok {
+		return fmt.Errorf("rate provider %s is already registered", name)
+	}
+	rateProviders[name] = p
+	return nil
+}
+
+// UnregisterRateProvider removes a previously registered rate provider.
+func UnregisterRateProvider(name string) {
+	providersMtx.Lock()
+	defer providersMtx.Unlock()
+	delete(rateProviders, name)
+}
+
+// RateProviders returns the sorted names of all the registered rate providers.
+func RateProviders() []string {
+	providersMtx.RLock()
+	defer providersMtx.RUnlock()
+	names := make([]string, 0, len(rateProviders))
+	for name := range rateProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// rateProvider returns the registered rate provider with the provided name.
+func rateProvider(name string) (RateProvider, bool) {
+	providersMtx.RLock()
+	defer providersMtx.RUnlock()
+	p, ok := rateProviders[name]
+	return p, ok
+}
+
+// splitMarket returns the base and quote currencies of a market in the repo's
+// format.
+func splitMarket(market string) (string, string) {
+	currencies := strings.Split(market, MktSep)
+	if len(currencies) != 2 {
+		return "", ""
+	}
+	return currencies[0], currencies[1]
+}
+
+func krakenGetTicker(market string) (*Ticker, error) {
+	pair, ok := krakenMarkets[market]
+	if !ok {
+		return nil, fmt.Errorf("Market %s not supported", market)
+	}
+
+	reqCfg := &utils.ReqConfig{
+		HTTPURL: fmt.Sprintf(krakenURLs.price, pair),
+		Method:  "GET",
+	}
+
+	resp := new(KrakenTickerResponse)
+	_, err := utils.HTTPRequest(reqCfg, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed to fetch ticker for %s: %w", kraken, market, err)
+	}
+
+	if len(resp.Error) > 0 {
+		return nil, fmt.Errorf("%s failed to fetch ticker for %s: %s", kraken, market, strings.Join(resp.Error, ", "))
+	}
+
+	// Kraken may return the pair using its alternate name so pick the first
+	// (and only) result.
+	for _, info := range resp.Result {
+		if len(info.Close) == 0 {
+			break
+		}
+
+		lastPrice, err := strconv.ParseFloat(info.Close[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("strconv.ParseFloat error: %w", err)
+		}
+
+		ticker := &Ticker{
+			Market:         market,
+			LastTradePrice: lastPrice,
+			lastUpdate:     time.Now(),
+		}
+
+		// Kraken only provides today's opening price.
+		if open, err := strconv.ParseFloat(info.Open, 64); err == nil && open > 0 {
+			percentChange := (lastPrice - open) / open * 100
+			ticker.PriceChangePercent = &percentChange
+		}
+
+		return ticker, nil
+	}
+
+	return nil, fmt.Errorf("%s returned no ticker for %s", kraken, market)
+}
+
+func coinbaseGetTicker(market string) (*Ticker, error) {
+	product, ok := coinbaseMarkets[market]
+	if !ok {
+		return nil, fmt.Errorf("Market %s not supported", market)
+	}
+
+	reqCfg := &utils.ReqConfig{
+		HTTPURL: fmt.Sprintf(coinbaseURLs.stats, product),
+		Method:  "GET",
+	}
+
+	resp := new(CoinbaseStatsResponse)
+	_, err := utils.HTTPRequest(reqCfg, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed to fetch ticker for %s: %w", coinbase, market, err)
+	}
+
+	ticker := &Ticker{
+		Market:         market,
+		LastTradePrice: resp.Last,
+		lastUpdate:     time.Now(),
+	}
+
+	if resp.Open > 0 {
+		percentChange := (resp.Last - resp.Open) / resp.Open * 100
+		ticker.PriceChangePercent = &percentChange
+	}
+
+	return ticker, nil
+}
+
+func coingeckoGetTicker(market string) (*Ticker, error) {
+	fromCur, toCur := splitMarket(market)
+	coinID, ok := coingeckoIDs[fromCur]
+	vsCurrency, vsOk := coingeckoIDs[toCur]
+	if toCur == "BTC" {
+		vsCurrency, vsOk = "btc", true
+	}
+	if !ok || !vsOk {
+		return nil, fmt.Errorf("Market %s not supported", market)
+	}
+
+	reqCfg := &utils.ReqConfig{
+		HTTPURL: fmt.Sprintf(coingeckoURLs.price, coinID, vsCurrency),
+		Method:  "GET",
+	}
+
+	resp := make(map[string]map[string]float64)
+	_, err := utils.HTTPRequest(reqCfg, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed to fetch ticker for %s: %w", coingecko, market, err)
+	}
+
+	prices, ok := resp[coinID]
+	if !ok || prices[vsCurrency] == 0 {
+		return nil, fmt.Errorf("%s returned no ticker for %s", coingecko, market)
+	}
+
+	ticker := &Ticker{
+		Market:         market,
+		LastTradePrice: prices[vsCurrency],
+		lastUpdate:     time.Now(),
+	}
+
+	if percentChange, ok := prices[vsCurrency+"_24h_change"]; ok {
+		ticker.PriceChangePercent = &percentChange
+	}
+
+	return ticker, nil
+}
+
+// fileRateProvider reads tickers from a local JSON file. It is useful when
+// every online rate source is unreachable or blocked.
+type fileRateProvider struct {
+	path string
+}
+
+// NewFileRateProvider returns a RateProvider that reads tickers from the JSON
+// file at path. The file holds an object keyed by market, e.g:
+//
+//	{"DCR-USDT": {"price": 15.2, "change": -1.4, "timestamp": 1690000000}}
+//
+// The file is read on every request so it can be updated while the app is
+// running. A zero timestamp uses the file's modification time.
+func NewFileRateProvider(path string) RateProvider {
+	return &fileRateProvider{path: path}
+}
+
+func (p *fileRateProvider) Name() string {
+	return FileRateSource
+}
+
+func (p *fileRateProvider) GetTicker(market string) (*Ticker, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", FileRateSource, err)
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", FileRateSource, err)
+	}
+
+	rates := make(map[string]*FileRate)
+	if err = json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("%s: invalid rates file: %w", FileRateSource, err)
+	}
+
+	rate, ok := rates[market]
+	if !ok || rate == nil || rate.Price <= 0 {
+		return nil, errors.New("Market " + market + " not found in rates file")
+	}
+
+	lastUpdate := info.ModTime()
+	if rate.Timestamp > 0 {
+		lastUpdate = time.Unix(rate.Timestamp, 0)
+	}
+
+	return &Ticker{
+		Market:             market,
+		LastTradePrice:     rate.Price,
+		PriceChangePercent: rate.Change,
+		lastUpdate:         lastUpdate,
+	}, nil
+}
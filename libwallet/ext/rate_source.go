@@ -54,8 +54,8 @@ var (
 		ws:    "wss://stream.binance.com:9443/stream?streams=%s",
 	}
 
-	// supportedMarkets is a map of markets supported by rate sources
-	// implemented (Binance, Bittrex).
+	// supportedMarkets is a map of markets supported by the registered rate
+	// sources. See RateProvider.
 	supportedMarkets = map[string]*struct{}{
 		values.BTCUSDTMarket: {},
 		values.DCRUSDTMarket: {},
@@ -111,6 +111,8 @@ type RateSource interface {
 	Refreshing() bool
 	LastUpdate() time.Time
 	GetTicker(market string) *Ticker
+	GetFiatTicker(usdtMarket, currency string) *Ticker
+	ForexRate(currency string) (float64, error)
 	ToggleStatus(disable bool)
 	ToggleSource(newSource string) error
 	AddRateListener(listener *RateListener, uniqueID string) error
@@ -145,6 +147,9 @@ type CommonRateSource struct {
 
 	rateListenersMtx sync.RWMutex
 	rateListeners    map[string]*RateListener
+
+	// forex is used to convert USD quotes to other fiat currencies.
+	forex *forexRates
 }
 
 // Name is the string associated with the rate source for display.
@@ -200,20 +205,9 @@ func (cs *CommonRateSource) ToggleSource(newSource string) error {
 		return nil // nothing to do
 	}
 
-	getTickerFn := dummyGetTickerFunc
-	wsProcessor := func([]byte) ([]*Ticker, error) { return nil, nil }
-	refresh := true
-	switch newSource {
-	case none: /* none is the dummy rate source for when user disables rates */
-		refresh = false
-	case binance:
-		getTickerFn = binanceGetTicker
-		wsProcessor = processBinanceWsMessage
-	case bittrex:
-		getTickerFn = binanceGetTicker
-		wsProcessor = processBittrexWsMessage
-	default:
-		return fmt.Errorf("New rate source %s is not supported", newSource)
+	getTickerFn, wsProcessor, err := sourceFuncs(newSource)
+	if err != nil {
+		return err
 	}
 
 	// Update source specific fields.
@@ -227,7 +221,7 @@ func (cs *CommonRateSource) ToggleSource(newSource string) error {
 
 	go cs.notifyRateListeners()
 
-	if refresh {
+	if newSource != none { /* none is the dummy rate source for when user disables rates */
 		cs.Refresh(true)
 	}
 
@@ -515,6 +509,9 @@ func (cs *CommonRateSource) Refresh(force bool) {
 			continue
 		}
 
+		if ticker.lastUpdate.IsZero() {
+			ticker.lastUpdate = time.Now()
+		}
 		tickers[market] = ticker
 	}
 
@@ -523,7 +520,7 @@ func (cs *CommonRateSource) Refresh(force bool) {
 	cs.mtx.Unlock()
 
 	// Check if the websocket connection is still on.
-	if cs.wsListening() {
+	if !cs.hasWebsocket() || cs.wsListening() {
 		return
 	}
 
@@ -575,6 +572,10 @@ func (cs *CommonRateSource) fetchRate(market string) *Ticker {
 		return nil
 	}
 
+	if newTicker.lastUpdate.IsZero() {
+		newTicker.lastUpdate = time.Now()
+	}
+
 	cs.mtx.Lock()
 	cs.tickers[market] = newTicker
 	cs.mtx.Unlock()
@@ -610,19 +611,9 @@ func (cs *CommonRateSource) GetTicker(market string) *Ticker {
 
 // Used to initialize a rate source.
 func NewCommonRateSource(ctx context.Context, source string) (*CommonRateSource, error) {
-	if source != binance && source != bittrex && source != none {
-		return nil, fmt.Errorf("New rate source %s is not supported", source)
-	}
-
-	getTickerFunc := dummyGetTickerFunc
-	wsProcessor := func([]byte) ([]*Ticker, error) { return nil, nil }
-	switch source {
-	case binance:
-		getTickerFunc = binanceGetTicker
-		wsProcessor = processBinanceWsMessage
-	case bittrex:
-		getTickerFunc = bittrexGetTicker
-		wsProcessor = processBittrexWsMessage
+	getTickerFunc, wsProcessor, err := sourceFuncs(source)
+	if err != nil {
+		return nil, err
 	}
 
 	s := &CommonRateSource{
@@ -633,6 +624,7 @@ func NewCommonRateSource(ctx context.Context, source string) (*CommonRateSource,
 		wsProcessor:   wsProcessor,
 		rateListeners: make(map[string]*RateListener),
 		sourceChanged: make(chan *struct{}),
+		forex:         newForexRates(),
 	}
 	s.cond = sync.NewCond(&s.mtx)
 
@@ -648,6 +640,35 @@ func NewCommonRateSource(ctx context.Context, source string) (*CommonRateSource,
 	return s, nil
 }
 
+// sourceFuncs returns the ticker and websocket message processing functions
+// for the named rate source.
+func sourceFuncs(source string) (func(string) (*Ticker, error), WebsocketProcessor, error) {
+	wsProcessor := func([]byte) ([]*Ticker, error) { return nil, nil }
+	if source == none {
+		return dummyGetTickerFunc, wsProcessor, nil
+	}
+
+	provider, ok := rateProvider(source)
+	if !ok {
+		return nil, nil, fmt.Errorf("New rate source %s is not supported", source)
+	}
+
+	switch source {
+	case binance:
+		wsProcessor = processBinanceWsMessage
+	case bittrex:
+		wsProcessor = processBittrexWsMessage
+	}
+
+	return provider.GetTicker, wsProcessor, nil
+}
+
+// hasWebsocket returns true if the rate source streams tickers over a
+// websocket connection. Other rate sources are only refreshed via HTTP.
+func (cs *CommonRateSource) hasWebsocket() bool {
+	return cs.source == binance || cs.source == bittrex
+}
+
 func binanceGetTicker(market string) (*Ticker, error) {
 	market = strings.ReplaceAll(market, MktSep, "")
 	if _, ok := binanceMarkets[market]; !ok {
@@ -136,6 +136,39 @@ type (
 		PriceChangePercent float64 `json:"priceChangePercent,string"`
 	}
 
+	// KrakenTickerResponse models kraken specific ticker information from
+	// public/Ticker.
+	KrakenTickerResponse struct {
+		Error  []string `json:"error"`
+		Result map[string]struct {
+			// Close is an array of the last trade price and lot volume.
+			Close []string `json:"c"`
+			// Open is today's opening price.
+			Open string `json:"o"`
+		} `json:"result"`
+	}
+
+	// CoinbaseStatsResponse models coinbase specific 24h stats from
+	// products/{product}/stats.
+	CoinbaseStatsResponse struct {
+		Open float64 `json:"open,string"`
+		Last float64 `json:"last,string"`
+	}
+
+	// FileRate is a single market's rate information read from a local rates
+	// file.
+	FileRate struct {
+		Price     float64  `json:"price"`
+		Change    *float64 `json:"change,omitempty"`
+		Timestamp int64    `json:"timestamp,omitempty"`
+	}
+
+	// ForexRatesResponse models the response of the forex rates API.
+	ForexRatesResponse struct {
+		Result string             `json:"result"`
+		Rates  map[string]float64 `json:"rates"`
+	}
+
 	// KuCoinTicker models Kucoin's specific ticker information.
 	KuCoinTicker struct {
 		Code int `json:"code,string"`
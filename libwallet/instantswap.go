@@ -10,306 +10,623 @@ import (
 	"decred.org/dcrwallet/v3/errors"
 	api "github.com/crypto-power/instantswap/instantswap"
 
-	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
-	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
-	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
-	"github.com/crypto-power/instantswap/blockexplorer"
-	_ "github.com/crypto-power/instantswap/blockexplorer/btcexplorer" //nolint:revive
-	_ "github.com/crypto-power/instantswap/blockexplorer/dcrexplorer"
 )
 
 const (
-	// BTCBlockTime is the average time it takes to mine a block on the BTC network.
-	BTCBlockTime = 10 * time.Minute
-	// DCRBlockTime is the average time it takes to mine a block on the DCR network.
-	DCRBlockTime = 5 * time.Minute
-
 	// DefaultMarketDeviation is the maximum deviation the server rate
 	// can deviate from the market rate.
 	DefaultMarketDeviation = 5 // 5%
-	// DefaultConfirmations is the number of confirmations required
-	DefaultConfirmations = 1
 	// DefaultRateRequestAmount is the amount used to perform the rate request query.
 	DefaultRateRequestAmount = 1
+	// DefaultConditionPollInterval is how often the exchange server rate is
+	// checked by a conditional scheduler whose condition is not met.
+	DefaultConditionPollInterval = 5 * time.Minute
+	// DefaultPayoutCheckInterval is how often the payout of a scheduled order
+	// is checked if the block time of the payout asset is unknown.
+	DefaultPayoutCheckInterval = 5 * time.Minute
+	// DefaultPayoutTimeout is how long after its creation a scheduled order
+	// may wait for its payout or refund before the scheduler stops with an
+	// error.
+	DefaultPayoutTimeout = 48 * time.Hour
 )
 
-// StartScheduler starts the automatic order scheduler.
+// errSchedulerNeedsPassphrase is returned by an order scheduler that needs the
+// spending passphrase to send its next deposit.
+var errSchedulerNeedsPassphrase = errors.New("order scheduler needs the spending passphrase")
+
+// StartScheduler saves a new order scheduler job and runs it until it ends or
+// ctx is canceled. The job is resumed from its saved state when the app
+// restarts, see resumeSchedulers.
 func (mgr *AssetsManager) StartScheduler(ctx context.Context, params instantswap.SchedulerParams) error {
 	const op errors.Op = "mgr.StartScheduler"
-	log.Info("Order Scheduler: started")
 
 	log.Info("Order Scheduler: verifying source wallet")
-	sourceWallet := mgr.WalletWithID(params.Order.SourceWalletID)
-	if sourceWallet == nil {
+	if mgr.WalletWithID(params.Order.SourceWalletID) == nil {
 		return errors.E(op, errors.Errorf("wallet with id:%d not found", params.Order.SourceWalletID))
 	}
 
-	mgr.InstantSwap.CancelOrderSchedulerMu.RLock()
+	if params.AutoSelectServer {
+		params.Order.ExchangeServer = instantswap.ExchangeServer{}
+	}
 
-	if mgr.InstantSwap.CancelOrderScheduler != nil {
-		mgr.InstantSwap.CancelOrderSchedulerMu.RUnlock()
-		return errors.New("scheduler already running")
+	if params.MaxDeviationRate <= 0 {
+		params.MaxDeviationRate = DefaultMarketDeviation // default 5%
+	}
+
+	if cond := params.Condition; cond != nil {
+		if cond.MinRate <= 0 || cond.TotalAmount <= 0 {
+			return errors.E(op, "conditional order requires a positive min rate and total amount")
+		}
+		if cond.PollInterval <= 0 {
+			cond.PollInterval = DefaultConditionPollInterval
+		}
+		params.Order.LimitRate = cond.MinRate
+		log.Infof("Order Scheduler: swapping %f %s when the rate is at least %f %s",
+			cond.TotalAmount, params.Order.FromCurrency, cond.MinRate, params.Order.ToCurrency)
+	}
+
+	passphrase := params.SpendingPassphrase
+	params.SpendingPassphrase = ""
+	job := &instantswap.SchedulerJob{
+		Params:    params,
+		State:     instantswap.JobScheduled,
+		StartedAt: time.Now().Unix(),
+	}
+	if err := mgr.InstantSwap.SaveSchedulerJob(job); err != nil {
+		return errors.E(op, err)
+	}
+
+	log.Infof("Order Scheduler %d: started", job.ID)
+	return mgr.runSchedulerJob(ctx, job, passphrase)
+}
+
+// ResumeScheduler resumes the saved order scheduler job with the provided ID.
+// Jobs resumed without the spending passphrase when the app started pause
+// before sending a deposit and are resumed with this method.
+func (mgr *AssetsManager) ResumeScheduler(ctx context.Context, jobID int, passphrase string) error {
+	const op errors.Op = "mgr.ResumeScheduler"
+
+	job, err := mgr.InstantSwap.GetSchedulerJob(jobID)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if !job.IsActive() {
+		return errors.E(op, "order scheduler has ended")
+	}
+
+	job.NeedsPassphrase = false
+	log.Infof("Order Scheduler %d: resuming from %s", job.ID, job.State)
+	return mgr.runSchedulerJob(ctx, job, passphrase)
+}
+
+// resumeSchedulers resumes the active order scheduler jobs saved before the
+// app was closed. Their pending orders are monitored until they complete but
+// no deposit is sent until the job is resumed with the spending passphrase.
+func (mgr *AssetsManager) resumeSchedulers() {
+	jobs, err := mgr.InstantSwap.ActiveSchedulerJobs()
+	if err != nil {
+		log.Errorf("Error fetching order scheduler jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		if job.NeedsPassphrase {
+			continue
+		}
+
+		log.Infof("Order Scheduler %d: resuming from %s", job.ID, job.State)
+		go func(job *instantswap.SchedulerJob) {
+			_ = mgr.runSchedulerJob(context.Background(), job, "")
+		}(job)
+	}
+}
+
+// runSchedulerJob runs job until it ends, needs the spending passphrase or ctx
+// is canceled. The job is kept active if ctx is canceled.
+func (mgr *AssetsManager) runSchedulerJob(ctx context.Context, job *instantswap.SchedulerJob, passphrase string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := mgr.InstantSwap.AddRunningScheduler(job.ID, cancel); err != nil {
+		return err
+	}
+
+	if passphrase != "" {
+		mgr.schedulerKeysMu.Lock()
+		mgr.schedulerKeys[job.ID] = job.Params.Order.SourceWalletID
+		mgr.schedulerKeysMu.Unlock()
 	}
 
-	mgr.InstantSwap.SchedulerStartTime = time.Now()
-	mgr.InstantSwap.SchedulerCtx, mgr.InstantSwap.CancelOrderScheduler = context.WithCancel(ctx)
 	mgr.InstantSwap.PublishOrderSchedulerStarted()
 	defer func() {
-		mgr.InstantSwap.CancelOrderScheduler = nil
-		mgr.InstantSwap.SchedulerStartTime = time.Time{}
+		mgr.schedulerKeysMu.Lock()
+		delete(mgr.schedulerKeys, job.ID)
+		mgr.schedulerKeysMu.Unlock()
+		mgr.InstantSwap.RemoveRunningScheduler(job.ID)
 		mgr.InstantSwap.PublishOrderSchedulerEnded()
-		log.Info("Order Scheduler: exited")
+		log.Infof("Order Scheduler %d: exited", job.ID)
 	}()
-	mgr.InstantSwap.CancelOrderSchedulerMu.RUnlock()
 
-	// Initialize the exchange server.
-	log.Info("Order Scheduler: initializing exchange server")
-	exchangeObject, err := mgr.InstantSwap.NewExchangeServer(params.Order.ExchangeServer)
-	if err != nil {
-		return errors.E(op, err)
+	err := mgr.runScheduler(ctx, job, passphrase)
+	switch {
+	case ctx.Err() != nil:
+		// Stopped by the user or by shutdown. The saved state is kept.
+		return ctx.Err()
+	case errors.Is(err, errSchedulerNeedsPassphrase):
+		log.Infof("Order Scheduler %d: paused until the spending passphrase is provided", job.ID)
+		job.NeedsPassphrase = true
+	default:
+		if err != nil {
+			log.Errorf("Order Scheduler %d: %v", job.ID, err)
+			job.LastError = err.Error()
+		}
+		job.State = instantswap.JobEnded
+	}
+
+	if saveErr := mgr.InstantSwap.SaveRunningSchedulerJob(job); saveErr != nil {
+		log.Errorf("Order Scheduler %d: error saving job: %v", job.ID, saveErr)
+	}
+	return err
+}
+
+// runScheduler moves job through its states until it ends. The state is saved
+// after every step so the job can be resumed.
+func (mgr *AssetsManager) runScheduler(ctx context.Context, job *instantswap.SchedulerJob, passphrase string) error {
+	sourceWallet := mgr.WalletWithID(job.Params.Order.SourceWalletID)
+	if sourceWallet == nil {
+		return errors.Errorf("wallet with id:%d not found", job.Params.Order.SourceWalletID)
 	}
 
-	var lastOrderTime time.Time
 	for {
 		// Check if scheduler has been shutdown and exit if true.
-		if mgr.InstantSwap.SchedulerCtx.Err() != nil {
-			return mgr.InstantSwap.SchedulerCtx.Err()
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
 
-		sourceAccountBalance, err := sourceWallet.GetAccountBalance(params.Order.SourceAccountNumber)
+		var err error
+		switch job.State {
+		case instantswap.JobVerified, instantswap.JobRefunded:
+			err = mgr.waitForNextOrder(ctx, job)
+		case instantswap.JobScheduled:
+			if passphrase == "" {
+				return errSchedulerNeedsPassphrase
+			}
+			var done bool
+			done, err = mgr.createScheduledOrder(ctx, job, sourceWallet)
+			if done && err == nil {
+				return nil
+			}
+		case instantswap.JobAwaitingDeposit:
+			err = mgr.sendScheduledDeposit(job, sourceWallet, passphrase)
+		case instantswap.JobDepositSent, instantswap.JobAwaitingPayout:
+			err = mgr.waitForScheduledPayout(ctx, job)
+		default:
+			err = errors.Errorf("unknown order scheduler state %q", job.State)
+		}
 		if err != nil {
-			log.Error("unable to get account balance")
-			return errors.E(op, err)
+			return err
 		}
+	}
+}
 
-		if sourceAccountBalance.Spendable.ToCoin() <= params.BalanceToMaintain {
-			log.Error("source wallet balance is less than or equals the set balance to maintain")
-			return errors.E(op, "source wallet balance is less than or equals the set balance to maintain") // stop scheduling if the source wallet balance is less than or equals the set balance to maintain
+// setSchedulerJobState saves job with the provided state.
+func (mgr *AssetsManager) setSchedulerJobState(job *instantswap.SchedulerJob, state instantswap.JobState) error {
+	job.State = state
+	return mgr.InstantSwap.SaveRunningSchedulerJob(job)
+}
+
+// waitForNextOrder waits until the next order of job is due. Conditional jobs
+// create their next order immediately.
+func (mgr *AssetsManager) waitForNextOrder(ctx context.Context, job *instantswap.SchedulerJob) error {
+	if cond := job.Params.Condition; cond != nil {
+		log.Infof("Order Scheduler %d: swapped %f of %f %s", job.ID, job.SwappedAmount,
+			cond.TotalAmount, job.Params.Order.FromCurrency)
+		return mgr.setSchedulerJobState(job, instantswap.JobScheduled)
+	}
+
+	log.Infof("Order Scheduler %d: creating next order based on selected frequency", job.ID)
+
+	// calculate time until the next order
+	nextOrderTime := time.Unix(job.LastOrderAt, 0).Add(job.Params.Frequency)
+	timeUntilNextOrder := time.Until(nextOrderTime)
+	if timeUntilNextOrder <= 0 {
+		log.Infof("Order Scheduler %d: the frequency has elapsed, starting next order immediately", job.ID)
+	} else {
+		log.Infof("Order Scheduler %d: %s until the next order is executed", job.ID, timeUntilNextOrder.Round(time.Second))
+		if err := waitOrCancel(ctx, timeUntilNextOrder); err != nil {
+			return err
 		}
+	}
+
+	return mgr.setSchedulerJobState(job, instantswap.JobScheduled)
+}
+
+// createScheduledOrder creates the next order of job if the scheduler's
+// requirements are met. done is true if the job has completed.
+func (mgr *AssetsManager) createScheduledOrder(ctx context.Context, job *instantswap.SchedulerJob, sourceWallet sharedW.Asset) (done bool, err error) {
+	params := &job.Params
+	cond := params.Condition
+
+	if cond != nil && !cond.Deadline.IsZero() && time.Now().After(cond.Deadline) {
+		log.Infof("Order Scheduler %d: deadline passed, swapped %f of %f %s",
+			job.ID, job.SwappedAmount, cond.TotalAmount, params.Order.FromCurrency)
+		return true, nil
+	}
 
-		fromCur := params.Order.FromCurrency
-		toCur := params.Order.ToCurrency
-		rateRequestParams := api.ExchangeRateRequest{
+	sourceAccountBalance, err := sourceWallet.GetAccountBalance(params.Order.SourceAccountNumber)
+	if err != nil {
+		log.Error("unable to get account balance")
+		return false, err
+	}
+
+	if sourceAccountBalance.Spendable.ToCoin() <= params.BalanceToMaintain {
+		// stop scheduling if the source wallet balance is less than or equals the set balance to maintain
+		return false, errors.New("source wallet balance is less than or equals the set balance to maintain")
+	}
+
+	fromCur := params.Order.FromCurrency
+	toCur := params.Order.ToCurrency
+
+	// Initialize the exchange server. The server with the best quote is
+	// selected before every order if AutoSelectServer is set.
+	if params.AutoSelectServer {
+		log.Infof("Order Scheduler %d: selecting the exchange server with the best quote", job.ID)
+		quote, err := mgr.InstantSwap.BestQuote(ctx, instantswap.QuoteRequest{
 			From:   fromCur,
 			To:     toCur,
-			Amount: DefaultRateRequestAmount, // amount needs to be greater than 0 to get the exchange rate
-		}
-		log.Info("Order Scheduler: getting exchange rate info")
-		res, err := mgr.InstantSwap.GetExchangeRateInfo(exchangeObject, rateRequestParams)
+			Amount: DefaultRateRequestAmount,
+		})
 		if err != nil {
-			log.Error("unable to get exchange server rate info")
-			return errors.E(op, err)
+			return false, err
 		}
+		params.Order.ExchangeServer = quote.ExchangeServer
+		log.Infof("Order Scheduler %d: using %s", job.ID, quote.ExchangeServer.Server)
+	}
 
-		if params.MaxDeviationRate <= 0 {
-			params.MaxDeviationRate = DefaultMarketDeviation // default 5%
-		}
+	log.Infof("Order Scheduler %d: initializing exchange server", job.ID)
+	exchangeObject, err := mgr.InstantSwap.NewExchangeServer(params.Order.ExchangeServer)
+	if err != nil {
+		return false, err
+	}
 
-		market := fromCur + "-" + toCur
-		source := mgr.RateSource.Name()
-		ticker := mgr.RateSource.GetTicker(market) // TODO: (@ukane-philemon) Can we proceed if there is no rate information from rate source? I think we should.
-		if ticker == nil {
-			return errors.E(op, fmt.Errorf("unable to get market rate from %s", source))
-		}
+	rateRequestParams := api.ExchangeRateRequest{
+		From:   fromCur,
+		To:     toCur,
+		Amount: DefaultRateRequestAmount, // amount needs to be greater than 0 to get the exchange rate
+	}
+	log.Infof("Order Scheduler %d: getting exchange rate info", job.ID)
+	res, err := mgr.InstantSwap.GetExchangeRateInfo(exchangeObject, rateRequestParams)
+	if err != nil {
+		log.Error("unable to get exchange server rate info")
+		return false, err
+	}
 
-		exchangeServerRate := res.EstimatedAmount // estimated receivable value for libwallet.DefaultRateRequestAmount (1)
-		rateSourceRate := ticker.LastTradePrice
-		// Current rate source supported Binance and Bittrex always returns
-		// ticker.LastTradePrice in's the quote asset unit e.g DCR-BTC, LTC-BTC.
-		// We will also do this when and if USDT is supported.
-		if strings.EqualFold(fromCur, "btc") {
-			rateSourceRate = 1 / ticker.LastTradePrice
-		}
+	if cond != nil && res.EstimatedAmount < cond.MinRate {
+		log.Infof("Order Scheduler %d: server rate %f is below the limit rate %f, checking again in %s",
+			job.ID, res.EstimatedAmount, cond.MinRate, cond.PollInterval)
+		return false, waitOrCancel(ctx, cond.PollInterval)
+	}
 
-		serverRateStr := values.StringF(values.StrServerRate, params.Order.ExchangeServer.Server, fromCur, exchangeServerRate, toCur)
-		log.Info(serverRateStr)
-		binanceRateStr := values.StringF(values.StrCurrencyConverterRate, source, fromCur, rateSourceRate, toCur)
-		log.Info(binanceRateStr)
-
-		// check if the server rate deviates from the market rate by ± 5%
-		// exit if true
-		percentageDiff := math.Abs((exchangeServerRate-rateSourceRate)/((exchangeServerRate+rateSourceRate)/2)) * 100
-		if percentageDiff > params.MaxDeviationRate {
-			log.Error("exchange rate deviates from the market rate by more than 5%")
-			return errors.E(op, "exchange rate deviates from the market rate by more than 5%")
-		}
+	market := fromCur + "-" + toCur
+	source := mgr.RateSource.Name()
+	ticker := mgr.RateSource.GetTicker(market)
+	if ticker == nil {
+		return false, fmt.Errorf("unable to get market rate from %s", source)
+	}
 
-		// set the max send amount to the max limit set by the server
-		invoicedAmount := res.Min
+	exchangeServerRate := res.EstimatedAmount // estimated receivable value for libwallet.DefaultRateRequestAmount (1)
+	rateSourceRate := ticker.LastTradePrice
+	// Current rate source supported Binance and Bittrex always returns
+	// ticker.LastTradePrice in's the quote asset unit e.g DCR-BTC, LTC-BTC.
+	// We will also do this when and if USDT is supported.
+	if strings.EqualFold(fromCur, "btc") {
+		rateSourceRate = 1 / ticker.LastTradePrice
+	}
+
+	serverRateStr := values.StringF(values.StrServerRate, params.Order.ExchangeServer.Server, fromCur, exchangeServerRate, toCur)
+	log.Info(serverRateStr)
+	binanceRateStr := values.StringF(values.StrCurrencyConverterRate, source, fromCur, rateSourceRate, toCur)
+	log.Info(binanceRateStr)
 
-		// if the max send limit is 0, then the server does not have a max limit constraint
-		// so we can send the entire source wallet balance
-		// if res.Max == 0 {
-		// 	invoicedAmount = sourceAccountBalance.Spendable.ToCoin() - params.BalanceToMaintain // deduct the balance to maintain from the source wallet balance
-		// }
+	// check if the server rate deviates from the market rate by ± 5%
+	// exit if true
+	percentageDiff := math.Abs((exchangeServerRate-rateSourceRate)/((exchangeServerRate+rateSourceRate)/2)) * 100
+	if percentageDiff > params.MaxDeviationRate {
+		return false, errors.New("exchange rate deviates from the market rate by more than 5%")
+	}
 
-		log.Info("Order Scheduler: check balance after exchange")
-		estimatedBalanceAfterExchange := sourceAccountBalance.Spendable.ToCoin() - invoicedAmount
-		if estimatedBalanceAfterExchange < params.BalanceToMaintain {
-			log.Error("source wallet balance after the exchange would be less than the set balance to maintain")
-			return errors.E(op, "source wallet balance after the exchange would be less than the set balance to maintain") // stop scheduling if the source wallet balance after the exchange would be less than the set balance to maintain
+	// set the max send amount to the max limit set by the server
+	invoicedAmount := res.Min
+	if cond != nil {
+		remaining := cond.TotalAmount - job.SwappedAmount
+		available := sourceAccountBalance.Spendable.ToCoin() - params.BalanceToMaintain
+		invoicedAmount = conditionalOrderAmount(remaining, available, res.Max)
+		if invoicedAmount < res.Min {
+			if remaining < res.Min {
+				log.Infof("Order Scheduler %d: the remaining %f %s is below the server minimum, swapped %f of %f",
+					job.ID, remaining, fromCur, job.SwappedAmount, cond.TotalAmount)
+				return true, nil
+			}
+			return false, errors.New("source wallet spendable balance is below the server minimum order amount")
 		}
+	}
 
-		log.Info("Order Scheduler: creating order")
-		params.Order.InvoicedAmount = invoicedAmount
-		order, err := mgr.InstantSwap.CreateOrder(exchangeObject, params.Order)
-		if err != nil {
-			log.Error("error creating order: ", err.Error())
-			return errors.E(op, err)
+	log.Infof("Order Scheduler %d: check balance after exchange", job.ID)
+	estimatedBalanceAfterExchange := sourceAccountBalance.Spendable.ToCoin() - invoicedAmount
+	if estimatedBalanceAfterExchange < params.BalanceToMaintain {
+		// stop scheduling if the source wallet balance after the exchange would be less than the set balance to maintain
+		return false, errors.New("source wallet balance after the exchange would be less than the set balance to maintain")
+	}
+
+	log.Infof("Order Scheduler %d: creating order", job.ID)
+	params.Order.InvoicedAmount = invoicedAmount
+	order, err := mgr.InstantSwap.CreateOrder(exchangeObject, params.Order)
+	if err != nil {
+		log.Error("error creating order: ", err.Error())
+		return false, err
+	}
+
+	job.OrderUUID = order.UUID
+	job.DepositAmount = invoicedAmount
+	job.DepositTxID = ""
+	job.PayoutCurrency = toCur
+	job.LastOrderAt = time.Now().Unix()
+	job.OrderCount++
+	return false, mgr.setSchedulerJobState(job, instantswap.JobAwaitingDeposit)
+}
+
+// sendScheduledDeposit sends the deposit of the current order of job. The
+// hash of the signed deposit is saved on the job before it is broadcast, so a
+// deposit that is found in the source wallet when the job resumes, e.g. after
+// the app restarted, is not sent again.
+func (mgr *AssetsManager) sendScheduledDeposit(job *instantswap.SchedulerJob, sourceWallet sharedW.Asset, passphrase string) error {
+	if job.DepositTxID != "" {
+		// The wallet saves its transactions when they are broadcast, so a
+		// saved deposit that it doesn't have was not broadcast.
+		tx, err := sourceWallet.GetTransactionRaw(job.DepositTxID)
+		if err == nil && tx != nil {
+			log.Infof("Order Scheduler %d: deposit %s was already sent", job.ID, job.DepositTxID)
+			return mgr.setSchedulerJobState(job, instantswap.JobDepositSent)
 		}
-		lastOrderTime = time.Now()
+		log.Infof("Order Scheduler %d: deposit %s was not broadcast, sending it again", job.ID, job.DepositTxID)
+	}
+
+	if passphrase == "" {
+		return errSchedulerNeedsPassphrase
+	}
 
-		log.Info("Order Scheduler: creating unsigned transaction")
-		// construct the transaction to send the invoiced amount to the exchange server
+	order, err := mgr.InstantSwap.GetOrderByUUIDRaw(job.OrderUUID)
+	if err != nil {
+		return err
+	}
 
-		err = sourceWallet.NewUnsignedTx(params.Order.SourceAccountNumber, nil)
-		if err != nil {
-			return errors.E(op, err)
+	// The wallets' unsigned tx is shared by all the scheduler jobs.
+	mgr.schedulerDepositMu.Lock()
+	defer mgr.schedulerDepositMu.Unlock()
+
+	log.Infof("Order Scheduler %d: creating unsigned transaction", job.ID)
+	// construct the transaction to send the invoiced amount to the exchange server
+	err = sourceWallet.NewUnsignedTx(job.Params.Order.SourceAccountNumber, nil)
+	if err != nil {
+		return err
+	}
+
+	amount := coinToAtoms(sourceWallet, job.DepositAmount)
+	log.Infof("Order Scheduler %d: adding send destination, address: %s, amount: %d", job.ID, order.DepositAddress, amount)
+	err = sourceWallet.AddSendDestination(order.DepositAddress, amount, false)
+	if err != nil {
+		log.Error("error adding send destination: ", err.Error())
+		return err
+	}
+
+	log.Infof("Order Scheduler %d: broadcasting tx", job.ID)
+	_, err = sourceWallet.SignAndBroadcast(passphrase, "", func(txHash string) error {
+		job.DepositTxID = txHash
+		return mgr.InstantSwap.SaveRunningSchedulerJob(job)
+	})
+	if err != nil {
+		log.Error("error broadcasting tx: ", err.Error())
+		return err
+	}
+
+	return mgr.setSchedulerJobState(job, instantswap.JobDepositSent)
+}
+
+// waitForScheduledPayout waits for the current order of job to be paid out
+// or refunded. The payout is verified in the wallet that receives it, which is
+// checked on its new transactions and blocks or after a block time.
+func (mgr *AssetsManager) waitForScheduledPayout(ctx context.Context, job *instantswap.SchedulerJob) error {
+	params := &job.Params
+	exchangeObject, err := mgr.InstantSwap.NewExchangeServer(params.Order.ExchangeServer)
+	if err != nil {
+		return err
+	}
+
+	activityCh := make(chan struct{}, 1)
+	listenerID := fmt.Sprintf("order_scheduler_%d", job.ID)
+	listener := &walletActivityListener{onActivity: func() {
+		select {
+		case activityCh <- struct{}{}:
+		default:
 		}
+	}}
+	for _, walletID := range []int{params.Order.SourceWalletID, params.Order.DestinationWalletID} {
+		wallet := mgr.WalletWithID(walletID)
+		if wallet == nil {
+			continue
+		}
+		if err := wallet.AddTxAndBlockNotificationListener(listener, true, listenerID); err != nil {
+			log.Errorf("Order Scheduler %d: error watching %s: %v", job.ID, wallet.GetWalletName(), err)
+			continue
+		}
+		defer wallet.RemoveTxAndBlockNotificationListener(listenerID)
+	}
 
-		var amount int64
-		switch sourceWallet.GetAssetType() {
-		case utils.BTCWalletAsset:
-			amount = btc.AmountSatoshi(params.Order.InvoicedAmount)
-		case utils.DCRWalletAsset:
-			amount = dcr.AmountAtom(params.Order.InvoicedAmount)
+	// wait for the order to be completed before scheduling the next order
+	for {
+		// depending on the block time for the asset, the order may take a while to complete
+		// so we wait for the estimated block time or wallet activity before checking the order status
+		blockTime := mgr.payoutBlockTime(job)
+		log.Infof("Order Scheduler %d: waiting up to %s for the %s payout", job.ID, blockTime, job.PayoutCurrency)
+		select {
+		case <-activityCh:
+		case <-time.After(blockTime):
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 
-		log.Infof("Order Scheduler: adding send destination, address: %s, amount: %d", order.DepositAddress, amount)
-		err = sourceWallet.AddSendDestination(order.DepositAddress, amount, false)
-		if err != nil {
-			log.Error("error adding send destination: ", err.Error())
-			return errors.E(op, err)
+		if time.Since(time.Unix(job.LastOrderAt, 0)) > DefaultPayoutTimeout {
+			return errors.Errorf("order %s was not paid out or refunded within %s", job.OrderUUID, DefaultPayoutTimeout)
 		}
 
-		log.Info("Order Scheduler: broadcasting tx")
-		_, err = sourceWallet.Broadcast(params.SpendingPassphrase, "")
+		log.Infof("Order Scheduler %d: get order info", job.ID)
+		orderInfo, err := mgr.InstantSwap.GetOrderInfo(exchangeObject, job.OrderUUID)
 		if err != nil {
-			log.Error("error broadcasting tx: ", err.Error())
-			return errors.E(op, err)
+			return err
 		}
 
-		// wait for the order to be completed before scheduling the next order
-		var isRefunded bool
-		for {
-			// Check if scheduler has been shutdown and exit if true.
-			if mgr.InstantSwap.SchedulerCtx.Err() != nil {
-				return mgr.InstantSwap.SchedulerCtx.Err()
+		if job.State == instantswap.JobDepositSent && orderInfo.Status != api.OrderStatusNew &&
+			orderInfo.Status != api.OrderStatusWaitingForDeposit {
+			log.Infof("Order Scheduler %d: deposit received by the exchange server", job.ID)
+			if err := mgr.setSchedulerJobState(job, instantswap.JobAwaitingPayout); err != nil {
+				return err
 			}
+		}
 
-			// depending on the block time for the asset, the order may take a while to complete
-			// so we wait for the estimated block time before checking the order status
-			switch params.Order.ToCurrency {
-			case utils.BTCWalletAsset.String():
-				log.Info("Order Scheduler: waiting for btc block time (10 minutes)")
-				time.Sleep(BTCBlockTime)
-			case utils.DCRWalletAsset.String():
-				log.Info("Order Scheduler: waiting for dcr block time (5 minutes)")
-				time.Sleep(DCRBlockTime)
+		if orderInfo.Status == api.OrderStatusRefunded {
+			// The refund is paid out in the source currency.
+			job.PayoutCurrency = params.Order.FromCurrency
+			sourceWallet := mgr.WalletWithID(params.Order.SourceWalletID)
+			if sourceWallet == nil {
+				return errors.Errorf("wallet with id:%d not found", params.Order.SourceWalletID)
 			}
 
-			log.Info("Order Scheduler: get newly created order info")
-			orderInfo, err := mgr.InstantSwap.GetOrderInfo(exchangeObject, order.UUID)
+			log.Infof("Order Scheduler %d: order was refunded, verifying the refund", job.ID)
+			_, ok, err := findRefundTx(sourceWallet, orderInfo, job.DepositAmount)
 			if err != nil {
-				return errors.E(op, err)
+				return err
+			}
+			if !ok {
+				log.Infof("Order Scheduler %d: refund is not confirmed, checking again", job.ID)
+				continue
 			}
 
-			if orderInfo.Status == api.OrderStatusRefunded {
-				log.Info("order was refunded. verifying that the order was refunded successfully from the blockchain explorer")
-				tmpFromCurrency := params.Order.FromCurrency
-				tmpToCurrency := params.Order.ToCurrency
-
-				// swap ToCurrency and FromCurrency
-				params.Order.ToCurrency = tmpFromCurrency
-				params.Order.FromCurrency = tmpToCurrency
+			log.Infof("Order Scheduler %d: order was refunded successfully", job.ID)
+			return mgr.setSchedulerJobState(job, instantswap.JobRefunded)
+		}
 
-				orderInfo.ReceiveAmount = params.Order.InvoicedAmount
+		verified, err := mgr.VerifyOrderPayout(orderInfo)
+		if err != nil {
+			return err
+		}
+		if !verified {
+			log.Infof("Order Scheduler %d: order is not completed, checking again", job.ID)
+			continue // order is not completed, check again
+		}
 
-				isRefunded = true
-			}
+		log.Infof("Order Scheduler %d: order was completed successfully", job.ID)
+		job.SwappedAmount += job.DepositAmount
+		return mgr.setSchedulerJobState(job, instantswap.JobVerified)
+	}
+}
 
-			log.Info("Order Scheduler: instantiate block explorer")
-			// verify that the order was completed successfully from the blockchain explorer
-			config := blockexplorer.Config{
-				EnableOutput: false,
-				Symbol:       params.Order.ToCurrency,
-			}
-			explorer, err := blockexplorer.NewExplorer(config) // TODO: Confirm if this still works as intended
-			if err != nil {
-				log.Error("error instantiating block explorer: ", err.Error())
-				return errors.E(op, err)
-			}
+// payoutBlockTime returns the block time of the asset the current order of
+// job pays out in.
+func (mgr *AssetsManager) payoutBlockTime(job *instantswap.SchedulerJob) time.Duration {
+	walletID := job.Params.Order.DestinationWalletID
+	if job.PayoutCurrency == job.Params.Order.FromCurrency {
+		walletID = job.Params.Order.SourceWalletID
+	}
 
-			verificationInfo := blockexplorer.TxVerifyRequest{
-				TxId:      orderInfo.TxID,
-				Amount:    orderInfo.ReceiveAmount,
-				CreatedAt: orderInfo.CreatedAt,
-				Address:   orderInfo.DestinationAddress,
-				Confirms:  DefaultConfirmations,
-			}
+	if wallet := mgr.WalletWithID(walletID); wallet != nil {
+		return time.Duration(wallet.TargetTimePerBlockMinutes() * float64(time.Minute))
+	}
+	return DefaultPayoutCheckInterval
+}
 
-			log.Infof("Order Scheduler: verifying transaction with ID: %s", orderInfo.TxID)
-			verification, err := explorer.VerifyTransaction(verificationInfo)
-			if err != nil {
-				log.Error("error verifying transaction: ", err.Error())
-				return errors.E(op, err)
-			}
+// coinToAtoms converts an amount in coins to the smallest unit of wallet's
+// asset.
+func coinToAtoms(wallet sharedW.Asset, coins float64) int64 {
+	return int64(math.Round(coins / wallet.ToAmount(1).ToCoin()))
+}
 
-			if verification.Verified {
-				if verification.BlockExplorerAmount.ToCoin() != orderInfo.ReceiveAmount {
-					log.Infof("received amount: %f", verification.BlockExplorerAmount.ToCoin())
-					log.Infof("expected amount: %f", orderInfo.ReceiveAmount)
-					log.Error("received amount does not match the expected amount")
-					return errors.E(op, err)
-				}
-
-				if isRefunded {
-					log.Info("order was refunded successfully")
-				} else {
-					log.Info("order was completed successfully")
-				}
-
-				break // order is completed, break out of the loop
-			}
+// conditionalOrderAmount returns the amount of the next order of a conditional
+// scheduler. It is the remaining amount limited by the available balance and
+// the server's max order amount. A zero serverMax is unlimited.
+func conditionalOrderAmount(remaining, available, serverMax float64) float64 {
+	amount := math.Min(remaining, available)
+	if serverMax > 0 {
+		amount = math.Min(amount, serverMax)
+	}
+	return amount
+}
 
-			log.Info("Order Scheduler: order is not completed, checking again")
-			continue // order is not completed, check again
-		}
+// waitOrCancel waits for d to elapse. ctx's error is returned if it is
+// canceled first.
+func waitOrCancel(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
-		log.Info("Order Scheduler: creating next order based on selected frequency")
+// StopScheduler ends all the order schedulers.
+func (mgr *AssetsManager) StopScheduler() {
+	jobs, err := mgr.InstantSwap.ActiveSchedulerJobs()
+	if err != nil {
+		log.Errorf("Error fetching order scheduler jobs: %v", err)
+		return
+	}
 
-		// calculate time until the next order
-		timeUntilNextOrder := params.Frequency - time.Since(lastOrderTime)
-		if timeUntilNextOrder <= 0 {
-			log.Info("Order Scheduler: the scheduler start time is equal to or greater than the frequency, starting next order immediately")
-		} else {
-			log.Infof("Order Scheduler: %s until the next order is executed", timeUntilNextOrder)
-			time.Sleep(timeUntilNextOrder)
+	for _, job := range jobs {
+		if err := mgr.StopSchedulerJob(job.ID); err != nil {
+			log.Errorf("Error stopping order scheduler %d: %v", job.ID, err)
 		}
 	}
 }
 
-// StopScheduler stops the order scheduler.
-func (mgr *AssetsManager) StopScheduler() {
-	mgr.InstantSwap.CancelOrderSchedulerMu.RLock()
-	if mgr.InstantSwap.CancelOrderScheduler != nil {
-		mgr.InstantSwap.CancelOrderScheduler()
-		mgr.InstantSwap.CancelOrderScheduler = nil
+// StopSchedulerJob ends the order scheduler job with the provided ID.
+func (mgr *AssetsManager) StopSchedulerJob(jobID int) error {
+	if err := mgr.InstantSwap.EndSchedulerJob(jobID); err != nil {
+		return err
 	}
-	mgr.InstantSwap.CancelOrderSchedulerMu.RUnlock()
-	log.Info("Order Scheduler: stopped")
+	log.Infof("Order Scheduler %d: stopped", jobID)
+	return nil
 }
 
-// IsOrderSchedulerRunning returns true if the order scheduler is running.
+// IsOrderSchedulerRunning returns true if an order scheduler is running.
 func (mgr *AssetsManager) IsOrderSchedulerRunning() bool {
-	mgr.InstantSwap.CancelOrderSchedulerMu.RLock()
-	defer mgr.InstantSwap.CancelOrderSchedulerMu.RUnlock()
-	return mgr.InstantSwap.CancelOrderScheduler != nil
+	return mgr.InstantSwap.RunningSchedulers() > 0
 }
 
-// GetShedulerRuntime returns the duration the order scheduler has been running.
+// GetShedulerRuntime returns the duration the longest running order scheduler
+// has been running.
 func (mgr *AssetsManager) GetShedulerRuntime() string {
-	return time.Since(mgr.InstantSwap.SchedulerStartTime).Round(time.Second).String()
+	jobs, err := mgr.InstantSwap.ActiveSchedulerJobs()
+	if err != nil {
+		log.Errorf("Error fetching order scheduler jobs: %v", err)
+	}
+
+	var startedAt int64
+	for _, job := range jobs {
+		if !mgr.InstantSwap.IsSchedulerRunning(job.ID) {
+			continue
+		}
+		if startedAt == 0 || job.StartedAt < startedAt {
+			startedAt = job.StartedAt
+		}
+	}
+
+	if startedAt == 0 {
+		return time.Duration(0).String()
+	}
+	return time.Since(time.Unix(startedAt, 0)).Round(time.Second).String()
 }
@@ -3,4 +3,6 @@ package instantswap
 const (
 	ErrSyncAlreadyInProgress = "sync_already_in_progress"
 	ErrListenerAlreadyExist  = "listener_already_exist"
+	ErrNoUsableQuote         = "no_usable_quote"
+	ErrSchedulerNotFound     = "scheduler_not_found"
 )
@@ -1,6 +1,7 @@
 package instantswap
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -34,10 +35,22 @@ func NewInstantSwap(db *storm.DB) (*InstantSwap, error) {
 		return nil, err
 	}
 
+	if err := db.Init(&ServerStatus{}); err != nil {
+		log.Errorf("Error initializing exchange server status database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&SchedulerJob{}); err != nil {
+		log.Errorf("Error initializing order scheduler database: %s", err.Error())
+		return nil, err
+	}
+
 	return &InstantSwap{
 		db: db,
 		mu: &sync.RWMutex{},
 
+		schedulers: make(map[int]context.CancelFunc),
+
 		notificationListenersMu: &sync.RWMutex{},
 
 		notificationListeners: make(map[string]OrderNotificationListener),
@@ -63,6 +76,22 @@ func (instantSwap *InstantSwap) saveOrder(order *Order) error {
 	return instantSwap.db.Save(order)
 }
 
+// ImportOrder saves an order restored from a backup, unless an order with the
+// same UUID is already saved. It returns true if the order was saved.
+func (instantSwap *InstantSwap) ImportOrder(order *Order) (bool, error) {
+	var existing Order
+	err := instantSwap.db.One("UUID", order.UUID, &existing)
+	if err == nil {
+		return false, nil
+	}
+	if err != storm.ErrNotFound {
+		return false, errors.Errorf("error checking if order was already saved: %s", err.Error())
+	}
+
+	order.ID = 0 // Get a new ID.
+	return true, instantSwap.saveOrder(order)
+}
+
 // UpdateOrder updates an order in the database.
 func (instantSwap *InstantSwap) UpdateOrder(order *Order) error {
 	return instantSwap.updateOrder(order)
@@ -179,10 +208,11 @@ func (instantSwap *InstantSwap) CreateOrder(exchangeObject instantswap.IDExchang
 		ToCurrency:     res.ToCurrency,
 
 		DepositAddress:     res.DepositAddress,
-		RefundAddress:      res.DepositAddress,
+		RefundAddress:      params.RefundAddress,
 		DestinationAddress: res.Destination,
 		ExchangeRate:       res.ExchangeRate,
 		ChargedFee:         res.ChargedFee,
+		LimitRate:          params.LimitRate,
 		ExpiryTime:         res.Expires,
 		Status:             instantswap.OrderStatusWaitingForDeposit,
 		CreatedAt:          time.Now().Unix(),
@@ -211,7 +241,11 @@ func (instantSwap *InstantSwap) GetOrderInfo(exchangeObject instantswap.IDExchan
 
 	order.TxID = res.TxID
 	order.ReceiveAmount = res.ReceiveAmount
-	order.Status = res.InternalStatus
+	// Orders whose payout was verified in the destination wallet remain
+	// completed regardless of the server's status.
+	if order.PayoutTxID == "" {
+		order.Status = res.InternalStatus
+	}
 	order.ExpiryTime = res.Expires
 	order.Confirmations = res.Confirmations
 	order.LastUpdate = res.LastUpdate
@@ -0,0 +1,242 @@
+package instantswap
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/instantswap/instantswap"
+)
+
+var (
+	// QuoteTimeout is the maximum time spent waiting for the exchange servers
+	// to return their quotes.
+	QuoteTimeout = 30 * time.Second
+
+	// blockTimes are the average block times used to estimate how long an
+	// order takes to complete.
+	blockTimes = map[string]time.Duration{
+		"BTC": 10 * time.Minute,
+		"DCR": 5 * time.Minute,
+		"LTC": 150 * time.Second,
+	}
+)
+
+// QuoteRequest is a request for the amount of To received for Amount of From.
+type QuoteRequest struct {
+	From   string
+	To     string
+	Amount float64
+}
+
+// Quote is a normalized offer from an exchange server.
+type Quote struct {
+	ExchangeServer ExchangeServer
+	QuoteRequest
+
+	// EstimatedReceive is the amount of To received for the requested amount.
+	EstimatedReceive float64
+	// Rate is the amount of To received for 1 From after fees.
+	Rate float64
+	// Fee is the difference in To between the server's advertised exchange
+	// rate and the estimated receive amount. It is zero if the server does
+	// not advertise a rate.
+	Fee float64
+	// Min and Max are the order amount limits of the server in From. A zero
+	// Max is unlimited.
+	Min float64
+	Max float64
+	// ETA is the estimated time to complete an order, based on the block
+	// times of both assets.
+	ETA time.Duration
+
+	// Err is set if the server failed to return a quote.
+	Err error
+}
+
+// InRange returns true if the requested amount is within the server's order
+// limits.
+func (q *Quote) InRange() bool {
+	return q.Amount >= q.Min && (q.Max <= 0 || q.Amount <= q.Max)
+}
+
+// Usable returns true if an order can be created from the quote.
+func (q *Quote) Usable() bool {
+	return q.Err == nil && q.EstimatedReceive > 0 && q.InRange()
+}
+
+// ServerStatus records the outcome of the latest quote requests made to an
+// exchange server.
+type ServerStatus struct {
+	Server         Server `storm:"id" json:"server"`
+	Failures       int    `json:"failures"` // Consecutive failures.
+	LastError      string `json:"lastError"`
+	LastFailureAt  int64  `json:"lastFailureAt"`
+	LastSuccessAt  int64  `json:"lastSuccessAt"`
+	TotalFailures  int    `json:"totalFailures"`
+	TotalSuccesses int    `json:"totalSuccesses"`
+}
+
+// estimateETA estimates how long an order from -> to takes to complete. The
+// deposit and the payout each need a block.
+func estimateETA(from, to string) time.Duration {
+	return blockTimes[strings.ToUpper(from)] + blockTimes[strings.ToUpper(to)]
+}
+
+// normalizeQuote converts the exchange rate info returned by a server to a
+// Quote.
+func normalizeQuote(server ExchangeServer, req QuoteRequest, res *instantswap.ExchangeRateInfo) *Quote {
+	q := &Quote{
+		ExchangeServer:   server,
+		QuoteRequest:     req,
+		EstimatedReceive: res.EstimatedAmount,
+		Min:              res.Min,
+		Max:              res.Max,
+		ETA:              estimateETA(req.From, req.To),
+	}
+	if req.Amount > 0 {
+		q.Rate = res.EstimatedAmount / req.Amount
+	}
+	if res.ExchangeRate > 0 {
+		if fee := req.Amount*res.ExchangeRate - res.EstimatedAmount; fee > 0 {
+			q.Fee = fee
+		}
+	}
+	return q
+}
+
+// RankQuotes orders quotes from the best to the worst offer. Usable quotes
+// come first, ordered by the estimated receive amount and then by ETA.
+func RankQuotes(quotes []*Quote) {
+	sort.SliceStable(quotes, func(i, j int) bool {
+		qi, qj := quotes[i], quotes[j]
+		if qi.Usable() != qj.Usable() {
+			return qi.Usable()
+		}
+		if qi.EstimatedReceive != qj.EstimatedReceive {
+			return qi.EstimatedReceive > qj.EstimatedReceive
+		}
+		return qi.ETA < qj.ETA
+	})
+}
+
+// GetQuotes requests a quote from each of servers concurrently and returns
+// them ranked by RankQuotes. The enabled exchange servers are used if none
+// is provided. Servers that fail or don't respond within QuoteTimeout have
+// their quote's Err set and the failure is recorded, see ServerStatuses.
+func (instantSwap *InstantSwap) GetQuotes(ctx context.Context, req QuoteRequest, servers ...ExchangeServer) []*Quote {
+	if len(servers) == 0 {
+		servers = instantSwap.ExchangeServers()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QuoteTimeout)
+	defer cancel()
+
+	quotes := make([]*Quote, len(servers))
+	var wg sync.WaitGroup
+	for i, server := range servers {
+		wg.Add(1)
+		go func(i int, server ExchangeServer) {
+			defer wg.Done()
+			quotes[i] = instantSwap.getQuote(ctx, server, req)
+		}(i, server)
+	}
+	wg.Wait()
+
+	for _, q := range quotes {
+		instantSwap.recordServerStatus(q.ExchangeServer.Server, q.Err)
+	}
+
+	RankQuotes(quotes)
+	return quotes
+}
+
+// getQuote requests a quote from server. The request is abandoned if ctx is
+// done first.
+func (instantSwap *InstantSwap) getQuote(ctx context.Context, server ExchangeServer, req QuoteRequest) *Quote {
+	type result struct {
+		res *instantswap.ExchangeRateInfo
+		err error
+	}
+
+	resCh := make(chan result, 1)
+	go func() {
+		exchangeObject, err := instantSwap.NewExchangeServer(server)
+		if err != nil {
+			resCh <- result{err: err}
+			return
+		}
+
+		res, err := instantSwap.GetExchangeRateInfo(exchangeObject, instantswap.ExchangeRateRequest{
+			From:   req.From,
+			To:     req.To,
+			Amount: req.Amount,
+		})
+		resCh <- result{res: res, err: err}
+	}()
+
+	select {
+	case r := <-resCh:
+		if r.err != nil {
+			log.Errorf("Error fetching %s quote: %v", server.Server, r.err)
+			return &Quote{ExchangeServer: server, QuoteRequest: req, Err: r.err}
+		}
+		return normalizeQuote(server, req, r.res)
+	case <-ctx.Done():
+		log.Errorf("Error fetching %s quote: %v", server.Server, ctx.Err())
+		return &Quote{ExchangeServer: server, QuoteRequest: req, Err: ctx.Err()}
+	}
+}
+
+// BestQuote returns the best usable quote of the enabled exchange servers.
+func (instantSwap *InstantSwap) BestQuote(ctx context.Context, req QuoteRequest) (*Quote, error) {
+	quotes := instantSwap.GetQuotes(ctx, req)
+	if len(quotes) == 0 || !quotes[0].Usable() {
+		return nil, errors.New(ErrNoUsableQuote)
+	}
+	return quotes[0], nil
+}
+
+// recordServerStatus records the outcome of a quote request to server.
+func (instantSwap *InstantSwap) recordServerStatus(server Server, quoteErr error) {
+	instantSwap.mu.Lock()
+	defer instantSwap.mu.Unlock()
+
+	status := ServerStatus{Server: server}
+	err := instantSwap.db.One("Server", server, &status)
+	if err != nil && err != storm.ErrNotFound {
+		log.Errorf("Error reading %s status: %v", server, err)
+		return
+	}
+
+	now := time.Now().Unix()
+	if quoteErr != nil {
+		status.Failures++
+		status.TotalFailures++
+		status.LastError = quoteErr.Error()
+		status.LastFailureAt = now
+	} else {
+		status.Failures = 0
+		status.TotalSuccesses++
+		status.LastSuccessAt = now
+	}
+
+	if err := instantSwap.db.Save(&status); err != nil {
+		log.Errorf("Error saving %s status: %v", server, err)
+	}
+}
+
+// ServerStatuses returns the recorded quote request outcomes of the exchange
+// servers.
+func (instantSwap *InstantSwap) ServerStatuses() ([]*ServerStatus, error) {
+	var statuses []*ServerStatus
+	err := instantSwap.db.All(&statuses)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return statuses, nil
+}
@@ -0,0 +1,200 @@
+package instantswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+// JobState is the state of a scheduler job's current order.
+type JobState string
+
+const (
+	// JobScheduled is the state of a job waiting to create its next order.
+	JobScheduled JobState = "scheduled"
+	// JobAwaitingDeposit is the state of a job whose order was created but
+	// whose deposit is not yet sent.
+	JobAwaitingDeposit JobState = "awaiting-deposit"
+	// JobDepositSent is the state of a job whose deposit was broadcast but
+	// not yet received by the exchange server.
+	JobDepositSent JobState = "deposit-sent"
+	// JobAwaitingPayout is the state of a job whose deposit was received by
+	// the exchange server.
+	JobAwaitingPayout JobState = "awaiting-payout"
+	// JobVerified is the state of a job whose order payout was verified.
+	JobVerified JobState = "verified"
+	// JobRefunded is the state of a job whose order deposit was refunded.
+	JobRefunded JobState = "refunded"
+	// JobEnded is the state of a job that stopped scheduling orders.
+	JobEnded JobState = "ended"
+)
+
+// HasPendingOrder returns true if the job's current order was created but not
+// completed.
+func (s JobState) HasPendingOrder() bool {
+	switch s {
+	case JobAwaitingDeposit, JobDepositSent, JobAwaitingPayout:
+		return true
+	}
+	return false
+}
+
+// SchedulerJob is a persisted order schedule. The job state is saved after
+// every step so that the schedule resumes where it stopped if the app
+// restarts.
+type SchedulerJob struct {
+	ID     int             `storm:"id,increment" json:"id"`
+	Params SchedulerParams `json:"params"` // Params.SpendingPassphrase is not persisted.
+	State  JobState        `storm:"index" json:"state"`
+
+	// OrderUUID, DepositTxID and PayoutCurrency describe the job's current
+	// order.
+	OrderUUID      string  `json:"orderUUID"`
+	DepositAmount  float64 `json:"depositAmount"`
+	DepositTxID    string  `json:"depositTxID"`
+	PayoutCurrency string  `json:"payoutCurrency"`
+
+	// SwappedAmount is the total amount of completed orders.
+	SwappedAmount float64 `json:"swappedAmount"`
+	OrderCount    int     `json:"orderCount"`
+
+	// NeedsPassphrase is set if the job was resumed without the spending
+	// passphrase and needs it to send its next deposit.
+	NeedsPassphrase bool `json:"needsPassphrase"`
+
+	StartedAt   int64  `json:"startedAt"`
+	LastOrderAt int64  `json:"lastOrderAt"`
+	UpdatedAt   int64  `json:"updatedAt"`
+	LastError   string `json:"lastError"`
+}
+
+// IsActive returns true if the job is still scheduling orders.
+func (job *SchedulerJob) IsActive() bool {
+	return job.State != JobEnded
+}
+
+// SaveSchedulerJob inserts or updates job.
+func (instantSwap *InstantSwap) SaveSchedulerJob(job *SchedulerJob) error {
+	job.UpdatedAt = time.Now().Unix()
+	if err := instantSwap.db.Save(job); err != nil {
+		return fmt.Errorf("error saving scheduler job: %w", err)
+	}
+	return nil
+}
+
+// SaveRunningSchedulerJob saves job if it is still running. It returns
+// context.Canceled without saving if the job was stopped, so a stopped job's
+// state is not overwritten by its exiting goroutine.
+func (instantSwap *InstantSwap) SaveRunningSchedulerJob(job *SchedulerJob) error {
+	instantSwap.schedulersMu.Lock()
+	defer instantSwap.schedulersMu.Unlock()
+
+	if _, ok := instantSwap.schedulers[job.ID]; !ok {
+		return context.Canceled
+	}
+	return instantSwap.SaveSchedulerJob(job)
+}
+
+// GetSchedulerJob returns the scheduler job with the provided ID.
+func (instantSwap *InstantSwap) GetSchedulerJob(id int) (*SchedulerJob, error) {
+	job := new(SchedulerJob)
+	err := instantSwap.db.One("ID", id, job)
+	if err == storm.ErrNotFound {
+		return nil, errors.New(ErrSchedulerNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching scheduler job: %w", err)
+	}
+	return job, nil
+}
+
+// ActiveSchedulerJobs returns the jobs that are still scheduling orders,
+// including jobs that are not running.
+func (instantSwap *InstantSwap) ActiveSchedulerJobs() ([]*SchedulerJob, error) {
+	var jobs []*SchedulerJob
+	err := instantSwap.db.Select(q.Not(q.Eq("State", JobEnded))).OrderBy("ID").Find(&jobs)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching scheduler jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// AddRunningScheduler records that the job with the provided ID is running.
+// cancel stops the job. An error is returned if the job is already running.
+func (instantSwap *InstantSwap) AddRunningScheduler(jobID int, cancel context.CancelFunc) error {
+	instantSwap.schedulersMu.Lock()
+	defer instantSwap.schedulersMu.Unlock()
+
+	if _, ok := instantSwap.schedulers[jobID]; ok {
+		return fmt.Errorf("scheduler job %d already running", jobID)
+	}
+	instantSwap.schedulers[jobID] = cancel
+	return nil
+}
+
+// RemoveRunningScheduler records that the job with the provided ID stopped
+// running.
+func (instantSwap *InstantSwap) RemoveRunningScheduler(jobID int) {
+	instantSwap.schedulersMu.Lock()
+	defer instantSwap.schedulersMu.Unlock()
+	delete(instantSwap.schedulers, jobID)
+}
+
+// IsSchedulerRunning returns true if the job with the provided ID is running.
+func (instantSwap *InstantSwap) IsSchedulerRunning(jobID int) bool {
+	instantSwap.schedulersMu.RLock()
+	defer instantSwap.schedulersMu.RUnlock()
+	_, ok := instantSwap.schedulers[jobID]
+	return ok
+}
+
+// RunningSchedulers returns the number of running scheduler jobs.
+func (instantSwap *InstantSwap) RunningSchedulers() int {
+	instantSwap.schedulersMu.RLock()
+	defer instantSwap.schedulersMu.RUnlock()
+	return len(instantSwap.schedulers)
+}
+
+// CancelSchedulers stops the running scheduler jobs without ending them. The
+// jobs resume from their saved state on the next start.
+func (instantSwap *InstantSwap) CancelSchedulers() {
+	instantSwap.schedulersMu.Lock()
+	defer instantSwap.schedulersMu.Unlock()
+	for jobID, cancel := range instantSwap.schedulers {
+		cancel()
+		delete(instantSwap.schedulers, jobID)
+	}
+}
+
+// CancelScheduler stops the job with the provided ID if it is running,
+// without ending it.
+func (instantSwap *InstantSwap) CancelScheduler(jobID int) {
+	instantSwap.schedulersMu.Lock()
+	defer instantSwap.schedulersMu.Unlock()
+	if cancel, ok := instantSwap.schedulers[jobID]; ok {
+		cancel()
+		delete(instantSwap.schedulers, jobID)
+	}
+}
+
+// EndSchedulerJob stops the job with the provided ID if it is running and
+// marks it as ended.
+func (instantSwap *InstantSwap) EndSchedulerJob(jobID int) error {
+	instantSwap.schedulersMu.Lock()
+	if cancel, ok := instantSwap.schedulers[jobID]; ok {
+		cancel()
+		delete(instantSwap.schedulers, jobID)
+	}
+	instantSwap.schedulersMu.Unlock()
+
+	job, err := instantSwap.GetSchedulerJob(jobID)
+	if err != nil {
+		return err
+	}
+	job.State = JobEnded
+	return instantSwap.SaveSchedulerJob(job)
+}
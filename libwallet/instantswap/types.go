@@ -55,10 +55,8 @@ type InstantSwap struct {
 	ctx        context.Context
 	cancelSync context.CancelFunc
 
-	SchedulerCtx           context.Context
-	CancelOrderScheduler   context.CancelFunc
-	CancelOrderSchedulerMu sync.RWMutex
-	SchedulerStartTime     time.Time
+	schedulersMu sync.RWMutex
+	schedulers   map[int]context.CancelFunc // Running scheduler jobs by job ID.
 
 	notificationListenersMu *sync.RWMutex // Pointer required to avoid copying literal values.
 	notificationListeners   map[string]OrderNotificationListener
@@ -85,6 +83,9 @@ type Order struct {
 	InvoicedAmount float64 `json:"invoicedAmount"`
 	ReceiveAmount  float64 `json:"receiveAmount"`
 	TxID           string  `json:"txid"`
+	// PayoutTxID is the hash of the payout transaction found in the
+	// destination wallet. It is set once the payout is verified.
+	PayoutTxID string `json:"payoutTxID"`
 
 	FromCurrency string `json:"fromCurrency"`
 	ToCurrency   string `json:"toCurrency"`
@@ -94,6 +95,9 @@ type Order struct {
 	DestinationAddress string  `json:"destinationAddress"` // Address where successfully converted funds would be sent to
 	ExchangeRate       float64 `json:"exchangeRate"`
 	ChargedFee         float64 `json:"chargedFee"`
+	// LimitRate is the minimum rate of the conditional scheduler that created
+	// the order. It is zero for other orders. See OrderCondition.
+	LimitRate float64 `json:"limitRate"`
 
 	Confirmations string             `json:"confirmations"`
 	Status        instantswap.Status `json:"status" storm:"index"`
@@ -117,5 +121,35 @@ type SchedulerParams struct {
 	// rate is greater than the MaxDeviationRate, the order is not created
 	MaxDeviationRate float64
 
-	SpendingPassphrase string
+	// AutoSelectServer selects the exchange server with the best quote
+	// before every order instead of using Order.ExchangeServer.
+	AutoSelectServer bool
+
+	// Condition turns the scheduler into a limit-style order. Orders are
+	// only created while the condition is met and the scheduler exits once
+	// the condition's amount is swapped. Frequency is ignored if set.
+	Condition *OrderCondition
+
+	// SpendingPassphrase is required to send deposits. It is never
+	// persisted, see SchedulerJob.
+	SpendingPassphrase string `json:"-"`
+}
+
+// OrderCondition is the condition of a limit-style scheduled swap. The
+// exchange server rate is polled every PollInterval and orders are created
+// while it is at least MinRate, until TotalAmount is swapped or Deadline
+// passes. TotalAmount may be filled by several orders if it exceeds the
+// server's max order amount or the spendable balance of the source account.
+type OrderCondition struct {
+	// MinRate is the minimum amount of ToCurrency to be received for 1
+	// FromCurrency.
+	MinRate float64
+	// TotalAmount is the total amount of FromCurrency to swap.
+	TotalAmount float64
+	// PollInterval is how often the exchange server rate is checked while
+	// the condition is not met.
+	PollInterval time.Duration
+	// Deadline is the time after which no new order is created. A zero
+	// Deadline never expires.
+	Deadline time.Time
 }
@@ -2,6 +2,7 @@ package loader
 
 import (
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -38,6 +39,9 @@ type WatchOnlyWalletParams struct {
 	WalletID       string
 	ExtendedPubKey string
 	PubPassphrase  []byte
+	// MasterKeyFingerprint is the fingerprint of the master key the extended
+	// public key derives from, or 0 if unknown.
+	MasterKeyFingerprint uint32
 }
 
 type CreateWalletParams struct {
@@ -60,6 +64,12 @@ type AssetLoader interface {
 	GetLoadedWallet() (*LoadedWallets, bool)
 	UnloadWallet() error
 	WalletExists(WalletID string) (bool, error)
+
+	// WalletDBPath returns the path of the database of the wallet with the
+	// provided ID.
+	WalletDBPath(WalletID string) string
+	// CopyWalletDB writes a copy of the loaded wallet's database to w.
+	CopyWalletDB(w io.Writer) error
 }
 
 func NewLoader(dbDirPath string) *Loader {
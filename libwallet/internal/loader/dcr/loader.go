@@ -7,6 +7,7 @@ package dcr
 
 import (
 	"context"
+	"io"
 	"path/filepath"
 	"sync"
 
@@ -369,3 +370,29 @@ func (l *dcrLoader) NetworkBackend() (n wallet.NetworkBackend, ok bool) {
 	l.mu.Unlock()
 	return n, n != nil
 }
+
+// WalletDBPath returns the path of the database of the wallet with the
+// provided ID.
+func (l *dcrLoader) WalletDBPath(walletID string) string {
+	path, _, _ := l.FileExists(walletID, walletDbName, utils.DCRWalletAsset)
+	return path
+}
+
+// CopyWalletDB writes a copy of the loaded wallet's database to w. The read
+// lock is held during the copy to keep the database from being closed.
+func (l *dcrLoader) CopyWalletDB(w io.Writer) error {
+	const op errors.Op = "loader.CopyWalletDB"
+
+	defer l.mu.RUnlock()
+	l.mu.RLock()
+
+	if l.db == nil {
+		return errors.E(op, errors.Invalid, "wallet is unopened")
+	}
+	// The opaque wallet.DB embeds the walletdb.DB that implements Copy.
+	db, ok := l.db.(interface{ Copy(io.Writer) error })
+	if !ok {
+		return errors.E(op, errors.Invalid, "wallet database can not be copied")
+	}
+	return db.Copy(w)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
 	"sync"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/ltcsuite/ltcd/ltcutil/hdkeychain"
 	"github.com/ltcsuite/ltcwallet/waddrmgr"
 	"github.com/ltcsuite/ltcwallet/wallet"
+	"github.com/ltcsuite/ltcwallet/walletdb"
 	_ "github.com/ltcsuite/ltcwallet/walletdb/bdb" // bdb init() registers a driver
 
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
@@ -164,10 +166,10 @@ func (l *ltcLoader) CreateWatchingOnlyWallet(_ context.Context, params *loader.W
 	// ImportAccountWithScope imports an account into the newly created watch-only wallet
 	// using the supported scope. The first parameter "default" will be the imported account's
 	// name, It doesn't matter what the account name use to be on a previous wallet.
-	//  Since the MasterFingerPrint is not provided when inputing the extended
-	// public key, 0 is set instead.
+	// The MasterFingerPrint is 0 if it was not provided with the extended
+	// public key.
 	addrSchema := waddrmgr.ScopeAddrMap[l.keyscope]
-	_, err = wal.ImportAccountWithScope("default", extendedKety, 0, l.keyscope, addrSchema)
+	_, err = wal.ImportAccountWithScope("default", extendedKety, params.MasterKeyFingerprint, l.keyscope, addrSchema)
 	if err != nil {
 		return nil, err
 	}
@@ -243,3 +245,35 @@ func (l *ltcLoader) WalletExists(walletID string) (bool, error) {
 	}
 	return exists, nil
 }
+
+// WalletDBPath returns the path of the database of the wallet with the
+// provided ID.
+func (l *ltcLoader) WalletDBPath(walletID string) string {
+	path, _, _ := l.FileExists(walletID, wallet.WalletDBName, utils.LTCWalletAsset)
+	return path
+}
+
+// CopyWalletDB writes a copy of the loaded wallet's database to w.
+func (l *ltcLoader) CopyWalletDB(w io.Writer) error {
+	defer l.mu.RUnlock()
+	l.mu.RLock()
+
+	if l.wallet == nil {
+		return errors.New("wallet is unopened")
+	}
+	return l.wallet.Database().Copy(w)
+}
+
+// DropTransactionHistory removes the transaction history of the wallet
+// database at dbPath, keeping the transaction labels. The wallet rescans the
+// chain from its birthday when it is next synced. The database must not be
+// opened by a loaded wallet, it is locked and opening it times out quickly.
+func DropTransactionHistory(dbPath string) error {
+	db, err := walletdb.Open("bdb", dbPath, true, time.Second)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return wallet.DropTransactionHistory(db, true)
+}
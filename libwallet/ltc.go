@@ -20,12 +20,14 @@ func initializeLTCWalletParameters(netType utils.NetworkType) (*chaincfg.Params,
 	return chainParams, nil
 }
 
-// CreateNewLTCWallet creates a new LTC wallet and returns it.
-func (mgr *AssetsManager) CreateNewLTCWallet(walletName, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
+// CreateNewLTCWallet creates a new LTC wallet with a seed of seedFormat and
+// returns it.
+func (mgr *AssetsManager) CreateNewLTCWallet(walletName, privatePassphrase string, privatePassphraseType int32, seedFormat sharedW.SeedFormat) (sharedW.Asset, error) {
 	pass := &sharedW.AuthInfo{
 		Name:            walletName,
 		PrivatePass:     privatePassphrase,
 		PrivatePassType: privatePassphraseType,
+		SeedFormat:      seedFormat,
 	}
 
 	wallet, err := ltc.CreateNewWallet(pass, mgr.params)
@@ -60,10 +62,28 @@ func (mgr *AssetsManager) CreateNewLTCWatchOnlyWallet(walletName, extendedPublic
 	return wallet, nil
 }
 
+// CreateNewLTCAddressWatchWallet creates a new LTC wallet watching the
+// transactions of addresses from the block at startHeight and returns it.
+func (mgr *AssetsManager) CreateNewLTCAddressWatchWallet(walletName string, addresses []string, startHeight int32) (sharedW.Asset, error) {
+	wallet, err := ltc.CreateAddressWatchWallet(walletName, addresses, startHeight, mgr.params)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.Assets.LTC.Wallets[wallet.GetWalletID()] = wallet
+
+	// extract the db interface if it hasn't been set already.
+	if mgr.db == nil && wallet != nil {
+		mgr.setDBInterface(wallet.(sharedW.AssetsManagerDB))
+	}
+
+	return wallet, nil
+}
+
 // LTCWalletWithSeed returns the ID of the LTC wallet that was created or restored
-// using the same seed as the one provided. Returns -1 if no wallet uses the
-// provided seed.
-func (mgr *AssetsManager) LTCWalletWithSeed(seedMnemonic string) (int, error) {
+// using the same seed and seed passphrase as the ones provided. Returns -1 if
+// no wallet uses the provided seed.
+func (mgr *AssetsManager) LTCWalletWithSeed(seedMnemonic, seedPassphrase string) (int, error) {
 	if len(seedMnemonic) == 0 {
 		return -1, errors.New(utils.ErrEmptySeed)
 	}
@@ -87,7 +107,7 @@ func (mgr *AssetsManager) LTCWalletWithSeed(seedMnemonic string) (int, error) {
 			if accs.AccountNumber == waddrmgr.ImportedAddrAccount {
 				continue
 			}
-			xpub, err := asset.DeriveAccountXpub(seedMnemonic,
+			xpub, err := asset.DeriveAccountXpub(seedMnemonic, seedPassphrase,
 				accs.AccountNumber, wallet.Internal().LTC.ChainParams())
 			if err != nil {
 				return -1, err
@@ -106,13 +126,18 @@ func (mgr *AssetsManager) LTCWalletWithSeed(seedMnemonic string) (int, error) {
 }
 
 // LTCWalletWithXPub returns the ID of the LTC wallet that has an account with the
-// provided xpub. Returns -1 if there is no such wallet.
+// provided xpub or output descriptors. Returns -1 if there is no such wallet.
 func (mgr *AssetsManager) LTCWalletWithXPub(xpub string) (int, error) {
 	for _, wallet := range mgr.Assets.LTC.Wallets {
 		if !wallet.WalletOpened() {
 			return -1, errors.Errorf("wallet %d is not open and cannot be checked", wallet.GetWalletID())
 		}
 
+		asset, ok := wallet.(*ltc.Asset)
+		if !ok {
+			return -1, fmt.Errorf("invalid asset type")
+		}
+
 		wAccs, err := wallet.GetAccountsRaw()
 		if err != nil {
 			return -1, err
@@ -122,12 +147,11 @@ func (mgr *AssetsManager) LTCWalletWithXPub(xpub string) (int, error) {
 			if accs.AccountNumber == ltc.ImportedAccountNumber {
 				continue
 			}
-			acctXPubKey, err := wallet.Internal().LTC.AccountProperties(ltc.GetScope(), accs.AccountNumber)
+			matches, err := asset.AccountKeyMatches(accs.AccountNumber, xpub)
 			if err != nil {
 				return -1, err
 			}
-
-			if acctXPubKey.AccountPubKey.String() == xpub {
+			if matches {
 				return wallet.GetWalletID(), nil
 			}
 		}
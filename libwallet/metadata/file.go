@@ -0,0 +1,104 @@
+package metadata
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/kevinburke/nacl"
+	"github.com/kevinburke/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// saltSize is the size of the random salt of the key derivation.
+const saltSize = 16
+
+// envelope is the encoding of an encrypted backup. Version is kept outside of
+// the encrypted data to reject unsupported backups before decrypting them.
+type envelope struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Data    []byte `json:"data"`
+}
+
+// deriveKey derives the encryption key of a backup from pass using scrypt.
+func deriveKey(pass, salt []byte) (nacl.Key, error) {
+	const N, r, p = 1 << 15, 8, 1
+
+	hash, err := scrypt.Key(pass, salt, N, r, p, 32)
+	if err != nil {
+		return nil, err
+	}
+	return nacl.Load(utils.EncodeHex(hash))
+}
+
+// Encrypt encodes the backup and encrypts it with pass.
+func Encrypt(b *Backup, pass []byte) ([]byte, error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(pass, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&envelope{
+		Version: b.Version,
+		Salt:    salt,
+		Data:    secretbox.EasySeal(data, key),
+	})
+}
+
+// Decrypt decrypts a backup encrypted with pass by Encrypt.
+func Decrypt(encrypted, pass []byte) (*Backup, error) {
+	var env envelope
+	if err := json.Unmarshal(encrypted, &env); err != nil {
+		return nil, fmt.Errorf("invalid metadata backup: %w", err)
+	}
+	if env.Version < 1 || env.Version > Version {
+		return nil, fmt.Errorf("unsupported metadata backup version %d", env.Version)
+	}
+
+	key, err := deriveKey(pass, env.Salt)
+	if err != nil {
+		return nil, err
+	}
+	data, err := secretbox.EasyOpen(env.Data, key)
+	if err != nil {
+		return nil, errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	b := new(Backup)
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("invalid metadata backup: %w", err)
+	}
+	return b, nil
+}
+
+// WriteFile encrypts the backup with pass and writes it to path.
+func WriteFile(b *Backup, pass []byte, path string) error {
+	encrypted, err := Encrypt(b, pass)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, encrypted, 0o600)
+}
+
+// ReadFile reads and decrypts the backup at path with pass.
+func ReadFile(path string, pass []byte) (*Backup, error) {
+	encrypted, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Decrypt(encrypted, pass)
+}
@@ -0,0 +1,57 @@
+package metadata
+
+import (
+	"encoding/json"
+
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+)
+
+// Version is the version of the backups written by this package. Backups
+// with a greater version can not be read.
+const Version = 1
+
+// Backup is the app metadata that can not be recovered from a wallet's seed.
+type Backup struct {
+	Version   int    `json:"version"`
+	CreatedAt int64  `json:"createdAt"`
+	Network   string `json:"network"`
+
+	Wallets []*Wallet `json:"wallets"`
+
+	// AppConfig holds the app wide settings, including the known DEX
+	// servers. It is only set for backups of the whole app.
+	AppConfig map[string]json.RawMessage `json:"appConfig,omitempty"`
+
+	// Orders are the exchange orders of the backed up wallets.
+	Orders []*instantswap.Order `json:"orders,omitempty"`
+}
+
+// Wallet is the metadata of a wallet.
+type Wallet struct {
+	// ID is the ID of the wallet when it was backed up. It links the
+	// wallet to its exchange orders.
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	AssetType string `json:"assetType"`
+	// XPub is the extended public key of the default account. It finds
+	// the wallet restored from the same seed.
+	XPub string `json:"xpub"`
+
+	AccountNames map[int32]string `json:"accountNames"`
+	// TxLabels are the transaction labels by transaction hash.
+	TxLabels map[string]string `json:"txLabels"`
+	// Config holds the wallet settings e.g the saved VSPs, ticket buyer and
+	// mixer config, as stored in the app database.
+	Config map[string]json.RawMessage `json:"config"`
+}
+
+// WalletByXPub returns the wallet of the provided asset type with xpub or nil
+// if there is none.
+func (b *Backup) WalletByXPub(assetType, xpub string) *Wallet {
+	for _, w := range b.Wallets {
+		if w.AssetType == assetType && w.XPub == xpub {
+			return w
+		}
+	}
+	return nil
+}
@@ -0,0 +1,428 @@
+package libwallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/metadata"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// metadataBackupsDir is the directory of RootDir where metadata backups
+	// are written.
+	metadataBackupsDir = "backups"
+
+	// pendingMetadataListenerID identifies the listeners that apply the
+	// restored metadata once the wallets discover their accounts and
+	// transactions.
+	pendingMetadataListenerID = "pending_metadata"
+)
+
+var (
+	// excludedAppConfigKeys are the app settings that are not backed up
+	// since restoring them could lock the user out of the app or that are
+	// specific to the device.
+	excludedAppConfigKeys = map[string]bool{
+		sharedW.IsStartupSecuritySetConfigKey: true,
+		sharedW.StartupSecurityTypeConfigKey:  true,
+		sharedW.UseBiometricConfigKey:         true,
+		sharedW.DBBackupDirConfigKey:          true,
+		sharedW.LastDBBackupConfigKey:         true,
+		sharedW.StartupUnlockBackoffConfigKey: true,
+	}
+
+	// excludedWalletConfigKeys are the wallet settings that describe the
+	// state of the wallet rather than the user's choices.
+	excludedWalletConfigKeys = map[string]bool{
+		sharedW.LastTxHashConfigKey:      true,
+		sharedW.PendingMetadataConfigKey: true,
+		sharedW.DropTxHistoryConfigKey:   true,
+		// Spending policies only change with the private passphrase.
+		sharedW.SpendingPolicyConfigKey:  true,
+		sharedW.SpendingHistoryConfigKey: true,
+		sharedW.DelayedSpendsConfigKey:   true,
+		sharedW.UnlockBackoffConfigKey:   true,
+	}
+)
+
+// pendingMetadata is the restored metadata of the accounts and transactions a
+// wallet has not discovered yet.
+type pendingMetadata struct {
+	AccountNames map[int32]string
+	TxLabels     map[string]string
+}
+
+func (pm *pendingMetadata) isEmpty() bool {
+	return len(pm.AccountNames) == 0 && len(pm.TxLabels) == 0
+}
+
+// BackupMetadata writes the metadata of the wallets with the provided IDs to a
+// new file encrypted with pass and returns its path. The metadata holds the
+// account names, transaction labels, wallet settings and exchange orders of the
+// wallets. The app settings and the metadata of all the wallets are backed up
+// if no wallet ID is provided.
+func (mgr *AssetsManager) BackupMetadata(pass string, walletIDs ...int) (string, error) {
+	wallets := mgr.AllWallets()
+	if len(walletIDs) > 0 {
+		wallets = make([]sharedW.Asset, 0, len(walletIDs))
+		for _, id := range walletIDs {
+			wallet := mgr.WalletWithID(id)
+			if wallet == nil {
+				return "", errors.New(utils.ErrNotExist)
+			}
+			wallets = append(wallets, wallet)
+		}
+	}
+
+	backup := &metadata.Backup{
+		Version:   metadata.Version,
+		CreatedAt: time.Now().Unix(),
+		Network:   string(mgr.NetType()),
+	}
+
+	backedUp := make(map[int]bool)
+	for _, wallet := range wallets {
+		walletMetadata, err := backupWalletMetadata(wallet)
+		if err != nil {
+			return "", fmt.Errorf("error backing up %s metadata: %w", wallet.GetWalletName(), err)
+		}
+		backup.Wallets = append(backup.Wallets, walletMetadata)
+		backedUp[wallet.GetWalletID()] = true
+	}
+
+	if len(walletIDs) == 0 && mgr.db != nil {
+		appConfig, err := mgr.db.WalletConfigValues()
+		if err != nil {
+			return "", err
+		}
+		for key := range excludedAppConfigKeys {
+			delete(appConfig, key)
+		}
+		backup.AppConfig = appConfig
+	}
+
+	orders, err := mgr.InstantSwap.GetOrdersRaw(0, 0, false)
+	if err != nil {
+		return "", err
+	}
+	for _, order := range orders {
+		if !backedUp[order.SourceWalletID] && !backedUp[order.DestinationWalletID] {
+			continue
+		}
+		backup.Orders = append(backup.Orders, order)
+
+		// Identify the other wallet of the order so that it is linked to
+		// the order on restore.
+		for _, id := range []int{order.SourceWalletID, order.DestinationWalletID} {
+			if wallet := mgr.WalletWithID(id); wallet != nil && !backedUp[id] {
+				xpub, err := wallet.GetExtendedPubKey(0)
+				if err != nil {
+					continue
+				}
+				backup.Wallets = append(backup.Wallets, &metadata.Wallet{
+					ID:        id,
+					Name:      wallet.GetWalletName(),
+					AssetType: string(wallet.GetAssetType()),
+					XPub:      xpub,
+				})
+				backedUp[id] = true
+			}
+		}
+	}
+
+	dir := filepath.Join(mgr.params.RootDir, metadataBackupsDir)
+	if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("metadata-%s.cpmeta", time.Now().Format("20060102-150405")))
+	if err := metadata.WriteFile(backup, []byte(pass), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// backupWalletMetadata returns the metadata of wallet.
+func backupWalletMetadata(wallet sharedW.Asset) (*metadata.Wallet, error) {
+	xpub, err := wallet.GetExtendedPubKey(0)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := wallet.GetAccountsRaw()
+	if err != nil {
+		return nil, err
+	}
+	accountNames := make(map[int32]string, len(accounts.Accounts))
+	for _, account := range accounts.Accounts {
+		accountNames[account.Number] = account.Name
+	}
+
+	txs, err := wallet.GetTransactionsRaw(0, 0, utils.TxFilterAll, false)
+	if err != nil {
+		return nil, err
+	}
+	txLabels := make(map[string]string)
+	for _, tx := range txs {
+		if tx.Label != "" {
+			txLabels[tx.Hash] = tx.Label
+		}
+	}
+
+	config, err := wallet.UserConfigValues()
+	if err != nil {
+		return nil, err
+	}
+	for key := range excludedWalletConfigKeys {
+		delete(config, key)
+	}
+
+	return &metadata.Wallet{
+		ID:           wallet.GetWalletID(),
+		Name:         wallet.GetWalletName(),
+		AssetType:    string(wallet.GetAssetType()),
+		XPub:         xpub,
+		AccountNames: accountNames,
+		TxLabels:     txLabels,
+		Config:       config,
+	}, nil
+}
+
+// RestoreMetadata decrypts the metadata backup at path with pass and merges it
+// into the wallet with walletID, or into all the wallets and the app settings
+// if walletID is negative. Backed up wallets are matched to the wallets
+// restored from the same seed. Wallet settings in the backup replace the
+// current ones, exchange orders are added unless they already exist and the
+// account names and transaction labels are applied as soon as the wallets
+// discover the accounts and transactions.
+func (mgr *AssetsManager) RestoreMetadata(path, pass string, walletID int) error {
+	backup, err := metadata.ReadFile(path, []byte(pass))
+	if err != nil {
+		return err
+	}
+	if backup.Network != string(mgr.NetType()) {
+		return errors.Errorf("metadata backup is for %s wallets", backup.Network)
+	}
+
+	// Map the IDs of the backed up wallets to the IDs of the restored ones.
+	walletIDs := make(map[int]int)
+	if walletID >= 0 {
+		wallet := mgr.WalletWithID(walletID)
+		if wallet == nil {
+			return errors.New(utils.ErrNotExist)
+		}
+		xpub, err := wallet.GetExtendedPubKey(0)
+		if err != nil {
+			return err
+		}
+		walletMetadata := backup.WalletByXPub(string(wallet.GetAssetType()), xpub)
+		if walletMetadata == nil {
+			return errors.Errorf("metadata backup has no metadata for %s", wallet.GetWalletName())
+		}
+		walletIDs[walletMetadata.ID] = walletID
+	}
+
+	for _, walletMetadata := range backup.Wallets {
+		if _, ok := walletIDs[walletMetadata.ID]; ok {
+			continue
+		}
+		id, err := mgr.WalletWithXPub(utils.AssetType(walletMetadata.AssetType), walletMetadata.XPub)
+		if err != nil {
+			log.Warnf("Error finding the restored wallet of %s: %v", walletMetadata.Name, err)
+			continue
+		}
+		if id >= 0 {
+			walletIDs[walletMetadata.ID] = id
+		}
+	}
+
+	for _, walletMetadata := range backup.Wallets {
+		id, ok := walletIDs[walletMetadata.ID]
+		if !ok || (walletID >= 0 && id != walletID) {
+			continue
+		}
+		mgr.restoreWalletMetadata(mgr.WalletWithID(id), walletMetadata)
+	}
+
+	if walletID < 0 {
+		mgr.restoreAppConfig(backup.AppConfig)
+	}
+
+	for _, order := range backup.Orders {
+		sourceID, sourceOK := walletIDs[order.SourceWalletID]
+		destinationID, destinationOK := walletIDs[order.DestinationWalletID]
+		// Orders are displayed with the details of both of their wallets.
+		if !sourceOK || !destinationOK {
+			continue
+		}
+		if walletID >= 0 && sourceID != walletID && destinationID != walletID {
+			continue
+		}
+
+		order.SourceWalletID, order.DestinationWalletID = sourceID, destinationID
+		if _, err := mgr.InstantSwap.ImportOrder(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreWalletMetadata merges walletMetadata into wallet.
+func (mgr *AssetsManager) restoreWalletMetadata(wallet sharedW.Asset, walletMetadata *metadata.Wallet) {
+	for key, value := range walletMetadata.Config {
+		if !excludedWalletConfigKeys[key] {
+			wallet.SaveUserConfigValue(key, value)
+		}
+	}
+
+	mgr.metadataMu.Lock()
+	pending := readPendingMetadata(wallet)
+	for number, name := range walletMetadata.AccountNames {
+		pending.AccountNames[number] = name
+	}
+	for txHash, label := range walletMetadata.TxLabels {
+		pending.TxLabels[txHash] = label
+	}
+	wallet.SaveUserConfigValue(sharedW.PendingMetadataConfigKey, pending)
+	mgr.metadataMu.Unlock()
+
+	if !mgr.applyPendingMetadata(wallet) {
+		mgr.watchPendingMetadata(wallet)
+	}
+}
+
+// restoreAppConfig saves the backed up app settings. The known DEX servers are
+// merged with the current ones.
+func (mgr *AssetsManager) restoreAppConfig(appConfig map[string]json.RawMessage) {
+	if mgr.db == nil {
+		return
+	}
+
+	for key, value := range appConfig {
+		if excludedAppConfigKeys[key] {
+			continue
+		}
+		if key != sharedW.KnownDexServersConfigKey {
+			mgr.db.SaveWalletConfigValue(key, value)
+			continue
+		}
+
+		var servers map[string][]byte
+		if err := json.Unmarshal(value, &servers); err != nil {
+			log.Errorf("Invalid backed up DEX servers: %v", err)
+			continue
+		}
+		knownServers, _ := mgr.GetDexServers()
+		for host, cert := range servers {
+			if _, ok := knownServers[host]; !ok {
+				knownServers[host] = cert
+			}
+		}
+		if err := mgr.SaveDexServers(knownServers); err != nil {
+			log.Errorf("Error restoring DEX servers: %v", err)
+		}
+	}
+}
+
+// readPendingMetadata returns the restored metadata that is not applied to
+// wallet yet. The caller must hold metadataMu.
+func readPendingMetadata(wallet sharedW.Asset) *pendingMetadata {
+	pending := new(pendingMetadata)
+	_ = wallet.ReadUserConfigValue(sharedW.PendingMetadataConfigKey, pending)
+	if pending.AccountNames == nil {
+		pending.AccountNames = make(map[int32]string)
+	}
+	if pending.TxLabels == nil {
+		pending.TxLabels = make(map[string]string)
+	}
+	return pending
+}
+
+// applyPendingMetadata names the accounts and labels the transactions of
+// wallet that were restored but not applied yet. It returns true once all the
+// restored metadata is applied.
+func (mgr *AssetsManager) applyPendingMetadata(wallet sharedW.Asset) bool {
+	mgr.metadataMu.Lock()
+	defer mgr.metadataMu.Unlock()
+
+	pending := readPendingMetadata(wallet)
+	if pending.isEmpty() {
+		return true
+	}
+
+	if accounts, err := wallet.GetAccountsRaw(); err == nil {
+		for _, account := range accounts.Accounts {
+			name, ok := pending.AccountNames[account.Number]
+			if !ok {
+				continue
+			}
+			if name != account.Name {
+				if err := wallet.RenameAccount(account.Number, name); err != nil {
+					log.Errorf("Error restoring the name of account %d of %s: %v", account.Number, wallet.GetWalletName(), err)
+				}
+			}
+			delete(pending.AccountNames, account.Number)
+		}
+	}
+
+	for txHash, label := range pending.TxLabels {
+		// Labels of transactions the wallet has not found yet are kept.
+		if err := wallet.SetTransactionLabel(txHash, label); err == nil {
+			delete(pending.TxLabels, txHash)
+		}
+	}
+
+	if pending.isEmpty() {
+		wallet.DeleteUserConfigValueForKey(sharedW.PendingMetadataConfigKey)
+		return true
+	}
+	wallet.SaveUserConfigValue(sharedW.PendingMetadataConfigKey, pending)
+	return false
+}
+
+// watchPendingMetadata applies the restored metadata of wallet as it discovers
+// new accounts and transactions, until all of it is applied.
+func (mgr *AssetsManager) watchPendingMetadata(wallet sharedW.Asset) {
+	applyCh := make(chan struct{}, 1)
+	listener := &walletActivityListener{onActivity: func() {
+		select {
+		case applyCh <- struct{}{}:
+		default:
+		}
+	}}
+	if err := wallet.AddTxAndBlockNotificationListener(listener, true, pendingMetadataListenerID); err != nil {
+		if err.Error() != utils.ErrListenerAlreadyExist {
+			log.Errorf("Error watching %s for restored metadata: %v", wallet.GetWalletName(), err)
+		}
+		return
+	}
+
+	go func() {
+		defer wallet.RemoveTxAndBlockNotificationListener(pendingMetadataListenerID)
+		for range applyCh {
+			if mgr.applyPendingMetadata(wallet) {
+				return
+			}
+		}
+	}()
+}
+
+// resumePendingMetadata watches the wallets whose restored metadata is not
+// fully applied.
+func (mgr *AssetsManager) resumePendingMetadata() {
+	for _, wallet := range mgr.AllWallets() {
+		mgr.metadataMu.Lock()
+		pending := readPendingMetadata(wallet)
+		mgr.metadataMu.Unlock()
+		if !pending.isEmpty() {
+			mgr.watchPendingMetadata(wallet)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package passphrase
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2Prefix  = "$argon2id$"
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+
+	// MinArgon2Memory is the minimum memory cost, in KiB, of the hashes.
+	MinArgon2Memory = 8 * 1024
+)
+
+// ErrMismatchedHash is returned when a passphrase does not match its hash.
+var ErrMismatchedHash = errors.New("the passphrase does not match the hash")
+
+// Argon2Params are the costs of an Argon2id hash.
+type Argon2Params struct {
+	// Memory is in KiB.
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+}
+
+// DefaultArgon2Params are the costs of the hashes if none are set.
+var DefaultArgon2Params = Argon2Params{Memory: 64 * 1024, Time: 3, Threads: 4}
+
+// IsArgon2Hash returns true if hash is an encoded Argon2id hash.
+func IsArgon2Hash(hash []byte) bool {
+	return strings.HasPrefix(string(hash), argon2Prefix)
+}
+
+// HashArgon2 hashes pass with Argon2id and a random salt. The hash is encoded
+// in the PHC string format with its parameters and salt.
+func HashArgon2(pass []byte, params Argon2Params) ([]byte, error) {
+	if params.Memory < MinArgon2Memory || params.Time == 0 || params.Threads == 0 {
+		return nil, fmt.Errorf("invalid argon2 parameters %+v", params)
+	}
+
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey(pass, salt, params.Time, params.Memory, params.Threads, argon2KeyLen)
+
+	b64 := base64.RawStdEncoding
+	return []byte(fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2Prefix, argon2.Version,
+		params.Memory, params.Time, params.Threads, b64.EncodeToString(salt), b64.EncodeToString(key))), nil
+}
+
+// VerifyArgon2 returns ErrMismatchedHash if pass does not match the encoded
+// Argon2id hash. The parameters of the hash are returned.
+func VerifyArgon2(hash, pass []byte) (Argon2Params, error) {
+	var params Argon2Params
+	var version int
+	parts := strings.Split(strings.TrimPrefix(string(hash), argon2Prefix), "$")
+	if !IsArgon2Hash(hash) || len(parts) != 4 {
+		return params, errors.New("invalid argon2 hash")
+	}
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, fmt.Errorf("unsupported argon2 version %q", parts[0])
+	}
+	_, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads)
+	if err != nil {
+		return params, fmt.Errorf("invalid argon2 parameters: %v", err)
+	}
+
+	b64 := base64.RawStdEncoding
+	salt, err := b64.DecodeString(parts[2])
+	if err != nil {
+		return params, err
+	}
+	key, err := b64.DecodeString(parts[3])
+	if err != nil || len(key) == 0 {
+		return params, errors.New("invalid argon2 hash")
+	}
+
+	computed := argon2.IDKey(pass, salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(computed, key) != 1 {
+		return params, ErrMismatchedHash
+	}
+	return params, nil
+}
@@ -0,0 +1,118 @@
+package passphrase
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// testParams keeps the hashes of the tests fast.
+var testParams = Argon2Params{Memory: MinArgon2Memory, Time: 1, Threads: 1}
+
+func TestHashArgon2(t *testing.T) {
+	hash, err := HashArgon2([]byte("pass"), testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsArgon2Hash(hash) {
+		t.Fatalf("%s is not an argon2 hash", hash)
+	}
+
+	tests := []struct {
+		name string
+		pass string
+		err  error
+	}{
+		{"right passphrase", "pass", nil},
+		{"wrong passphrase", "Pass", ErrMismatchedHash},
+		{"empty passphrase", "", ErrMismatchedHash},
+	}
+
+	for _, test := range tests {
+		params, err := VerifyArgon2(hash, []byte(test.pass))
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+		}
+		if params != testParams {
+			t.Errorf("%s: params %+v, want %+v", test.name, params, testParams)
+		}
+	}
+
+	invalid := []Argon2Params{
+		{Memory: MinArgon2Memory - 1, Time: 1, Threads: 1},
+		{Memory: MinArgon2Memory, Time: 0, Threads: 1},
+		{Memory: MinArgon2Memory, Time: 1, Threads: 0},
+	}
+	for _, params := range invalid {
+		if _, err := HashArgon2([]byte("pass"), params); err == nil {
+			t.Errorf("HashArgon2 with %+v succeeded", params)
+		}
+	}
+}
+
+func TestVerifyArgon2PHC(t *testing.T) {
+	b64 := base64.RawStdEncoding
+	salt := b64.EncodeToString([]byte("saltsaltsaltsalt"))
+	key := b64.EncodeToString(argon2.IDKey([]byte("pass"), []byte("saltsaltsaltsalt"), 1, MinArgon2Memory, 1, 32))
+	shortKey := b64.EncodeToString(argon2.IDKey([]byte("pass"), []byte("saltsaltsaltsalt"), 1, MinArgon2Memory, 1, 16))
+
+	tests := []struct {
+		name  string
+		hash  string
+		valid bool
+		err   error
+	}{{
+		name:  "valid",
+		hash:  fmt.Sprintf("$argon2id$v=19$m=%d,t=1,p=1$%s$%s", MinArgon2Memory, salt, key),
+		valid: true,
+	}, {
+		name:  "key length from the hash",
+		hash:  fmt.Sprintf("$argon2id$v=19$m=%d,t=1,p=1$%s$%s", MinArgon2Memory, salt, shortKey),
+		valid: true,
+	}, {
+		name: "other parameters",
+		hash: fmt.Sprintf("$argon2id$v=19$m=%d,t=2,p=1$%s$%s", MinArgon2Memory, salt, key),
+		err:  ErrMismatchedHash,
+	}, {
+		name: "argon2i",
+		hash: fmt.Sprintf("$argon2i$v=19$m=%d,t=1,p=1$%s$%s", MinArgon2Memory, salt, key),
+	}, {
+		name: "unsupported version",
+		hash: fmt.Sprintf("$argon2id$v=16$m=%d,t=1,p=1$%s$%s", MinArgon2Memory, salt, key),
+	}, {
+		name: "missing version",
+		hash: fmt.Sprintf("$argon2id$m=%d,t=1,p=1$%s$%s", MinArgon2Memory, salt, key),
+	}, {
+		name: "malformed parameters",
+		hash: fmt.Sprintf("$argon2id$v=19$t=1,m=%d,p=1$%s$%s", MinArgon2Memory, salt, key),
+	}, {
+		name: "padded salt",
+		hash: fmt.Sprintf("$argon2id$v=19$m=%d,t=1,p=1$%s==$%s", MinArgon2Memory, salt, key),
+	}, {
+		name: "empty key",
+		hash: fmt.Sprintf("$argon2id$v=19$m=%d,t=1,p=1$%s$", MinArgon2Memory, salt),
+	}, {
+		name: "extra field",
+		hash: fmt.Sprintf("$argon2id$v=19$m=%d,t=1,p=1$%s$%s$", MinArgon2Memory, salt, key),
+	}, {
+		name: "bcrypt",
+		hash: "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy",
+	}}
+
+	for _, test := range tests {
+		_, err := VerifyArgon2([]byte(test.hash), []byte("pass"))
+		switch {
+		case test.valid && err != nil:
+			t.Errorf("%s: error %v", test.name, err)
+		case test.err != nil && !errors.Is(err, test.err):
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+		case !test.valid && err == nil:
+			t.Errorf("%s: VerifyArgon2 succeeded", test.name)
+		case !test.valid && test.err == nil && errors.Is(err, ErrMismatchedHash):
+			t.Errorf("%s: hash was not rejected as invalid", test.name)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package passphrase
+
+import (
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// freeAttempts is the number of failed attempts allowed without delay.
+	freeAttempts = 3
+
+	// baseDelay is the delay after the first failed attempt past the free
+	// attempts. It doubles with every failed attempt up to maxDelay.
+	baseDelay = 2 * time.Second
+	maxDelay  = 15 * time.Minute
+)
+
+// RetryError is returned when an unlock is attempted before the delay of the
+// previous failed attempts is over.
+type RetryError struct {
+	RetryAt time.Time
+}
+
+func (e *RetryError) Error() string {
+	return utils.ErrTooManyAttempts
+}
+
+// Backoff rate limits the failed attempts to unlock with a passphrase with an
+// exponential delay. It is saved between attempts.
+type Backoff struct {
+	Failures    int
+	LastFailure time.Time
+}
+
+// RetryAt returns when the next attempt is allowed.
+func (b *Backoff) RetryAt() time.Time {
+	if b.Failures < freeAttempts {
+		return time.Time{}
+	}
+
+	delay := maxDelay
+	if shift := b.Failures - freeAttempts; shift < 32 {
+		delay = baseDelay << shift
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+	return b.LastFailure.Add(delay)
+}
+
+// Check returns a *RetryError if an attempt at now is not allowed yet.
+func (b *Backoff) Check(now time.Time) error {
+	if retryAt := b.RetryAt(); now.Before(retryAt) {
+		return &RetryError{RetryAt: retryAt}
+	}
+	return nil
+}
+
+// Fail records a failed attempt at now.
+func (b *Backoff) Fail(now time.Time) {
+	b.Failures++
+	b.LastFailure = now
+}
@@ -0,0 +1,77 @@
+package passphrase
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoffRetryAt(t *testing.T) {
+	lastFailure := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		failures int
+		delay    time.Duration
+	}{
+		{0, 0},
+		{freeAttempts - 1, 0},
+		{freeAttempts, baseDelay},
+		{freeAttempts + 1, 2 * baseDelay},
+		{freeAttempts + 8, 256 * baseDelay},
+		{freeAttempts + 9, maxDelay},
+		{freeAttempts + 31, maxDelay},
+		{freeAttempts + 32, maxDelay},
+		{freeAttempts + 1000, maxDelay},
+	}
+
+	for _, test := range tests {
+		b := &Backoff{Failures: test.failures, LastFailure: lastFailure}
+		retryAt := b.RetryAt()
+		if test.delay == 0 {
+			if !retryAt.IsZero() {
+				t.Errorf("%d failures: retry at %v, want no delay", test.failures, retryAt)
+			}
+			continue
+		}
+		if delay := retryAt.Sub(lastFailure); delay != test.delay {
+			t.Errorf("%d failures: delay %v, want %v", test.failures, delay, test.delay)
+		}
+	}
+}
+
+func TestBackoffCheck(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var b Backoff
+
+	for i := 0; i < freeAttempts; i++ {
+		if err := b.Check(now); err != nil {
+			t.Fatalf("attempt %d: error %v", i, err)
+		}
+		b.Fail(now)
+	}
+	if b.Failures != freeAttempts || !b.LastFailure.Equal(now) {
+		t.Fatalf("backoff %+v after %d failures", b, freeAttempts)
+	}
+
+	tests := []struct {
+		name  string
+		at    time.Time
+		retry bool
+	}{
+		{"immediately", now, true},
+		{"before the delay", now.Add(baseDelay - time.Nanosecond), true},
+		{"after the delay", now.Add(baseDelay), false},
+	}
+
+	for _, test := range tests {
+		err := b.Check(test.at)
+		var retryErr *RetryError
+		if errors.As(err, &retryErr) != test.retry {
+			t.Errorf("%s: error %v, retry %v", test.name, err, test.retry)
+			continue
+		}
+		if test.retry && !retryErr.RetryAt.Equal(now.Add(baseDelay)) {
+			t.Errorf("%s: retry at %v, want %v", test.name, retryErr.RetryAt, now.Add(baseDelay))
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package passphrase
+
+import (
+	"unicode/utf8"
+
+	"decred.org/dcrwallet/v3/errors"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// Policy is the minimum strength of the passphrases of one type.
+type Policy struct {
+	MinLength int
+	// MinScore is the minimum score of the strength estimate of the
+	// passphrases, from 0 to MaxScore.
+	MinScore int
+	// Numeric is set for PINs, which can only have digits.
+	Numeric bool
+}
+
+var (
+	// DefaultPINPolicy is the policy of the PINs if none is set.
+	DefaultPINPolicy = Policy{MinLength: 6, MinScore: 1, Numeric: true}
+
+	// DefaultPasswordPolicy is the policy of the passwords if none is set.
+	DefaultPasswordPolicy = Policy{MinLength: 8, MinScore: 2}
+)
+
+// Validate checks that the values of the policy are consistent.
+func (p *Policy) Validate() error {
+	if p.MinLength < 1 || p.MinScore < 0 || p.MinScore > MaxScore {
+		return errors.E(errors.Invalid, "invalid passphrase policy")
+	}
+	return nil
+}
+
+// Check returns an error if pass does not comply with the policy. The user
+// inputs, e.g. the wallet name, make the passphrases that contain them
+// weaker.
+func (p *Policy) Check(pass string, userInputs ...string) error {
+	if p.Numeric && !isDigits(pass) {
+		return errors.New(utils.ErrPINNotNumeric)
+	}
+	if utf8.RuneCountInString(pass) < p.MinLength {
+		return errors.New(utils.ErrPassphraseTooShort)
+	}
+	if Estimate(pass, userInputs...).Score < p.MinScore {
+		return errors.New(utils.ErrWeakPassphrase)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+// Package passphrase checks the strength of the passphrases against the
+// passphrase policies, hashes the startup passphrase with Argon2id and rate
+// limits the failed unlock attempts.
+package passphrase
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// MaxScore is the score of the strongest passphrases.
+const MaxScore = 4
+
+// scoreThresholds are the base 10 logarithms of the number of guesses needed
+// to reach each score above zero, as in zxcvbn.
+var scoreThresholds = []float64{3, 6, 8, 10}
+
+// commonPassphrases are the most used passwords and words of passwords, most
+// used first. The number of guesses of a match is its rank.
+var commonPassphrases = []string{
+	"password", "123456", "qwerty", "abc123", "letmein", "monkey", "dragon",
+	"111111", "baseball", "iloveyou", "trustno1", "sunshine", "master",
+	"welcome", "shadow", "ashley", "football", "jesus", "michael", "ninja",
+	"mustang", "admin", "login", "princess", "starwars", "solo", "passw0rd",
+	"freedom", "whatever", "qazwsx", "hello", "charlie", "superman", "secret",
+	"love", "summer", "winter", "spring", "autumn", "flower", "hunter",
+	"computer", "internet", "bitcoin", "decred", "litecoin", "crypto", "wallet",
+	"money", "satoshi", "moon", "lambo", "hodl", "cryptopower", "passphrase",
+	"pass", "test", "user", "guest", "root", "changeme", "default", "access",
+}
+
+// keyboardRows are the rows of a qwerty keyboard, matched forward and backward.
+var keyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// leetSubstitutions are the common substitutions of letters in passwords.
+var leetSubstitutions = strings.NewReplacer(
+	"4", "a", "@", "a", "8", "b", "3", "e", "6", "g", "1", "i", "!", "i",
+	"0", "o", "5", "s", "$", "s", "7", "t", "2", "z",
+)
+
+// Strength is the estimated strength of a passphrase.
+type Strength struct {
+	// Guesses is the base 10 logarithm of the estimated number of guesses
+	// needed to find the passphrase.
+	Guesses float64
+	// Score is from 0, too guessable, to MaxScore, very unguessable.
+	Score int
+}
+
+// Estimate estimates the strength of pass in the way of zxcvbn: pass is split
+// in the sequence of dictionary words, repeats, sequences, keyboard patterns,
+// years and brute-forced characters that needs the fewest guesses. The user
+// inputs, e.g. the wallet name, are guessed first.
+func Estimate(pass string, userInputs ...string) Strength {
+	runes := []rune(pass)
+	if len(runes) == 0 {
+		return Strength{}
+	}
+
+	dictionary := make(map[string]int, len(commonPassphrases)+len(userInputs))
+	for _, input := range userInputs {
+		if input = strings.ToLower(strings.TrimSpace(input)); input != "" {
+			dictionary[input] = 1
+		}
+	}
+	for i, word := range commonPassphrases {
+		if _, ok := dictionary[word]; !ok {
+			dictionary[word] = i + 2
+		}
+	}
+
+	bruteForce := math.Log10(float64(cardinality(runes)))
+
+	// best[j] is the log of the fewest guesses of the first j runes.
+	best := make([]float64, len(runes)+1)
+	for j := 1; j <= len(runes); j++ {
+		best[j] = best[j-1] + bruteForce
+		for i := 0; i < j; i++ {
+			if guesses, ok := matchGuesses(runes[i:j], dictionary); ok {
+				// Each extra match is a guess of how the pass is split.
+				if guesses += best[i]; i > 0 {
+					guesses++
+				}
+				best[j] = math.Min(best[j], guesses)
+			}
+		}
+	}
+
+	strength := Strength{Guesses: best[len(runes)]}
+	for _, threshold := range scoreThresholds {
+		if strength.Guesses >= threshold {
+			strength.Score++
+		}
+	}
+	return strength
+}
+
+// cardinality returns the size of the character sets of runes.
+func cardinality(runes []rune) int {
+	var lower, upper, digits, symbols, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digits = true
+		case r < unicode.MaxASCII:
+			symbols = true
+		default:
+			other = true
+		}
+	}
+
+	var size int
+	for _, set := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digits, 10}, {symbols, 33}, {other, 100}} {
+		if set.used {
+			size += set.size
+		}
+	}
+	return size
+}
+
+// matchGuesses returns the log of the guesses of part if it matches a
+// pattern.
+func matchGuesses(part []rune, dictionary map[string]int) (float64, bool) {
+	text := string(part)
+	lower := strings.ToLower(text)
+
+	guesses := math.Inf(1)
+	variations := 0.0
+	if lower != text {
+		// The capitalization of the word must be guessed too.
+		variations += math.Log10(2)
+		if strings.ToUpper(string(part[:1]))+strings.ToLower(string(part[1:])) != text {
+			variations += math.Log10(float64(len(part)))
+		}
+	}
+	if rank, ok := dictionary[lower]; ok {
+		guesses = math.Min(guesses, math.Log10(float64(rank))+variations)
+	}
+	if unleeted := leetSubstitutions.Replace(lower); unleeted != lower {
+		if rank, ok := dictionary[unleeted]; ok {
+			guesses = math.Min(guesses, math.Log10(float64(rank))+variations+math.Log10(float64(2*len(part))))
+		}
+	}
+
+	if len(part) >= 3 {
+		if isRepeat(part) {
+			guesses = math.Min(guesses, math.Log10(float64(cardinality(part[:1])*len(part))))
+		}
+		if descending, ok := isSequence(part); ok {
+			seq := math.Log10(float64(26 * len(part)))
+			if descending {
+				seq += math.Log10(2)
+			}
+			guesses = math.Min(guesses, seq)
+		}
+	}
+	if len(part) >= 4 && isKeyboardPattern(lower) {
+		guesses = math.Min(guesses, math.Log10(float64(len(keyboardRows)*20*len(part))))
+	}
+	if len(part) == 4 && (strings.HasPrefix(text, "19") || strings.HasPrefix(text, "20")) && isDigits(text) {
+		// A year.
+		guesses = math.Min(guesses, math.Log10(200))
+	}
+	if (len(part) == 6 || len(part) == 8) && isDigits(text) {
+		// A date.
+		guesses = math.Min(guesses, math.Log10(365*200))
+	}
+
+	return guesses, !math.IsInf(guesses, 1)
+}
+
+func isRepeat(part []rune) bool {
+	for _, r := range part[1:] {
+		if r != part[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// isSequence returns true if the runes of part follow each other in one
+// direction, e.g. abcd or 9876.
+func isSequence(part []rune) (descending bool, ok bool) {
+	step := part[1] - part[0]
+	if step != 1 && step != -1 {
+		return false, false
+	}
+	for i := 2; i < len(part); i++ {
+		if part[i]-part[i-1] != step {
+			return false, false
+		}
+	}
+	return step == -1, true
+}
+
+func isKeyboardPattern(lower string) bool {
+	reversed := []rune(lower)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	for _, row := range keyboardRows {
+		if strings.Contains(row, lower) || strings.Contains(row, string(reversed)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(text string) bool {
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package libwallet
+
+import (
+	"decred.org/dcrwallet/v3/errors"
+	"golang.org/x/crypto/bcrypt"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/passphrase"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// PassphrasePolicy returns the policy of the passphrases of passType, the
+// startup passphrase and the private passphrases of the wallets.
+func (mgr *AssetsManager) PassphrasePolicy(passType int32) *passphrase.Policy {
+	return sharedW.ReadPassphrasePolicy(mgr.db, passType)
+}
+
+// SetPassphrasePolicy sets the policy of the new passphrases of passType. The
+// passphrases that are already set are not checked again.
+func (mgr *AssetsManager) SetPassphrasePolicy(passType int32, policy *passphrase.Policy) error {
+	if passType != sharedW.PassphraseTypePin && passType != sharedW.PassphraseTypePass {
+		return errors.New(utils.ErrInvalid)
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if !mgr.IsAssetManagerDB() {
+		return errors.New(utils.ErrWalletNotFound)
+	}
+
+	var policies map[int32]*passphrase.Policy
+	_ = mgr.db.ReadWalletConfigValue(sharedW.PassphrasePoliciesConfigKey, &policies)
+	if policies == nil {
+		policies = make(map[int32]*passphrase.Policy)
+	}
+	policies[passType] = policy
+	mgr.db.SaveWalletConfigValue(sharedW.PassphrasePoliciesConfigKey, policies)
+	return nil
+}
+
+// StartupPassphraseMemory returns the memory cost, in KiB, of the Argon2id
+// hash of the startup passphrase.
+func (mgr *AssetsManager) StartupPassphraseMemory() uint32 {
+	memory := passphrase.DefaultArgon2Params.Memory
+	if mgr.IsAssetManagerDB() {
+		mgr.db.ReadWalletConfigValue(sharedW.StartupPassphraseMemoryConfigKey, &memory)
+	}
+	return memory
+}
+
+// SetStartupPassphraseMemory sets the memory cost, in KiB, of the Argon2id
+// hash of the startup passphrase. The hash is upgraded the next time the
+// startup passphrase is verified.
+func (mgr *AssetsManager) SetStartupPassphraseMemory(memory uint32) error {
+	if memory < passphrase.MinArgon2Memory {
+		return errors.E(errors.Invalid, "the memory cost is below the minimum")
+	}
+	if !mgr.IsAssetManagerDB() {
+		return errors.New(utils.ErrWalletNotFound)
+	}
+	mgr.db.SaveWalletConfigValue(sharedW.StartupPassphraseMemoryConfigKey, memory)
+	return nil
+}
+
+// startupArgon2Params returns the parameters of the Argon2id hash of the
+// startup passphrase.
+func (mgr *AssetsManager) startupArgon2Params() passphrase.Argon2Params {
+	params := passphrase.DefaultArgon2Params
+	params.Memory = mgr.StartupPassphraseMemory()
+	return params
+}
+
+// hashStartupPassphrase hashes the startup passphrase with Argon2id.
+func (mgr *AssetsManager) hashStartupPassphrase(startupPassphrase string) ([]byte, error) {
+	return passphrase.HashArgon2([]byte(startupPassphrase), mgr.startupArgon2Params())
+}
+
+// compareStartupPassphrase returns an error if startupPassphrase does not
+// match hash. The hashes of the installs that predate Argon2id are bcrypt
+// hashes. rehash is set if hash does not use the current Argon2id
+// parameters.
+func (mgr *AssetsManager) compareStartupPassphrase(hash []byte, startupPassphrase string) (rehash bool, err error) {
+	if !passphrase.IsArgon2Hash(hash) {
+		return true, bcrypt.CompareHashAndPassword(hash, []byte(startupPassphrase))
+	}
+
+	params, err := passphrase.VerifyArgon2(hash, []byte(startupPassphrase))
+	return params != mgr.startupArgon2Params(), err
+}
@@ -0,0 +1,145 @@
+package libwallet
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/pricehistory"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// PriceHistoryDays is the number of days of price history kept for each
+// supported asset.
+const PriceHistoryDays = 365
+
+// PortfolioPoint is the value of the user's assets at the end of a day.
+type PortfolioPoint struct {
+	Timestamp int64
+	// Value is the total value of the assets in USD.
+	Value float64
+	// AssetValues is the USD value of each asset.
+	AssetValues map[utils.AssetType]float64
+}
+
+// balanceChange is the change a transaction made to the balance of an asset.
+type balanceChange struct {
+	timestamp int64
+	amount    int64
+}
+
+// SyncPriceHistory fetches and caches the daily price history of all the
+// supported assets. Nothing is fetched if the exchange rate API is disabled.
+func (mgr *AssetsManager) SyncPriceHistory(ctx context.Context) error {
+	if mgr.IsPrivacyModeOn() || !mgr.IsHTTPAPIPrivacyModeOff(utils.ExchangeHTTPAPI) {
+		return nil
+	}
+
+	markets := make([]string, 0, len(values.AssetExchangeMarketValue))
+	for _, market := range values.AssetExchangeMarketValue {
+		markets = append(markets, market)
+	}
+	return mgr.PriceHistory.Sync(ctx, PriceHistoryDays, markets...)
+}
+
+// ImportPriceHistory imports daily price history from the CSV file at path.
+// See pricehistory.PriceHistory.Import for the file format.
+func (mgr *AssetsManager) ImportPriceHistory(path string) (int, error) {
+	return mgr.PriceHistory.ImportFile(path)
+}
+
+// PortfolioHistory returns the daily value of the provided asset types from
+// the start of from's day till today. The balance of each day is computed by
+// replaying the transaction history of the asset's wallets and it is valued
+// using the cached price history. All asset types are used if none is
+// provided.
+func (mgr *AssetsManager) PortfolioHistory(from time.Time, assetTypes ...utils.AssetType) ([]*PortfolioPoint, error) {
+	if len(assetTypes) == 0 {
+		assetTypes = mgr.AllAssetTypes()
+	}
+
+	start := from.UTC().Truncate(pricehistory.CandleDuration)
+	now := time.Now()
+
+	var points []*PortfolioPoint
+	for day := start; !day.After(now); day = day.Add(pricehistory.CandleDuration) {
+		points = append(points, &PortfolioPoint{
+			Timestamp:   day.Unix(),
+			AssetValues: make(map[utils.AssetType]float64),
+		})
+	}
+
+	for _, assetType := range assetTypes {
+		market, ok := values.AssetExchangeMarketValue[assetType]
+		if !ok {
+			continue
+		}
+
+		wallets := mgr.AssetWallets(assetType)
+		if len(wallets) == 0 {
+			continue
+		}
+
+		changes, err := mgr.balanceChanges(assetType)
+		if err != nil {
+			return nil, err
+		}
+
+		candles, err := mgr.PriceHistory.Candles(market, start.Add(-7*pricehistory.CandleDuration), now)
+		if err != nil {
+			return nil, err
+		}
+
+		var balance int64
+		var i int
+		for _, point := range points {
+			endOfDay := point.Timestamp + int64(pricehistory.CandleDuration.Seconds())
+			for ; i < len(changes) && changes[i].timestamp < endOfDay; i++ {
+				balance += changes[i].amount
+			}
+
+			price := pricehistory.CloseAt(candles, time.Unix(point.Timestamp, 0))
+			value := wallets[0].ToAmount(balance).MulF64(price).ToCoin()
+			point.AssetValues[assetType] = value
+			point.Value += value
+		}
+	}
+
+	return points, nil
+}
+
+// balanceChanges returns the changes made to the balance of the asset type by
+// every transaction of its wallets, ordered by time.
+func (mgr *AssetsManager) balanceChanges(assetType utils.AssetType) ([]*balanceChange, error) {
+	var changes []*balanceChange
+	for _, wallet := range mgr.AssetWallets(assetType) {
+		txs, err := wallet.GetTransactionsRaw(0, 0, utils.TxFilterAll, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tx := range txs {
+			var amount int64
+			for _, input := range tx.Inputs {
+				if input.AccountNumber != -1 {
+					amount -= input.Amount
+				}
+			}
+			for _, output := range tx.Outputs {
+				if output.AccountNumber != -1 {
+					amount += output.Amount
+				}
+			}
+
+			if amount != 0 {
+				changes = append(changes, &balanceChange{timestamp: tx.Timestamp, amount: amount})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].timestamp < changes[j].timestamp
+	})
+	return changes, nil
+}
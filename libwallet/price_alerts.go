@@ -0,0 +1,127 @@
+package libwallet
+
+import (
+	"context"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/ext"
+	"github.com/crypto-power/cryptopower/libwallet/pricealert"
+	"github.com/crypto-power/cryptopower/libwallet/pricehistory"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// priceAlertsListenerID identifies the rate listener that evaluates the
+// price alerts.
+const priceAlertsListenerID = "price_alerts"
+
+// startPriceAlerts evaluates the user's price alerts on every rate update
+// until ctx is canceled.
+func (mgr *AssetsManager) startPriceAlerts(ctx context.Context) error {
+	rateListener := ext.NewRateListener()
+	if err := mgr.RateSource.AddRateListener(rateListener, priceAlertsListenerID); err != nil {
+		return err
+	}
+
+	go func() {
+		defer mgr.RateSource.RemoveRateListener(priceAlertsListenerID)
+		for {
+			select {
+			case <-rateListener.RateUpdateChan:
+				if mgr.PriceAlerts.HasEnabledAlerts() {
+					mgr.PriceAlerts.Evaluate(mgr.priceAlertSnapshot())
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// priceAlertSnapshot returns the current prices, 24h price changes and
+// portfolio value used to evaluate the price alerts.
+func (mgr *AssetsManager) priceAlertSnapshot() *pricealert.Snapshot {
+	snapshot := &pricealert.Snapshot{
+		Prices:  make(map[string]float64),
+		Changes: make(map[string]float64),
+	}
+
+	now := time.Now()
+	balances := make(map[utils.AssetType]float64)
+	for assetType, market := range values.AssetExchangeMarketValue {
+		ticker := mgr.RateSource.GetTicker(market)
+		if ticker == nil || ticker.LastTradePrice <= 0 {
+			continue
+		}
+		snapshot.Prices[market] = ticker.LastTradePrice
+
+		if ticker.PriceChangePercent != nil {
+			snapshot.Changes[market] = *ticker.PriceChangePercent
+		} else if change, ok := mgr.priceChange24h(market, ticker.LastTradePrice, now); ok {
+			snapshot.Changes[market] = change
+		}
+
+		balance, err := mgr.assetBalance(assetType)
+		if err != nil {
+			log.Errorf("Error fetching %s balance: %v", assetType, err)
+			continue
+		}
+		balances[assetType] = balance * ticker.LastTradePrice
+	}
+
+	// The portfolio value is only known if every asset with wallets has a
+	// price.
+	snapshot.HasPortfolioValue = len(mgr.AllWallets()) > 0
+	for _, assetType := range mgr.AllAssetTypes() {
+		if len(mgr.AssetWallets(assetType)) == 0 {
+			continue
+		}
+		value, ok := balances[assetType]
+		if !ok {
+			snapshot.HasPortfolioValue = false
+			break
+		}
+		snapshot.PortfolioValue += value
+	}
+
+	return snapshot
+}
+
+// priceChange24h computes the 24h price change percentage of market from the
+// cached price history. Used with rate sources that don't provide it.
+func (mgr *AssetsManager) priceChange24h(market string, price float64, now time.Time) (float64, bool) {
+	dayAgo := now.Add(-pricehistory.CandleDuration)
+	candles, err := mgr.PriceHistory.Candles(market, dayAgo.Add(-7*pricehistory.CandleDuration), now)
+	if err != nil {
+		log.Errorf("Error fetching %s price history: %v", market, err)
+		return 0, false
+	}
+
+	prevPrice := pricehistory.CloseAt(candles, dayAgo)
+	if prevPrice <= 0 {
+		return 0, false
+	}
+	return (price - prevPrice) / prevPrice * 100, true
+}
+
+// assetBalance returns the total balance in coins of the spendable wallets of
+// assetType.
+func (mgr *AssetsManager) assetBalance(assetType utils.AssetType) (float64, error) {
+	var total float64
+	for _, wallet := range mgr.AssetWallets(assetType) {
+		if wallet.IsWatchingOnlyWallet() {
+			continue
+		}
+
+		accounts, err := wallet.GetAccountsRaw()
+		if err != nil {
+			return 0, err
+		}
+		for _, account := range accounts.Accounts {
+			total += account.Balance.Total.ToCoin()
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,263 @@
+package pricealert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// New returns a PriceAlerts that stores alerts in db.
+func New(db *storm.DB) (*PriceAlerts, error) {
+	if err := db.Init(&Alert{}); err != nil {
+		log.Errorf("Error initializing price alerts database: %s", err.Error())
+		return nil, err
+	}
+
+	return &PriceAlerts{
+		db:                    db,
+		alerts:                make(map[int]*Alert),
+		notificationListeners: make(map[string]AlertNotificationListener),
+	}, nil
+}
+
+// Load reads the stored alerts. The alerts are encrypted with the startup
+// passphrase when it is set and can only be loaded once it is entered.
+func (pa *PriceAlerts) Load() error {
+	var alerts []*Alert
+	if err := pa.db.All(&alerts); err != nil && err != storm.ErrNotFound {
+		return fmt.Errorf("error fetching price alerts: %w", err)
+	}
+
+	pa.mtx.Lock()
+	defer pa.mtx.Unlock()
+
+	pa.alerts = make(map[int]*Alert, len(alerts))
+	for _, alert := range alerts {
+		pa.alerts[alert.ID] = alert
+	}
+	return nil
+}
+
+// validate checks that the alert can be evaluated.
+func validate(alert *Alert) error {
+	if !alert.Kind.IsValid() {
+		return fmt.Errorf("unsupported alert kind %q", alert.Kind)
+	}
+	if !alert.Kind.IsPortfolio() && alert.Market == "" {
+		return errors.New("alert market is required")
+	}
+	if alert.Threshold <= 0 || math.IsInf(alert.Threshold, 0) || math.IsNaN(alert.Threshold) {
+		return errors.New("alert threshold must be a positive number")
+	}
+	return nil
+}
+
+// AddAlert validates and stores a new enabled alert.
+func (pa *PriceAlerts) AddAlert(kind Kind, market string, threshold float64) (*Alert, error) {
+	alert := &Alert{
+		Kind:      kind,
+		Market:    market,
+		Threshold: threshold,
+		Enabled:   true,
+		CreatedAt: time.Now().Unix(),
+	}
+	if kind.IsPortfolio() {
+		alert.Market = ""
+	}
+
+	if err := validate(alert); err != nil {
+		return nil, err
+	}
+
+	pa.mtx.Lock()
+	defer pa.mtx.Unlock()
+
+	if err := pa.db.Save(alert); err != nil {
+		return nil, fmt.Errorf("error saving alert: %w", err)
+	}
+	pa.alerts[alert.ID] = alert
+
+	log.Infof("Added %s alert %d", alert.Kind, alert.ID)
+	return copyAlert(alert), nil
+}
+
+// SetAlertEnabled enables or disables the alert with the provided ID. A
+// re-enabled alert is re-armed.
+func (pa *PriceAlerts) SetAlertEnabled(id int, enabled bool) error {
+	pa.mtx.Lock()
+	defer pa.mtx.Unlock()
+
+	alert, ok := pa.alerts[id]
+	if !ok {
+		return errors.New(utils.ErrNotExist)
+	}
+
+	updated := copyAlert(alert)
+	updated.Enabled = enabled
+	updated.Triggered = false
+	if err := pa.db.Save(updated); err != nil {
+		return fmt.Errorf("error updating alert: %w", err)
+	}
+	pa.alerts[id] = updated
+	return nil
+}
+
+// DeleteAlert deletes the alert with the provided ID.
+func (pa *PriceAlerts) DeleteAlert(id int) error {
+	pa.mtx.Lock()
+	defer pa.mtx.Unlock()
+
+	alert, ok := pa.alerts[id]
+	if !ok {
+		return errors.New(utils.ErrNotExist)
+	}
+
+	if err := pa.db.DeleteStruct(alert); err != nil {
+		return fmt.Errorf("error deleting alert: %w", err)
+	}
+	delete(pa.alerts, id)
+	return nil
+}
+
+// Alerts returns copies of the stored alerts ordered by creation time.
+func (pa *PriceAlerts) Alerts() []*Alert {
+	pa.mtx.Lock()
+	alerts := make([]*Alert, 0, len(pa.alerts))
+	for _, alert := range pa.alerts {
+		alerts = append(alerts, copyAlert(alert))
+	}
+	pa.mtx.Unlock()
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].ID < alerts[j].ID
+	})
+	return alerts
+}
+
+// HasEnabledAlerts returns true if at least one alert is enabled.
+func (pa *PriceAlerts) HasEnabledAlerts() bool {
+	pa.mtx.Lock()
+	defer pa.mtx.Unlock()
+	for _, alert := range pa.alerts {
+		if alert.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// observe returns the value alert is evaluated against and whether its
+// condition holds. ok is false if the snapshot has no value for the alert.
+func observe(alert *Alert, snapshot *Snapshot) (value float64, holds, ok bool) {
+	switch alert.Kind {
+	case PriceAbove, PriceBelow:
+		value, ok = snapshot.Prices[alert.Market]
+		if !ok || value <= 0 {
+			return 0, false, false
+		}
+		if alert.Kind == PriceAbove {
+			return value, value > alert.Threshold, true
+		}
+		return value, value < alert.Threshold, true
+	case PriceMove:
+		value, ok = snapshot.Changes[alert.Market]
+		if !ok {
+			return 0, false, false
+		}
+		return value, math.Abs(value) >= alert.Threshold, true
+	case PortfolioAbove, PortfolioBelow:
+		if !snapshot.HasPortfolioValue {
+			return 0, false, false
+		}
+		value = snapshot.PortfolioValue
+		if alert.Kind == PortfolioAbove {
+			return value, value > alert.Threshold, true
+		}
+		return value, value < alert.Threshold, true
+	}
+	return 0, false, false
+}
+
+// Evaluate checks the enabled alerts against snapshot. Alerts whose condition
+// started holding are marked as triggered and delivered to the notification
+// listeners. Triggered alerts whose condition no longer holds are re-armed.
+// The notifications of the triggered alerts are returned.
+func (pa *PriceAlerts) Evaluate(snapshot *Snapshot) []*Notification {
+	var notifications []*Notification
+
+	pa.mtx.Lock()
+	for id, alert := range pa.alerts {
+		if !alert.Enabled {
+			continue
+		}
+
+		value, holds, ok := observe(alert, snapshot)
+		if !ok || holds == alert.Triggered {
+			continue
+		}
+
+		updated := copyAlert(alert)
+		updated.Triggered = holds
+		if holds {
+			updated.LastTriggeredAt = time.Now().Unix()
+		}
+		if err := pa.db.Save(updated); err != nil {
+			log.Errorf("Error updating alert %d: %v", id, err)
+			continue
+		}
+		pa.alerts[id] = updated
+
+		if holds {
+			notifications = append(notifications, &Notification{
+				Alert: copyAlert(updated),
+				Value: value,
+			})
+		}
+	}
+	pa.mtx.Unlock()
+
+	for _, n := range notifications {
+		log.Infof("%s alert %d triggered at %.2f", n.Alert.Kind, n.Alert.ID, n.Value)
+		pa.publishAlert(n)
+	}
+
+	return notifications
+}
+
+func copyAlert(alert *Alert) *Alert {
+	c := *alert
+	return &c
+}
+
+func (pa *PriceAlerts) publishAlert(notification *Notification) {
+	pa.notificationListenersMu.RLock()
+	defer pa.notificationListenersMu.RUnlock()
+
+	for _, notificationListener := range pa.notificationListeners {
+		notificationListener.OnPriceAlert(notification)
+	}
+}
+
+func (pa *PriceAlerts) AddNotificationListener(notificationListener AlertNotificationListener, uniqueIdentifier string) error {
+	pa.notificationListenersMu.Lock()
+	defer pa.notificationListenersMu.Unlock()
+
+	if _, ok := pa.notificationListeners[uniqueIdentifier]; ok {
+		return errors.New(utils.ErrListenerAlreadyExist)
+	}
+
+	pa.notificationListeners[uniqueIdentifier] = notificationListener
+	return nil
+}
+
+func (pa *PriceAlerts) RemoveNotificationListener(uniqueIdentifier string) {
+	pa.notificationListenersMu.Lock()
+	defer pa.notificationListenersMu.Unlock()
+
+	delete(pa.notificationListeners, uniqueIdentifier)
+}
@@ -0,0 +1,102 @@
+package pricealert
+
+import (
+	"sync"
+
+	"github.com/asdine/storm"
+)
+
+// Kind is the condition that triggers an alert.
+type Kind string
+
+const (
+	// PriceAbove triggers when the price of a market rises above the
+	// alert's threshold.
+	PriceAbove Kind = "price_above"
+	// PriceBelow triggers when the price of a market falls below the
+	// alert's threshold.
+	PriceBelow Kind = "price_below"
+	// PriceMove triggers when the price of a market moves by more than the
+	// alert's threshold percentage in 24h, in either direction.
+	PriceMove Kind = "price_move"
+	// PortfolioAbove triggers when the total value of the user's assets
+	// rises above the alert's threshold.
+	PortfolioAbove Kind = "portfolio_above"
+	// PortfolioBelow triggers when the total value of the user's assets
+	// falls below the alert's threshold.
+	PortfolioBelow Kind = "portfolio_below"
+)
+
+// IsPortfolio returns true if the kind of alert is evaluated against the
+// portfolio value instead of a market's price.
+func (k Kind) IsPortfolio() bool {
+	return k == PortfolioAbove || k == PortfolioBelow
+}
+
+// IsValid returns true if k is a supported kind of alert.
+func (k Kind) IsValid() bool {
+	switch k {
+	case PriceAbove, PriceBelow, PriceMove, PortfolioAbove, PortfolioBelow:
+		return true
+	}
+	return false
+}
+
+// Alert is a user defined condition on a market's price or the portfolio
+// value. Prices and values are in USD(T).
+type Alert struct {
+	ID   int  `storm:"id,increment" json:"id"`
+	Kind Kind `json:"kind"`
+	// Market is the USDT market whose price is watched e.g DCR-USDT. It is
+	// empty for portfolio alerts.
+	Market string `json:"market"`
+	// Threshold is a USD price or value, or a percentage for PriceMove
+	// alerts.
+	Threshold float64 `json:"threshold"`
+	Enabled   bool    `json:"enabled"`
+	CreatedAt int64   `json:"createdAt"`
+
+	// Triggered is true while the alert's condition holds. An alert is only
+	// delivered when its condition starts holding, it is re-armed once the
+	// condition no longer holds.
+	Triggered       bool  `json:"triggered"`
+	LastTriggeredAt int64 `json:"lastTriggeredAt"`
+}
+
+// Snapshot is the market information alerts are evaluated against.
+type Snapshot struct {
+	// Prices maps USDT markets to their last trade price.
+	Prices map[string]float64
+	// Changes maps USDT markets to their 24h price change percentage.
+	Changes map[string]float64
+	// PortfolioValue is the total USD value of the user's assets. It is only
+	// used if HasPortfolioValue is true.
+	PortfolioValue    float64
+	HasPortfolioValue bool
+}
+
+// Notification is delivered to listeners when an alert is triggered.
+type Notification struct {
+	Alert *Alert
+	// Value is the price, value or percentage that triggered the alert.
+	Value float64
+}
+
+// AlertNotificationListener is notified of triggered alerts.
+type AlertNotificationListener interface {
+	OnPriceAlert(notification *Notification)
+}
+
+// PriceAlerts persists user defined alerts in the app database and evaluates
+// them against rate updates.
+type PriceAlerts struct {
+	db *storm.DB
+
+	// mtx protects alerts which caches the stored alerts as they are
+	// evaluated on every rate update.
+	mtx    sync.Mutex
+	alerts map[int]*Alert
+
+	notificationListenersMu sync.RWMutex
+	notificationListeners   map[string]AlertNotificationListener
+}
@@ -0,0 +1,277 @@
+package pricehistory
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+var (
+	// klinesURL is used to fetch the daily candles of a market from Binance.
+	// See: https://binance-docs.github.io/apidocs/spot/en/#kline-candlestick-data
+	klinesURL = "https://api.binance.com/api/v3/klines?symbol=%s&interval=1d&startTime=%d&limit=%d"
+
+	// maxKlinesPerRequest is the maximum number of candles returned by a
+	// single klines request.
+	maxKlinesPerRequest = 1000
+)
+
+// New returns a PriceHistory that stores candles in db.
+func New(db *storm.DB) (*PriceHistory, error) {
+	if err := db.Init(&Candle{}); err != nil {
+		log.Errorf("Error initializing price history database: %s", err.Error())
+		return nil, err
+	}
+
+	return &PriceHistory{db: db}, nil
+}
+
+// candleKey returns the unique key of the candle for market on day.
+func candleKey(market string, day int64) string {
+	return fmt.Sprintf("%s:%d", market, day)
+}
+
+// startOfDay returns the unix timestamp of the start of t's UTC day.
+func startOfDay(t time.Time) int64 {
+	return t.UTC().Truncate(CandleDuration).Unix()
+}
+
+// SaveCandles saves the provided candles, overwriting any existing candle for
+// the same market and day.
+func (ph *PriceHistory) SaveCandles(candles []*Candle) error {
+	tx, err := ph.db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range candles {
+		c.Timestamp = startOfDay(time.Unix(c.Timestamp, 0))
+		c.Key = candleKey(c.Market, c.Timestamp)
+		if err := tx.Save(c); err != nil {
+			return fmt.Errorf("error saving %s candle: %w", c.Market, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Candles returns the stored candles of market between from and to, ordered
+// by time.
+func (ph *PriceHistory) Candles(market string, from, to time.Time) ([]*Candle, error) {
+	query := ph.db.Select(
+		q.Eq("Market", market),
+		q.Gte("Timestamp", startOfDay(from)),
+		q.Lte("Timestamp", to.Unix()),
+	).OrderBy("Timestamp")
+
+	var candles []*Candle
+	err := query.Find(&candles)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching candles: %s", err.Error())
+	}
+
+	return candles, nil
+}
+
+// LastCandle returns the most recent stored candle of market. nil is returned
+// if no candle is stored.
+func (ph *PriceHistory) LastCandle(market string) (*Candle, error) {
+	var candles []*Candle
+	err := ph.db.Select(q.Eq("Market", market)).OrderBy("Timestamp").Reverse().Limit(1).Find(&candles)
+	if err != nil {
+		if err == storm.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return candles[0], nil
+}
+
+// Sync fetches and stores the daily candles of markets for the last days. Only
+// candles newer than the last stored candle of each market are fetched.
+func (ph *PriceHistory) Sync(ctx context.Context, days int, markets ...string) error {
+	ph.syncMtx.Lock()
+	defer ph.syncMtx.Unlock()
+
+	start := time.Now().Add(-time.Duration(days) * CandleDuration)
+	for _, market := range markets {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		from := start
+		last, err := ph.LastCandle(market)
+		if err != nil {
+			return err
+		}
+		// Always refetch the last stored candle as it may have been
+		// incomplete when it was fetched.
+		if last != nil && last.Time().After(from) {
+			from = last.Time()
+		}
+
+		candles, err := fetchCandles(market, from)
+		if err != nil {
+			return err
+		}
+
+		if err := ph.SaveCandles(candles); err != nil {
+			return err
+		}
+		log.Debugf("Synced %d %s candles", len(candles), market)
+	}
+
+	return nil
+}
+
+// fetchCandles fetches the daily candles of market from the start of from's
+// day till now.
+func fetchCandles(market string, from time.Time) ([]*Candle, error) {
+	symbol := strings.ReplaceAll(market, "-", "")
+	var candles []*Candle
+	startTime := startOfDay(from) * 1000
+	for {
+		reqCfg := &utils.ReqConfig{
+			HTTPURL: fmt.Sprintf(klinesURL, symbol, startTime, maxKlinesPerRequest),
+			Method:  "GET",
+		}
+
+		var klines [][]interface{}
+		if _, err := utils.HTTPRequest(reqCfg, &klines); err != nil {
+			return nil, fmt.Errorf("failed to fetch %s candles: %w", market, err)
+		}
+
+		for _, k := range klines {
+			c, err := parseKline(market, k)
+			if err != nil {
+				return nil, err
+			}
+			candles = append(candles, c)
+		}
+
+		if len(klines) < maxKlinesPerRequest {
+			return candles, nil
+		}
+		startTime = (candles[len(candles)-1].Timestamp + int64(CandleDuration.Seconds())) * 1000
+	}
+}
+
+// parseKline parses a Binance kline which is an array of the open time in
+// milliseconds followed by the open, high, low and close prices as strings.
+func parseKline(market string, kline []interface{}) (*Candle, error) {
+	if len(kline) < 5 {
+		return nil, errors.New("invalid kline")
+	}
+
+	openTime, ok := kline[0].(float64)
+	if !ok {
+		return nil, errors.New("unexpected type received as kline open time")
+	}
+
+	var prices [4]float64
+	for i := range prices {
+		priceStr, ok := kline[i+1].(string)
+		if !ok {
+			return nil, errors.New("unexpected type received as kline price")
+		}
+		price, err := strconv.ParseFloat(priceStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("strconv.ParseFloat error: %w", err)
+		}
+		prices[i] = price
+	}
+
+	return &Candle{
+		Market:    market,
+		Timestamp: int64(openTime) / 1000,
+		Open:      prices[0],
+		High:      prices[1],
+		Low:       prices[2],
+		Close:     prices[3],
+	}, nil
+}
+
+// Import reads candles from CSV data and stores them. Each record has the
+// fields market, timestamp, open, high, low and close where timestamp is a
+// unix timestamp in seconds. A header record is allowed. The number of
+// imported candles is returned.
+func (ph *PriceHistory) Import(r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 6
+	reader.TrimLeadingSpace = true
+
+	var candles []*Candle
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		timestamp, err := strconv.ParseInt(record[1], 10, 64)
+		if err != nil {
+			if line == 1 {
+				continue // header
+			}
+			return 0, fmt.Errorf("line %d: invalid timestamp: %w", line, err)
+		}
+
+		c := &Candle{
+			Market:    strings.ToUpper(record[0]),
+			Timestamp: timestamp,
+		}
+		for i, price := range []*float64{&c.Open, &c.High, &c.Low, &c.Close} {
+			if *price, err = strconv.ParseFloat(record[i+2], 64); err != nil {
+				return 0, fmt.Errorf("line %d: invalid price: %w", line, err)
+			}
+		}
+		candles = append(candles, c)
+	}
+
+	if err := ph.SaveCandles(candles); err != nil {
+		return 0, err
+	}
+
+	return len(candles), nil
+}
+
+// ImportFile imports candles from the CSV file at path. See Import.
+func (ph *PriceHistory) ImportFile(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return ph.Import(f)
+}
+
+// CloseAt returns the close price of the candle that covers t from candles
+// which must be ordered by time. The last known close before t is used if
+// there is no candle for t's day. Zero is returned if there is no candle at or
+// before t.
+func CloseAt(candles []*Candle, t time.Time) float64 {
+	day := startOfDay(t)
+	i := sort.Search(len(candles), func(i int) bool {
+		return candles[i].Timestamp > day
+	})
+	if i == 0 {
+		return 0
+	}
+	return candles[i-1].Close
+}
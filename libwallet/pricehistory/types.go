@@ -0,0 +1,40 @@
+package pricehistory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/asdine/storm"
+)
+
+const (
+	// CandleDuration is the period covered by each stored candle.
+	CandleDuration = 24 * time.Hour
+)
+
+// PriceHistory stores daily OHLC price history of the supported markets in
+// the app database so it is available offline once cached.
+type PriceHistory struct {
+	db *storm.DB
+
+	// syncMtx prevents concurrent syncs of the same data.
+	syncMtx sync.Mutex
+}
+
+// Candle is the daily OHLC price information for a market. Prices are quoted
+// in the market's quote currency e.g USDT for DCR-USDT.
+type Candle struct {
+	// Key is a unique market and day combination e.g DCR-USDT:1690156800.
+	Key       string  `storm:"id" json:"key"`
+	Market    string  `storm:"index" json:"market"`
+	Timestamp int64   `storm:"index" json:"timestamp"` // Start of the UTC day.
+	Open      float64 `json:"open"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Close     float64 `json:"close"`
+}
+
+// Time returns the start of the day covered by the candle.
+func (c *Candle) Time() time.Time {
+	return time.Unix(c.Timestamp, 0).UTC()
+}
@@ -0,0 +1,219 @@
+package libwallet
+
+import (
+	"sort"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// BackgroundService is a service that runs with the keys of a wallet
+// unlocked, or with its spending passphrase in memory.
+type BackgroundService string
+
+const (
+	ServiceAccountMixer   BackgroundService = "account_mixer"
+	ServiceTicketBuyer    BackgroundService = "ticket_buyer"
+	ServiceOrderScheduler BackgroundService = "order_scheduler"
+)
+
+// BackgroundServices are the services that may keep running when the app is
+// locked, in the order they are displayed.
+var BackgroundServices = []BackgroundService{
+	ServiceAccountMixer,
+	ServiceTicketBuyer,
+	ServiceOrderScheduler,
+}
+
+// KeyHolder is a service, or a wallet left unlocked, that holds the keys of a
+// wallet.
+type KeyHolder struct {
+	// Service is empty if the wallet is unlocked by no service.
+	Service    BackgroundService
+	WalletID   int
+	WalletName string
+	// SchedulerJobID is the ID of the job of an order scheduler.
+	SchedulerJobID int
+}
+
+// AutoLockTimeout returns how long the app can stay idle before it is locked.
+// Zero disables the auto-lock.
+func (mgr *AssetsManager) AutoLockTimeout() time.Duration {
+	var minutes int
+	if mgr.IsAssetManagerDB() {
+		mgr.db.ReadWalletConfigValue(sharedW.AutoLockTimeoutConfigKey, &minutes)
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// SetAutoLockTimeout sets how long the app can stay idle before it is locked,
+// rounded to the minute. Zero disables the auto-lock.
+func (mgr *AssetsManager) SetAutoLockTimeout(timeout time.Duration) {
+	if mgr.IsAssetManagerDB() {
+		mgr.db.SaveWalletConfigValue(sharedW.AutoLockTimeoutConfigKey, int(timeout/time.Minute))
+	}
+}
+
+// LockOnSleep returns true if the app is locked when the system sleeps or the
+// app window is hidden.
+func (mgr *AssetsManager) LockOnSleep() bool {
+	var lock bool
+	if mgr.IsAssetManagerDB() {
+		mgr.db.ReadWalletConfigValue(sharedW.LockOnSleepConfigKey, &lock)
+	}
+	return lock
+}
+
+// SetLockOnSleep sets whether the app is locked when the system sleeps or the
+// app window is hidden.
+func (mgr *AssetsManager) SetLockOnSleep(lock bool) {
+	if mgr.IsAssetManagerDB() {
+		mgr.db.SaveWalletConfigValue(sharedW.LockOnSleepConfigKey, lock)
+	}
+}
+
+// keepRunningWhenLocked returns the services that keep running when the app is
+// locked.
+func (mgr *AssetsManager) keepRunningWhenLocked() map[BackgroundService]bool {
+	var services []BackgroundService
+	if mgr.IsAssetManagerDB() {
+		mgr.db.ReadWalletConfigValue(sharedW.KeepRunningWhenLockedConfigKey, &services)
+	}
+
+	keep := make(map[BackgroundService]bool, len(services))
+	for _, service := range services {
+		keep[service] = true
+	}
+	return keep
+}
+
+// KeepsRunningWhenLocked returns true if service keeps running, with the keys
+// it holds, when the app is locked.
+func (mgr *AssetsManager) KeepsRunningWhenLocked(service BackgroundService) bool {
+	return mgr.keepRunningWhenLocked()[service]
+}
+
+// SetKeepRunningWhenLocked sets whether service keeps running when the app is
+// locked. The services that do not are stopped when the app is locked.
+func (mgr *AssetsManager) SetKeepRunningWhenLocked(service BackgroundService, keep bool) {
+	if !mgr.IsAssetManagerDB() {
+		return
+	}
+
+	services := mgr.keepRunningWhenLocked()
+	services[service] = keep
+	kept := make([]BackgroundService, 0, len(services))
+	for service, keep := range services {
+		if keep {
+			kept = append(kept, service)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i] < kept[j] })
+	mgr.db.SaveWalletConfigValue(sharedW.KeepRunningWhenLockedConfigKey, kept)
+}
+
+// UnlockedKeyHolders returns the services that hold the keys of the wallets
+// and the wallets that are unlocked by no service.
+func (mgr *AssetsManager) UnlockedKeyHolders() []*KeyHolder {
+	mgr.schedulerKeysMu.Lock()
+	schedulerJobs := make(map[int][]int)
+	for jobID, walletID := range mgr.schedulerKeys {
+		schedulerJobs[walletID] = append(schedulerJobs[walletID], jobID)
+	}
+	mgr.schedulerKeysMu.Unlock()
+
+	var holders []*KeyHolder
+	for _, wallet := range mgr.AllWallets() {
+		walletID, walletName := wallet.GetWalletID(), wallet.GetWalletName()
+		holder := func(service BackgroundService) *KeyHolder {
+			return &KeyHolder{Service: service, WalletID: walletID, WalletName: walletName}
+		}
+
+		var services []*KeyHolder
+		if asset, ok := wallet.(*dcr.Asset); ok {
+			if asset.IsAccountMixerActive() {
+				services = append(services, holder(ServiceAccountMixer))
+			}
+			if asset.IsAutoTicketsPurchaseActive() {
+				services = append(services, holder(ServiceTicketBuyer))
+			}
+		}
+		jobIDs := schedulerJobs[walletID]
+		sort.Ints(jobIDs)
+		for _, jobID := range jobIDs {
+			scheduler := holder(ServiceOrderScheduler)
+			scheduler.SchedulerJobID = jobID
+			services = append(services, scheduler)
+		}
+
+		if len(services) == 0 && wallet.WalletOpened() && !wallet.IsLocked() {
+			services = append(services, holder(""))
+		}
+		holders = append(holders, services...)
+	}
+	return holders
+}
+
+// LockSession stops the background services that do not keep running when
+// the app is locked and locks the wallets whose keys are not held by the
+// remaining services. The paused order schedulers are resumed with the
+// spending passphrase.
+func (mgr *AssetsManager) LockSession() {
+	keep := mgr.keepRunningWhenLocked()
+	for _, holder := range mgr.UnlockedKeyHolders() {
+		if holder.Service == "" || keep[holder.Service] {
+			continue
+		}
+
+		var err error
+		switch holder.Service {
+		case ServiceAccountMixer:
+			err = mgr.WalletWithID(holder.WalletID).(*dcr.Asset).StopAccountMixer()
+		case ServiceTicketBuyer:
+			err = mgr.WalletWithID(holder.WalletID).(*dcr.Asset).StopAutoTicketsPurchase()
+		case ServiceOrderScheduler:
+			err = mgr.pauseScheduler(holder.SchedulerJobID)
+		}
+		if err != nil {
+			log.Errorf("Error stopping the %s of wallet %d: %v", holder.Service, holder.WalletID, err)
+		} else {
+			log.Infof("Stopped the %s of wallet %d to lock the app", holder.Service, holder.WalletID)
+		}
+	}
+
+	held := make(map[int]bool)
+	for _, holder := range mgr.UnlockedKeyHolders() {
+		if holder.Service != "" {
+			held[holder.WalletID] = true
+		}
+	}
+	for _, wallet := range mgr.AllWallets() {
+		if !held[wallet.GetWalletID()] && wallet.WalletOpened() {
+			wallet.LockWallet()
+		}
+	}
+}
+
+// pauseScheduler stops the order scheduler job with the provided ID and drops
+// its spending passphrase. The job is resumed with ResumeScheduler.
+func (mgr *AssetsManager) pauseScheduler(jobID int) error {
+	mgr.InstantSwap.CancelScheduler(jobID)
+	mgr.schedulerKeysMu.Lock()
+	delete(mgr.schedulerKeys, jobID)
+	mgr.schedulerKeysMu.Unlock()
+
+	job, err := mgr.InstantSwap.GetSchedulerJob(jobID)
+	if err != nil {
+		return err
+	}
+	if !job.IsActive() {
+		return nil
+	}
+	job.NeedsPassphrase = true
+	if err := mgr.InstantSwap.SaveSchedulerJob(job); err != nil {
+		return err
+	}
+	log.Infof("Order Scheduler %d: paused until the spending passphrase is provided", jobID)
+	return nil
+}
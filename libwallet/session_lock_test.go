@@ -0,0 +1,131 @@
+package libwallet
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/asdine/storm"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// configDB is an in-memory assets manager level config database.
+type configDB map[string]json.RawMessage
+
+func (db configDB) DeleteWalletConfigValue(key string) {
+	delete(db, key)
+}
+
+func (db configDB) SaveWalletConfigValue(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	db[key] = data
+	return nil
+}
+
+func (db configDB) ReadWalletConfigValue(key string, valueOut interface{}) error {
+	data, ok := db[key]
+	if !ok {
+		return storm.ErrNotFound
+	}
+	return json.Unmarshal(data, valueOut)
+}
+
+func (db configDB) WalletConfigValues() (map[string]json.RawMessage, error) {
+	return db, nil
+}
+
+// keyWallet is an opened wallet whose keys are locked or not. Only the
+// methods used to find the key holders are implemented.
+type keyWallet struct {
+	sharedW.Asset
+	id     int
+	locked bool
+}
+
+func (w *keyWallet) GetWalletID() int           { return w.id }
+func (w *keyWallet) GetWalletName() string      { return "" }
+func (w *keyWallet) WalletOpened() bool         { return true }
+func (w *keyWallet) IsLocked() bool             { return w.locked }
+func (w *keyWallet) IsWatchingOnlyWallet() bool { return false }
+
+func TestKeepRunningWhenLocked(t *testing.T) {
+	db := configDB{}
+	mgr := &AssetsManager{db: db}
+
+	steps := []struct {
+		service BackgroundService
+		keep    bool
+		saved   []BackgroundService
+	}{
+		{ServiceTicketBuyer, true, []BackgroundService{ServiceTicketBuyer}},
+		{ServiceAccountMixer, true, []BackgroundService{ServiceAccountMixer, ServiceTicketBuyer}},
+		{ServiceOrderScheduler, false, []BackgroundService{ServiceAccountMixer, ServiceTicketBuyer}},
+		{ServiceTicketBuyer, false, []BackgroundService{ServiceAccountMixer}},
+	}
+
+	for _, step := range steps {
+		mgr.SetKeepRunningWhenLocked(step.service, step.keep)
+		if got := mgr.KeepsRunningWhenLocked(step.service); got != step.keep {
+			t.Errorf("%s: keeps running %v, want %v", step.service, got, step.keep)
+		}
+
+		var saved []BackgroundService
+		if err := db.ReadWalletConfigValue(sharedW.KeepRunningWhenLockedConfigKey, &saved); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(saved, step.saved) {
+			t.Errorf("%s: saved %v, want %v", step.service, saved, step.saved)
+		}
+	}
+
+	// Without a config database, no service keeps running.
+	mgr = &AssetsManager{}
+	mgr.SetKeepRunningWhenLocked(ServiceAccountMixer, true)
+	if len(mgr.keepRunningWhenLocked()) != 0 {
+		t.Error("service kept running without a config database")
+	}
+}
+
+func TestUnlockedKeyHolders(t *testing.T) {
+	mgr := &AssetsManager{
+		Assets: new(Assets),
+		schedulerKeys: map[int]int{
+			// Job ID to wallet ID.
+			5: 2,
+			3: 2,
+			7: 4,
+		},
+	}
+	mgr.Assets.BTC.Wallets = map[int]sharedW.Asset{
+		1: &keyWallet{id: 1},
+		2: &keyWallet{id: 2, locked: true},
+		3: &keyWallet{id: 3, locked: true},
+		4: &keyWallet{id: 4},
+	}
+
+	want := []KeyHolder{
+		// An unlocked wallet without services holds its own keys.
+		{WalletID: 1},
+		// The order schedulers hold the keys, in the order of their jobs.
+		{Service: ServiceOrderScheduler, WalletID: 2, SchedulerJobID: 3},
+		{Service: ServiceOrderScheduler, WalletID: 2, SchedulerJobID: 5},
+		// An unlocked wallet with services is only held by them.
+		{Service: ServiceOrderScheduler, WalletID: 4, SchedulerJobID: 7},
+	}
+
+	holders := mgr.UnlockedKeyHolders()
+	got := make([]KeyHolder, 0, len(holders))
+	for _, holder := range holders {
+		got = append(got, *holder)
+	}
+	// The wallets are not returned in a set order.
+	sort.SliceStable(got, func(i, j int) bool { return got[i].WalletID < got[j].WalletID })
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("key holders %+v, want %+v", got, want)
+	}
+}
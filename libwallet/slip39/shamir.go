@@ -0,0 +1,65 @@
+package slip39
+
+import "errors"
+
+// rawShare is a point of the polynomials over GF(256) that share a secret,
+// one polynomial per byte of the secret.
+type rawShare struct {
+	x    byte
+	data []byte
+}
+
+// expTable and logTable are the exponent and logarithm tables of GF(256)
+// with the Rijndael polynomial x^8 + x^4 + x^3 + x + 1 and generator x + 1.
+var expTable, logTable = func() (exp [255]byte, log [256]byte) {
+	poly := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(poly)
+		log[poly] = byte(i)
+		// Multiply poly by the generator x + 1.
+		poly = poly<<1 ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11b
+		}
+	}
+	return exp, log
+}()
+
+// interpolate returns the value at x of the polynomials that pass through
+// the shares.
+func interpolate(shares []rawShare, x byte) ([]byte, error) {
+	seen := make(map[byte]bool, len(shares))
+	for _, s := range shares {
+		if seen[s.x] {
+			return nil, errors.New("duplicate share index")
+		}
+		seen[s.x] = true
+		if len(s.data) != len(shares[0].data) {
+			return nil, errors.New("shares have different lengths")
+		}
+		if s.x == x {
+			return append([]byte{}, s.data...), nil
+		}
+	}
+
+	logProd := 0
+	for _, s := range shares {
+		logProd += int(logTable[s.x^x])
+	}
+
+	result := make([]byte, len(shares[0].data))
+	for _, s := range shares {
+		logBasis := logProd - int(logTable[s.x^x])
+		for _, other := range shares {
+			logBasis -= int(logTable[s.x^other.x])
+		}
+		logBasis = (logBasis%255 + 255) % 255
+
+		for i, v := range s.data {
+			if v != 0 {
+				result[i] ^= expTable[(int(logTable[v])+logBasis)%255]
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,462 @@
+// Package slip39 splits secrets into SLIP-39 Shamir shares and recovers them.
+// See https://github.com/satoshilabs/slips/blob/master/slip-0039.md.
+package slip39
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	radixBits                = 10
+	idLengthBits             = 15
+	extendableFlagLengthBits = 1
+	iterationExpLengthBits   = 4
+	idExpLengthWords         = 2
+	checksumLengthWords      = 3
+	digestLengthBytes        = 4
+	metadataLengthWords      = idExpLengthWords + 2 + checksumLengthWords
+	minStrengthBits          = 128
+	minMnemonicLengthWords   = metadataLengthWords + (minStrengthBits+radixBits-1)/radixBits
+	baseIterationCount       = 10000
+	roundCount               = 4
+	secretIndex              = 255
+	digestIndex              = 254
+
+	// MaxShareCount is the maximum number of shares a secret is split into.
+	MaxShareCount = 16
+
+	// iterationExponent sets the number of PBKDF2 iterations used to
+	// encrypt the secret to baseIterationCount << iterationExponent.
+	iterationExponent = 1
+)
+
+var (
+	// ErrInvalidShare is returned for a mnemonic that is not a SLIP-39
+	// share.
+	ErrInvalidShare = errors.New("invalid SLIP-39 share")
+	// ErrInsufficientShares is returned when there are not enough shares to
+	// recover the secret.
+	ErrInsufficientShares = errors.New("insufficient SLIP-39 shares")
+	// ErrMismatchedShares is returned for shares of different secrets.
+	ErrMismatchedShares = errors.New("SLIP-39 shares belong to different secrets")
+)
+
+var wordIndexes = func() map[string]int {
+	indexes := make(map[string]int, 1<<radixBits)
+	for i, word := range WordList() {
+		indexes[word] = i
+	}
+	return indexes
+}()
+
+// share is a decoded SLIP-39 mnemonic.
+type share struct {
+	identifier        uint16
+	extendable        bool
+	iterationExponent int
+	groupIndex        int
+	groupThreshold    int
+	groupCount        int
+	memberIndex       int
+	memberThreshold   int
+	value             []byte
+}
+
+// Split splits secret into count shares, any threshold of which recover it
+// with passphrase. The secret must be at least 16 bytes long and have an even
+// length.
+func Split(secret, passphrase []byte, threshold, count int) ([]string, error) {
+	if len(secret)*8 < minStrengthBits || len(secret)%2 != 0 {
+		return nil, fmt.Errorf("secret must be at least %d bytes long and have an even length", minStrengthBits/8)
+	}
+	if threshold < 1 || threshold > count || count > MaxShareCount {
+		return nil, fmt.Errorf("invalid %d-of-%d sharing, at most %d shares are supported", threshold, count, MaxShareCount)
+	}
+	if threshold == 1 && count > 1 {
+		return nil, errors.New("sharing with a threshold of 1 must use a single share")
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(id[:]) & (1<<idLengthBits - 1)
+
+	ems := encrypt(secret, passphrase, iterationExponent, identifier, true)
+	rawShares, err := splitSecret(threshold, count, ems)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonics := make([]string, 0, count)
+	for _, raw := range rawShares {
+		s := &share{
+			identifier:        identifier,
+			extendable:        true,
+			iterationExponent: iterationExponent,
+			groupThreshold:    1,
+			groupCount:        1,
+			memberIndex:       int(raw.x),
+			memberThreshold:   threshold,
+			value:             raw.data,
+		}
+		mnemonics = append(mnemonics, s.mnemonic())
+	}
+	return mnemonics, nil
+}
+
+// Combine recovers the secret of the mnemonics with passphrase. The
+// mnemonics must hold enough shares of each of enough groups.
+func Combine(mnemonics []string, passphrase []byte) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, ErrInsufficientShares
+	}
+
+	var first *share
+	groups := make(map[int]map[int]*share)
+	for _, mnemonic := range mnemonics {
+		s, err := parseShare(mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = s
+		}
+		if s.identifier != first.identifier || s.extendable != first.extendable ||
+			s.iterationExponent != first.iterationExponent || s.groupThreshold != first.groupThreshold ||
+			s.groupCount != first.groupCount || len(s.value) != len(first.value) {
+			return nil, ErrMismatchedShares
+		}
+
+		members, ok := groups[s.groupIndex]
+		if !ok {
+			members = make(map[int]*share)
+			groups[s.groupIndex] = members
+		}
+		if existing, ok := members[s.memberIndex]; ok {
+			if !bytes.Equal(existing.value, s.value) {
+				return nil, ErrMismatchedShares
+			}
+			continue
+		}
+		for _, member := range members {
+			if member.memberThreshold != s.memberThreshold {
+				return nil, ErrMismatchedShares
+			}
+		}
+		members[s.memberIndex] = s
+	}
+
+	var groupShares []rawShare
+	for groupIndex, members := range groups {
+		var memberThreshold int
+		memberShares := make([]rawShare, 0, len(members))
+		for _, member := range members {
+			memberThreshold = member.memberThreshold
+			memberShares = append(memberShares, rawShare{x: byte(member.memberIndex), data: member.value})
+		}
+		if len(memberShares) < memberThreshold {
+			continue
+		}
+		groupSecret, err := recoverSecret(memberThreshold, memberShares[:memberThreshold])
+		if err != nil {
+			return nil, err
+		}
+		groupShares = append(groupShares, rawShare{x: byte(groupIndex), data: groupSecret})
+		if len(groupShares) == first.groupThreshold {
+			break
+		}
+	}
+	if len(groupShares) < first.groupThreshold {
+		return nil, ErrInsufficientShares
+	}
+
+	ems, err := recoverSecret(first.groupThreshold, groupShares)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(ems, passphrase, first.iterationExponent, first.identifier, first.extendable), nil
+}
+
+// Threshold returns the number of shares of the group of mnemonic needed to
+// recover the secret.
+func Threshold(mnemonic string) (int, error) {
+	s, err := parseShare(mnemonic)
+	if err != nil {
+		return 0, err
+	}
+	return s.memberThreshold, nil
+}
+
+// mnemonic encodes the share as a mnemonic.
+func (s *share) mnemonic() string {
+	idExp := uint64(s.identifier)<<(extendableFlagLengthBits+iterationExpLengthBits) | uint64(s.iterationExponent)
+	if s.extendable {
+		idExp |= 1 << iterationExpLengthBits
+	}
+	params := uint64(s.groupIndex)<<16 | uint64(s.groupThreshold-1)<<12 | uint64(s.groupCount-1)<<8 |
+		uint64(s.memberIndex)<<4 | uint64(s.memberThreshold-1)
+
+	valueWords := (len(s.value)*8 + radixBits - 1) / radixBits
+	indexes := intToIndexes(new(big.Int).SetUint64(idExp), idExpLengthWords)
+	indexes = append(indexes, intToIndexes(new(big.Int).SetUint64(params), 2)...)
+	indexes = append(indexes, intToIndexes(new(big.Int).SetBytes(s.value), valueWords)...)
+	indexes = append(indexes, createChecksum(indexes, s.extendable)...)
+
+	wordList := WordList()
+	words := make([]string, len(indexes))
+	for i, index := range indexes {
+		words[i] = wordList[index]
+	}
+	return strings.Join(words, " ")
+}
+
+// parseShare decodes mnemonic.
+func parseShare(mnemonic string) (*share, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) < minMnemonicLengthWords {
+		return nil, ErrInvalidShare
+	}
+	paddingBits := (radixBits * (len(words) - metadataLengthWords)) % 16
+	if paddingBits > 8 {
+		return nil, ErrInvalidShare
+	}
+
+	indexes := make([]int, len(words))
+	for i, word := range words {
+		index, ok := wordIndexes[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidShare, word)
+		}
+		indexes[i] = index
+	}
+
+	idExp := indexesToInt(indexes[:idExpLengthWords]).Uint64()
+	s := &share{
+		identifier:        uint16(idExp >> (extendableFlagLengthBits + iterationExpLengthBits)),
+		extendable:        (idExp>>iterationExpLengthBits)&1 == 1,
+		iterationExponent: int(idExp & (1<<iterationExpLengthBits - 1)),
+	}
+	if !verifyChecksum(indexes, s.extendable) {
+		return nil, fmt.Errorf("%w: invalid checksum", ErrInvalidShare)
+	}
+
+	params := indexesToInt(indexes[idExpLengthWords : idExpLengthWords+2]).Uint64()
+	s.groupIndex = int(params >> 16 & 0xf)
+	s.groupThreshold = int(params>>12&0xf) + 1
+	s.groupCount = int(params>>8&0xf) + 1
+	s.memberIndex = int(params >> 4 & 0xf)
+	s.memberThreshold = int(params&0xf) + 1
+	if s.groupCount < s.groupThreshold {
+		return nil, ErrInvalidShare
+	}
+
+	valueIndexes := indexes[idExpLengthWords+2 : len(indexes)-checksumLengthWords]
+	valueBytes := (radixBits*len(valueIndexes) - paddingBits) / 8
+	value := indexesToInt(valueIndexes)
+	if value.BitLen() > valueBytes*8 {
+		return nil, fmt.Errorf("%w: invalid padding", ErrInvalidShare)
+	}
+	s.value = value.FillBytes(make([]byte, valueBytes))
+	return s, nil
+}
+
+// intToIndexes returns the count words of radixBits that encode n.
+func intToIndexes(n *big.Int, count int) []int {
+	indexes := make([]int, count)
+	mask := big.NewInt(1<<radixBits - 1)
+	v := new(big.Int).Set(n)
+	for i := count - 1; i >= 0; i-- {
+		indexes[i] = int(new(big.Int).And(v, mask).Int64())
+		v.Rsh(v, radixBits)
+	}
+	return indexes
+}
+
+// indexesToInt returns the number encoded by the indexes of words.
+func indexesToInt(indexes []int) *big.Int {
+	n := new(big.Int)
+	for _, index := range indexes {
+		n.Lsh(n, radixBits)
+		n.Or(n, big.NewInt(int64(index)))
+	}
+	return n
+}
+
+// customizationString returns the customization string of the checksum and
+// of the encryption of non-extendable shares.
+func customizationString(extendable bool) []byte {
+	if extendable {
+		return []byte("shamir_extendable")
+	}
+	return []byte("shamir")
+}
+
+// rs1024Polymod computes the Reed-Solomon checksum polynomial of values.
+func rs1024Polymod(values []int) uint32 {
+	gen := [10]uint32{
+		0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009,
+		0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120,
+	}
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ uint32(v)
+		for i := 0; i < 10; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func checksumValues(indexes []int, extendable bool) []int {
+	custom := customizationString(extendable)
+	values := make([]int, 0, len(custom)+len(indexes)+checksumLengthWords)
+	for _, c := range custom {
+		values = append(values, int(c))
+	}
+	return append(values, indexes...)
+}
+
+// createChecksum returns the checksum words of the indexes of words.
+func createChecksum(indexes []int, extendable bool) []int {
+	values := append(checksumValues(indexes, extendable), make([]int, checksumLengthWords)...)
+	polymod := rs1024Polymod(values) ^ 1
+	checksum := make([]int, checksumLengthWords)
+	for i := range checksum {
+		checksum[i] = int(polymod>>(radixBits*(checksumLengthWords-1-i))) & (1<<radixBits - 1)
+	}
+	return checksum
+}
+
+// verifyChecksum returns true if the indexes of words end with their
+// checksum.
+func verifyChecksum(indexes []int, extendable bool) bool {
+	return rs1024Polymod(checksumValues(indexes, extendable)) == 1
+}
+
+// salt returns the salt of the encryption of the secret.
+func salt(identifier uint16, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	return append(customizationString(false), byte(identifier>>8), byte(identifier))
+}
+
+// roundFunction is the round function of the Feistel network that encrypts
+// the secret.
+func roundFunction(i int, passphrase []byte, exponent int, salt, r []byte) []byte {
+	pass := append([]byte{byte(i)}, passphrase...)
+	iterations := (baseIterationCount << exponent) / roundCount
+	return pbkdf2.Key(pass, append(append([]byte{}, salt...), r...), iterations, len(r), sha256.New)
+}
+
+func xor(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
+
+// encrypt encrypts secret with passphrase.
+func encrypt(secret, passphrase []byte, exponent int, identifier uint16, extendable bool) []byte {
+	l, r := secret[:len(secret)/2], secret[len(secret)/2:]
+	s := salt(identifier, extendable)
+	for i := 0; i < roundCount; i++ {
+		l, r = r, xor(l, roundFunction(i, passphrase, exponent, s, r))
+	}
+	return append(append([]byte{}, r...), l...)
+}
+
+// decrypt decrypts the encrypted secret ems with passphrase.
+func decrypt(ems, passphrase []byte, exponent int, identifier uint16, extendable bool) []byte {
+	l, r := ems[:len(ems)/2], ems[len(ems)/2:]
+	s := salt(identifier, extendable)
+	for i := roundCount - 1; i >= 0; i-- {
+		l, r = r, xor(l, roundFunction(i, passphrase, exponent, s, r))
+	}
+	return append(append([]byte{}, r...), l...)
+}
+
+// createDigest returns the digest that authenticates the shared secret.
+func createDigest(randomData, sharedSecret []byte) []byte {
+	mac := hmac.New(sha256.New, randomData)
+	mac.Write(sharedSecret)
+	return mac.Sum(nil)[:digestLengthBytes]
+}
+
+// splitSecret splits secret into count raw shares, any threshold of which
+// recover it.
+func splitSecret(threshold, count int, secret []byte) ([]rawShare, error) {
+	if threshold == 1 {
+		shares := make([]rawShare, count)
+		for i := range shares {
+			shares[i] = rawShare{x: byte(i), data: secret}
+		}
+		return shares, nil
+	}
+
+	randomShareCount := threshold - 2
+	shares := make([]rawShare, 0, count)
+	for i := 0; i < randomShareCount; i++ {
+		data := make([]byte, len(secret))
+		if _, err := rand.Read(data); err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), data: data})
+	}
+
+	randomPart := make([]byte, len(secret)-digestLengthBytes)
+	if _, err := rand.Read(randomPart); err != nil {
+		return nil, err
+	}
+	digest := createDigest(randomPart, secret)
+	baseShares := append(append([]rawShare{}, shares...),
+		rawShare{x: digestIndex, data: append(digest, randomPart...)},
+		rawShare{x: secretIndex, data: secret},
+	)
+
+	for i := randomShareCount; i < count; i++ {
+		data, err := interpolate(baseShares, byte(i))
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), data: data})
+	}
+	return shares, nil
+}
+
+// recoverSecret recovers the secret of threshold shares and verifies its
+// digest.
+func recoverSecret(threshold int, shares []rawShare) ([]byte, error) {
+	if threshold == 1 {
+		return shares[0].data, nil
+	}
+
+	secret, err := interpolate(shares, secretIndex)
+	if err != nil {
+		return nil, err
+	}
+	digestShare, err := interpolate(shares, digestIndex)
+	if err != nil {
+		return nil, err
+	}
+	digest, randomPart := digestShare[:digestLengthBytes], digestShare[digestLengthBytes:]
+	if !hmac.Equal(digest, createDigest(randomPart, secret)) {
+		return nil, ErrMismatchedShares
+	}
+	return secret, nil
+}
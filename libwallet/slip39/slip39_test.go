@@ -0,0 +1,147 @@
+package slip39
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// The vectors are from
+// https://github.com/trezor/python-shamir-mnemonic/blob/master/vectors.json,
+// all of which use the passphrase "TREZOR".
+func TestCombineVectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		mnemonics []string
+		secret    string
+		err       error
+	}{{
+		name: "valid mnemonic without sharing",
+		mnemonics: []string{
+			"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard",
+		},
+		secret: "bb54aac4b89dc868ba37d9cc21b2cece",
+	}, {
+		name: "mnemonic with invalid checksum",
+		mnemonics: []string{
+			"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision kidney",
+		},
+		err: ErrInvalidShare,
+	}, {
+		name: "basic sharing 2-of-3",
+		mnemonics: []string{
+			"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+			"shadow pistol academic acid actress prayer class unknown daughter sweater depict flip twice unkind craft early superior advocate guest smoking",
+		},
+		secret: "b43ceb7e57a0ea8766221624d01b0864",
+	}, {
+		name: "basic sharing 2-of-3 with one share",
+		mnemonics: []string{
+			"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+		},
+		err: ErrInsufficientShares,
+	}, {
+		name: "basic sharing 2-of-3 with a repeated share",
+		mnemonics: []string{
+			"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+			"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+		},
+		err: ErrInsufficientShares,
+	}, {
+		name: "shares of different secrets",
+		mnemonics: []string{
+			"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard",
+			"shadow pistol academic acid actress prayer class unknown daughter sweater depict flip twice unkind craft early superior advocate guest smoking",
+		},
+		err: ErrMismatchedShares,
+	}, {
+		name: "unknown word",
+		mnemonics: []string{
+			"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboards",
+		},
+		err: ErrInvalidShare,
+	}, {
+		name:      "no mnemonics",
+		mnemonics: nil,
+		err:       ErrInsufficientShares,
+	}}
+
+	for _, test := range tests {
+		secret, err := Combine(test.mnemonics, []byte("TREZOR"))
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if got := hex.EncodeToString(secret); got != test.secret {
+			t.Errorf("%s: secret %s, want %s", test.name, got, test.secret)
+		}
+	}
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x5a}, 32)
+	passphrase := []byte("TREZOR")
+
+	tests := []struct {
+		threshold, count int
+	}{
+		{1, 1},
+		{2, 3},
+		{3, 5},
+		{MaxShareCount, MaxShareCount},
+	}
+
+	for _, test := range tests {
+		mnemonics, err := Split(secret, passphrase, test.threshold, test.count)
+		if err != nil {
+			t.Fatalf("%d-of-%d: Split error: %v", test.threshold, test.count, err)
+		}
+		if len(mnemonics) != test.count {
+			t.Fatalf("%d-of-%d: %d shares, want %d", test.threshold, test.count, len(mnemonics), test.count)
+		}
+		threshold, err := Threshold(mnemonics[0])
+		if err != nil || threshold != test.threshold {
+			t.Errorf("%d-of-%d: Threshold %d (%v), want %d", test.threshold, test.count, threshold, err, test.threshold)
+		}
+
+		recovered, err := Combine(mnemonics[test.count-test.threshold:], passphrase)
+		if err != nil {
+			t.Fatalf("%d-of-%d: Combine error: %v", test.threshold, test.count, err)
+		}
+		if !bytes.Equal(recovered, secret) {
+			t.Errorf("%d-of-%d: secret %x, want %x", test.threshold, test.count, recovered, secret)
+		}
+
+		if test.threshold > 1 {
+			_, err := Combine(mnemonics[:test.threshold-1], passphrase)
+			if !errors.Is(err, ErrInsufficientShares) {
+				t.Errorf("%d-of-%d: Combine below threshold error %v, want %v", test.threshold, test.count, err, ErrInsufficientShares)
+			}
+		}
+	}
+}
+
+func TestSplitInvalid(t *testing.T) {
+	tests := []struct {
+		name             string
+		secret           []byte
+		threshold, count int
+	}{
+		{"short secret", make([]byte, 14), 2, 3},
+		{"odd secret length", make([]byte, 17), 2, 3},
+		{"threshold above count", make([]byte, 16), 4, 3},
+		{"zero threshold", make([]byte, 16), 0, 3},
+		{"too many shares", make([]byte, 16), 2, MaxShareCount + 1},
+		{"threshold of 1 with several shares", make([]byte, 16), 1, 2},
+	}
+
+	for _, test := range tests {
+		if _, err := Split(test.secret, nil, test.threshold, test.count); err == nil {
+			t.Errorf("%s: Split succeeded", test.name)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package libwallet
+
+import (
+	"context"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	api "github.com/crypto-power/instantswap/instantswap"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// payoutVerifierID identifies the listeners that verify the payouts of
+	// the exchange orders.
+	payoutVerifierID = "swap_payout_verifier"
+
+	// payoutTxSearchLimit is the number of recent transactions searched for
+	// the payout of an order.
+	payoutTxSearchLimit = 100
+
+	// payoutAmountTolerance is the fraction by which a payout may fall short
+	// of the order's receive amount, which some servers only estimate.
+	payoutAmountTolerance = 0.01
+
+	// refundFeeTolerance is the fraction of the deposit of an order that its
+	// refund may fall short of, as servers deduct their network fee.
+	refundFeeTolerance = 0.1
+
+	// payoutVerifyWindow is how long after their creation the payouts of
+	// orders reported completed by their server are still verified.
+	payoutVerifyWindow = 7 * 24 * time.Hour
+)
+
+// walletActivityListener calls onActivity whenever a wallet sees a new
+// transaction or block.
+type walletActivityListener struct {
+	onActivity func()
+}
+
+func (l *walletActivityListener) OnTransaction(_ string)                          { l.onActivity() }
+func (l *walletActivityListener) OnBlockAttached(_ int, _ int32)                  { l.onActivity() }
+func (l *walletActivityListener) OnTransactionConfirmed(_ int, _ string, _ int32) { l.onActivity() }
+
+// payoutVerifier verifies the payouts of the pending exchange orders when the
+// orders are synced or created and when a wallet receiving a payout sees a new
+// transaction or block.
+type payoutVerifier struct {
+	mgr      *AssetsManager
+	verifyCh chan struct{}
+}
+
+// notify schedules the verification of the pending payouts. Notifications
+// received while a verification is scheduled are coalesced.
+func (pv *payoutVerifier) notify() {
+	select {
+	case pv.verifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (pv *payoutVerifier) OnExchangeOrdersSynced()             { pv.notify() }
+func (pv *payoutVerifier) OnOrderCreated(_ *instantswap.Order) { pv.notify() }
+func (pv *payoutVerifier) OnOrderSchedulerStarted()            {}
+func (pv *payoutVerifier) OnOrderSchedulerEnded()              {}
+
+// startPayoutVerification verifies the payouts of the pending exchange orders
+// against the destination wallets' transactions until ctx is canceled.
+func (mgr *AssetsManager) startPayoutVerification(ctx context.Context) error {
+	pv := &payoutVerifier{
+		mgr:      mgr,
+		verifyCh: make(chan struct{}, 1),
+	}
+	if err := mgr.InstantSwap.AddNotificationListener(pv, payoutVerifierID); err != nil {
+		return err
+	}
+
+	go func() {
+		defer mgr.InstantSwap.RemoveNotificationListener(payoutVerifierID)
+		for {
+			select {
+			case <-pv.verifyCh:
+				pv.verifyPendingPayouts()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	pv.notify()
+	return nil
+}
+
+// verifyPendingPayouts verifies the payouts of the orders that are not yet
+// final. The destination wallets of the orders are watched for new
+// transactions and blocks.
+func (pv *payoutVerifier) verifyPendingPayouts() {
+	orders, err := pv.mgr.InstantSwap.GetOrdersRaw(0, 0, true)
+	if err != nil {
+		log.Errorf("Error fetching exchange orders: %v", err)
+		return
+	}
+
+	for _, order := range orders {
+		if !isPayoutPending(order) {
+			continue
+		}
+
+		wallet := pv.mgr.WalletWithID(order.DestinationWalletID)
+		if wallet == nil {
+			continue
+		}
+
+		err := wallet.AddTxAndBlockNotificationListener(&walletActivityListener{onActivity: pv.notify}, true, payoutVerifierID)
+		if err != nil && err.Error() != utils.ErrListenerAlreadyExist {
+			log.Errorf("Error watching %s for order payouts: %v", wallet.GetWalletName(), err)
+		}
+
+		if _, err := pv.mgr.VerifyOrderPayout(order); err != nil {
+			log.Errorf("Error verifying order %s payout: %v", order.UUID, err)
+		}
+	}
+}
+
+// isPayoutPending returns true if the payout of order may still be received.
+func isPayoutPending(order *instantswap.Order) bool {
+	if order.PayoutTxID != "" {
+		return false
+	}
+
+	switch order.Status {
+	case api.OrderStatusCompleted:
+		return time.Since(time.Unix(order.CreatedAt, 0)) < payoutVerifyWindow
+	case api.OrderStatusRefunded, api.OrderStatusCanceled, api.OrderStatusExpired, api.OrderStatusFailed:
+		return false
+	}
+	return true
+}
+
+// VerifyOrderPayout checks the transactions of the destination wallet of order
+// for its payout. The order is marked as completed once a transaction paying
+// the receive amount to the order's destination address has the wallet's
+// required confirmations. Returns true if the payout is verified.
+func (mgr *AssetsManager) VerifyOrderPayout(order *instantswap.Order) (bool, error) {
+	if order.PayoutTxID != "" {
+		return true, nil
+	}
+
+	wallet := mgr.WalletWithID(order.DestinationWalletID)
+	if wallet == nil {
+		return false, errors.Errorf("wallet with id:%d not found", order.DestinationWalletID)
+	}
+
+	txHash, ok, err := findReceivedTx(wallet, order.DestinationAddress, order.ReceiveAmount, order.TxID, order.CreatedAt)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	log.Infof("Order %s payout %s verified", order.UUID, txHash)
+	order.PayoutTxID = txHash
+	order.Status = api.OrderStatusCompleted
+	if err := mgr.InstantSwap.UpdateOrder(order); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// findReceivedTx searches the recent transactions of wallet for one paying
+// about amount to address since the provided unix time, preferring the
+// transaction with hash txHash. ok is true if the transaction has the
+// wallet's required confirmations.
+func findReceivedTx(wallet sharedW.Asset, address string, amount float64, txHash string, since int64) (hash string, ok bool, err error) {
+	txs, err := wallet.GetTransactionsRaw(0, payoutTxSearchLimit, utils.TxFilterReceived, true)
+	if err != nil {
+		return "", false, err
+	}
+
+	minAmount := coinToAtoms(wallet, amount*(1-payoutAmountTolerance))
+	var match *sharedW.Transaction
+	for _, tx := range txs {
+		if tx.Timestamp < since {
+			continue
+		}
+
+		var received int64
+		for _, output := range tx.Outputs {
+			if output.Address == address {
+				received += output.Amount
+			}
+		}
+		if received == 0 || received < minAmount {
+			continue
+		}
+
+		if match == nil || tx.Hash == txHash {
+			match = tx
+		}
+	}
+
+	if match == nil {
+		return "", false, nil
+	}
+	return match.Hash, txConfirmations(wallet, match) >= wallet.RequiredConfirmations(), nil
+}
+
+// findRefundTx searches wallet for the refund of the deposit of order. The
+// transaction reported by the server is used if the wallet has it, otherwise
+// the recent transactions are searched for one paying the refund address at
+// most the deposit and no less than the deposit net of the server's fee. ok is
+// true if the transaction has the wallet's required confirmations.
+func findRefundTx(wallet sharedW.Asset, order *instantswap.Order, deposit float64) (hash string, ok bool, err error) {
+	if order.TxID != "" {
+		tx, err := wallet.GetTransactionRaw(order.TxID)
+		if err == nil && tx != nil {
+			return tx.Hash, txConfirmations(wallet, tx) >= wallet.RequiredConfirmations(), nil
+		}
+	}
+
+	txs, err := wallet.GetTransactionsRaw(0, payoutTxSearchLimit, utils.TxFilterReceived, true)
+	if err != nil {
+		return "", false, err
+	}
+
+	minAmount := coinToAtoms(wallet, deposit*(1-refundFeeTolerance))
+	maxAmount := coinToAtoms(wallet, deposit)
+	for _, tx := range txs {
+		if tx.Timestamp < order.CreatedAt {
+			continue
+		}
+
+		var received int64
+		for _, output := range tx.Outputs {
+			if output.Address == order.RefundAddress {
+				received += output.Amount
+			}
+		}
+		if received >= minAmount && received <= maxAmount {
+			return tx.Hash, txConfirmations(wallet, tx) >= wallet.RequiredConfirmations(), nil
+		}
+	}
+	return "", false, nil
+}
+
+// txConfirmations returns the number of confirmations of tx.
+func txConfirmations(wallet sharedW.Asset, tx *sharedW.Transaction) int32 {
+	if tx.BlockHeight == sharedW.UnminedTxHeight {
+		return 0
+	}
+	return wallet.GetBestBlockHeight() - tx.BlockHeight + 1
+}
@@ -0,0 +1,221 @@
+package libwallet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	api "github.com/crypto-power/instantswap/instantswap"
+
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/pricehistory"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// swapReportsDir is the directory of RootDir where swap reports are exported.
+const swapReportsDir = "exports"
+
+// SwapLegKind is the side of a swap recorded by a SwapReportEntry.
+type SwapLegKind string
+
+const (
+	// SwapDisposal is the asset sent to the exchange server.
+	SwapDisposal SwapLegKind = "disposal"
+	// SwapAcquisition is the asset received from the exchange server.
+	SwapAcquisition SwapLegKind = "acquisition"
+)
+
+// SwapReportFormat is the file format of an exported swap report.
+type SwapReportFormat string
+
+const (
+	SwapReportCSV  SwapReportFormat = "csv"
+	SwapReportJSON SwapReportFormat = "json"
+)
+
+// SwapReportEntry is one side of a completed exchange order. Every order is
+// reported as the disposal of the source asset and the acquisition of the
+// destination asset, sharing the order UUID.
+type SwapReportEntry struct {
+	OrderUUID string      `json:"orderUUID"`
+	Server    string      `json:"server"`
+	Timestamp int64       `json:"timestamp"`
+	Kind      SwapLegKind `json:"kind"`
+	Asset     string      `json:"asset"`
+	Amount    float64     `json:"amount"`
+	// FiatValue is the USD value of Amount at the time of the order. It is
+	// zero if the price history of the asset is not available.
+	FiatValue float64 `json:"fiatValue"`
+	// Fee is the order's charged fee in units of the acquired asset. It is
+	// only set on the acquisition.
+	Fee          float64 `json:"fee"`
+	FeeFiatValue float64 `json:"feeFiatValue"`
+	// WalletID and TxID identify the transaction of the wallet that sent
+	// the deposit or received the payout. TxID is empty for payouts that
+	// were not verified.
+	WalletID int    `json:"walletID"`
+	TxID     string `json:"txid"`
+}
+
+// SwapReport returns the disposal and acquisition entries of the completed
+// exchange orders, oldest first. Fiat values are computed from the cached
+// price history, see SyncPriceHistory.
+func (mgr *AssetsManager) SwapReport() ([]*SwapReportEntry, error) {
+	orders, err := mgr.InstantSwap.GetOrdersRaw(0, 0, false, api.OrderStatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string][]*pricehistory.Candle)
+	priceAt := func(asset string, timestamp int64) (float64, error) {
+		market, ok := values.AssetExchangeMarketValue[utils.AssetType(asset)]
+		if !ok {
+			return 0, nil
+		}
+		candles, ok := prices[market]
+		if !ok {
+			candles, err = mgr.PriceHistory.Candles(market, time.Unix(0, 0), time.Now())
+			if err != nil {
+				return 0, err
+			}
+			prices[market] = candles
+		}
+		return pricehistory.CloseAt(candles, time.Unix(timestamp, 0)), nil
+	}
+
+	entries := make([]*SwapReportEntry, 0, len(orders)*2)
+	for _, order := range orders {
+		fromPrice, err := priceAt(order.FromCurrency, order.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		toPrice, err := priceAt(order.ToCurrency, order.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		sent := order.InvoicedAmount
+		if sent == 0 {
+			sent = order.OrderedAmount
+		}
+
+		server := orderServerName(order)
+		entries = append(entries, &SwapReportEntry{
+			OrderUUID: order.UUID,
+			Server:    server,
+			Timestamp: order.CreatedAt,
+			Kind:      SwapDisposal,
+			Asset:     order.FromCurrency,
+			Amount:    sent,
+			FiatValue: sent * fromPrice,
+			WalletID:  order.SourceWalletID,
+			TxID:      order.TxID,
+		}, &SwapReportEntry{
+			OrderUUID:    order.UUID,
+			Server:       server,
+			Timestamp:    order.CreatedAt,
+			Kind:         SwapAcquisition,
+			Asset:        order.ToCurrency,
+			Amount:       order.ReceiveAmount,
+			FiatValue:    order.ReceiveAmount * toPrice,
+			Fee:          order.ChargedFee,
+			FeeFiatValue: order.ChargedFee * toPrice,
+			WalletID:     order.DestinationWalletID,
+			TxID:         order.PayoutTxID,
+		})
+	}
+	return entries, nil
+}
+
+// orderServerName returns the name of the exchange server of order, falling
+// back to the legacy Server field of old orders.
+func orderServerName(order *instantswap.Order) string {
+	if order.ExchangeServer.Server != "" {
+		return order.ExchangeServer.Server.ToString()
+	}
+	return order.Server.ToString()
+}
+
+// ExportSwapReport writes the swap report to a new file of the provided format
+// and returns its path.
+func (mgr *AssetsManager) ExportSwapReport(format SwapReportFormat) (string, error) {
+	entries, err := mgr.SwapReport()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(mgr.params.RootDir, swapReportsDir)
+	if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("swaps-%s.%s", time.Now().Format("20060102-150405"), format))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	switch format {
+	case SwapReportCSV:
+		err = WriteSwapReportCSV(f, entries)
+	case SwapReportJSON:
+		err = WriteSwapReportJSON(f, entries)
+	default:
+		err = errors.Errorf("unsupported swap report format %q", format)
+	}
+	if err != nil {
+		return "", err
+	}
+	return path, f.Close()
+}
+
+// WriteSwapReportCSV writes entries to w as CSV with a header row. Times are
+// formatted as RFC 3339 in UTC.
+func WriteSwapReportCSV(w io.Writer, entries []*SwapReportEntry) error {
+	writer := csv.NewWriter(w)
+	header := []string{"order_uuid", "server", "time", "kind", "asset", "amount",
+		"fiat_value_usd", "fee", "fee_fiat_value_usd", "wallet_id", "txid"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	for _, entry := range entries {
+		record := []string{
+			entry.OrderUUID,
+			entry.Server,
+			time.Unix(entry.Timestamp, 0).UTC().Format(time.RFC3339),
+			string(entry.Kind),
+			entry.Asset,
+			formatFloat(entry.Amount),
+			formatFloat(entry.FiatValue),
+			formatFloat(entry.Fee),
+			formatFloat(entry.FeeFiatValue),
+			strconv.Itoa(entry.WalletID),
+			entry.TxID,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteSwapReportJSON writes entries to w as an indented JSON array.
+func WriteSwapReportJSON(w io.Writer, entries []*SwapReportEntry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
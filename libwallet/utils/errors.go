@@ -46,6 +46,16 @@ const (
 	ErrNoMixableOutput              = "err_no_mixable_output"
 	ErrInvalidVoteBit               = "err_invalid_vote_bit"
 	ErrNotSynced                    = "err_not_synced"
+	ErrSpendingLimitExceeded        = "spending_limit_exceeded"
+	ErrDestinationNotAllowed        = "destination_not_allowed"
+	ErrSpendDelayed                 = "spend_delayed"
+	ErrPassphraseTooShort           = "passphrase_too_short"
+	ErrWeakPassphrase               = "weak_passphrase"
+	ErrPINNotNumeric                = "pin_not_numeric"
+	ErrTooManyAttempts              = "too_many_attempts"
+	ErrInvalidPrivateKey            = "invalid_private_key"
+	ErrNothingToSweep               = "nothing_to_sweep"
+	ErrAddressWatchWallet           = "address_watch_wallet"
 )
 
 var (
@@ -0,0 +1,389 @@
+package libwallet
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"decred.org/dcrwallet/v3/errors"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/diagnostics"
+	btcloader "github.com/crypto-power/cryptopower/libwallet/internal/loader/btc"
+	ltcloader "github.com/crypto-power/cryptopower/libwallet/internal/loader/ltc"
+	"github.com/crypto-power/cryptopower/libwallet/metadata"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// walletDBName is the name of the database of every wallet asset.
+const walletDBName = "wallet.db"
+
+// walletDataDir returns the data directory of wallet. The root directory is
+// not set on the wallets that failed to load.
+func (mgr *AssetsManager) walletDataDir(wallet *sharedW.Wallet) string {
+	if wallet.RootDir() == "" {
+		return filepath.Join(mgr.params.RootDir, wallet.DataDir())
+	}
+	return wallet.DataDir()
+}
+
+// walletRecord returns the stored wallet with walletID.
+func (mgr *AssetsManager) walletRecord(walletID int) (*sharedW.Wallet, error) {
+	wallet := new(sharedW.Wallet)
+	if err := mgr.params.DB.One("ID", walletID, wallet); err != nil {
+		return nil, utils.TranslateError(err)
+	}
+	wallet.SetNetType(mgr.NetType())
+	return wallet, nil
+}
+
+// markBadWallet shuts down the wallet and moves it to the bad wallets of its
+// asset type.
+func (mgr *AssetsManager) markBadWallet(wallet sharedW.Asset) {
+	record, err := mgr.walletRecord(wallet.GetWalletID())
+	if err != nil {
+		log.Errorf("Error reading wallet %s: %v", wallet.GetWalletName(), err)
+		return
+	}
+
+	wallet.Shutdown()
+
+	switch wallet.GetAssetType() {
+	case utils.BTCWalletAsset:
+		delete(mgr.Assets.BTC.Wallets, record.ID)
+		mgr.Assets.BTC.BadWallets[record.ID] = record
+	case utils.DCRWalletAsset:
+		delete(mgr.Assets.DCR.Wallets, record.ID)
+		mgr.Assets.DCR.BadWallets[record.ID] = record
+	case utils.LTCWalletAsset:
+		delete(mgr.Assets.LTC.Wallets, record.ID)
+		mgr.Assets.LTC.BadWallets[record.ID] = record
+	}
+}
+
+// reloadBadWallet loads the bad wallet with walletID again after a repair. It
+// remains a bad wallet if it still fails to load.
+func (mgr *AssetsManager) reloadBadWallet(walletID int) {
+	delete(mgr.Assets.BTC.BadWallets, walletID)
+	delete(mgr.Assets.DCR.BadWallets, walletID)
+	delete(mgr.Assets.LTC.BadWallets, walletID)
+
+	record, err := mgr.walletRecord(walletID)
+	if err != nil {
+		log.Errorf("Error reading wallet %d: %v", walletID, err)
+		return
+	}
+	mgr.loadExistingWallet(record)
+}
+
+// dropTxHistory drops the transaction history of the BTC or LTC wallet
+// database at path.
+func dropTxHistory(assetType utils.AssetType, path string) error {
+	switch assetType {
+	case utils.BTCWalletAsset:
+		return btcloader.DropTransactionHistory(path)
+	case utils.LTCWalletAsset:
+		return ltcloader.DropTransactionHistory(path)
+	default:
+		return errors.Errorf("the transaction history of %s wallets can not be dropped", assetType)
+	}
+}
+
+// applyTxHistoryDrop drops the transaction history of the wallet if a rescan
+// repair was requested while the wallet was open. It must be called before the
+// wallet is opened.
+func (mgr *AssetsManager) applyTxHistoryDrop(wallet sharedW.Asset) {
+	if !wallet.ReadBoolConfigValueForKey(sharedW.DropTxHistoryConfigKey, false) {
+		return
+	}
+
+	log.Infof("Dropping the transaction history of wallet %s", wallet.GetWalletName())
+	path := filepath.Join(wallet.DataDir(), walletDBName)
+	if err := dropTxHistory(wallet.GetAssetType(), path); err != nil {
+		log.Errorf("Error dropping the transaction history of wallet %s: %v", wallet.GetWalletName(), err)
+		return
+	}
+	wallet.DeleteUserConfigValueForKey(sharedW.DropTxHistoryConfigKey)
+}
+
+// DiagnoseWallet checks the database of the wallet with walletID and, if the
+// wallet is open, its address manager, transaction store and transaction
+// index. The returned report lists the problems found and their repairs.
+func (mgr *AssetsManager) DiagnoseWallet(walletID int) (*diagnostics.Report, error) {
+	report := diagnostics.NewReport(walletID)
+
+	if badWallet := mgr.getbadWallet(walletID); badWallet != nil {
+		path := filepath.Join(mgr.walletDataDir(badWallet), walletDBName)
+		diagnostics.CheckWalletDB(report, path, badWallet.Type)
+		if report.OK() {
+			// The other databases of the wallet are recreated by a rebuild.
+			report.Add(diagnostics.CheckDatabase, diagnostics.RepairRebuild,
+				"wallet database is intact but the wallet failed to load")
+		}
+		return report, nil
+	}
+
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return nil, errors.New(utils.ErrNotExist)
+	}
+	if !wallet.WalletOpened() {
+		return nil, errors.New(utils.ErrWalletNotLoaded)
+	}
+
+	// The open database is locked, a copy of it is checked instead.
+	for path, copyFn := range wallet.Databases() {
+		if filepath.Base(path) != walletDBName {
+			continue
+		}
+		if err := checkWalletDBCopy(report, copyFn, wallet.GetAssetType()); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := wallet.GetAccountsRaw(); err != nil {
+		report.Add(diagnostics.CheckAddressManager, diagnostics.RepairRebuild, "accounts can not be read: %v", err)
+	}
+	if _, err := wallet.GetExtendedPubKey(0); err != nil {
+		report.Add(diagnostics.CheckAddressManager, diagnostics.RepairRebuild, "default account keys can not be read: %v", err)
+	}
+
+	// DCR transactions are read from the transaction index, the others
+	// from the transaction store.
+	repair := diagnostics.RepairRescan
+	if wallet.GetAssetType() == utils.DCRWalletAsset {
+		repair = diagnostics.RepairReindex
+	}
+	txs, err := wallet.GetTransactionsRaw(0, 0, utils.TxFilterAll, false)
+	if err != nil {
+		report.Add(diagnostics.CheckTxStore, repair, "transactions can not be read: %v", err)
+	}
+	bestHeight := wallet.GetBestBlockHeight()
+	var ahead int
+	for _, tx := range txs {
+		if tx.BlockHeight > bestHeight {
+			ahead++
+		}
+	}
+	if ahead > 0 {
+		report.Add(diagnostics.CheckTxStore, repair, "%d transactions are mined above the best block %d", ahead, bestHeight)
+	}
+
+	if verifier, ok := wallet.(interface {
+		VerifyTxIndex(*diagnostics.Report) error
+	}); ok {
+		if err := verifier.VerifyTxIndex(report); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// checkWalletDBCopy writes a copy of a wallet database to a temporary file
+// and checks it.
+func checkWalletDBCopy(report *diagnostics.Report, copyFn func(w io.Writer) error, assetType utils.AssetType) error {
+	f, err := os.CreateTemp("", "wallet-diagnosis")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	err = copyFn(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		report.Add(diagnostics.CheckDatabase, diagnostics.RepairRebuild, "wallet database can not be read: %v", err)
+		return nil
+	}
+
+	diagnostics.CheckWalletDB(report, f.Name(), assetType)
+	return nil
+}
+
+// RepairWallet applies repair to the wallet with walletID. A rebuild restores
+// the wallet from seed, or from its stored seed if seed is empty, with the
+// private passphrase privPass. It returns true if the repair completes when
+// the app is restarted.
+func (mgr *AssetsManager) RepairWallet(walletID int, repair diagnostics.Repair, seed, privPass string) (bool, error) {
+	badWallet := mgr.getbadWallet(walletID)
+	wallet := mgr.WalletWithID(walletID)
+	if badWallet == nil && wallet == nil {
+		return false, errors.New(utils.ErrNotExist)
+	}
+
+	switch repair {
+	case diagnostics.RepairNone:
+		return false, nil
+
+	case diagnostics.RepairReindex:
+		indexer, ok := wallet.(interface{ ReindexTransactions() error })
+		if !ok {
+			return false, errors.New(utils.ErrWalletNotLoaded)
+		}
+		return false, indexer.ReindexTransactions()
+
+	case diagnostics.RepairRescan:
+		if badWallet != nil {
+			path := filepath.Join(mgr.walletDataDir(badWallet), walletDBName)
+			if err := dropTxHistory(badWallet.Type, path); err != nil {
+				return false, err
+			}
+			mgr.reloadBadWallet(walletID)
+			if mgr.getbadWallet(walletID) != nil {
+				return false, errors.Errorf("wallet %s still fails to load", badWallet.Name)
+			}
+			return false, mgr.WalletWithID(walletID).OpenWallet()
+		}
+		if wallet.GetAssetType() == utils.DCRWalletAsset {
+			return false, wallet.RescanBlocks()
+		}
+		// The database of the open wallet is locked.
+		wallet.SaveUserConfigValue(sharedW.DropTxHistoryConfigKey, true)
+		return true, nil
+
+	case diagnostics.RepairRebuild:
+		return false, mgr.rebuildWallet(walletID, seed, privPass)
+
+	default:
+		return false, errors.New(utils.ErrInvalid)
+	}
+}
+
+// rebuildWallet replaces the wallet with walletID with a wallet restored from
+// seed. The account names, transaction labels, settings and exchange orders of
+// the wallet are moved to the restored wallet, which takes its name.
+func (mgr *AssetsManager) rebuildWallet(walletID int, seed, privPass string) error {
+	record, err := mgr.walletRecord(walletID)
+	if err != nil {
+		return err
+	}
+	if seed == "" {
+		if record.EncryptedSeed == nil {
+			return errors.New(utils.ErrEmptySeed)
+		}
+		if seed, err = record.DecryptSeed(privPass); err != nil {
+			return err
+		}
+	}
+
+	oldWallet := mgr.WalletWithID(walletID)
+	walletMetadata, err := mgr.rebuildMetadata(oldWallet, record)
+	if err != nil {
+		return err
+	}
+
+	// The passphrase of the wallet may predate its policy.
+	newWallet, err := mgr.restoreWallet(record.Type, record.Name+"-rebuilt", seed, "", privPass, record.PrivatePassphraseType)
+	if err != nil {
+		return err
+	}
+	newID := newWallet.GetWalletID()
+
+	discardNewWallet := func(err error) error {
+		if delErr := mgr.DeleteWallet(newID, privPass); delErr != nil {
+			log.Errorf("Error removing the rebuilt wallet: %v", delErr)
+		}
+		return err
+	}
+
+	if walletMetadata.XPub != "" {
+		xpub, err := newWallet.GetExtendedPubKey(0)
+		if err != nil {
+			return discardNewWallet(err)
+		}
+		if xpub != walletMetadata.XPub {
+			return discardNewWallet(errors.New(utils.ErrInvalid))
+		}
+	}
+
+	if oldWallet != nil {
+		err = mgr.DeleteWallet(walletID, privPass)
+	} else {
+		err = mgr.DeleteBadWallet(walletID)
+	}
+	if err != nil {
+		return discardNewWallet(err)
+	}
+
+	if err := mgr.moveWalletSwaps(walletID, newID); err != nil {
+		log.Errorf("Error moving the exchange orders of wallet %s: %v", record.Name, err)
+	}
+	if err := newWallet.RenameWallet(record.Name); err != nil {
+		log.Errorf("Error renaming the rebuilt wallet: %v", err)
+	}
+	mgr.restoreWalletMetadata(newWallet, walletMetadata)
+
+	log.Infof("Wallet %s rebuilt with ID %d", record.Name, newID)
+	return nil
+}
+
+// rebuildMetadata returns the metadata that is moved to the rebuilt wallet.
+// Only the settings of wallets that can not be read are kept.
+func (mgr *AssetsManager) rebuildMetadata(wallet sharedW.Asset, record *sharedW.Wallet) (*metadata.Wallet, error) {
+	if wallet != nil && wallet.WalletOpened() {
+		walletMetadata, err := backupWalletMetadata(wallet)
+		if err == nil {
+			return walletMetadata, nil
+		}
+		log.Warnf("Keeping the settings only of wallet %s: %v", record.Name, err)
+	}
+
+	config, err := sharedW.WalletUserConfigValues(mgr.params.DB, record.ID)
+	if err != nil {
+		return nil, err
+	}
+	for key := range excludedWalletConfigKeys {
+		delete(config, key)
+	}
+	return &metadata.Wallet{
+		ID:        record.ID,
+		Name:      record.Name,
+		AssetType: string(record.Type),
+		Config:    config,
+	}, nil
+}
+
+// moveWalletSwaps moves the exchange orders and atomic swaps of the wallet
+// with oldID to the wallet with newID.
+func (mgr *AssetsManager) moveWalletSwaps(oldID, newID int) error {
+	orders, err := mgr.InstantSwap.GetOrdersRaw(0, 0, false)
+	if err != nil {
+		return err
+	}
+	for _, order := range orders {
+		if order.SourceWalletID != oldID && order.DestinationWalletID != oldID {
+			continue
+		}
+		if order.SourceWalletID == oldID {
+			order.SourceWalletID = newID
+		}
+		if order.DestinationWalletID == oldID {
+			order.DestinationWalletID = newID
+		}
+		if err := mgr.InstantSwap.UpdateOrder(order); err != nil {
+			return err
+		}
+	}
+
+	swaps, err := mgr.AtomicSwaps.All()
+	if err != nil {
+		return err
+	}
+	for _, swap := range swaps {
+		if swap.WalletID != oldID && swap.ReceiveWalletID != oldID {
+			continue
+		}
+		if swap.WalletID == oldID {
+			swap.WalletID = newID
+		}
+		if swap.ReceiveWalletID == oldID {
+			swap.ReceiveWalletID = newID
+		}
+		if err := mgr.AtomicSwaps.Save(swap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -14,8 +14,11 @@ import (
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/atomicswap"
 	"github.com/crypto-power/cryptopower/libwallet/ext"
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/pricealert"
+	"github.com/crypto-power/cryptopower/libwallet/pricehistory"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/listeners"
 	"github.com/crypto-power/cryptopower/logger"
@@ -150,6 +153,9 @@ func init() {
 	dcrw.UseLogger(dcrLog)
 	spv.UseLogger(dcrSpv)
 	instantswap.UseLogger(sharedWLog)
+	atomicswap.UseLogger(sharedWLog)
+	pricehistory.UseLogger(extLog)
+	pricealert.UseLogger(extLog)
 	dcrdex.UseLogger(winLog)
 
 	logger.New(subsystemSLoggers, subsystemBLoggers)
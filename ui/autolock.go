@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"image"
+	"time"
+
+	"gioui.org/io/pointer"
+	"gioui.org/io/system"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+
+	"github.com/crypto-power/cryptopower/ui/page"
+)
+
+const (
+	// autoLockCheckInterval is how often the idle time of the app is checked.
+	autoLockCheckInterval = 30 * time.Second
+
+	// sleepDetectionThreshold is how late a check of the idle time must be for
+	// the system to be considered to have slept in between.
+	sleepDetectionThreshold = 2 * time.Minute
+)
+
+// activityTag is the tag of the pointer handler that records the activity of
+// the user.
+var activityTag = new(int)
+
+// recordActivity resets the idle time of the app if the user interacted with
+// the window since the last frame.
+func (win *Window) recordActivity(evt system.FrameEvent) {
+	if len(evt.Queue.Events(activityTag)) > 0 {
+		win.lastActivity = time.Now()
+	}
+}
+
+// addActivityInputOp registers a pass-through pointer handler over the whole
+// window to observe the activity of the user without intercepting it.
+func (win *Window) addActivityInputOp(ops *op.Ops, size image.Point) {
+	m := op.Record(ops)
+	area := clip.Rect{Max: size}.Push(ops)
+	pass := pointer.PassOp{}.Push(ops)
+	pointer.InputOp{Tag: activityTag, Types: pointer.Press | pointer.Move}.Add(ops)
+	pass.Pop()
+	area.Pop()
+	op.Defer(ops, m.Stop())
+}
+
+// checkAutoLock locks the app if it has been idle for longer than the
+// auto-lock timeout or if the system slept and the app locks on sleep.
+func (win *Window) checkAutoLock() {
+	// The wall clock is used as the monotonic clock may not advance while the
+	// system sleeps.
+	now := time.Now().Round(0)
+	lastCheck := win.lastLockCheck
+	win.lastLockCheck = now
+
+	mgr := win.load.WL.AssetsManager
+	if mgr == nil || !mgr.IsStartupSecuritySet() {
+		return
+	}
+
+	if mgr.LockOnSleep() && !lastCheck.IsZero() && now.Sub(lastCheck) > autoLockCheckInterval+sleepDetectionThreshold {
+		log.Info("System sleep detected, locking the app")
+		win.lockApp()
+		return
+	}
+
+	if timeout := mgr.AutoLockTimeout(); timeout > 0 && now.Sub(win.lastActivity.Round(0)) > timeout {
+		log.Infof("App idle for more than %v, locking the app", timeout)
+		win.lockApp()
+	}
+}
+
+// handleStageEvent locks the app when its window is hidden, e.g. when the
+// screen is locked or the system is about to sleep, if the app locks on sleep.
+func (win *Window) handleStageEvent(evt system.StageEvent) {
+	mgr := win.load.WL.AssetsManager
+	if evt.Stage != system.StagePaused || mgr == nil || !mgr.IsStartupSecuritySet() || !mgr.LockOnSleep() {
+		return
+	}
+	log.Info("App window hidden, locking the app")
+	win.lockApp()
+}
+
+// lockApp closes all the pages and modals and displays the startup passphrase
+// prompt. The background services that do not keep running when the app is
+// locked are stopped and the wallets are locked.
+func (win *Window) lockApp() {
+	if win.navigator.CurrentPage() == nil || win.navigator.CurrentPageID() == page.StartPageID {
+		return
+	}
+
+	for modal := win.navigator.TopModal(); modal != nil; modal = win.navigator.TopModal() {
+		win.navigator.DismissModal(modal.ID())
+	}
+	win.navigator.ClearStackAndDisplay(page.NewLockedStartPage(win.load))
+	win.lastActivity = time.Now()
+
+	go win.load.WL.AssetsManager.LockSession()
+}
@@ -10,6 +10,7 @@ import (
 	"gioui.org/widget/material"
 
 	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -230,6 +231,13 @@ func (cm *CreatePasswordModal) Handle() {
 				cm.confirmPasswordEditor.SetError(values.String(values.StrConfirmSpendingPassword))
 				return
 			}
+
+			// New passwords must comply with the password policy.
+			policy := cm.WL.AssetsManager.PassphrasePolicy(sharedW.PassphraseTypePass)
+			if err := policy.Check(cm.passwordEditor.Editor.Text(), cm.walletName.Editor.Text()); err != nil {
+				cm.passwordEditor.SetError(utils.PassphrasePolicyError(policy, err))
+				return
+			}
 		}
 		cm.SetLoading(true)
 		go func() {
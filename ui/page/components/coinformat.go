@@ -10,6 +10,7 @@ import (
 	"gioui.org/unit"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/load"
+	uiUtils "github.com/crypto-power/cryptopower/ui/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
@@ -72,7 +73,7 @@ func formatBalanceWithHiden(gtx C, l *load.Load, amount string, mainTextSize uni
 	txt := l.Theme.Label(mainTextSize, amount)
 	if isUSD {
 		if !IsFetchExchangeRateAPIAllowed(l.WL) {
-			txt.Text = "$ --"
+			txt.Text = uiUtils.FiatSymbol(l.WL.AssetsManager.GetFiatCurrency()) + " --"
 		}
 	}
 	if isBalanceHidden {
@@ -851,26 +851,17 @@ func CalculateTotalAssetsBalance(l *load.Load) (map[libutils.AssetType]sharedW.A
 }
 
 func CalculateAssetsUSDBalance(l *load.Load, assetsTotalBalance map[libutils.AssetType]sharedW.AssetAmount) (map[libutils.AssetType]float64, error) {
-	usdBalance := func(bal sharedW.AssetAmount, market string) (float64, error) {
-		rate := l.WL.AssetsManager.RateSource.GetTicker(market)
-		if rate == nil || rate.LastTradePrice <= 0 {
-			return 0, fmt.Errorf("No rate information available")
-		}
-
-		return bal.MulF64(rate.LastTradePrice).ToCoin(), nil
-	}
-
 	assetsTotalUSDBalance := make(map[libutils.AssetType]float64)
 	for assetType, balance := range assetsTotalBalance {
-		marketValue, exist := values.AssetExchangeMarketValue[assetType]
-		if !exist {
+		if _, exist := values.AssetExchangeMarketValue[assetType]; !exist {
 			return nil, fmt.Errorf("Unsupported asset type: %s", assetType)
 		}
-		usdBal, err := usdBalance(balance, marketValue)
-		if err != nil {
-			return nil, err
+
+		rate := l.WL.AssetsManager.FiatTicker(assetType)
+		if rate == nil || rate.LastTradePrice <= 0 {
+			return nil, fmt.Errorf("No rate information available")
 		}
-		assetsTotalUSDBalance[assetType] = usdBal
+		assetsTotalUSDBalance[assetType] = balance.MulF64(rate.LastTradePrice).ToCoin()
 	}
 
 	return assetsTotalUSDBalance, nil
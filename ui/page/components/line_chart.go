@@ -0,0 +1,69 @@
+package components
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// LineChart draws a line through a series of values. The values are scaled to
+// fit the available width and the chart's height.
+type LineChart struct {
+	Values    []float64
+	Color     color.NRGBA
+	Height    unit.Dp
+	LineWidth unit.Dp
+}
+
+// Layout draws the chart using the max width of the constraints.
+func (lc LineChart) Layout(gtx C) D {
+	size := image.Point{X: gtx.Constraints.Max.X, Y: gtx.Dp(lc.Height)}
+	if len(lc.Values) < 2 || size.X <= 0 {
+		return D{Size: size}
+	}
+
+	min, max := lc.Values[0], lc.Values[0]
+	for _, v := range lc.Values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	lineWidth := float32(gtx.Dp(lc.LineWidth))
+	if lineWidth <= 0 {
+		lineWidth = float32(gtx.Dp(2))
+	}
+
+	// Leave room for the line width at the top and bottom of the chart.
+	height := float32(size.Y) - lineWidth
+	stepX := float32(size.X) / float32(len(lc.Values)-1)
+	point := func(i int) f32.Point {
+		y := height / 2
+		if max > min {
+			y = height - float32((lc.Values[i]-min)/(max-min))*height
+		}
+		return f32.Pt(float32(i)*stepX, y+lineWidth/2)
+	}
+
+	var path clip.Path
+	path.Begin(gtx.Ops)
+	path.MoveTo(point(0))
+	for i := 1; i < len(lc.Values); i++ {
+		path.LineTo(point(i))
+	}
+
+	paint.FillShape(gtx.Ops, lc.Color, clip.Stroke{
+		Path:  path.End(),
+		Width: lineWidth,
+	}.Op())
+
+	return layout.Dimensions{Size: size}
+}
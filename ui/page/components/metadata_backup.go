@@ -0,0 +1,49 @@
+package components
+
+import (
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// BackupMetadataModal returns a modal that backs up the metadata of the
+// wallets with the provided IDs, or of the whole app if none is provided, to a
+// file encrypted with the entered password.
+func BackupMetadataModal(l *load.Load, walletIDs ...int) *modal.CreatePasswordModal {
+	return modal.NewCreatePasswordModal(l).
+		EnableName(false).
+		Title(values.String(values.StrBackupMetadata)).
+		SetDescription(values.String(values.StrBackupMetadataDesc)).
+		PasswordHint(values.String(values.StrBackupPassword)).
+		ConfirmPasswordHint(values.String(values.StrConfirmBackupPassword)).
+		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
+			path, err := l.WL.AssetsManager.BackupMetadata(password, walletIDs...)
+			if err != nil {
+				m.SetError(err.Error())
+				m.SetLoading(false)
+				return false
+			}
+			l.Toast.Notify(values.StringF(values.StrMetadataBackedUp, path), true)
+			return true
+		})
+}
+
+// RestoreMetadataModal returns a modal that restores a metadata backup into
+// the wallet with walletID, or into the whole app if walletID is negative.
+func RestoreMetadataModal(l *load.Load, walletID int) *modal.CreatePasswordModal {
+	return modal.NewCreatePasswordModal(l).
+		EnableName(true).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrRestoreMetadata)).
+		NameHint(values.String(values.StrBackupFilePath)).
+		PasswordHint(values.String(values.StrBackupPassword)).
+		SetPositiveButtonCallback(func(path, password string, m *modal.CreatePasswordModal) bool {
+			if err := l.WL.AssetsManager.RestoreMetadata(path, password, walletID); err != nil {
+				m.SetError(err.Error())
+				m.SetLoading(false)
+				return false
+			}
+			l.Toast.Notify(values.String(values.StrMetadataRestored))
+			return true
+		})
+}
@@ -0,0 +1,73 @@
+package components
+
+import (
+	"fmt"
+
+	"golang.org/x/text/message"
+
+	"github.com/crypto-power/cryptopower/libwallet/pricealert"
+	"github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// PriceAlertKinds are the kinds of price alerts that can be created, in the
+// order they are displayed.
+var PriceAlertKinds = []struct {
+	Kind  pricealert.Kind
+	Title string
+}{
+	{Kind: pricealert.PriceAbove, Title: values.StrAlertPriceAbove},
+	{Kind: pricealert.PriceBelow, Title: values.StrAlertPriceBelow},
+	{Kind: pricealert.PriceMove, Title: values.StrAlertPriceMove},
+	{Kind: pricealert.PortfolioAbove, Title: values.StrAlertPortfolioAbove},
+	{Kind: pricealert.PortfolioBelow, Title: values.StrAlertPortfolioBelow},
+}
+
+// formatAlertValue formats the threshold or observed value of a price alert.
+// Alert prices and values are in USD.
+func formatAlertValue(p *message.Printer, kind pricealert.Kind, v float64) string {
+	if kind == pricealert.PriceMove {
+		return fmt.Sprintf("%.2f%%", v)
+	}
+	return utils.FormatAsFiatString(p, values.USDCurrency, v)
+}
+
+// PriceAlertTitle returns a short description of alert's condition.
+func PriceAlertTitle(p *message.Printer, alert *pricealert.Alert) string {
+	var kindTitle string
+	for _, k := range PriceAlertKinds {
+		if k.Kind == alert.Kind {
+			kindTitle = values.String(k.Title)
+		}
+	}
+
+	threshold := formatAlertValue(p, alert.Kind, alert.Threshold)
+	if alert.Kind.IsPortfolio() {
+		return fmt.Sprintf("%s %s", kindTitle, threshold)
+	}
+	return fmt.Sprintf("%s %s %s", alert.Market, kindTitle, threshold)
+}
+
+// PriceAlertMessage returns the message displayed to the user when a price
+// alert is triggered.
+func PriceAlertMessage(p *message.Printer, n *pricealert.Notification) string {
+	alert := n.Alert
+	threshold := formatAlertValue(p, alert.Kind, alert.Threshold)
+	value := formatAlertValue(p, alert.Kind, n.Value)
+	switch alert.Kind {
+	case pricealert.PriceAbove:
+		return values.StringF(values.StrPriceAboveNotif, alert.Market, threshold, value)
+	case pricealert.PriceBelow:
+		return values.StringF(values.StrPriceBelowNotif, alert.Market, threshold, value)
+	case pricealert.PriceMove:
+		if n.Value > 0 {
+			value = "+" + value
+		}
+		return values.StringF(values.StrPriceMoveNotif, alert.Market, value)
+	case pricealert.PortfolioAbove:
+		return values.StringF(values.StrPortfolioAboveNotif, threshold, value)
+	case pricealert.PortfolioBelow:
+		return values.StringF(values.StrPortfolioBelowNotif, threshold, value)
+	}
+	return PriceAlertTitle(p, alert)
+}
@@ -22,7 +22,9 @@ import (
 
 const CreateRestorePageID = "Restore"
 
-var tabTitles = []string{"Seed Words", "Hex"}
+const sharesTabIndex = 2
+
+var tabTitles = []string{"Seed Words", "Hex", "SLIP-39 Shares"}
 
 type Restore struct {
 	*load.Load
@@ -135,7 +137,7 @@ func (pg *Restore) restoreLayout(gtx layout.Context) layout.Dimensions {
 			layout.Rigid(pg.tabLayout),
 			layout.Rigid(pg.Theme.Separator().Layout),
 			layout.Rigid(func(gtx C) D {
-				if pg.tabIndex == 1 {
+				if pg.tabIndex != 0 {
 					return D{}
 				}
 				return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
@@ -148,7 +150,7 @@ func (pg *Restore) restoreLayout(gtx layout.Context) layout.Dimensions {
 				})
 			}),
 			layout.Rigid(func(gtx C) D {
-				if pg.toggleSeedInput.IsChecked() || pg.tabIndex == 1 {
+				if pg.toggleSeedInput.IsChecked() || pg.tabIndex != 0 {
 					return pg.seedInputComponent(gtx)
 				}
 				return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, pg.indexLayout)
@@ -176,9 +178,12 @@ func (pg *Restore) seedInputComponent(gtx C) D {
 								Right: values.MarginPadding16,
 								Top:   values.MarginPadding30,
 							}.Layout(gtx, func(gtx C) D {
-								if pg.tabIndex == 0 {
+								switch pg.tabIndex {
+								case 0:
 									pg.seedInputEditor.Hint = values.String(values.StrEnterWalletSeed)
-								} else {
+								case sharesTabIndex:
+									pg.seedInputEditor.Hint = values.String(values.StrEnterShares)
+								default:
 									pg.seedInputEditor.Hint = values.String(values.StrEnterWalletHex)
 								}
 								return pg.seedInputEditor.Layout(gtx)
@@ -194,9 +199,12 @@ func (pg *Restore) seedInputComponent(gtx C) D {
 											Top:    values.MarginPadding16,
 											Bottom: values.MarginPadding16,
 										}.Layout(gtx, func(gtx C) D {
-											if pg.tabIndex == 0 {
+											switch pg.tabIndex {
+											case 0:
 												pg.confirmSeedButton.Text = values.String(values.StrValidateWalSeed)
-											} else {
+											case sharesTabIndex:
+												pg.confirmSeedButton.Text = values.String(values.StrVerifyShares)
+											default:
 												pg.confirmSeedButton.Text = values.String(values.StrValidateWalHex)
 											}
 											return pg.confirmSeedButton.Layout(gtx)
@@ -218,6 +226,9 @@ func (pg *Restore) restoreMobileLayout(gtx layout.Context) layout.Dimensions {
 			layout.Rigid(pg.tabLayout),
 			layout.Rigid(pg.Theme.Separator().Layout),
 			layout.Flexed(1, func(gtx C) D {
+				if pg.tabIndex == sharesTabIndex {
+					return pg.seedInputComponent(gtx)
+				}
 				return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, pg.indexLayout)
 			}),
 		)
@@ -351,8 +362,24 @@ func (pg *Restore) restoreFromSeedEditor() {
 	}
 
 	seedOrHex := strings.TrimSpace(pg.seedInputEditor.Editor.Text())
-	// Check if the user did input a hex or seed. If its a hex set the correct tabindex.
-	if len(seedOrHex) > MaxSeedBytes {
+	var shares []string
+	if pg.tabIndex == sharesTabIndex {
+		// Restore the seed from the shares entered one per line.
+		for _, line := range strings.Split(seedOrHex, "\n") {
+			if share := strings.TrimSpace(line); share != "" {
+				shares = append(shares, share)
+			}
+		}
+		seed, err := sharedW.CombineSeedShares(shares)
+		if err != nil {
+			errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(errModal)
+			clearEditor()
+			return
+		}
+		seedOrHex = seed
+	} else if len(seedOrHex) > MaxSeedBytes {
+		// Check if the user did input a hex or seed. If its a hex set the correct tabindex.
 		pg.tabIndex = 0
 	} else {
 		pg.tabIndex = 1
@@ -360,7 +387,7 @@ func (pg *Restore) restoreFromSeedEditor() {
 
 	if !sharedW.VerifySeed(seedOrHex, pg.walletType) {
 		errMsg := values.String(values.StrInvalidHex)
-		if pg.tabIndex == 0 {
+		if pg.tabIndex != 1 {
 			errMsg = values.String(values.StrInvalidSeedPhrase)
 		}
 		errModal := modal.NewErrorModal(pg.Load, errMsg, modal.DefaultClickFunc())
@@ -369,11 +396,11 @@ func (pg *Restore) restoreFromSeedEditor() {
 		return
 	}
 
-	walletWithSameSeed, err := pg.WL.AssetsManager.WalletWithSeed(pg.walletType, seedOrHex)
+	walletWithSameSeed, err := pg.WL.AssetsManager.WalletWithSeed(pg.walletType, seedOrHex, "")
 	if err != nil {
 		log.Error(err)
 		errMsg := values.String(values.StrInvalidHex)
-		if pg.tabIndex == 0 {
+		if pg.tabIndex != 1 {
 			errMsg = values.String(values.StrSeedValidationFailed)
 		}
 		errModal := modal.NewErrorModal(pg.Load, errMsg, modal.DefaultClickFunc())
@@ -395,7 +422,12 @@ func (pg *Restore) restoreFromSeedEditor() {
 		ShowWalletInfoTip(true).
 		SetParent(pg).
 		SetPositiveButtonCallback(func(walletName, password string, m *modal.CreatePasswordModal) bool {
-			_, err := pg.WL.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, seedOrHex, password, sharedW.PassphraseTypePass)
+			var err error
+			if shares != nil {
+				_, err = pg.WL.AssetsManager.RestoreWalletFromShares(pg.walletType, pg.walletName, shares, password, sharedW.PassphraseTypePass)
+			} else {
+				_, err = pg.WL.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, seedOrHex, "", password, sharedW.PassphraseTypePass)
+			}
 			if err != nil {
 				errString := err.Error()
 				if err.Error() == libutils.ErrExist {
@@ -14,6 +14,7 @@ import (
 	"github.com/crypto-power/cryptopower/app"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/bip39"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -24,6 +25,8 @@ import (
 const (
 	SeedRestorePageID = "seed_restore"
 	numberOfSeeds     = 32
+	// bip39SeedWords is the number of words of the longest BIP39 mnemonics.
+	bip39SeedWords = 24
 )
 
 type seedEditors struct {
@@ -62,6 +65,10 @@ type SeedRestore struct {
 	seedPhrase string
 	walletName string
 
+	seedFormat           sharedW.SeedFormat
+	bip39Toggle          *cryptomaterial.Switch
+	seedPassphraseEditor cryptomaterial.Editor
+
 	openPopupIndex  int
 	selected        int
 	suggestionLimit int
@@ -86,6 +93,7 @@ func NewSeedRestorePage(l *load.Load, walletName string, walletType libutils.Ass
 		openPopupIndex:  -1,
 		walletName:      walletName,
 		walletType:      walletType,
+		bip39Toggle:     l.Theme.Switch(),
 	}
 
 	pg.optionsMenuCard = cryptomaterial.Card{Color: pg.Theme.Color.Surface}
@@ -97,6 +105,9 @@ func NewSeedRestorePage(l *load.Load, walletName string, walletType libutils.Ass
 	pg.resetSeedFields = l.Theme.OutlineButton(values.String(values.StrClearAll))
 	pg.resetSeedFields.Font.Weight = font.Medium
 
+	pg.seedPassphraseEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSeedPassphrase))
+	pg.seedPassphraseEditor.Editor.SingleLine = true
+
 	for i := 0; i <= numberOfSeeds; i++ {
 		widgetEditor := new(widget.Editor)
 		widgetEditor.SingleLine, widgetEditor.Submit = true, true
@@ -133,6 +144,32 @@ func (pg *SeedRestore) SetParentNav(window app.WindowNavigator) {
 	pg.window = window
 }
 
+// setSeedFormat switches the seed editors to the words of format.
+func (pg *SeedRestore) setSeedFormat(format sharedW.SeedFormat) {
+	pg.seedFormat = format
+	if format == sharedW.SeedFormatBIP39 {
+		pg.allSuggestions = bip39.WordList()
+	} else {
+		pg.allSuggestions = dcr.PGPWordList()
+	}
+	pg.seedPassphraseEditor.Editor.SetText("")
+	pg.resetSeeds()
+	pg.setEditorFocus()
+}
+
+// activeEditors returns the seed editors of the words of the seed format.
+func (pg *SeedRestore) activeEditors() []cryptomaterial.RestoreEditor {
+	if pg.seedFormat == sharedW.SeedFormatBIP39 {
+		return pg.seedEditors.editors[:bip39SeedWords]
+	}
+	return pg.seedEditors.editors
+}
+
+// lastSeedIndex returns the index of the last active seed editor.
+func (pg *SeedRestore) lastSeedIndex() int {
+	return len(pg.activeEditors()) - 1
+}
+
 func (pg *SeedRestore) setEditorFocus() {
 	pg.seedEditors.focusIndex = -1
 	pg.seedEditors.editors[0].Edit.Editor.Focus()
@@ -169,7 +206,9 @@ func (pg *SeedRestore) restore(gtx C) D {
 				Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
 				Padding:     layout.UniformInset(values.MarginPadding15),
 			}.Layout(gtx,
+				layout.Rigid(pg.seedFormatLayout),
 				layout.Rigid(pg.seedEditorViewDesktop),
+				layout.Rigid(pg.seedPassphraseLayout),
 				layout.Rigid(pg.resetSeedFields.Layout),
 			)
 		}),
@@ -200,11 +239,13 @@ func (pg *SeedRestore) restoreMobile(gtx C) D {
 								Bottom: values.MarginPadding10,
 							}.Layout(gtx, pg.Theme.Body1(values.String(values.StrEnterSeedPhrase)).Layout)
 						}),
+						layout.Rigid(pg.seedFormatLayout),
 						layout.Rigid(func(gtx C) D {
 							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 								layout.Flexed(1, func(gtx C) D {
 									return pg.seedEditorViewMobile(gtx)
 								}),
+								layout.Rigid(pg.seedPassphraseLayout),
 								layout.Rigid(func(gtx C) D {
 									return pg.resetSeedFields.Layout(gtx)
 								}),
@@ -230,35 +271,52 @@ func (pg *SeedRestore) restoreButtonSection(gtx C) D {
 	})
 }
 
+// seedFormatLayout lays out the switch between PGP and BIP39 seed words for
+// the assets that support BIP39 seeds.
+func (pg *SeedRestore) seedFormatLayout(gtx C) D {
+	if !sharedW.SupportsSeedFormat(pg.walletType, sharedW.SeedFormatBIP39) {
+		return D{}
+	}
+	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.bip39Toggle.Layout)
+			}),
+			layout.Rigid(pg.Theme.Label(values.TextSize16, values.String(values.StrBIP39Seed)).Layout),
+		)
+	})
+}
+
+// seedPassphraseLayout lays out the passphrase editor of BIP39 seeds.
+func (pg *SeedRestore) seedPassphraseLayout(gtx C) D {
+	if pg.seedFormat != sharedW.SeedFormatBIP39 {
+		return D{}
+	}
+	return layout.Inset{Top: values.MarginPadding10, Bottom: values.MarginPadding10}.Layout(gtx, pg.seedPassphraseEditor.Layout)
+}
+
 func (pg *SeedRestore) seedEditorViewDesktop(gtx C) D {
+	const columns = 5
 	inset := layout.Inset{
 		Right: values.MarginPadding5,
 	}
-	return layout.Flex{}.Layout(gtx,
-		layout.Flexed(1, func(gtx C) D {
-			return inset.Layout(gtx, func(gtx C) D {
-				return pg.inputsGroup(gtx, pg.seedList, 7, 0)
-			})
-		}),
-		layout.Flexed(1, func(gtx C) D {
-			return inset.Layout(gtx, func(gtx C) D {
-				return pg.inputsGroup(gtx, pg.seedList, 7, 1)
-			})
-		}),
-		layout.Flexed(1, func(gtx C) D {
-			return inset.Layout(gtx, func(gtx C) D {
-				return pg.inputsGroup(gtx, pg.seedList, 7, 2)
-			})
-		}),
-		layout.Flexed(1, func(gtx C) D {
+	seedCount := len(pg.activeEditors())
+	children := make([]layout.FlexChild, 0, columns)
+	for column := 0; column < columns; column++ {
+		column := column
+		// Editors are laid out row by row, the first columns get the
+		// editors of an incomplete last row.
+		count := (seedCount - column + columns - 1) / columns
+		children = append(children, layout.Flexed(1, func(gtx C) D {
+			if column == columns-1 {
+				return pg.inputsGroup(gtx, pg.seedList, count, column)
+			}
 			return inset.Layout(gtx, func(gtx C) D {
-				return pg.inputsGroup(gtx, pg.seedList, 6, 3)
+				return pg.inputsGroup(gtx, pg.seedList, count, column)
 			})
-		}),
-		layout.Flexed(1, func(gtx C) D {
-			return pg.inputsGroup(gtx, pg.seedList, 6, 4)
-		}),
-	)
+		}))
+	}
+	return layout.Flex{}.Layout(gtx, children...)
 }
 
 func (pg *SeedRestore) seedEditorViewMobile(gtx layout.Context) layout.Dimensions {
@@ -268,7 +326,7 @@ func (pg *SeedRestore) seedEditorViewMobile(gtx layout.Context) layout.Dimension
 	return layout.Flex{}.Layout(gtx,
 		layout.Flexed(1, func(gtx C) D {
 			return inset.Layout(gtx, func(gtx C) D {
-				return pg.inputsGroupMobile(gtx, pg.seedList, 33, 0)
+				return pg.inputsGroupMobile(gtx, pg.seedList, len(pg.activeEditors()), 0)
 			})
 		}),
 	)
@@ -316,11 +374,11 @@ func (pg *SeedRestore) onSuggestionSeedsClicked() {
 				pg.seedEditors.editors[index].Edit.Editor.SetText(b.text)
 				pg.seedEditors.editors[index].Edit.Editor.MoveCaret(len(b.text), 0)
 				pg.seedClicked = true
-				if index != numberOfSeeds {
+				if index != pg.lastSeedIndex() {
 					pg.seedEditors.editors[index+1].Edit.Editor.Focus()
 				}
 
-				if index == numberOfSeeds {
+				if index == pg.lastSeedIndex() {
 					pg.isLastEditor = true
 				}
 			}
@@ -351,12 +409,12 @@ func (pg *SeedRestore) editorSeedsEventsHandler() {
 			pg.openPopupIndex = i
 		}
 
-		if i != numberOfSeeds {
+		if i != pg.lastSeedIndex() {
 			pg.isLastEditor = false
 		}
 	}
 
-	for i := 0; i < len(pg.seedEditors.editors); i++ {
+	for i := 0; i < len(pg.activeEditors()); i++ {
 		editor := &pg.seedEditors.editors[i]
 		text := editor.Edit.Editor.Text()
 
@@ -375,12 +433,12 @@ func (pg *SeedRestore) editorSeedsEventsHandler() {
 				}
 
 				//  Handles Enter and Return keyboard events.
-				if i != numberOfSeeds {
+				if i != pg.lastSeedIndex() {
 					pg.seedEditors.editors[i+1].Edit.Editor.Focus()
 					pg.selected = 0
 				}
 
-				if i == numberOfSeeds {
+				if i == pg.lastSeedIndex() {
 					pg.selected = 0
 					pg.isLastEditor = true
 				}
@@ -465,6 +523,10 @@ func (pg *SeedRestore) updateSeedResetBtn() bool {
 }
 
 func (pg *SeedRestore) validateSeeds() (bool, string) {
+	if pg.seedFormat == sharedW.SeedFormatBIP39 {
+		return pg.validateBIP39Seeds()
+	}
+
 	seedPhrase := ""
 	allSuggesString := strings.Join(pg.allSuggestions, " ")
 
@@ -479,6 +541,43 @@ func (pg *SeedRestore) validateSeeds() (bool, string) {
 	return true, seedPhrase
 }
 
+// validateBIP39Seeds validates the words of a BIP39 mnemonic. The words fill
+// the first 12, 15, 18, 21 or 24 editors.
+func (pg *SeedRestore) validateBIP39Seeds() (bool, string) {
+	words := make([]string, 0, bip39SeedWords)
+	for i, editor := range pg.activeEditors() {
+		word := editor.Edit.Editor.Text()
+		if word == "" {
+			if len(words) == i {
+				continue
+			}
+			pg.seedEditors.editors[i].Edit.HintColor = pg.Theme.Color.Danger
+			return false, ""
+		}
+		if len(words) != i || seedPosition(word, pg.allSuggestions) == -1 {
+			pg.seedEditors.editors[i].Edit.HintColor = pg.Theme.Color.Danger
+			return false, ""
+		}
+		words = append(words, word)
+	}
+
+	if len(words) < 12 || len(words)%3 != 0 {
+		return false, ""
+	}
+	return true, strings.Join(words, " ")
+}
+
+// seedPosition returns the index of seed in allSeeds or -1 if allSeeds does
+// not contain it.
+func seedPosition(seed string, allSeeds []string) int {
+	for i := range allSeeds {
+		if allSeeds[i] == seed {
+			return i
+		}
+	}
+	return -1
+}
+
 func (pg *SeedRestore) verifySeeds() bool {
 	isValid, seedphrase := pg.validateSeeds()
 	pg.seedPhrase = ""
@@ -494,7 +593,7 @@ func (pg *SeedRestore) verifySeeds() bool {
 
 	// Compare seed with existing wallets seed. On positive match abort import
 	// to prevent duplicate wallet. walletWithSameSeed >= 0 if there is a match.
-	walletWithSameSeed, err := pg.WL.AssetsManager.WalletWithSeed(pg.walletType, pg.seedPhrase)
+	walletWithSameSeed, err := pg.WL.AssetsManager.WalletWithSeed(pg.walletType, pg.seedPhrase, pg.seedPassphrase())
 	if err != nil {
 		log.Error(err)
 		return false
@@ -509,6 +608,14 @@ func (pg *SeedRestore) verifySeeds() bool {
 	return true
 }
 
+// seedPassphrase returns the passphrase of BIP39 seeds.
+func (pg *SeedRestore) seedPassphrase() string {
+	if pg.seedFormat != sharedW.SeedFormatBIP39 {
+		return ""
+	}
+	return pg.seedPassphraseEditor.Editor.Text()
+}
+
 func (pg *SeedRestore) resetSeeds() {
 	for i := 0; i < len(pg.seedEditors.editors); i++ {
 		pg.seedEditors.editors[i].Edit.Editor.SetText("")
@@ -554,6 +661,14 @@ func (pg *SeedRestore) HandleUserInteractions() {
 		}
 	}
 
+	if pg.bip39Toggle.Changed() {
+		format := sharedW.SeedFormatPGP
+		if pg.bip39Toggle.IsChecked() {
+			format = sharedW.SeedFormatBIP39
+		}
+		pg.setSeedFormat(format)
+	}
+
 	if pg.validateSeed.Clicked() {
 		if !pg.verifySeeds() {
 			return
@@ -566,7 +681,7 @@ func (pg *SeedRestore) HandleUserInteractions() {
 			ShowWalletInfoTip(true).
 			SetParent(pg).
 			SetPositiveButtonCallback(func(walletName, password string, m *modal.CreatePasswordModal) bool {
-				_, err := pg.WL.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, pg.seedPhrase, password, sharedW.PassphraseTypePass)
+				_, err := pg.WL.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, pg.seedPhrase, pg.seedPassphrase(), password, sharedW.PassphraseTypePass)
 				if err != nil {
 					errString := err.Error()
 					if err.Error() == libutils.ErrExist {
@@ -641,7 +756,7 @@ func (pg *SeedRestore) HandleKeyPress(evt *key.Event) {
 		if len(pg.suggestions) > 0 {
 			pg.seedClicked = true
 		}
-		switchSeedEditors(pg.seedEditors.editors, 1)
+		switchSeedEditors(pg.activeEditors(), 1)
 	}
 
 	// If seed suggestion list is opened and tab key is pressed select
@@ -654,7 +769,7 @@ func (pg *SeedRestore) HandleKeyPress(evt *key.Event) {
 	}
 
 	if evt.Name == key.NameTab && evt.Modifiers == key.ModShift && evt.State == key.Press && pg.openPopupIndex == -1 {
-		switchSeedEditors(pg.seedEditors.editors, -1)
+		switchSeedEditors(pg.activeEditors(), -1)
 	}
 
 	if evt.Name == key.NameDownArrow && evt.State == key.Press {
@@ -668,7 +783,7 @@ func (pg *SeedRestore) HandleKeyPress(evt *key.Event) {
 		if len(pg.suggestions) > 0 {
 			pg.seedClicked = true
 		}
-		switchSeedEditors(pg.seedEditors.editors, 5)
+		switchSeedEditors(pg.activeEditors(), 5)
 	}
 
 	if evt.Name == key.NameUpArrow && evt.State == key.Press {
@@ -679,21 +794,21 @@ func (pg *SeedRestore) HandleKeyPress(evt *key.Event) {
 			}
 			return
 		}
-		switchSeedEditors(pg.seedEditors.editors, -5)
+		switchSeedEditors(pg.activeEditors(), -5)
 	}
 
 	if evt.Name == key.NameLeftArrow && evt.State == key.Press && pg.openPopupIndex == -1 {
 		if len(pg.suggestions) > 0 {
 			pg.seedClicked = true
 		}
-		switchSeedEditors(pg.seedEditors.editors, -1)
+		switchSeedEditors(pg.activeEditors(), -1)
 	}
 
 	if evt.Name == key.NameRightArrow && evt.State == key.Press && pg.openPopupIndex == -1 {
 		if len(pg.suggestions) > 0 {
 			pg.seedClicked = true
 		}
-		switchSeedEditors(pg.seedEditors.editors, 1)
+		switchSeedEditors(pg.activeEditors(), 1)
 	}
 
 	if (evt.Name == key.NameReturn || evt.Name == key.NameEnter) && pg.openPopupIndex != -1 && evt.State == key.Press && len(pg.suggestions) != 0 {
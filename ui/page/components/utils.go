@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"image/color"
 	"strings"
@@ -14,6 +15,8 @@ import (
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/passphrase"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -162,3 +165,23 @@ func LayoutOrderAmount(l *load.Load, gtx C, assetType string, amount float64) D
 
 	return l.Theme.Label(values.TextSize16, convertedAmountStr).Layout(gtx)
 }
+
+// BroadcastError returns the message displayed for an error returned by
+// Broadcast.
+func BroadcastError(err error) string {
+	var delayed *sharedW.SpendDelayedError
+	if errors.As(err, &delayed) {
+		return values.StringF(values.StrSpendDelayed, delayed.ReadyAt.Local().Format("Jan 2 15:04"))
+	}
+	return PassphraseError(err)
+}
+
+// PassphraseError returns the message displayed for an error returned by an
+// unlock with a passphrase.
+func PassphraseError(err error) string {
+	var retry *passphrase.RetryError
+	if errors.As(err, &retry) {
+		return values.StringF(values.StrTooManyAttemptsRetryAt, retry.RetryAt.Local().Format("15:04:05"))
+	}
+	return values.TranslateErr(err.Error())
+}
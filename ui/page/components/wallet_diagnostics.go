@@ -0,0 +1,116 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/diagnostics"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// DiagnoseWallet checks the wallet with walletID and shows the problems found
+// with the repair that fixes them. onRepaired is called after a repair.
+func DiagnoseWallet(l *load.Load, window app.WindowNavigator, walletID int, onRepaired func()) {
+	go func() {
+		report, err := l.WL.AssetsManager.DiagnoseWallet(walletID)
+		if err != nil {
+			window.ShowModal(modal.NewErrorModal(l, err.Error(), modal.DefaultClickFunc()))
+			return
+		}
+		if report.OK() {
+			window.ShowModal(modal.NewSuccessModal(l, values.String(values.StrWalletDBOK), modal.DefaultClickFunc()))
+			return
+		}
+
+		problems := make([]string, 0, len(report.Problems)+1)
+		for _, problem := range report.Problems {
+			problems = append(problems, "• "+problem.Description)
+		}
+		repair := report.Repair()
+		problems = append(problems, "", values.StringF(values.StrRecommendedRepair, repairName(repair)))
+
+		reportModal := modal.NewCustomModal(l).
+			Title(values.String(values.StrWalletDiagnosis)).
+			Body(strings.Join(problems, "\n")).
+			SetNegativeButtonText(values.String(values.StrCancel)).
+			SetPositiveButtonText(values.String(values.StrRepair)).
+			SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+				if repair == diagnostics.RepairRebuild {
+					window.ShowModal(rebuildWalletModal(l, walletID, onRepaired))
+					return true
+				}
+				go repairWallet(l, window, walletID, repair, onRepaired)
+				return true
+			})
+		window.ShowModal(reportModal)
+	}()
+}
+
+// repairName returns the display name of repair.
+func repairName(repair diagnostics.Repair) string {
+	switch repair {
+	case diagnostics.RepairReindex:
+		return values.String(values.StrRepairReindex)
+	case diagnostics.RepairRescan:
+		return values.String(values.StrRepairRescan)
+	case diagnostics.RepairRebuild:
+		return values.String(values.StrRepairRebuild)
+	default:
+		return repair.String()
+	}
+}
+
+// repairWallet applies the repairs that need no passphrase.
+func repairWallet(l *load.Load, window app.WindowNavigator, walletID int, repair diagnostics.Repair, onRepaired func()) {
+	restartRequired, err := l.WL.AssetsManager.RepairWallet(walletID, repair, "", "")
+	if err != nil {
+		window.ShowModal(modal.NewErrorModal(l, err.Error(), modal.DefaultClickFunc()))
+		return
+	}
+	if restartRequired {
+		l.Toast.Notify(values.String(values.StrRepairOnRestart))
+	} else {
+		l.Toast.Notify(values.String(values.StrWalletRepaired))
+	}
+	onRepaired()
+}
+
+// rebuildWalletModal returns a modal that rebuilds the wallet with walletID
+// from its seed. The seed is entered if the wallet has no stored seed.
+func rebuildWalletModal(l *load.Load, walletID int, onRepaired func()) *modal.CreatePasswordModal {
+	hasStoredSeed := false
+	if wallet := l.WL.AssetsManager.WalletWithID(walletID); wallet != nil {
+		hasStoredSeed = wallet.GetEncryptedSeed() != ""
+	}
+	for _, badWallets := range []map[int]*sharedW.Wallet{
+		l.WL.AssetsManager.DCRBadWallets(),
+		l.WL.AssetsManager.BTCBadWallets(),
+		l.WL.AssetsManager.LTCBadWallets(),
+	} {
+		if wallet, ok := badWallets[walletID]; ok {
+			hasStoredSeed = wallet.GetEncryptedSeed() != ""
+		}
+	}
+
+	return modal.NewCreatePasswordModal(l).
+		EnableName(!hasStoredSeed).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrRepairRebuild)).
+		SetDescription(values.String(values.StrRebuildWalletDesc)).
+		NameHint(values.String(values.StrEnterSeedPhrase)).
+		PasswordHint(values.String(values.StrSpendingPassword)).
+		SetPositiveButtonCallback(func(seed, password string, m *modal.CreatePasswordModal) bool {
+			_, err := l.WL.AssetsManager.RepairWallet(walletID, diagnostics.RepairRebuild, strings.TrimSpace(seed), password)
+			if err != nil {
+				m.SetError(err.Error())
+				m.SetLoading(false)
+				return false
+			}
+			l.Toast.Notify(values.String(values.StrWalletRepaired))
+			onRepaired()
+			return true
+		})
+}
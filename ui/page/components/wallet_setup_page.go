@@ -2,6 +2,8 @@ package components
 
 import (
 	"errors"
+	"strconv"
+	"strings"
 
 	"gioui.org/layout"
 	"gioui.org/unit"
@@ -48,9 +50,11 @@ type CreateWallet struct {
 	assetTypeError        cryptomaterial.Label
 	walletName            cryptomaterial.Editor
 	watchOnlyWalletHex    cryptomaterial.Editor
+	watchStartHeight      cryptomaterial.Editor
 	passwordEditor        cryptomaterial.Editor
 	confirmPasswordEditor cryptomaterial.Editor
 	watchOnlyCheckBox     cryptomaterial.CheckBoxStyle
+	bip39CheckBox         cryptomaterial.CheckBoxStyle
 	materialLoader        material.LoaderStyle
 
 	continueBtn cryptomaterial.Button
@@ -64,6 +68,10 @@ type CreateWallet struct {
 
 	showLoader bool
 	isLoading  bool
+
+	// watchedAddresses is set when the watch only editor holds a list of
+	// addresses instead of an extended public key.
+	watchedAddresses []string
 }
 
 func NewCreateWallet(l *load.Load, walletCreationSuccessCallback func(), assetType ...libutils.AssetType) *CreateWallet {
@@ -82,6 +90,7 @@ func NewCreateWallet(l *load.Load, walletCreationSuccessCallback func(), assetTy
 		restoreBtn:           l.Theme.Button(values.String(values.StrRestore)),
 		importBtn:            l.Theme.Button(values.String(values.StrImport)),
 		watchOnlyCheckBox:    l.Theme.CheckBox(new(widget.Bool), values.String(values.StrImportWatchingOnlyWallet)),
+		bip39CheckBox:        l.Theme.CheckBox(new(widget.Bool), values.String(values.StrUseBIP39Seed)),
 		selectedWalletAction: -1,
 		assetTypeError:       l.Theme.Body1(""),
 
@@ -111,6 +120,9 @@ func NewCreateWallet(l *load.Load, walletCreationSuccessCallback func(), assetTy
 	pg.watchOnlyWalletHex = l.Theme.Editor(new(widget.Editor), values.String(values.StrExtendedPubKey))
 	pg.watchOnlyWalletHex.Editor.SingleLine, pg.watchOnlyWalletHex.Editor.Submit, pg.watchOnlyWalletHex.IsTitleLabel = false, true, false
 
+	pg.watchStartHeight = l.Theme.Editor(new(widget.Editor), values.String(values.StrScanFromHeightHint))
+	pg.watchStartHeight.Editor.SingleLine = true
+
 	pg.passwordEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword))
 	pg.passwordEditor.Editor.SingleLine, pg.passwordEditor.Editor.Submit = true, true
 	pg.passwordEditor.Hint = values.String(values.StrSpendingPassword)
@@ -352,6 +364,12 @@ func (pg *CreateWallet) createNewWallet(gtx C) D {
 				Bottom: values.MarginPadding20,
 			}.Layout(gtx, pg.confirmPasswordEditor.Layout)
 		}),
+		layout.Rigid(func(gtx C) D {
+			if !pg.supportsBIP39() {
+				return D{}
+			}
+			return layout.Inset{Bottom: values.MarginPadding20}.Layout(gtx, pg.bip39CheckBox.Layout)
+		}),
 		layout.Rigid(func(gtx C) D {
 			return layout.Flex{}.Layout(gtx,
 				layout.Flexed(1, func(gtx C) D {
@@ -369,6 +387,21 @@ func (pg *CreateWallet) createNewWallet(gtx C) D {
 	)
 }
 
+// supportsBIP39 returns true if wallets of the selected asset type can be
+// created with a BIP39 seed.
+func (pg *CreateWallet) supportsBIP39() bool {
+	assetType := pg.assetTypeSelector.SelectedAssetType()
+	return assetType != nil && sharedW.SupportsSeedFormat(*assetType, sharedW.SeedFormatBIP39)
+}
+
+// seedFormat returns the format of the seed of the new wallet.
+func (pg *CreateWallet) seedFormat() sharedW.SeedFormat {
+	if pg.supportsBIP39() && pg.bip39CheckBox.CheckBox.Value {
+		return sharedW.SeedFormatBIP39
+	}
+	return sharedW.SeedFormatPGP
+}
+
 func (pg *CreateWallet) restoreWallet(gtx C) D {
 	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 		layout.Rigid(pg.Theme.Label(values.TextSize16, values.String(values.StrExistingWalletName)).Layout),
@@ -384,14 +417,32 @@ func (pg *CreateWallet) restoreWallet(gtx C) D {
 						if !pg.watchOnlyCheckBox.CheckBox.Value {
 							return D{}
 						}
+						// BTC and LTC watch only wallets can also be imported
+						// from the output descriptors of an account.
+						title := values.String(values.StrExtendedPubKey)
+						if ast := pg.assetTypeSelector.SelectedAssetType(); ast != nil && *ast != libutils.DCRWalletAsset {
+							title = values.String(values.StrExtendedPubKeyOrDescriptors)
+						}
+						pg.watchOnlyWalletHex.Hint = title
 						return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 							layout.Rigid(func(gtx C) D {
 								return layout.Inset{
 									Top:    values.MarginPadding10,
 									Bottom: values.MarginPadding8,
-								}.Layout(gtx, pg.Theme.Label(values.TextSize16, values.String(values.StrExtendedPubKey)).Layout)
+								}.Layout(gtx, pg.Theme.Label(values.TextSize16, title).Layout)
 							}),
 							layout.Rigid(pg.watchOnlyWalletHex.Layout),
+							layout.Rigid(func(gtx C) D {
+								hint := pg.Theme.Label(values.TextSize14, values.String(values.StrWatchAddressesHint))
+								hint.Color = pg.Theme.Color.GrayText2
+								return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, hint.Layout)
+							}),
+							layout.Rigid(func(gtx C) D {
+								if len(pg.watchedAddresses) == 0 {
+									return D{}
+								}
+								return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.watchStartHeight.Layout)
+							}),
 						)
 					}),
 				)
@@ -436,13 +487,19 @@ func (pg *CreateWallet) HandleUserInteractions() {
 	}
 
 	// editor event listener
-	isSubmit, isChanged := cryptomaterial.HandleEditorEvents(pg.walletName.Editor, pg.watchOnlyWalletHex.Editor, pg.passwordEditor.Editor, pg.confirmPasswordEditor.Editor)
+	isSubmit, isChanged := cryptomaterial.HandleEditorEvents(pg.walletName.Editor, pg.watchOnlyWalletHex.Editor, pg.watchStartHeight.Editor, pg.passwordEditor.Editor, pg.confirmPasswordEditor.Editor)
 	if isChanged {
 		// reset error when any editor is modified
 		pg.walletName.SetError("")
 		pg.passwordEditor.SetError("")
 		pg.confirmPasswordEditor.SetError("")
 		pg.watchOnlyWalletHex.SetError("")
+		pg.watchStartHeight.SetError("")
+
+		pg.watchedAddresses = nil
+		if ast := pg.assetTypeSelector.SelectedAssetType(); ast != nil {
+			pg.watchedAddresses, _ = pg.WL.AssetsManager.DecodeWatchedAddresses(*ast, pg.watchOnlyWalletHex.Editor.Text())
+		}
 	}
 
 	// create wallet action
@@ -477,7 +534,7 @@ func (pg *CreateWallet) HandleUserInteractions() {
 				wal.SetBoolConfigValueForKey(sharedW.AccountMixerConfigSet, true)
 
 			case libutils.BTCWalletAsset:
-				_, err := pg.WL.AssetsManager.CreateNewBTCWallet(pg.walletName.Editor.Text(), pg.passwordEditor.Editor.Text(), sharedW.PassphraseTypePass)
+				_, err := pg.WL.AssetsManager.CreateNewBTCWallet(pg.walletName.Editor.Text(), pg.passwordEditor.Editor.Text(), sharedW.PassphraseTypePass, pg.seedFormat())
 				if err != nil {
 					if err.Error() == libutils.ErrExist {
 						pg.walletName.SetError(values.StringF(values.StrWalletExist, pg.walletName.Editor.Text()))
@@ -490,7 +547,7 @@ func (pg *CreateWallet) HandleUserInteractions() {
 				}
 
 			case libutils.LTCWalletAsset:
-				_, err := pg.WL.AssetsManager.CreateNewLTCWallet(pg.walletName.Editor.Text(), pg.passwordEditor.Editor.Text(), sharedW.PassphraseTypePass)
+				_, err := pg.WL.AssetsManager.CreateNewLTCWallet(pg.walletName.Editor.Text(), pg.passwordEditor.Editor.Text(), sharedW.PassphraseTypePass, pg.seedFormat())
 				if err != nil {
 					if err.Error() == libutils.ErrExist {
 						pg.walletName.SetError(values.StringF(values.StrWalletExist, pg.walletName.Editor.Text()))
@@ -522,6 +579,14 @@ func (pg *CreateWallet) HandleUserInteractions() {
 		pg.showLoader = true
 		var err error
 		go func() {
+			// The asset type may have changed since the addresses were
+			// entered, decode them again.
+			addresses, decodeErr := pg.WL.AssetsManager.DecodeWatchedAddresses(*pg.assetTypeSelector.SelectedAssetType(), pg.watchOnlyWalletHex.Editor.Text())
+			if decodeErr == nil {
+				pg.createAddressWatchWallet(addresses)
+				return
+			}
+
 			switch *pg.assetTypeSelector.SelectedAssetType() {
 			case libutils.DCRWalletAsset:
 				var walletWithXPub int
@@ -563,6 +628,34 @@ func (pg *CreateWallet) HandleUserInteractions() {
 	}
 }
 
+// createAddressWatchWallet creates a wallet watching the addresses entered in
+// the watch only editor.
+func (pg *CreateWallet) createAddressWatchWallet(addresses []string) {
+	var startHeight int32
+	if text := strings.TrimSpace(pg.watchStartHeight.Editor.Text()); text != "" {
+		height, err := strconv.ParseInt(text, 10, 32)
+		if err != nil || height < 0 {
+			pg.watchStartHeight.SetError(values.String(values.StrInvalidBlockHeight))
+			pg.showLoader = false
+			return
+		}
+		startHeight = int32(height)
+	}
+
+	_, err := pg.WL.AssetsManager.CreateAddressWatchWallet(*pg.assetTypeSelector.SelectedAssetType(),
+		pg.walletName.Editor.Text(), addresses, startHeight)
+	if err != nil {
+		if err.Error() == libutils.ErrExist {
+			pg.watchOnlyWalletHex.SetError(values.StringF(values.StrWalletExist, pg.walletName.Editor.Text()))
+		} else {
+			pg.watchOnlyWalletHex.SetError(values.TranslateErr(err.Error()))
+		}
+		pg.showLoader = false
+		return
+	}
+	pg.walletCreationSuccessCallback()
+}
+
 func (pg *CreateWallet) passwordsMatch(editors ...*widget.Editor) bool {
 	if len(editors) < 2 {
 		return false
@@ -600,9 +693,17 @@ func (pg *CreateWallet) validCreateWalletInputs() bool {
 	}
 
 	validPassword := utils.EditorsNotEmpty(pg.confirmPasswordEditor.Editor)
-	if len(pg.passwordEditor.Editor.Text()) > 0 {
+	if password := pg.passwordEditor.Editor.Text(); len(password) > 0 {
 		passwordsMatch := pg.passwordsMatch(pg.passwordEditor.Editor, pg.confirmPasswordEditor.Editor)
-		return validPassword && passwordsMatch
+		if !validPassword || !passwordsMatch {
+			return false
+		}
+
+		policy := pg.WL.AssetsManager.PassphrasePolicy(sharedW.PassphraseTypePass)
+		if err := policy.Check(password, pg.walletName.Editor.Text()); err != nil {
+			pg.passwordEditor.SetError(utils.PassphrasePolicyError(policy, err))
+			return false
+		}
 	}
 
 	return true
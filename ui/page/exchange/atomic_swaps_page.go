@@ -0,0 +1,567 @@
+package exchange
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/io/clipboard"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/atomicswap"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const AtomicSwapsPageID = "AtomicSwaps"
+
+// swapAssets are the assets whose wallets support atomic swaps.
+var swapAssets = []libutils.AssetType{libutils.DCRWalletAsset, libutils.BTCWalletAsset, libutils.LTCWalletAsset}
+
+// swapRow holds the widgets of a stored swap.
+type swapRow struct {
+	swap    *atomicswap.Swap
+	message string
+
+	copyButton       cryptomaterial.Button
+	saveButton       cryptomaterial.Button
+	acceptButton     cryptomaterial.Button
+	fundButton       cryptomaterial.Button
+	redeemButton     cryptomaterial.Button
+	autoRedeemButton cryptomaterial.Button
+	refundButton     cryptomaterial.Button
+	cancelButton     cryptomaterial.Button
+}
+
+func (row *swapRow) canAccept() bool {
+	return row.swap.Role == atomicswap.Participant && row.swap.State == atomicswap.StateOffered
+}
+
+func (row *swapRow) canFund() bool {
+	s := row.swap
+	return s.Contract == nil && ((s.Role == atomicswap.Initiator && s.State == atomicswap.StateAccepted) ||
+		(s.Role == atomicswap.Participant && s.State == atomicswap.StateInitiated))
+}
+
+func (row *swapRow) canRedeem() bool {
+	s := row.swap
+	return s.State == atomicswap.StateParticipated && s.CounterpartyContract != nil && len(s.Secret) > 0
+}
+
+func (row *swapRow) canAutoRedeem() bool {
+	s := row.swap
+	return s.Role == atomicswap.Participant && s.CounterpartyContract != nil && s.PresignedRedeem == nil &&
+		len(s.Secret) == 0 && (s.State == atomicswap.StateInitiated || s.State == atomicswap.StateParticipated)
+}
+
+func (row *swapRow) canRefund() bool {
+	return row.swap.Refundable() && time.Now().Unix() >= row.swap.LockTime
+}
+
+func (row *swapRow) canCancel() bool {
+	return row.swap.Contract == nil && !row.swap.State.IsFinal()
+}
+
+// AtomicSwapsPage lists the user's atomic swaps, creates swap offers and
+// imports the swap messages received from counterparties.
+type AtomicSwapsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	pageContainer *widget.List
+
+	sourceWalletSelector       *components.WalletAndAccountSelector
+	sourceAccountSelector      *components.WalletAndAccountSelector
+	destinationWalletSelector  *components.WalletAndAccountSelector
+	destinationAccountSelector *components.WalletAndAccountSelector
+
+	sendAmount    cryptomaterial.Editor
+	receiveAmount cryptomaterial.Editor
+	createButton  cryptomaterial.Button
+
+	messageEditor cryptomaterial.Editor
+	importButton  cryptomaterial.Button
+
+	rows []*swapRow
+}
+
+func NewAtomicSwapsPage(l *load.Load) *AtomicSwapsPage {
+	pg := &AtomicSwapsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(AtomicSwapsPageID),
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		sendAmount:    l.Theme.Editor(new(widget.Editor), values.String(values.StrSwapSendAmountHint)),
+		receiveAmount: l.Theme.Editor(new(widget.Editor), values.String(values.StrSwapReceiveAmountHint)),
+		createButton:  l.Theme.Button(values.String(values.StrCreateOffer)),
+		messageEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrSwapMessageHint)),
+		importButton:  l.Theme.Button(values.String(values.StrImport)),
+	}
+	pg.sendAmount.Editor.SingleLine = true
+	pg.receiveAmount.Editor.SingleLine = true
+
+	pg.sourceWalletSelector, pg.sourceAccountSelector = pg.walletAndAccountSelectors(values.StrFrom, libutils.DCRWalletAsset)
+	pg.destinationWalletSelector, pg.destinationAccountSelector = pg.walletAndAccountSelectors(values.StrTo, libutils.BTCWalletAsset)
+
+	return pg
+}
+
+// walletAndAccountSelectors returns the selectors of a swap wallet and
+// account, with a wallet of assetType selected if there is one.
+func (pg *AtomicSwapsPage) walletAndAccountSelectors(title string, assetType libutils.AssetType) (*components.WalletAndAccountSelector, *components.WalletAndAccountSelector) {
+	walletSelector := components.NewWalletAndAccountSelector(pg.Load, swapAssets...).
+		Title(values.String(title))
+	walletSelector.SetSelectedAsset(assetType)
+
+	accountSelector := components.NewWalletAndAccountSelector(pg.Load).
+		Title(values.String(values.StrAccount)).
+		AccountValidator(func(account *sharedW.Account) bool {
+			return account.Number != load.MaxInt32
+		})
+	if walletSelector.SelectedWallet() != nil {
+		accountSelector.SelectFirstValidAccount(walletSelector.SelectedWallet())
+	}
+
+	walletSelector.WalletSelected(func(selectedWallet *load.WalletMapping) {
+		accountSelector.SelectFirstValidAccount(selectedWallet)
+	})
+	return walletSelector, accountSelector
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *AtomicSwapsPage) OnNavigatedTo() {
+	pg.loadSwaps()
+}
+
+// loadSwaps recreates the swap rows from the stored swaps.
+func (pg *AtomicSwapsPage) loadSwaps() {
+	swaps, err := pg.WL.AssetsManager.AtomicSwaps.All()
+	if err != nil {
+		log.Errorf("Error fetching atomic swaps: %v", err)
+	}
+
+	rows := make([]*swapRow, 0, len(swaps))
+	for _, swap := range swaps {
+		row := &swapRow{
+			swap:             swap,
+			copyButton:       pg.Theme.OutlineButton(values.String(values.StrCopyMessage)),
+			saveButton:       pg.Theme.OutlineButton(values.String(values.StrSaveMessage)),
+			acceptButton:     pg.Theme.Button(values.String(values.StrAcceptOffer)),
+			fundButton:       pg.Theme.Button(values.String(values.StrFundContract)),
+			redeemButton:     pg.Theme.Button(values.String(values.StrRedeem)),
+			autoRedeemButton: pg.Theme.OutlineButton(values.String(values.StrAutoRedeem)),
+			refundButton:     pg.Theme.Button(values.String(values.StrRefund)),
+			cancelButton:     pg.Theme.OutlineButton(values.String(values.StrCancel)),
+		}
+		if msg, err := pg.WL.AssetsManager.SwapMessage(swap.ID); err == nil {
+			row.message, _ = msg.Encode()
+		}
+		rows = append(rows, row)
+	}
+	pg.rows = rows
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *AtomicSwapsPage) Layout(gtx C) D {
+	pageContent := []func(gtx C) D{
+		pg.newOfferLayout,
+		pg.importMessageLayout,
+		pg.swapsLayout,
+	}
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.Center.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding600)
+		gtx.Constraints.Max.X = gtx.Constraints.Min.X
+		gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+		return pg.Theme.List(pg.pageContainer).Layout(gtx, len(pageContent), func(gtx C, i int) D {
+			return layout.Inset{Right: values.MarginPadding2, Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+					return layout.UniformInset(values.MarginPadding15).Layout(gtx, pageContent[i])
+				})
+			})
+		})
+	})
+}
+
+func (pg *AtomicSwapsPage) newOfferLayout(gtx C) D {
+	side := func(walletSelector, accountSelector *components.WalletAndAccountSelector, amount *cryptomaterial.Editor) layout.FlexChild {
+		return layout.Flexed(0.5, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return walletSelector.Layout(pg.ParentWindow(), gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+						return accountSelector.Layout(pg.ParentWindow(), gtx)
+					})
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, amount.Layout)
+				}),
+			)
+		})
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.Theme.Label(values.TextSize16, values.String(values.StrNewSwapOffer)).Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
+					side(pg.sourceWalletSelector, pg.sourceAccountSelector, &pg.sendAmount),
+					layout.Rigid(layout.Spacer{Width: values.MarginPadding10}.Layout),
+					side(pg.destinationWalletSelector, pg.destinationAccountSelector, &pg.receiveAmount),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.E.Layout(gtx, pg.createButton.Layout)
+			})
+		}),
+	)
+}
+
+func (pg *AtomicSwapsPage) importMessageLayout(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.messageEditor.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.E.Layout(gtx, pg.importButton.Layout)
+			})
+		}),
+	)
+}
+
+func (pg *AtomicSwapsPage) swapsLayout(gtx C) D {
+	if len(pg.rows) == 0 {
+		txt := pg.Theme.Body2(values.String(values.StrNoAtomicSwaps))
+		txt.Color = pg.Theme.Color.GrayText3
+		return layout.Center.Layout(gtx, txt.Layout)
+	}
+
+	rows := make([]layout.FlexChild, 0, len(pg.rows))
+	for i, row := range pg.rows {
+		i, row := i, row
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+						return pg.swapRowLayout(gtx, row)
+					})
+				}),
+				layout.Rigid(func(gtx C) D {
+					if i == len(pg.rows)-1 {
+						return D{}
+					}
+					return pg.Theme.Separator().Layout(gtx)
+				}),
+			)
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
+func (pg *AtomicSwapsPage) swapRowLayout(gtx C, row *swapRow) D {
+	swap := row.swap
+	if row.copyButton.Clicked() {
+		clipboard.WriteOp{Text: row.message}.Add(gtx.Ops)
+		pg.Toast.Notify(values.String(values.StrSwapMessageCopied))
+	}
+
+	title := swapAmount(swap.Asset, swap.Amount) + " → " + swapAmount(swap.CounterpartyAsset, swap.CounterpartyAmount)
+	status := string(swap.Role) + " · " + string(swap.State)
+	if swap.Refundable() {
+		status += " · " + values.StringF(values.StrSwapRefundableAt, time.Unix(swap.LockTime, 0).Format(time.RFC822))
+	}
+
+	buttons := make([]layout.FlexChild, 0, 8)
+	addButton := func(show bool, button *cryptomaterial.Button) {
+		if show {
+			buttons = append(buttons, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, button.Layout)
+			}))
+		}
+	}
+	addButton(row.canAccept(), &row.acceptButton)
+	addButton(row.canFund(), &row.fundButton)
+	addButton(row.canRedeem(), &row.redeemButton)
+	addButton(row.canAutoRedeem(), &row.autoRedeemButton)
+	addButton(row.canRefund(), &row.refundButton)
+	addButton(row.message != "", &row.copyButton)
+	addButton(row.message != "", &row.saveButton)
+	addButton(row.canCancel(), &row.cancelButton)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.Theme.Body1(title).Layout),
+		layout.Rigid(func(gtx C) D {
+			txt := pg.Theme.Caption(status)
+			txt.Color = pg.Theme.Color.GrayText2
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if swap.LastError == "" {
+				return D{}
+			}
+			txt := pg.Theme.Caption(swap.LastError)
+			txt.Color = pg.Theme.Color.Danger
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if len(buttons) == 0 {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx, buttons...)
+			})
+		}),
+	)
+}
+
+// createOffer creates a swap offer from the form values.
+func (pg *AtomicSwapsPage) createOffer() {
+	sourceWallet := pg.sourceWalletSelector.SelectedWallet()
+	destinationWallet := pg.destinationWalletSelector.SelectedWallet()
+	if sourceWallet == nil || destinationWallet == nil ||
+		pg.sourceAccountSelector.SelectedAccount() == nil || pg.destinationAccountSelector.SelectedAccount() == nil {
+		return
+	}
+
+	amount, ok := parseSwapAmount(&pg.sendAmount, sourceWallet.GetAssetType())
+	if !ok {
+		return
+	}
+	receiveAmount, ok := parseSwapAmount(&pg.receiveAmount, destinationWallet.GetAssetType())
+	if !ok {
+		return
+	}
+
+	_, err := pg.WL.AssetsManager.CreateSwapOffer(sourceWallet.GetWalletID(), pg.sourceAccountSelector.SelectedAccount().Number, amount,
+		destinationWallet.GetWalletID(), pg.destinationAccountSelector.SelectedAccount().Number, receiveAmount)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+
+	pg.sendAmount.Editor.SetText("")
+	pg.receiveAmount.Editor.SetText("")
+	pg.loadSwaps()
+}
+
+// importMessage processes the swap message, or the swap message file, of the
+// message editor.
+func (pg *AtomicSwapsPage) importMessage() {
+	text := strings.TrimSpace(pg.messageEditor.Editor.Text())
+	var msg *atomicswap.Message
+	var err error
+	if strings.HasPrefix(text, "cpswap:") {
+		msg, err = atomicswap.DecodeMessage(text)
+	} else {
+		msg, err = atomicswap.ReadMessageFile(text)
+	}
+	if err != nil {
+		pg.messageEditor.SetError(err.Error())
+		return
+	}
+
+	swap, err := pg.WL.AssetsManager.ProcessSwapMessage(msg)
+	if err != nil {
+		pg.messageEditor.SetError(err.Error())
+		return
+	}
+
+	// Preselect the wallets of the received offer.
+	if swap.Role == atomicswap.Participant && swap.State == atomicswap.StateOffered {
+		pg.sourceWalletSelector.SetSelectedAsset(swap.Asset)
+		pg.destinationWalletSelector.SetSelectedAsset(swap.CounterpartyAsset)
+		pg.sourceAccountSelector.SelectFirstValidAccount(pg.sourceWalletSelector.SelectedWallet())
+		pg.destinationAccountSelector.SelectFirstValidAccount(pg.destinationWalletSelector.SelectedWallet())
+	}
+
+	pg.messageEditor.Editor.SetText("")
+	pg.Toast.Notify(values.String(values.StrSwapMessageImported))
+	pg.loadSwaps()
+}
+
+// acceptOffer accepts a received offer with the wallets selected in the
+// form.
+func (pg *AtomicSwapsPage) acceptOffer(swap *atomicswap.Swap) {
+	sourceWallet := pg.sourceWalletSelector.SelectedWallet()
+	destinationWallet := pg.destinationWalletSelector.SelectedWallet()
+	if sourceWallet == nil || destinationWallet == nil ||
+		pg.sourceAccountSelector.SelectedAccount() == nil || pg.destinationAccountSelector.SelectedAccount() == nil {
+		return
+	}
+
+	_, err := pg.WL.AssetsManager.AcceptSwapOffer(swap.ID, sourceWallet.GetWalletID(), pg.sourceAccountSelector.SelectedAccount().Number,
+		destinationWallet.GetWalletID(), pg.destinationAccountSelector.SelectedAccount().Number)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	pg.loadSwaps()
+}
+
+// promptPassphrase asks for the spending passphrase of the wallet with
+// walletID and calls action with it.
+func (pg *AtomicSwapsPage) promptPassphrase(walletID int, title, msg string, action func(passphrase string) error) {
+	wallet := pg.WL.AssetsManager.WalletWithID(walletID)
+	if wallet == nil {
+		return
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(title).
+		SetDescription(values.StringF(msg, wallet.GetWalletName())).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if err := action(password); err != nil {
+				pm.SetError(err.Error())
+				pm.SetLoading(false)
+				return false
+			}
+
+			pm.Dismiss()
+			pg.loadSwaps()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *AtomicSwapsPage) HandleUserInteractions() {
+	pg.sourceWalletSelector.Handle(pg.ParentWindow())
+	pg.sourceAccountSelector.Handle(pg.ParentWindow())
+	pg.destinationWalletSelector.Handle(pg.ParentWindow())
+	pg.destinationAccountSelector.Handle(pg.ParentWindow())
+
+	for _, editor := range []*cryptomaterial.Editor{&pg.sendAmount, &pg.receiveAmount, &pg.messageEditor} {
+		if _, isChanged := cryptomaterial.HandleEditorEvents(editor.Editor); isChanged {
+			editor.SetError("")
+		}
+	}
+
+	if pg.createButton.Clicked() {
+		pg.createOffer()
+	}
+
+	if pg.importButton.Clicked() {
+		pg.importMessage()
+	}
+
+	for _, row := range pg.rows {
+		swap := row.swap
+		switch {
+		case row.acceptButton.Clicked():
+			pg.acceptOffer(swap)
+
+		case row.fundButton.Clicked():
+			pg.promptPassphrase(swap.WalletID, values.String(values.StrFundContract), values.StrFundSwapMsg, func(passphrase string) error {
+				_, err := pg.WL.AssetsManager.FundSwap(swap.ID, passphrase)
+				return err
+			})
+
+		case row.redeemButton.Clicked():
+			pg.promptPassphrase(swap.ReceiveWalletID, values.String(values.StrRedeem), values.StrRedeemSwapMsg, func(passphrase string) error {
+				_, err := pg.WL.AssetsManager.RedeemSwap(swap.ID, passphrase)
+				return err
+			})
+
+		case row.autoRedeemButton.Clicked():
+			pg.promptPassphrase(swap.ReceiveWalletID, values.String(values.StrAutoRedeem), values.StrAutoRedeemSwapMsg, func(passphrase string) error {
+				_, err := pg.WL.AssetsManager.PresignSwapRedeem(swap.ID, passphrase)
+				return err
+			})
+
+		case row.refundButton.Clicked():
+			if _, err := pg.WL.AssetsManager.RefundSwap(swap.ID); err != nil {
+				pg.Toast.NotifyError(err.Error())
+			}
+			pg.loadSwaps()
+
+		case row.saveButton.Clicked():
+			path, err := pg.WL.AssetsManager.SaveSwapMessage(swap.ID)
+			if err != nil {
+				pg.Toast.NotifyError(err.Error())
+				continue
+			}
+			pg.Toast.Notify(values.StringF(values.StrSwapMessageSaved, path), true)
+
+		case row.cancelButton.Clicked():
+			if err := pg.WL.AssetsManager.CancelSwap(swap.ID); err != nil {
+				pg.Toast.NotifyError(err.Error())
+			}
+			pg.loadSwaps()
+
+		default:
+			continue
+		}
+		break
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *AtomicSwapsPage) OnNavigatedFrom() {}
+
+// parseSwapAmount returns the amount of editor in the smallest unit of
+// assetType.
+func parseSwapAmount(editor *cryptomaterial.Editor, assetType libutils.AssetType) (int64, bool) {
+	coins, err := strconv.ParseFloat(strings.TrimSpace(editor.Editor.Text()), 64)
+	if err != nil || coins <= 0 {
+		editor.SetError(values.String(values.StrInvalidAmount))
+		return 0, false
+	}
+
+	switch assetType {
+	case libutils.BTCWalletAsset:
+		return btc.AmountSatoshi(coins), true
+	case libutils.DCRWalletAsset:
+		return dcr.AmountAtom(coins), true
+	case libutils.LTCWalletAsset:
+		return ltc.AmountLitoshi(coins), true
+	}
+	return 0, false
+}
+
+// swapAmount formats an amount of assetType in its smallest unit.
+func swapAmount(assetType libutils.AssetType, amount int64) string {
+	switch assetType {
+	case libutils.BTCWalletAsset:
+		return btc.Amount(amount).String()
+	case libutils.DCRWalletAsset:
+		return dcr.Amount(amount).String()
+	case libutils.LTCWalletAsset:
+		return ltc.Amount(amount).String()
+	}
+	return strconv.FormatInt(amount, 10)
+}
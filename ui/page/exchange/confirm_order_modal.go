@@ -142,7 +142,7 @@ func (com *confirmOrderModal) confirmOrder() {
 		_, err = com.sourceWalletSelector.SelectedWallet().Broadcast(password, "")
 		if err != nil {
 			com.WL.AssetsManager.InstantSwap.DeleteOrder(order)
-			com.SetError(err.Error())
+			com.SetError(components.BroadcastError(err))
 			com.SetLoading(false)
 			return
 		}
@@ -79,6 +79,7 @@ type CreateOrderPage struct {
 	refreshIcon            *cryptomaterial.Image
 	viewAllButton          cryptomaterial.Button
 	navToSettingsBtn       cryptomaterial.Button
+	bestQuoteBtn           cryptomaterial.Button
 
 	min          float64
 	max          float64
@@ -182,6 +183,10 @@ func NewCreateOrderPage(l *load.Load) *CreateOrderPage {
 
 	pg.navToSettingsBtn = pg.Theme.Button(values.StringF(values.StrEnableAPI, values.String(values.StrExchange)))
 
+	pg.bestQuoteBtn = pg.Theme.OutlineButton(values.String(values.StrBestRate))
+	pg.bestQuoteBtn.TextSize = values.TextSize14
+	pg.bestQuoteBtn.Inset = layout.UniformInset(values.MarginPadding6)
+
 	pg.exchangeSelector.ExchangeSelected(func(es *Exchange) {
 		pg.selectedExchange = es
 
@@ -268,6 +273,67 @@ func (pg *CreateOrderPage) initPage() {
 	pg.listenForNotifications()
 	pg.loadOrderConfig()
 	go pg.scroll.FetchScrollData(false, pg.ParentWindow())
+	pg.promptResumeScheduler()
+}
+
+// promptResumeScheduler asks for the spending password of an order scheduler
+// that was paused when the app restarted, to resume or stop it. The schedulers
+// with the skipped IDs are not prompted.
+func (pg *CreateOrderPage) promptResumeScheduler(skip ...int) {
+	jobs, err := pg.WL.AssetsManager.InstantSwap.ActiveSchedulerJobs()
+	if err != nil {
+		log.Errorf("Error fetching order schedulers: %v", err)
+		return
+	}
+
+	skipped := make(map[int]bool, len(skip))
+	for _, id := range skip {
+		skipped[id] = true
+	}
+
+	var job *instantswap.SchedulerJob
+	for _, j := range jobs {
+		if j.NeedsPassphrase && !skipped[j.ID] && !pg.WL.AssetsManager.InstantSwap.IsSchedulerRunning(j.ID) {
+			job = j
+			break
+		}
+	}
+	if job == nil {
+		return
+	}
+
+	sourceWallet := pg.WL.AssetsManager.WalletWithID(job.Params.Order.SourceWalletID)
+	if sourceWallet == nil {
+		return
+	}
+
+	order := job.Params.Order
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		SetCancelable(false).
+		Title(values.String(values.StrResumeScheduler)).
+		SetDescription(values.StringF(values.StrResumeSchedulerMsg, order.FromCurrency, order.ToCurrency, sourceWallet.GetWalletName())).
+		SetNegativeButtonText(values.String(values.StrStopScheduler)).
+		SetNegativeButtonCallback(func() {
+			if err := pg.WL.AssetsManager.StopSchedulerJob(job.ID); err != nil {
+				pg.Toast.NotifyError(err.Error())
+			}
+			pg.promptResumeScheduler(skip...)
+		}).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if err := sourceWallet.UnlockWallet(password); err != nil {
+				pm.SetError(err.Error())
+				pm.SetLoading(false)
+				return false
+			}
+
+			go pg.WL.AssetsManager.ResumeScheduler(context.Background(), job.ID, password)
+			pm.Dismiss()
+			pg.promptResumeScheduler(append(skip, job.ID)...)
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
 }
 
 func (pg *CreateOrderPage) OnNavigatedFrom() {
@@ -296,6 +362,10 @@ func (pg *CreateOrderPage) HandleUserInteractions() {
 		pg.ParentNavigator().Display(NewOrderDetailsPage(pg.Load, orderItems[selectedItem]))
 	}
 
+	if pg.bestQuoteBtn.Clicked() && !pg.fetchingRate {
+		go pg.selectBestQuote()
+	}
+
 	if pg.refreshExchangeRateBtn.Button.Clicked() {
 		go func() {
 			err := pg.getExchangeRateInfo()
@@ -728,6 +798,9 @@ func (pg *CreateOrderPage) layout(gtx C) D {
 											layout.Rigid(func(gtx C) D {
 												return pg.exchangeSelector.Layout(pg.ParentWindow(), gtx)
 											}),
+											layout.Rigid(func(gtx C) D {
+												return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.bestQuoteBtn.Layout)
+											}),
 										)
 									})
 								}),
@@ -1124,6 +1197,36 @@ func (pg *CreateOrderPage) updateExchangeRate() {
 	}
 }
 
+// selectBestQuote requests quotes from all the enabled exchange servers and
+// selects the server with the best offer for the entered amount.
+func (pg *CreateOrderPage) selectBestQuote() {
+	amount := float64(libwallet.DefaultRateRequestAmount)
+	if f, err := strconv.ParseFloat(pg.fromAmountEditor.Edit.Editor.Text(), 64); err == nil && f > 0 {
+		amount = f
+	}
+
+	req := instantswap.QuoteRequest{
+		From:   pg.fromCurrency.String(),
+		To:     pg.toCurrency.String(),
+		Amount: amount,
+	}
+
+	pg.fetchingRate = true
+	quote, err := pg.WL.AssetsManager.InstantSwap.BestQuote(pg.ctx, req)
+	pg.fetchingRate = false
+	if err != nil {
+		log.Error(err)
+		pg.Toast.NotifyError(values.String(values.StrNoUsableQuote))
+		pg.ParentWindow().Reload()
+		return
+	}
+
+	if pg.exchangeSelector.SelectExchangeServer(quote.ExchangeServer.Server) {
+		pg.Toast.Notify(values.StringF(values.StrBestQuoteSelected, quote.ExchangeServer.Server.CapFirstLetter(), quote.EstimatedReceive, req.To))
+	}
+	pg.ParentWindow().Reload()
+}
+
 func (pg *CreateOrderPage) getExchangeRateInfo() error {
 	pg.exchangeRate = -1
 	pg.fetchingRate = true
@@ -1321,7 +1424,7 @@ func (pg *CreateOrderPage) listenForNotifications() {
 				case wallet.OrderSchedulerStarted:
 					pg.scheduler.SetChecked(pg.WL.AssetsManager.IsOrderSchedulerRunning())
 				case wallet.OrderSchedulerEnded:
-					pg.scheduler.SetChecked(false)
+					pg.scheduler.SetChecked(pg.WL.AssetsManager.IsOrderSchedulerRunning())
 				}
 			case <-pg.ctx.Done():
 				pg.WL.AssetsManager.RateSource.RemoveRateListener(CreateOrderPageID)
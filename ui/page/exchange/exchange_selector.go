@@ -160,6 +160,27 @@ func (es *ExSelector) SetSelectedExchangeName(name string) {
 	}
 }
 
+// SelectExchangeServer sets the exchange of server as the current selected
+// exchange and executes the exchange selected callback. It returns false if
+// server is not supported.
+func (es *ExSelector) SelectExchangeServer(server instantswap.Server) bool {
+	for _, v := range es.SupportedExchanges() {
+		if v.Server.Server != server {
+			continue
+		}
+
+		if es.selectedExchange != nil && es.selectedExchange.Name != v.Name {
+			es.changed = true
+		}
+		es.SetSelectedExchange(v)
+		if es.exchangeCallback != nil {
+			es.exchangeCallback(v)
+		}
+		return true
+	}
+	return false
+}
+
 func (es *ExSelector) Handle(window app.WindowNavigator) {
 	for es.openSelectorDialog.Clicked() {
 		es.title(es.dialogTitle)
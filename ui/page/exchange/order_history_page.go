@@ -7,6 +7,7 @@ import (
 	"gioui.org/widget/material"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
 	"github.com/crypto-power/cryptopower/listeners"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
@@ -45,6 +46,9 @@ type OrderHistoryPage struct {
 	refreshClickable *cryptomaterial.Clickable
 	refreshIcon      *cryptomaterial.Image
 	statusDropdown   *cryptomaterial.DropDown
+
+	exportCSVBtn  cryptomaterial.Button
+	exportJSONBtn cryptomaterial.Button
 }
 
 func NewOrderHistoryPage(l *load.Load) *OrderHistoryPage {
@@ -53,6 +57,8 @@ func NewOrderHistoryPage(l *load.Load) *OrderHistoryPage {
 		GenericPageModal: app.NewGenericPageModal(OrderHistoryPageID),
 		refreshClickable: l.Theme.NewClickable(true),
 		refreshIcon:      l.Theme.Icons.Restore,
+		exportCSVBtn:     l.Theme.OutlineButton(values.String(values.StrExportCSV)),
+		exportJSONBtn:    l.Theme.OutlineButton(values.String(values.StrExportJSON)),
 	}
 
 	pg.backButton, _ = components.SubpageHeaderButtons(l)
@@ -106,6 +112,24 @@ func (pg *OrderHistoryPage) HandleUserInteractions() {
 	if pg.refreshClickable.Clicked() {
 		go pg.WL.AssetsManager.InstantSwap.Sync(pg.ctx)
 	}
+
+	if pg.exportCSVBtn.Clicked() {
+		go pg.exportSwapReport(libwallet.SwapReportCSV)
+	}
+
+	if pg.exportJSONBtn.Clicked() {
+		go pg.exportSwapReport(libwallet.SwapReportJSON)
+	}
+}
+
+// exportSwapReport exports the completed orders for tax reporting.
+func (pg *OrderHistoryPage) exportSwapReport(format libwallet.SwapReportFormat) {
+	path, err := pg.WL.AssetsManager.ExportSwapReport(format)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	pg.Toast.Notify(values.StringF(values.StrSwapReportExported, path), true)
 }
 
 func (pg *OrderHistoryPage) Layout(gtx C) D {
@@ -160,7 +184,11 @@ func (pg *OrderHistoryPage) layout(gtx C) D {
 					return layout.Inset{}.Layout(gtx, func(gtx C) D {
 						return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 							layout.Rigid(func(gtx C) D {
-								return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+								return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+									layout.Rigid(pg.exportCSVBtn.Layout),
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.exportJSONBtn.Layout)
+									}),
 									layout.Flexed(1, func(gtx C) D {
 										body := func(gtx C) D {
 											return layout.Flex{Axis: layout.Horizontal, Alignment: layout.End}.Layout(gtx,
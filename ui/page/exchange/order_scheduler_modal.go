@@ -2,7 +2,9 @@ package exchange
 
 import (
 	"context"
+	"errors"
 	"strconv"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/layout"
@@ -40,11 +42,15 @@ type orderSchedulerModal struct {
 
 	balanceToMaintain          cryptomaterial.Editor
 	balanceToMaintainErrorText string
+	limitRate                  cryptomaterial.Editor
+	limitAmount                cryptomaterial.Editor
+	limitDeadline              cryptomaterial.Editor
 	passwordEditor             cryptomaterial.Editor
 	copyRedirect               *cryptomaterial.Clickable
 
 	exchangeSelector  *ExSelector
 	frequencySelector *FrequencySelector
+	autoSelectServer  *cryptomaterial.Switch
 
 	materialLoader material.LoaderStyle
 
@@ -63,6 +69,7 @@ func newOrderSchedulerModalModal(l *load.Load, data *orderData) *orderSchedulerM
 		Modal:             l.Theme.ModalFloatTitle(values.String(values.StrOrderScheduler)),
 		exchangeSelector:  NewExSelector(l, instantswap.FlypMe),
 		frequencySelector: NewFrequencySelector(l),
+		autoSelectServer:  l.Theme.Switch(),
 		orderData:         data,
 		copyRedirect:      l.Theme.NewClickable(false),
 		exchangeRate:      -1,
@@ -82,6 +89,13 @@ func newOrderSchedulerModalModal(l *load.Load, data *orderData) *orderSchedulerM
 	osm.balanceToMaintain = l.Theme.Editor(new(widget.Editor), values.StringF(values.StrBalanceToMaintain, osm.fromCurrency))
 	osm.balanceToMaintain.Editor.SingleLine, osm.balanceToMaintain.Editor.Submit = true, true
 
+	osm.limitRate = l.Theme.Editor(new(widget.Editor), values.StringF(values.StrLimitRateHint, osm.toCurrency, osm.fromCurrency))
+	osm.limitRate.Editor.SingleLine = true
+	osm.limitAmount = l.Theme.Editor(new(widget.Editor), values.StringF(values.StrLimitAmountHint, osm.fromCurrency))
+	osm.limitAmount.Editor.SingleLine = true
+	osm.limitDeadline = l.Theme.Editor(new(widget.Editor), values.String(values.StrLimitDeadlineHint))
+	osm.limitDeadline.Editor.SingleLine = true
+
 	osm.passwordEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword))
 	osm.passwordEditor.Editor.SetText("")
 	osm.passwordEditor.Editor.SingleLine = true
@@ -191,11 +205,18 @@ func (osm *orderSchedulerModal) Handle() {
 }
 
 func (osm *orderSchedulerModal) canStart() bool {
-	if osm.exchangeSelector.selectedExchange == nil {
+	if osm.exchangeSelector.selectedExchange == nil && !osm.autoSelectServer.IsChecked() {
+		return false
+	}
+
+	condition, err := osm.limitCondition()
+	if err != nil {
 		return false
 	}
 
-	if osm.frequencySelector.selectedFrequency == nil {
+	// Conditional orders are created when the condition is met instead of
+	// at the selected frequency.
+	if condition == nil && osm.frequencySelector.selectedFrequency == nil {
 		return false
 	}
 
@@ -210,6 +231,40 @@ func (osm *orderSchedulerModal) canStart() bool {
 	return true
 }
 
+// limitCondition returns the condition of a limit-style scheduler from the
+// optional limit fields. nil is returned if no limit rate is set.
+func (osm *orderSchedulerModal) limitCondition() (*instantswap.OrderCondition, error) {
+	if !components.InputsNotEmpty(osm.limitRate.Editor) {
+		return nil, nil
+	}
+
+	minRate, err := strconv.ParseFloat(osm.limitRate.Editor.Text(), 64)
+	if err != nil || minRate <= 0 {
+		return nil, errors.New(values.String(values.StrInvalidAmount))
+	}
+
+	totalAmount, err := strconv.ParseFloat(osm.limitAmount.Editor.Text(), 64)
+	if err != nil || totalAmount <= 0 {
+		return nil, errors.New(values.String(values.StrInvalidAmount))
+	}
+
+	condition := &instantswap.OrderCondition{
+		MinRate:      minRate,
+		TotalAmount:  totalAmount,
+		PollInterval: libwallet.DefaultConditionPollInterval,
+	}
+
+	if components.InputsNotEmpty(osm.limitDeadline.Editor) {
+		hours, err := strconv.ParseFloat(osm.limitDeadline.Editor.Text(), 64)
+		if err != nil || hours <= 0 {
+			return nil, errors.New(values.String(values.StrInvalidAmount))
+		}
+		condition.Deadline = time.Now().Add(time.Duration(hours * float64(time.Hour)))
+	}
+
+	return condition, nil
+}
+
 func (osm *orderSchedulerModal) Layout(gtx layout.Context) D {
 	w := []layout.Widget{
 		func(gtx C) D {
@@ -321,6 +376,16 @@ func (osm *orderSchedulerModal) Layout(gtx layout.Context) D {
 																)
 															})
 														}),
+														layout.Rigid(func(gtx C) D {
+															return layout.Inset{
+																Bottom: values.MarginPadding16,
+															}.Layout(gtx, func(gtx C) D {
+																return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+																	layout.Flexed(1, osm.Theme.Label(values.TextSize14, values.String(values.StrBestRate)).Layout),
+																	layout.Rigid(osm.autoSelectServer.Layout),
+																)
+															})
+														}),
 														layout.Rigid(func(gtx C) D {
 															return layout.Inset{
 																Bottom: values.MarginPadding16,
@@ -362,6 +427,27 @@ func (osm *orderSchedulerModal) Layout(gtx layout.Context) D {
 																)
 															})
 														}),
+														layout.Rigid(func(gtx C) D {
+															return layout.Inset{
+																Bottom: values.MarginPadding16,
+															}.Layout(gtx, func(gtx C) D {
+																return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+																	layout.Rigid(osm.limitRate.Layout),
+																	layout.Rigid(func(gtx C) D {
+																		if !components.InputsNotEmpty(osm.limitRate.Editor) {
+																			return D{}
+																		}
+																		return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, osm.limitAmount.Layout)
+																	}),
+																	layout.Rigid(func(gtx C) D {
+																		if !components.InputsNotEmpty(osm.limitRate.Editor) {
+																			return D{}
+																		}
+																		return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, osm.limitDeadline.Layout)
+																	}),
+																)
+															})
+														}),
 														layout.Rigid(func(gtx C) D {
 															return layout.Inset{
 																Bottom: values.MarginPadding16,
@@ -465,9 +551,18 @@ func (osm *orderSchedulerModal) startOrderScheduler() {
 		}
 
 		balanceToMaintain, _ := strconv.ParseFloat(osm.balanceToMaintain.Editor.Text(), 32)
+		condition, _ := osm.limitCondition()
+		var frequency time.Duration
+		if osm.frequencySelector.selectedFrequency != nil {
+			frequency = osm.frequencySelector.selectedFrequency.item
+		}
+		var exchangeServer instantswap.ExchangeServer
+		if osm.exchangeSelector.selectedExchange != nil {
+			exchangeServer = osm.exchangeSelector.selectedExchange.Server
+		}
 		params := instantswap.SchedulerParams{
 			Order: instantswap.Order{
-				ExchangeServer:           osm.exchangeSelector.selectedExchange.Server,
+				ExchangeServer:           exchangeServer,
 				SourceWalletID:           osm.orderData.sourceWalletID,
 				SourceAccountNumber:      osm.orderData.sourceAccountNumber,
 				DestinationWalletID:      osm.orderData.destinationWalletID,
@@ -480,8 +575,10 @@ func (osm *orderSchedulerModal) startOrderScheduler() {
 				RefundAddress:      osm.orderData.refundAddress,
 			},
 
-			Frequency:          osm.frequencySelector.selectedFrequency.item,
+			Frequency:          frequency,
 			BalanceToMaintain:  balanceToMaintain,
+			Condition:          condition,
+			AutoSelectServer:   osm.autoSelectServer.IsChecked(),
 			SpendingPassphrase: osm.passwordEditor.Editor.Text(),
 		}
 
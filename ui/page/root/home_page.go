@@ -11,10 +11,12 @@ import (
 
 	"github.com/crypto-power/cryptopower/app"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/pricealert"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/notification"
 	"github.com/crypto-power/cryptopower/ui/page/components"
 	"github.com/crypto-power/cryptopower/ui/page/governance"
 	"github.com/crypto-power/cryptopower/ui/page/send"
@@ -170,6 +172,29 @@ func (hp *HomePage) OnNavigatedTo() {
 	}
 
 	hp.isBalanceHidden = hp.WL.AssetsManager.IsTotalBalanceVisible()
+
+	err := hp.WL.AssetsManager.PriceAlerts.AddNotificationListener(hp, HomePageID)
+	if err != nil {
+		log.Errorf("Error adding price alerts notification listener: %v", err)
+	}
+}
+
+// OnPriceAlert is called when one of the user's price alerts is triggered.
+// The alert is shown as a toast and a desktop notification.
+// Satisfies the pricealert.AlertNotificationListener interface.
+func (hp *HomePage) OnPriceAlert(n *pricealert.Notification) {
+	msg := components.PriceAlertMessage(hp.Printer, n)
+	hp.Toast.Notify(msg, true)
+
+	systemNotification, err := notification.NewSystemNotification()
+	if err == nil {
+		err = systemNotification.Notify(msg)
+	}
+	if err != nil {
+		log.Infof("could not initiate desktop notification, reason: %v", err)
+	}
+
+	hp.ParentWindow().Reload()
 }
 
 // OnDarkModeChanged is triggered whenever the dark mode setting is changed
@@ -349,6 +374,7 @@ func (hp *HomePage) OnNavigatedFrom() {
 		activeTab.OnNavigatedFrom()
 	}
 
+	hp.WL.AssetsManager.PriceAlerts.RemoveNotificationListener(HomePageID)
 	hp.ctxCancel()
 }
 
@@ -806,7 +832,7 @@ func (hp *HomePage) CalculateAssetsUSDBalance() {
 			totalBalance += balance
 		}
 
-		hp.totalBalanceUSD = utils.FormatAsUSDString(hp.Printer, totalBalance)
+		hp.totalBalanceUSD = utils.FormatAsFiatString(hp.Printer, hp.WL.AssetsManager.GetFiatCurrency(), totalBalance)
 		hp.ParentWindow().Reload()
 	}
 }
@@ -179,21 +179,13 @@ func (mp *MainPage) fetchExchangeRate() {
 	}
 
 	mp.isFetchingExchangeRate = true
-	var market string
-	switch mp.assetType {
-	case libutils.DCRWalletAsset:
-		market = values.DCRUSDTMarket
-	case libutils.BTCWalletAsset:
-		market = values.BTCUSDTMarket
-	case libutils.LTCWalletAsset:
-		market = values.LTCUSDTMarket
-	default:
+	if _, ok := values.AssetExchangeMarketValue[mp.assetType]; !ok {
 		log.Errorf("Unsupported asset type: %s", mp.assetType)
 		mp.isFetchingExchangeRate = false
 		return
 	}
 
-	rate := mp.WL.AssetsManager.RateSource.GetTicker(market)
+	rate := mp.WL.AssetsManager.FiatTicker(mp.assetType)
 	if rate == nil || rate.LastTradePrice <= 0 {
 		mp.isFetchingExchangeRate = false
 		return
@@ -214,7 +206,7 @@ func (mp *MainPage) updateBalance() {
 	}
 	mp.totalBalance = totalBalance.Total
 	balanceInUSD := totalBalance.Total.MulF64(mp.usdExchangeRate).ToCoin()
-	mp.totalBalanceUSD = utils.FormatAsUSDString(mp.Printer, balanceInUSD)
+	mp.totalBalanceUSD = utils.FormatAsFiatString(mp.Printer, mp.WL.AssetsManager.GetFiatCurrency(), balanceInUSD)
 }
 
 // OnDarkModeChanged is triggered whenever the dark mode setting is changed
@@ -449,10 +441,14 @@ func (mp *MainPage) LayoutTopBar(gtx C) D {
 							}),
 							layout.Rigid(func(gtx C) D {
 								if isWatchOnlyWallet {
+									tag := values.String(values.StrWatchOnly)
+									if mp.selectedWallet.IsAddressWatchWallet() {
+										tag = values.String(values.StrAddressWatch)
+									}
 									return layout.Inset{
 										Left: values.MarginPadding10,
 									}.Layout(gtx, func(gtx C) D {
-										return components.WalletHightlighLabel(mp.Theme, gtx, values.TextSize16, values.String(values.StrWatchOnly))
+										return components.WalletHightlighLabel(mp.Theme, gtx, values.TextSize16, tag)
 									})
 								}
 								return D{}
@@ -84,6 +84,8 @@ type OverviewPage struct {
 	materialLoader    material.LoaderStyle
 	forceRefreshRates *cryptomaterial.Clickable
 
+	portfolio *portfolioChart
+
 	mixerSliderData      map[int]*mixerData
 	sortedMixerSlideKeys []int
 
@@ -179,6 +181,7 @@ func NewOverviewPage(l *load.Load, showNavigationFunc showNavigationFunc) *Overv
 		showNavigationFunc: showNavigationFunc,
 	}
 
+	pg.portfolio = newPortfolioChart(l.Theme, l.WL.AssetsManager.AllAssetTypes())
 	pg.materialLoader = material.Loader(l.Theme.Base)
 	pg.mixerSlider = l.Theme.Slider()
 	pg.mixerSlider.ButtonBackgroundColor = values.TransparentColor(values.TransparentDeepBlue, 0.02)
@@ -214,6 +217,7 @@ func (pg *OverviewPage) OnNavigatedTo() {
 	pg.updateAssetsSliders()
 	go pg.updateAssetsUSDBalance()
 	go pg.loadTransactions()
+	go pg.loadPortfolio(true)
 
 	pg.proposalItems = components.LoadProposals(pg.Load, libwallet.ProposalCategoryAll, 0, 3, true)
 	pg.orders = components.LoadOrders(pg.Load, 0, 3, true)
@@ -238,6 +242,8 @@ func (pg *OverviewPage) HandleUserInteractions() {
 		pg.ParentNavigator().Display(walPage)
 	}
 
+	pg.handlePortfolioInteractions()
+
 	if pg.forceRefreshRates.Clicked() {
 		go pg.WL.AssetsManager.RateSource.Refresh(true)
 	}
@@ -291,6 +297,7 @@ func (pg *OverviewPage) layoutDesktop(gtx layout.Context) layout.Dimensions {
 	pageContent := []func(gtx C) D{
 		pg.sliderLayout,
 		pg.marketOverview,
+		pg.portfolioOverview,
 		pg.txStakingSection,
 		pg.recentTrades,
 		pg.recentProposal,
@@ -313,6 +320,7 @@ func (pg *OverviewPage) layoutMobile(gtx C) D {
 	pageContent := []func(gtx C) D{
 		pg.sliderLayout,
 		pg.mobileMarketOverview,
+		pg.portfolioOverview,
 		pg.txStakingSection,
 		pg.recentTrades,
 		pg.recentProposal,
@@ -752,7 +760,7 @@ func (pg *OverviewPage) mobileMarketOverview(gtx C) D {
 									}),
 									layout.Rigid(func(gtx C) D {
 										return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
-											txt := pg.Theme.Label(values.TextSize16, utils.FormatAsUSDString(pg.Printer, rate.LastTradePrice))
+											txt := pg.Theme.Label(values.TextSize16, utils.FormatAsFiatString(pg.Printer, pg.WL.AssetsManager.GetFiatCurrency(), rate.LastTradePrice))
 											txt.Color = pg.Theme.Color.Text
 											return txt.Layout(gtx)
 										})
@@ -811,7 +819,7 @@ func (pg *OverviewPage) marketRates() map[string]*ext.Ticker {
 
 	for i := range pg.mktValues {
 		asset := pg.mktValues[i]
-		rate := pg.WL.AssetsManager.RateSource.GetTicker(asset.market)
+		rate := pg.WL.AssetsManager.FiatTicker(asset.assetType)
 		if rate == nil || rate.LastTradePrice <= 0 {
 			continue
 		}
@@ -869,7 +877,7 @@ func (pg *OverviewPage) marketTableRows(gtx C, asset assetMarketData, rate *ext.
 			Alignment: layout.Middle,
 		}.Layout(gtx,
 			layout.Flexed(.785, func(gtx C) D {
-				return layout.E.Layout(gtx, pg.assetTableLabel(utils.FormatAsUSDString(pg.Printer, rate.LastTradePrice), pg.Theme.Color.Text))
+				return layout.E.Layout(gtx, pg.assetTableLabel(utils.FormatAsFiatString(pg.Printer, pg.WL.AssetsManager.GetFiatCurrency(), rate.LastTradePrice), pg.Theme.Color.Text))
 			}),
 			layout.Flexed(.215, func(gtx C) D {
 				hasRateChange := rate.PriceChangePercent != nil
@@ -1079,8 +1087,9 @@ func (pg *OverviewPage) updateAssetsUSDBalance() {
 			return
 		}
 
+		currency := pg.WL.AssetsManager.GetFiatCurrency()
 		toUSDString := func(balance float64) string {
-			return utils.FormatAsUSDString(pg.Printer, balance)
+			return utils.FormatAsFiatString(pg.Printer, currency, balance)
 		}
 
 		for assetType, balance := range assetsTotalUSDBalance {
@@ -1114,7 +1123,7 @@ func (pg *OverviewPage) updateAssetsSliders() {
 		return &assetBalanceSliderItem{
 			assetType:       assetFullName,
 			totalBalance:    totalBalance,
-			totalBalanceUSD: "$--",
+			totalBalanceUSD: utils.FiatSymbol(pg.WL.AssetsManager.GetFiatCurrency()) + "--",
 			image:           icon,
 			backgroundImage: bkgImage,
 		}
@@ -0,0 +1,190 @@
+package root
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gioui.org/layout"
+
+	"github.com/crypto-power/cryptopower/libwallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// portfolioPeriods are the selectable periods of the portfolio chart.
+var portfolioPeriods = []struct {
+	title string
+	days  int
+}{
+	{title: "7D", days: 7},
+	{title: "30D", days: 30},
+	{title: "1Y", days: libwallet.PriceHistoryDays},
+}
+
+// portfolioChart shows the value of the user's assets over time.
+type portfolioChart struct {
+	period *cryptomaterial.SegmentedControl
+	asset  *cryptomaterial.SegmentedControl
+
+	// assetTypes maps the asset segments to their asset type. The first
+	// segment shows all the assets.
+	assetTypes []libutils.AssetType
+
+	mtx     sync.RWMutex
+	points  []*libwallet.PortfolioPoint
+	loading bool
+}
+
+func newPortfolioChart(th *cryptomaterial.Theme, assetTypes []libutils.AssetType) *portfolioChart {
+	periods := make([]string, 0, len(portfolioPeriods))
+	for _, p := range portfolioPeriods {
+		periods = append(periods, p.title)
+	}
+
+	assets := []string{values.String(values.StrAll)}
+	for _, assetType := range assetTypes {
+		assets = append(assets, assetType.String())
+	}
+
+	return &portfolioChart{
+		period:     th.SegmentedControl(periods),
+		asset:      th.SegmentedControl(assets),
+		assetTypes: assetTypes,
+	}
+}
+
+// selectedAssets returns the asset types of the selected asset segment.
+func (pc *portfolioChart) selectedAssets() []libutils.AssetType {
+	i := pc.asset.SelectedIndex()
+	if i <= 0 || i > len(pc.assetTypes) {
+		return pc.assetTypes
+	}
+	return pc.assetTypes[i-1 : i]
+}
+
+// loadPortfolio syncs the cached price history if allowed and recomputes the
+// portfolio value for the selected period and asset.
+func (pg *OverviewPage) loadPortfolio(syncPrices bool) {
+	pc := pg.portfolio
+	pc.mtx.Lock()
+	if pc.loading {
+		pc.mtx.Unlock()
+		return
+	}
+	pc.loading = true
+	pc.mtx.Unlock()
+
+	defer func() {
+		pc.mtx.Lock()
+		pc.loading = false
+		pc.mtx.Unlock()
+		pg.ParentWindow().Reload()
+	}()
+
+	if syncPrices && components.IsFetchExchangeRateAPIAllowed(pg.WL) {
+		if err := pg.WL.AssetsManager.SyncPriceHistory(pg.ctx); err != nil {
+			log.Errorf("Error syncing price history: %v", err)
+		}
+	}
+
+	days := portfolioPeriods[pc.period.SelectedIndex()].days
+	from := time.Now().AddDate(0, 0, -days)
+	points, err := pg.WL.AssetsManager.PortfolioHistory(from, pc.selectedAssets()...)
+	if err != nil {
+		log.Errorf("Error computing portfolio history: %v", err)
+		return
+	}
+
+	pc.mtx.Lock()
+	pc.points = points
+	pc.mtx.Unlock()
+}
+
+func (pg *OverviewPage) handlePortfolioInteractions() {
+	if pg.portfolio.period.Changed() || pg.portfolio.asset.Changed() {
+		go pg.loadPortfolio(false)
+	}
+}
+
+// portfolioValues returns the portfolio values to chart in the user's fiat
+// currency. USD values are returned if no forex rate is available.
+func (pg *OverviewPage) portfolioValues() ([]float64, string) {
+	pg.portfolio.mtx.RLock()
+	points := pg.portfolio.points
+	pg.portfolio.mtx.RUnlock()
+
+	currency := pg.WL.AssetsManager.GetFiatCurrency()
+	rate, err := pg.WL.AssetsManager.RateSource.ForexRate(currency)
+	if err != nil {
+		currency, rate = values.USDCurrency, 1
+	}
+
+	chartValues := make([]float64, 0, len(points))
+	for _, p := range points {
+		chartValues = append(chartValues, p.Value*rate)
+	}
+	return chartValues, currency
+}
+
+func (pg *OverviewPage) portfolioOverview(gtx C) D {
+	chartValues, currency := pg.portfolioValues()
+	if len(chartValues) == 0 {
+		return D{}
+	}
+
+	titleLayout := func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(pg.Theme.Body2(values.String(values.StrPortfolioValue)).Layout),
+			layout.Flexed(1, func(gtx C) D {
+				return layout.E.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(pg.portfolio.asset.Layout),
+						layout.Rigid(pg.portfolio.period.Layout),
+					)
+				})
+			}),
+		)
+	}
+
+	first, last := chartValues[0], chartValues[len(chartValues)-1]
+	changeStr := "----"
+	changeCol := pg.Theme.Color.GrayText4
+	if first > 0 {
+		change := (last - first) / first * 100
+		changeStr = fmt.Sprintf("%.2f%%", change)
+		changeCol = pg.Theme.Color.Success
+		if change < 0 {
+			changeCol = pg.Theme.Color.Danger
+		} else {
+			changeStr = "+" + changeStr
+		}
+	}
+
+	return pg.pageContentWrapper(gtx, values.String(values.StrPortfolioValue), titleLayout, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Alignment: layout.Baseline}.Layout(gtx,
+					layout.Rigid(pg.Theme.Label(values.TextSize20, utils.FormatAsFiatString(pg.Printer, currency, last)).Layout),
+					layout.Rigid(func(gtx C) D {
+						lbl := pg.Theme.Label(values.TextSize14, changeStr)
+						lbl.Color = changeCol
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+					}),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, components.LineChart{
+					Values:    chartValues,
+					Color:     pg.Theme.Color.Primary,
+					Height:    values.MarginPadding150,
+					LineWidth: values.MarginPadding2,
+				}.Layout)
+			}),
+		)
+	})
+}
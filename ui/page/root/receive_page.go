@@ -18,6 +18,7 @@ import (
 	"github.com/crypto-power/cryptopower/app"
 	"github.com/crypto-power/cryptopower/libwallet"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
@@ -209,7 +210,11 @@ func (pg *ReceivePage) layoutDesktop(gtx layout.Context) layout.Dimensions {
 					}),
 					layout.Rigid(func(gtx C) D {
 						if pg.WL.SelectedWallet.Wallet.IsWatchingOnlyWallet() {
-							warning := pg.Theme.Label(values.TextSize16, values.String(values.StrWarningWatchWallet))
+							text := values.String(values.StrWarningWatchWallet)
+							if pg.WL.SelectedWallet.Wallet.IsAddressWatchWallet() {
+								text = values.String(values.StrAddressWatchNoNewAddress)
+							}
+							warning := pg.Theme.Label(values.TextSize16, text)
 							warning.Color = pg.Theme.Color.Danger
 							return layout.Center.Layout(gtx, warning.Layout)
 						}
@@ -450,6 +455,10 @@ func (pg *ReceivePage) HandleUserInteractions() {
 		newAddr, err := pg.generateNewAddress()
 		if err != nil {
 			log.Debug("Error generating new address" + err.Error())
+			if err.Error() == libutils.ErrAddressWatchWallet {
+				pg.Toast.NotifyError(values.TranslateErr(err.Error()))
+				pg.isNewAddr = false
+			}
 			return
 		}
 
@@ -20,6 +20,7 @@ const (
 var tabTitles = []string{
 	values.String(values.StrDcrDex),
 	values.String(values.StrCentralizedExchange),
+	values.String(values.StrAtomicSwaps),
 }
 
 type TradePage struct {
@@ -96,6 +97,9 @@ func (pg *TradePage) HandleUserInteractions() {
 	if pg.tab.SelectedIndex() == 1 {
 		pg.Display(exchange.NewCreateOrderPage(pg.Load))
 	}
+	if pg.tab.SelectedIndex() == 2 {
+		pg.Display(exchange.NewAtomicSwapsPage(pg.Load))
+	}
 }
 
 // OnNavigatedFrom is called when the page is about to be removed from
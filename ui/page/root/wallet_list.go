@@ -56,9 +56,11 @@ func (pg *WalletSelectorPage) loadBadWallets() {
 	populateBadWallets := func(assetType libutils.AssetType, badWallets map[int]*sharedW.Wallet) {
 		for _, badWallet := range badWallets {
 			listItem := &badWalletListItem{
-				Wallet:    badWallet,
-				deleteBtn: pg.Theme.OutlineButton(values.String(values.StrDeleted)),
+				Wallet:      badWallet,
+				diagnoseBtn: pg.Theme.OutlineButton(values.String(values.StrDiagnose)),
+				deleteBtn:   pg.Theme.OutlineButton(values.String(values.StrDeleted)),
 			}
+			listItem.diagnoseBtn.Inset = layout.Inset{}
 			listItem.deleteBtn.Color = pg.Theme.Color.Danger
 			listItem.deleteBtn.Inset = layout.Inset{}
 			pg.badWalletsList[assetType] = append(pg.badWalletsList[assetType], listItem)
@@ -187,7 +189,14 @@ func (pg *WalletSelectorPage) badWalletsWrapper(gtx C, badWalletsList []*badWall
 					return layout.Flex{}.Layout(gtx,
 						layout.Rigid(pg.Theme.Body2(badWallet.Name).Layout),
 						layout.Flexed(1, func(gtx C) D {
-							return layout.E.Layout(gtx, badWallet.deleteBtn.Layout)
+							return layout.E.Layout(gtx, func(gtx C) D {
+								return layout.Flex{}.Layout(gtx,
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Right: m16}.Layout(gtx, badWallet.diagnoseBtn.Layout)
+									}),
+									layout.Rigid(badWallet.deleteBtn.Layout),
+								)
+							})
 						}),
 					)
 				}),
@@ -275,10 +284,14 @@ func (pg *WalletSelectorPage) walletWrapper(gtx C, item *load.WalletItem) D {
 						}),
 						layout.Rigid(func(gtx C) D {
 							if item.Wallet.IsWatchingOnlyWallet() {
+								tag := values.String(values.StrWatchOnly)
+								if item.Wallet.IsAddressWatchWallet() {
+									tag = values.String(values.StrAddressWatch)
+								}
 								return layout.Inset{
 									Left: values.MarginPadding8,
 								}.Layout(gtx, func(gtx C) D {
-									return components.WalletHightlighLabel(pg.Theme, gtx, values.TextSize12, values.String(values.StrWatchOnly))
+									return components.WalletHightlighLabel(pg.Theme, gtx, values.TextSize12, tag)
 								})
 							}
 							return D{}
@@ -331,7 +344,7 @@ func (pg *WalletSelectorPage) walletWrapper(gtx C, item *load.WalletItem) D {
 					layout.Rigid(func(gtx C) D {
 						usdBalance := ""
 						if components.IsFetchExchangeRateAPIAllowed(pg.WL) {
-							usdBalance = utils.FormatAsUSDString(pg.Printer, item.TotalBalance.MulF64(pg.assetRate[item.Wallet.GetAssetType()]).ToCoin())
+							usdBalance = utils.FormatAsFiatString(pg.Printer, pg.WL.AssetsManager.GetFiatCurrency(), item.TotalBalance.MulF64(pg.assetRate[item.Wallet.GetAssetType()]).ToCoin())
 						}
 						return components.LayoutBalanceWithStateUSD(gtx, pg.Load, usdBalance)
 					}),
@@ -27,7 +27,8 @@ type (
 
 type badWalletListItem struct {
 	*sharedW.Wallet
-	deleteBtn cryptomaterial.Button
+	diagnoseBtn cryptomaterial.Button
+	deleteBtn   cryptomaterial.Button
 }
 
 type walletIndexTuple struct {
@@ -132,17 +133,11 @@ func (pg *WalletSelectorPage) OnNavigatedTo() {
 		}
 		pg.assetsTotalUSDBalance = assetsTotalUSDBalance
 
-		// calculate assets USD rate
+		// calculate assets fiat rate
 		for assetType := range assetsBalance {
-			marketValue, exist := values.AssetExchangeMarketValue[assetType]
-			if !exist {
-				log.Errorf("Unsupported asset type: %s", assetType)
-				break
-			}
-
-			rate := pg.WL.AssetsManager.RateSource.GetTicker(marketValue)
-			if err != nil {
-				log.Error(err)
+			rate := pg.WL.AssetsManager.FiatTicker(assetType)
+			if rate == nil {
+				log.Errorf("No rate information available for %s", assetType)
 				break
 			}
 			pg.assetRate[assetType] = rate.LastTradePrice
@@ -193,6 +188,13 @@ func (pg *WalletSelectorPage) HandleUserInteractions() {
 				pg.deleteBadWallet(badWallet.Wallet.ID)
 				pg.ParentWindow().Reload()
 			}
+			if badWallet.diagnoseBtn.Clicked() {
+				components.DiagnoseWallet(pg.Load, pg.ParentWindow(), badWallet.Wallet.ID, func() {
+					pg.loadWallets()
+					pg.loadBadWallets()
+					pg.ParentWindow().Reload()
+				})
+			}
 		}
 	}
 
@@ -357,7 +359,7 @@ func (pg *WalletSelectorPage) dropdownTitleLayout(gtx C, asset libutils.AssetTyp
 							layout.Rigid(func(gtx C) D {
 								usdBalance := ""
 								if components.IsFetchExchangeRateAPIAllowed(pg.WL) {
-									usdBalance = utils.FormatAsUSDString(pg.Printer, pg.assetsTotalUSDBalance[asset])
+									usdBalance = utils.FormatAsFiatString(pg.Printer, pg.WL.AssetsManager.GetFiatCurrency(), pg.assetsTotalUSDBalance[asset])
 								}
 								return components.LayoutBalanceWithStateUSD(gtx, pg.Load, usdBalance)
 							}),
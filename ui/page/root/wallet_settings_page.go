@@ -53,6 +53,9 @@ type WalletSettingsPage struct {
 	changeWalletName, addAccount, deleteWallet *cryptomaterial.Clickable
 	verifyMessage, validateAddr, signMessage   *cryptomaterial.Clickable
 	updateConnectToPeer, setGapLimit           *cryptomaterial.Clickable
+	deepRecovery                               *cryptomaterial.Clickable
+	backupMetadata, restoreMetadata            *cryptomaterial.Clickable
+	checkWalletDB, spendingPolicy, sweepKeys   *cryptomaterial.Clickable
 
 	backButton cryptomaterial.IconButton
 	infoButton cryptomaterial.IconButton
@@ -74,6 +77,7 @@ func NewWalletSettingsPage(l *load.Load, walletCallbackFunc func()) *WalletSetti
 		changePass:          l.Theme.NewClickable(false),
 		rescan:              l.Theme.NewClickable(false),
 		setGapLimit:         l.Theme.NewClickable(false),
+		deepRecovery:        l.Theme.NewClickable(false),
 		changeAccount:       l.Theme.NewClickable(false),
 		checklog:            l.Theme.NewClickable(false),
 		checkStats:          l.Theme.NewClickable(false),
@@ -84,6 +88,11 @@ func NewWalletSettingsPage(l *load.Load, walletCallbackFunc func()) *WalletSetti
 		validateAddr:        l.Theme.NewClickable(false),
 		signMessage:         l.Theme.NewClickable(false),
 		updateConnectToPeer: l.Theme.NewClickable(false),
+		backupMetadata:      l.Theme.NewClickable(false),
+		restoreMetadata:     l.Theme.NewClickable(false),
+		checkWalletDB:       l.Theme.NewClickable(false),
+		spendingPolicy:      l.Theme.NewClickable(false),
+		sweepKeys:           l.Theme.NewClickable(false),
 
 		spendUnconfirmed:  l.Theme.Switch(),
 		spendUnmixedFunds: l.Theme.Switch(),
@@ -200,6 +209,8 @@ func (pg *WalletSettingsPage) generalSection() layout.Widget {
 				return layout.Inset{}.Layout(gtx, pg.sectionContent(pg.changePass, values.String(values.StrSpendingPassword)))
 			}),
 			layout.Rigid(pg.sectionContent(pg.changeWalletName, values.String(values.StrRenameWalletSheetTitle))),
+			layout.Rigid(pg.sectionContent(pg.backupMetadata, values.String(values.StrBackupMetadata))),
+			layout.Rigid(pg.sectionContent(pg.restoreMetadata, values.String(values.StrRestoreMetadata))),
 			layout.Rigid(func(gtx C) D {
 				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
 					return pg.subSection(gtx, values.String(values.StrUnconfirmedFunds), pg.spendUnconfirmed.Layout)
@@ -258,7 +269,14 @@ func (pg *WalletSettingsPage) debug() layout.Widget {
 				}
 				return D{}
 			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.IsAddressWatchWallet() {
+					return D{}
+				}
+				return pg.sectionDimension(gtx, pg.deepRecovery, values.String(values.StrDeepRecovery))
+			}),
 			layout.Rigid(pg.sectionContent(pg.checklog, values.String(values.StrCheckWalletLog))),
+			layout.Rigid(pg.sectionContent(pg.checkWalletDB, values.String(values.StrCheckWalletDB))),
 			layout.Rigid(pg.sectionContent(pg.checkStats, values.String(values.StrCheckStatistics))),
 		)
 	}
@@ -274,6 +292,13 @@ func (pg *WalletSettingsPage) securityTools() layout.Widget {
 			layout.Rigid(pg.sectionContent(pg.verifyMessage, values.String(values.StrVerifyMessage))),
 			layout.Rigid(pg.sectionContent(pg.validateAddr, values.String(values.StrValidateMsg))),
 			layout.Rigid(pg.sectionContent(pg.signMessage, values.String(values.StrSignMessage))),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				return pg.sectionDimension(gtx, pg.spendingPolicy, values.String(values.StrSpendingPolicy))
+			}),
+			layout.Rigid(pg.sectionContent(pg.sweepKeys, values.String(values.StrSweepPrivateKeys))),
 		)
 	}
 
@@ -618,6 +643,10 @@ func (pg *WalletSettingsPage) HandleUserInteractions() {
 		pg.gapLimitModal()
 	}
 
+	if pg.deepRecovery.Clicked() {
+		pg.ParentNavigator().Display(s.NewDeepRecoveryPage(pg.Load, pg.wallet))
+	}
+
 	for pg.deleteWallet.Clicked() {
 		pg.deleteWalletModal()
 		break
@@ -696,10 +725,36 @@ func (pg *WalletSettingsPage) HandleUserInteractions() {
 		pg.ParentNavigator().Display(security.NewSignMessagePage(pg.Load))
 	}
 
+	if pg.spendingPolicy.Clicked() {
+		pg.ParentNavigator().Display(s.NewSpendingPolicyPage(pg.Load, pg.wallet))
+	}
+
+	if pg.sweepKeys.Clicked() {
+		pg.ParentNavigator().Display(s.NewSweepKeysPage(pg.Load, pg.wallet))
+	}
+
+	if pg.backupMetadata.Clicked() {
+		pg.ParentWindow().ShowModal(components.BackupMetadataModal(pg.Load, pg.wallet.GetWalletID()))
+	}
+
+	if pg.restoreMetadata.Clicked() {
+		pg.ParentWindow().ShowModal(components.RestoreMetadataModal(pg.Load, pg.wallet.GetWalletID()))
+	}
+
 	if pg.checklog.Clicked() {
 		pg.ParentNavigator().Display(s.NewLogPage(pg.Load, pg.wallet.LogFile(), values.String(values.StrWalletLog)))
 	}
 
+	if pg.checkWalletDB.Clicked() {
+		walletID := pg.wallet.GetWalletID()
+		components.DiagnoseWallet(pg.Load, pg.ParentWindow(), walletID, func() {
+			// A rebuilt wallet replaces the wallet with a new ID.
+			if pg.WL.AssetsManager.WalletWithID(walletID) == nil {
+				pg.walletCallbackFunc()
+			}
+		})
+	}
+
 	if pg.checkStats.Clicked() {
 		pg.ParentNavigator().Display(s.NewStatPage(pg.Load))
 	}
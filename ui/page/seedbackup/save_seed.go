@@ -49,6 +49,7 @@ type SaveSeedPage struct {
 	seedList     *widget.List
 	hexLabel     cryptomaterial.Label
 	copy         cryptomaterial.Button
+	splitButton  cryptomaterial.Button
 
 	infoText   string
 	seed       string
@@ -69,6 +70,7 @@ func NewSaveSeedPage(l *load.Load, wallet sharedW.Asset, redirect Redirectfunc)
 		copy:             l.Theme.Button(values.String(values.StrCopy)),
 		infoText:         values.String(values.StrAskedEnterSeedWords),
 		actionButton:     l.Theme.Button(values.String(values.StrWroteAllWords)),
+		splitButton:      l.Theme.OutlineButton(values.String(values.StrSplitSeed)),
 		seedList: &widget.List{
 			List: layout.List{Axis: layout.Vertical},
 		},
@@ -88,6 +90,7 @@ func NewSaveSeedPage(l *load.Load, wallet sharedW.Asset, redirect Redirectfunc)
 	pg.backButton.Icon = l.Theme.Icons.ContentClear
 
 	pg.actionButton.Font.Weight = font.Medium
+	pg.splitButton.TextSize = values.TextSize14
 
 	return pg
 }
@@ -99,6 +102,9 @@ func NewSaveSeedPage(l *load.Load, wallet sharedW.Asset, redirect Redirectfunc)
 func (pg *SaveSeedPage) OnNavigatedTo() {
 	if pg.seedFormatRadioGroup.Value == "" {
 		pg.seedFormatRadioGroup.Value = seedHexFormat
+		if pg.isBIP39Seed() {
+			pg.seedFormatRadioGroup.Value = seedWordFormat
+		}
 	}
 	passwordModal := modal.NewCreatePasswordModal(pg.Load).
 		EnableName(false).
@@ -117,33 +123,26 @@ func (pg *SaveSeedPage) OnNavigatedTo() {
 			pg.seed = seed
 
 			wordList := strings.Split(seed, " ")
-			row1 := wordList[:11]
-			row2 := wordList[11:22]
-			row3 := wordList[22:]
+			rowCount := (len(wordList) + 2) / 3
 
 			// for mobile
-			rowMobile1 := wordList[:17]
-			rowMobile2 := wordList[17:]
+			mobileRowCount := (len(wordList) + 1) / 2
 			mobileRows := make([]saveSeedRow, 0)
-			for i := range rowMobile1 {
-				r2 := ""
-				if i < len(rowMobile2) {
-					r2 = rowMobile2[i]
-				}
+			for i := 0; i < mobileRowCount; i++ {
 				mobileRows = append(mobileRows, saveSeedRow{
 					rowIndex: i + 1,
-					word1:    rowMobile1[i],
-					word2:    r2,
+					word1:    wordAt(wordList, i),
+					word2:    wordAt(wordList, i+mobileRowCount),
 				})
 			}
 
 			rows := make([]saveSeedRow, 0)
-			for i := range row1 {
+			for i := 0; i < rowCount; i++ {
 				rows = append(rows, saveSeedRow{
 					rowIndex: i + 1,
-					word1:    row1[i],
-					word2:    row2[i],
-					word3:    row3[i],
+					word1:    wordAt(wordList, i),
+					word2:    wordAt(wordList, i+rowCount),
+					word3:    wordAt(wordList, i+2*rowCount),
 				})
 			}
 			pg.rows = rows
@@ -167,6 +166,42 @@ func (pg *SaveSeedPage) HandleUserInteractions() {
 	for pg.actionButton.Clicked() {
 		pg.ParentNavigator().Display(NewVerifySeedPage(pg.Load, pg.wallet, pg.seed, pg.redirectCallback))
 	}
+
+	for pg.splitButton.Clicked() {
+		pg.splitSeed()
+	}
+}
+
+// splitSeed splits the seed into SLIP-39 shares and displays them.
+func (pg *SaveSeedPage) splitSeed() {
+	splitModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(true).
+		NameHint(values.String(values.StrSharesThresholdHint)).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrSplitSeed)).
+		SetDescription(values.String(values.StrSplitSeedDesc)).
+		PasswordHint(values.String(values.StrSpendingPassword)).
+		SetPositiveButtonCallback(func(sharing, password string, m *modal.CreatePasswordModal) bool {
+			var threshold, count int
+			sharing = strings.ReplaceAll(strings.ToLower(sharing), "-", " ")
+			if _, err := fmt.Sscanf(sharing, "%d of %d", &threshold, &count); err != nil {
+				m.SetError(values.String(values.StrInvalidSharesThreshold))
+				m.SetLoading(false)
+				return false
+			}
+
+			shares, err := pg.wallet.SplitSeed(password, threshold, count)
+			if err != nil {
+				m.SetError(err.Error())
+				m.SetLoading(false)
+				return false
+			}
+
+			m.Dismiss()
+			pg.ParentNavigator().Display(NewSaveSharesPage(pg.Load, pg.wallet, shares, threshold, pg.redirectCallback))
+			return true
+		})
+	pg.ParentWindow().ShowModal(splitModal)
 }
 
 // OnNavigatedFrom is called when the page is about to be removed from
@@ -200,7 +235,7 @@ func (pg *SaveSeedPage) layoutDesktop(gtx C) D {
 		Body: func(gtx C) D {
 			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 				layout.Rigid(func(gtx C) D {
-					label := pg.Theme.Label(values.TextSize16, values.String(values.StrWriteDownAll33Words))
+					label := pg.Theme.Label(values.TextSize16, pg.writeDownWordsText())
 					label.Color = pg.Theme.Color.GrayText1
 					return label.Layout(gtx)
 				}),
@@ -225,6 +260,12 @@ func (pg *SaveSeedPage) layoutDesktop(gtx C) D {
 					)
 				}),
 				layout.Flexed(1, pg.hexLayout),
+				layout.Rigid(func(gtx C) D {
+					if pg.isBIP39Seed() {
+						return layout.Inset{Bottom: values.MarginPadding120}.Layout(gtx, layout.Spacer{}.Layout)
+					}
+					return layout.Inset{Bottom: values.MarginPadding120}.Layout(gtx, pg.splitButton.Layout)
+				}),
 			)
 		},
 	}
@@ -248,10 +289,16 @@ func (pg *SaveSeedPage) layoutMobile(gtx C) D {
 		Body: func(gtx C) D {
 			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 				layout.Rigid(func(gtx C) D {
-					label := pg.Theme.Label(values.TextSize16, values.String(values.StrWriteDownAll33Words))
+					label := pg.Theme.Label(values.TextSize16, pg.writeDownWordsText())
 					label.Color = pg.Theme.Color.GrayText1
 					return label.Layout(gtx)
 				}),
+				layout.Rigid(func(gtx C) D {
+					if pg.isBIP39Seed() {
+						return D{}
+					}
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.splitButton.Layout)
+				}),
 				layout.Rigid(func(gtx C) D {
 					label := pg.Theme.Label(values.TextSize14, values.String(values.StrYourSeedWords))
 					label.Color = pg.Theme.Color.GrayText1
@@ -302,7 +349,7 @@ func (pg *SaveSeedPage) mobileSeedRow(gtx C, row saveSeedRow) D {
 			if row.word2 == "" {
 				return layout.Dimensions{}
 			}
-			return seedItem(pg.Theme, gtx, itemWidth, row.rowIndex+17, row.word2)
+			return seedItem(pg.Theme, gtx, itemWidth, row.rowIndex+len(pg.mobileRows), row.word2)
 		}),
 	)
 }
@@ -337,7 +384,10 @@ func (pg *SaveSeedPage) hexLayout(gtx layout.Context) layout.Dimensions {
 									hexString, _ := components.SeedWordsToHex(pg.seed)
 									pg.hexLabel.Text = hexString
 								case seedWordFormat:
-									pg.hexLabel.Text = pg.seed[:117] + "..."
+									pg.hexLabel.Text = pg.seed
+									if len(pg.seed) > 117 {
+										pg.hexLabel.Text = pg.seed[:117] + "..."
+									}
 								}
 							}
 							return pg.hexLabel.Layout(gtx)
@@ -388,10 +438,16 @@ func (pg *SaveSeedPage) desktopSeedRow(gtx C, row saveSeedRow) D {
 			return seedItem(pg.Theme, gtx, itemWidth, row.rowIndex, row.word1)
 		}),
 		layout.Rigid(func(gtx C) D {
-			return seedItem(pg.Theme, gtx, itemWidth, row.rowIndex+11, row.word2)
+			if row.word2 == "" {
+				return layout.Dimensions{}
+			}
+			return seedItem(pg.Theme, gtx, itemWidth, row.rowIndex+len(pg.rows), row.word2)
 		}),
 		layout.Rigid(func(gtx C) D {
-			return seedItem(pg.Theme, gtx, itemWidth, row.rowIndex+22, row.word3)
+			if row.word3 == "" {
+				return layout.Dimensions{}
+			}
+			return seedItem(pg.Theme, gtx, itemWidth, row.rowIndex+2*len(pg.rows), row.word3)
 		}),
 	)
 }
@@ -442,9 +498,12 @@ func (pg *SaveSeedPage) layoutVoteChoice() layout.Widget {
 func (pg *SaveSeedPage) layoutItems() []layout.FlexChild {
 	options := make([]layout.FlexChild, 0)
 
-	hexBtn := pg.Theme.RadioButton(pg.seedFormatRadioGroup, seedHexFormat, values.String(values.StrHex), pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary)
-	hexRadioItem := layout.Rigid(hexBtn.Layout)
-	options = append(options, hexRadioItem)
+	// BIP39 seeds are only restored from their words.
+	if !pg.isBIP39Seed() {
+		hexBtn := pg.Theme.RadioButton(pg.seedFormatRadioGroup, seedHexFormat, values.String(values.StrHex), pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary)
+		hexRadioItem := layout.Rigid(hexBtn.Layout)
+		options = append(options, hexRadioItem)
+	}
 
 	wrdBtn := pg.Theme.RadioButton(pg.seedFormatRadioGroup, seedWordFormat, values.String(values.StrWord), pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary)
 	wrdRadioItem := layout.Rigid(wrdBtn.Layout)
@@ -452,3 +511,25 @@ func (pg *SaveSeedPage) layoutItems() []layout.FlexChild {
 
 	return options
 }
+
+// isBIP39Seed returns true if the wallet seed is a BIP39 mnemonic.
+func (pg *SaveSeedPage) isBIP39Seed() bool {
+	return pg.wallet.GetSeedFormat() == sharedW.SeedFormatBIP39
+}
+
+// writeDownWordsText returns the instruction to write down the seed words.
+func (pg *SaveSeedPage) writeDownWordsText() string {
+	if !pg.isBIP39Seed() {
+		return values.String(values.StrWriteDownAll33Words)
+	}
+	return values.StringF(values.StrWriteDownAllWords, len(strings.Fields(pg.seed)))
+}
+
+// wordAt returns the word at index of words or an empty string if index is
+// out of range.
+func wordAt(words []string, index int) string {
+	if index < len(words) {
+		return words[index]
+	}
+	return ""
+}
@@ -0,0 +1,183 @@
+package seedbackup
+
+import (
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const SaveSharesPageID = "save_shares"
+
+// SaveSharesPage shows the SLIP-39 shares of a wallet seed one at a time.
+type SaveSharesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet    sharedW.Asset
+	shares    []string
+	threshold int
+	index     int
+
+	backButton   cryptomaterial.IconButton
+	actionButton cryptomaterial.Button
+	shareList    *widget.List
+
+	redirectCallback Redirectfunc
+}
+
+func NewSaveSharesPage(l *load.Load, wallet sharedW.Asset, shares []string, threshold int, redirect Redirectfunc) *SaveSharesPage {
+	pg := &SaveSharesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(SaveSharesPageID),
+		wallet:           wallet,
+		shares:           shares,
+		threshold:        threshold,
+		actionButton:     l.Theme.Button(values.String(values.StrNext)),
+		shareList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+
+		redirectCallback: redirect,
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+	pg.backButton.Icon = l.Theme.Icons.ContentClear
+
+	pg.actionButton.Font.Weight = font.Medium
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *SaveSharesPage) OnNavigatedTo() {}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *SaveSharesPage) HandleUserInteractions() {
+	for pg.actionButton.Clicked() {
+		if pg.index < len(pg.shares)-1 {
+			pg.index++
+			pg.shareList.Position.First = 0
+			continue
+		}
+		pg.ParentNavigator().Display(NewVerifySharesPage(pg.Load, pg.wallet, pg.redirectCallback))
+	}
+
+	if pg.index == len(pg.shares)-1 {
+		pg.actionButton.Text = values.String(values.StrWroteAllShares)
+	} else {
+		pg.actionButton.Text = values.String(values.StrNext)
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *SaveSharesPage) OnNavigatedFrom() {}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *SaveSharesPage) Layout(gtx C) D {
+	isMobile := pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView)
+	columns := 3
+	bottomMargin := values.MarginPadding2
+	if isMobile {
+		columns = 2
+		// bottom margin accounts for action button's height + components.UniformPadding bottom margin 24dp + 16dp
+		bottomMargin = values.MarginPadding120
+	}
+
+	words := strings.Fields(pg.shares[pg.index])
+	rowCount := (len(words) + columns - 1) / columns
+
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.StringF(values.StrWriteDownShare, pg.index+1, len(pg.shares)),
+		SubTitle:   values.String(values.StrStep1),
+		BackButton: pg.backButton,
+		Back: func() {
+			promptToExit(pg.Load, pg.ParentWindow(), pg.redirectCallback)
+		},
+		Body: func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					label := pg.Theme.Label(values.TextSize16, values.StringF(values.StrWriteDownShareDesc, len(words), pg.threshold))
+					label.Color = pg.Theme.Color.GrayText1
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return cryptomaterial.LinearLayout{
+						Width:       cryptomaterial.MatchParent,
+						Height:      cryptomaterial.WrapContent,
+						Orientation: layout.Vertical,
+						Background:  pg.Theme.Color.Surface,
+						Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+						Margin:      layout.Inset{Top: values.MarginPadding16, Bottom: bottomMargin},
+						Padding:     layout.Inset{Top: values.MarginPadding16, Right: values.MarginPadding16, Bottom: values.MarginPadding8, Left: values.MarginPadding16},
+					}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							return pg.Theme.List(pg.shareList).Layout(gtx, rowCount, func(gtx C, row int) D {
+								return pg.shareRow(gtx, words, row, rowCount, columns)
+							})
+						}),
+					)
+				}),
+			)
+		},
+	}
+
+	layout := func(gtx C) D {
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+	return container(gtx, isMobile, *pg.Theme, layout, "", pg.actionButton, true)
+}
+
+// shareRow lays out the words of a share at row of each of the columns.
+func (pg *SaveSharesPage) shareRow(gtx C, words []string, row, rowCount, columns int) D {
+	itemWidth := gtx.Constraints.Max.X / columns
+	topMargin := values.MarginPadding8
+	if row == 0 {
+		topMargin = values.MarginPadding16
+	}
+
+	items := make([]layout.FlexChild, 0, columns)
+	for column := 0; column < columns; column++ {
+		index := column*rowCount + row
+		if index >= len(words) {
+			break
+		}
+		items = append(items, layout.Rigid(func(gtx C) D {
+			return seedItem(pg.Theme, gtx, itemWidth, index+1, words[index])
+		}))
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:  cryptomaterial.MatchParent,
+		Height: cryptomaterial.WrapContent,
+		Margin: layout.Inset{Top: topMargin},
+	}.Layout(gtx, items...)
+}
@@ -0,0 +1,153 @@
+package seedbackup
+
+import (
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const VerifySharesPageID = "verify_shares"
+
+// VerifySharesPage verifies that enough of the SLIP-39 shares written down
+// restore the wallet seed.
+type VerifySharesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	backButton        cryptomaterial.IconButton
+	actionButton      cryptomaterial.Button
+	sharesInputEditor cryptomaterial.Editor
+	redirectCallback  Redirectfunc
+}
+
+func NewVerifySharesPage(l *load.Load, wallet sharedW.Asset, redirect Redirectfunc) *VerifySharesPage {
+	pg := &VerifySharesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(VerifySharesPageID),
+		wallet:           wallet,
+
+		actionButton:     l.Theme.Button(values.String(values.StrVerify)),
+		redirectCallback: redirect,
+	}
+
+	pg.actionButton.Font.Weight = font.Medium
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+	pg.backButton.Icon = l.Theme.Icons.ContentClear
+
+	pg.sharesInputEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrEnterShares))
+	pg.sharesInputEditor.Editor.SingleLine = false
+	pg.sharesInputEditor.Editor.SetText("")
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *VerifySharesPage) OnNavigatedTo() {}
+
+// shares returns the non-empty lines of the shares editor.
+func (pg *VerifySharesPage) shares() []string {
+	var shares []string
+	for _, line := range strings.Split(pg.sharesInputEditor.Editor.Text(), "\n") {
+		if share := strings.TrimSpace(line); share != "" {
+			shares = append(shares, share)
+		}
+	}
+	return shares
+}
+
+func (pg *VerifySharesPage) verifyShares() {
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmToVerifySeed)).
+		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
+			_, err := pg.wallet.VerifySeedSharesForWallet(pg.shares(), password)
+			if err != nil {
+				if err.Error() == utils.ErrInvalid {
+					msg := values.String(values.StrSeedValidationFailed)
+					errModal := modal.NewErrorModal(pg.Load, msg, modal.DefaultClickFunc())
+					pg.ParentWindow().ShowModal(errModal)
+					m.Dismiss()
+					return false
+				}
+
+				m.SetLoading(false)
+				m.SetError(err.Error())
+				return false
+			}
+			m.Dismiss()
+			pg.ParentNavigator().Display(NewBackupSuccessPage(pg.Load, pg.redirectCallback))
+
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *VerifySharesPage) HandleUserInteractions() {
+	pg.actionButton.SetEnabled(len(pg.shares()) > 0)
+
+	for pg.actionButton.Clicked() {
+		pg.verifyShares()
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *VerifySharesPage) OnNavigatedFrom() {}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *VerifySharesPage) Layout(gtx C) D {
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrVerifyShares),
+		SubTitle:   values.String(values.StrStep2of2),
+		BackButton: pg.backButton,
+		Back: func() {
+			promptToExit(pg.Load, pg.ParentWindow(), pg.redirectCallback)
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				return layout.UniformInset(values.MarginPadding16).Layout(gtx, pg.sharesInputEditor.Layout)
+			})
+		},
+	}
+
+	layout := func(gtx C) D {
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+	isMobile := pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView)
+	return container(gtx, isMobile, *pg.Theme, layout, "", pg.actionButton, true)
+}
@@ -121,7 +121,7 @@ func NewSendPage(l *load.Load, isModalLayout bool) *Page {
 		}
 	}
 
-	pg.amount = newSendAmount(l.Theme, pg.selectedWallet.GetAssetType())
+	pg.amount = newSendAmount(l.Theme, pg.selectedWallet.GetAssetType(), l.WL.AssetsManager.GetFiatCurrency())
 	pg.sendDestination = newSendDestination(l, pg.selectedWallet.GetAssetType())
 
 	callbackFunc := func() libUtil.AssetType {
@@ -301,21 +301,14 @@ func (pg *Page) fetchExchangeRate() {
 		return
 	}
 	pg.isFetchingExchangeRate = true
-	var market string
-	switch pg.selectedWallet.GetAssetType() {
-	case libUtil.DCRWalletAsset:
-		market = values.DCRUSDTMarket
-	case libUtil.BTCWalletAsset:
-		market = values.BTCUSDTMarket
-	case libUtil.LTCWalletAsset:
-		market = values.LTCUSDTMarket
-	default:
-		log.Errorf("Unsupported asset type: %s", pg.selectedWallet.GetAssetType())
+	assetType := pg.selectedWallet.GetAssetType()
+	if _, ok := values.AssetExchangeMarketValue[assetType]; !ok {
+		log.Errorf("Unsupported asset type: %s", assetType)
 		pg.isFetchingExchangeRate = false
 		return
 	}
 
-	rate := pg.WL.AssetsManager.RateSource.GetTicker(market)
+	rate := pg.WL.AssetsManager.FiatTicker(assetType)
 	if rate == nil || rate.LastTradePrice <= 0 {
 		pg.isFetchingExchangeRate = false
 		return
@@ -439,13 +432,14 @@ func (pg *Page) constructTx() {
 
 	if pg.exchangeRate != -1 && pg.usdExchangeSet {
 		pg.feeRateSelector.USDExchangeSet = true
-		pg.txFeeUSD = fmt.Sprintf("$%.4f", utils.CryptoToUSD(pg.exchangeRate, feeAndSize.Fee.CoinValue))
+		currency := pg.WL.AssetsManager.GetFiatCurrency()
+		pg.txFeeUSD = fmt.Sprintf("%s%.4f", utils.FiatSymbol(currency), utils.CryptoToUSD(pg.exchangeRate, feeAndSize.Fee.CoinValue))
 		pg.feeRateSelector.TxFeeUSD = pg.txFeeUSD
-		pg.totalCostUSD = utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, totalSendingAmount.ToCoin()))
-		pg.balanceAfterSendUSD = utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
+		pg.totalCostUSD = utils.FormatAsFiatString(pg.Printer, currency, utils.CryptoToUSD(pg.exchangeRate, totalSendingAmount.ToCoin()))
+		pg.balanceAfterSendUSD = utils.FormatAsFiatString(pg.Printer, currency, utils.CryptoToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
 
 		usdAmount := utils.CryptoToUSD(pg.exchangeRate, wal.ToAmount(amountAtom).ToCoin())
-		pg.sendAmountUSD = utils.FormatAsUSDString(pg.Printer, usdAmount)
+		pg.sendAmountUSD = utils.FormatAsFiatString(pg.Printer, currency, usdAmount)
 	}
 }
 
@@ -457,7 +451,7 @@ func (pg *Page) showBalanceAfterSend() {
 		}
 		balanceAfterSend := sourceAccount.Balance.Spendable
 		pg.balanceAfterSend = balanceAfterSend.String()
-		pg.balanceAfterSendUSD = utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
+		pg.balanceAfterSendUSD = utils.FormatAsFiatString(pg.Printer, pg.WL.AssetsManager.GetFiatCurrency(), utils.CryptoToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
 	}
 }
 
@@ -33,7 +33,7 @@ type sendAmount struct {
 	exchangeRate float64
 }
 
-func newSendAmount(theme *cryptomaterial.Theme, assetType libUtil.AssetType) *sendAmount {
+func newSendAmount(theme *cryptomaterial.Theme, assetType libUtil.AssetType, fiatCurrency string) *sendAmount {
 	sa := &sendAmount{
 		theme:        theme,
 		exchangeRate: -1,
@@ -50,7 +50,7 @@ func newSendAmount(theme *cryptomaterial.Theme, assetType libUtil.AssetType) *se
 	sa.amountEditor.CustomButton.Text = values.String(values.StrMax)
 	sa.amountEditor.CustomButton.CornerRadius = values.MarginPadding0
 
-	sa.usdAmountEditor = theme.Editor(new(widget.Editor), values.String(values.StrAmount)+" (USD)")
+	sa.usdAmountEditor = theme.Editor(new(widget.Editor), fmt.Sprintf("%s (%s)", values.String(values.StrAmount), fiatCurrency))
 	sa.usdAmountEditor.Editor.SetText("")
 	sa.usdAmountEditor.HasCustomButton = true
 	sa.usdAmountEditor.Editor.SingleLine = true
@@ -85,7 +85,7 @@ func (scm *sendConfirmModal) broadcastTransaction() {
 	go func() {
 		_, err := scm.asset.Broadcast(password, scm.txLabel)
 		if err != nil {
-			scm.SetError(err.Error())
+			scm.SetError(components.BroadcastError(err))
 			scm.SetLoading(false)
 			return
 		}
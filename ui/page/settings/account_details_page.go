@@ -337,6 +337,9 @@ func (pg *AcctDetailsPage) acctInfoLayout(gtx C, leftText, rightText string) D {
 }
 
 func (pg *AcctDetailsPage) extendedPubkey(gtx C) D {
+	if pg.extendedKey == "" {
+		return D{}
+	}
 	return pg.pageSections(gtx, func(gtx C) D {
 		return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 			layout.Rigid(func(gtx C) D {
@@ -438,6 +441,10 @@ func (pg *AcctDetailsPage) HandleUserInteractions() {
 }
 
 func (pg *AcctDetailsPage) loadExtendedPubKey() {
+	// The key of an address watch wallet is not the key of its addresses.
+	if pg.WL.SelectedWallet.Wallet.IsAddressWatchWallet() {
+		return
+	}
 	xpub, err := pg.WL.SelectedWallet.Wallet.GetExtendedPubKey(pg.account.Number)
 	if err != nil {
 		pg.Toast.NotifyError(err.Error())
@@ -0,0 +1,295 @@
+package settings
+
+import (
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	AutoLockPageID = "AutoLock"
+
+	// keyHoldersRefreshInterval is how often the key holders are reloaded as
+	// the services start and stop in the background.
+	keyHoldersRefreshInterval = 5 * time.Second
+)
+
+// autoLockTimeouts are the idle timeouts of the auto-lock, in the order they
+// are displayed. Zero disables the auto-lock.
+var autoLockTimeouts = []time.Duration{
+	0,
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	30 * time.Minute,
+	60 * time.Minute,
+}
+
+// serviceTitles are the titles of the background services.
+var serviceTitles = map[libwallet.BackgroundService]string{
+	libwallet.ServiceAccountMixer:   values.StrAccountMixer,
+	libwallet.ServiceTicketBuyer:    values.StrAutoTicketPurchase,
+	libwallet.ServiceOrderScheduler: values.StrOrderScheduler,
+}
+
+// AutoLockPage edits when the app is locked and which background services
+// keep running when it is, and lists what holds unlocked keys.
+type AutoLockPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	pageContainer *widget.List
+	backButton    cryptomaterial.IconButton
+
+	timeout     *cryptomaterial.SegmentedControl
+	lockOnSleep *cryptomaterial.Switch
+	keepRunning map[libwallet.BackgroundService]*cryptomaterial.Switch
+	keyHolders  []*libwallet.KeyHolder
+	lastRefresh time.Time
+
+	// startupSecuritySet is false if no startup password is set, the app can
+	// not be locked without it.
+	startupSecuritySet bool
+}
+
+func NewAutoLockPage(l *load.Load) *AutoLockPage {
+	timeouts := make([]string, 0, len(autoLockTimeouts))
+	for _, timeout := range autoLockTimeouts {
+		timeouts = append(timeouts, timeoutTitle(timeout))
+	}
+
+	pg := &AutoLockPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(AutoLockPageID),
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		timeout:     l.Theme.SegmentedControl(timeouts),
+		lockOnSleep: l.Theme.Switch(),
+		keepRunning: make(map[libwallet.BackgroundService]*cryptomaterial.Switch),
+	}
+	for _, service := range libwallet.BackgroundServices {
+		pg.keepRunning[service] = l.Theme.Switch()
+	}
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// timeoutTitle returns the title of an idle timeout of the auto-lock.
+func timeoutTitle(timeout time.Duration) string {
+	if timeout == 0 {
+		return values.String(values.StrNever)
+	}
+	return values.StringF(values.StrMinutesShort, int(timeout/time.Minute))
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *AutoLockPage) OnNavigatedTo() {
+	assetsManager := pg.WL.AssetsManager
+	pg.startupSecuritySet = assetsManager.IsStartupSecuritySet()
+	pg.timeout.SetSelectedSegment(timeoutTitle(assetsManager.AutoLockTimeout()))
+	pg.lockOnSleep.SetChecked(assetsManager.LockOnSleep())
+	pg.lockOnSleep.SetEnabled(pg.startupSecuritySet)
+	for service, keep := range pg.keepRunning {
+		keep.SetChecked(assetsManager.KeepsRunningWhenLocked(service))
+		keep.SetEnabled(pg.startupSecuritySet)
+	}
+	pg.refreshKeyHolders()
+}
+
+// refreshKeyHolders reloads what holds unlocked keys.
+func (pg *AutoLockPage) refreshKeyHolders() {
+	pg.keyHolders = pg.WL.AssetsManager.UnlockedKeyHolders()
+	pg.lastRefresh = time.Now()
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *AutoLockPage) Layout(gtx C) D {
+	return layout.UniformInset(values.MarginPadding20).Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(pg.pageHeaderLayout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding20}.Layout(gtx, pg.pageContentLayout)
+			}),
+		)
+	})
+}
+
+func (pg *AutoLockPage) pageHeaderLayout(gtx C) D {
+	return layout.W.Layout(gtx, func(gtx C) D {
+		return layout.Flex{}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{
+					Right: values.MarginPadding16,
+					Top:   values.MarginPaddingMinus2,
+				}.Layout(gtx, pg.backButton.Layout)
+			}),
+			layout.Rigid(pg.Theme.Label(values.TextSize20, values.String(values.StrAutoLock)).Layout),
+		)
+	})
+}
+
+func (pg *AutoLockPage) pageContentLayout(gtx C) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.Center.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding550)
+		gtx.Constraints.Max.X = gtx.Constraints.Min.X
+		gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+		sections := []layout.Widget{pg.settingsLayout, pg.keyHoldersLayout}
+		return pg.Theme.List(pg.pageContainer).Layout(gtx, len(sections), func(gtx C, i int) D {
+			return layout.Inset{Right: values.MarginPadding2, Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					return layout.UniformInset(values.MarginPadding15).Layout(gtx, sections[i])
+				})
+			})
+		})
+	})
+}
+
+func (pg *AutoLockPage) settingsLayout(gtx C) D {
+	desc := pg.Theme.Body2(values.String(values.StrAutoLockDesc))
+	desc.Color = pg.Theme.Color.GrayText2
+
+	rows := []layout.FlexChild{
+		layout.Rigid(desc.Layout),
+	}
+	if !pg.startupSecuritySet {
+		// The options have no effect until a startup password is set.
+		warning := pg.Theme.Body2(values.String(values.StrAutoLockNeedsStartupPassword))
+		warning.Color = pg.Theme.Color.Danger
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, warning.Layout)
+		}))
+	}
+	rows = append(rows,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, pg.Theme.Body1(values.String(values.StrLockAfterIdle)).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !pg.startupSecuritySet {
+				gtx = gtx.Disabled()
+			}
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.timeout.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return pg.switchRow(gtx, values.String(values.StrLockOnSleep), pg.lockOnSleep)
+		}),
+		layout.Rigid(func(gtx C) D {
+			title := pg.Theme.Body2(values.String(values.StrKeepRunningWhenLocked))
+			title.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, title.Layout)
+		}),
+	)
+	for _, service := range libwallet.BackgroundServices {
+		service := service
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return pg.switchRow(gtx, values.String(serviceTitles[service]), pg.keepRunning[service])
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
+func (pg *AutoLockPage) switchRow(gtx C, title string, option *cryptomaterial.Switch) D {
+	return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, pg.Theme.Body1(title).Layout),
+			layout.Rigid(option.Layout),
+		)
+	})
+}
+
+func (pg *AutoLockPage) keyHoldersLayout(gtx C) D {
+	rows := []layout.FlexChild{
+		layout.Rigid(pg.Theme.Body1(values.String(values.StrUnlockedKeys)).Layout),
+	}
+	if len(pg.keyHolders) == 0 {
+		empty := pg.Theme.Body2(values.String(values.StrNoUnlockedKeys))
+		empty.Color = pg.Theme.Color.GrayText2
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, empty.Layout)
+		}))
+	}
+
+	for _, holder := range pg.keyHolders {
+		var service string
+		switch holder.Service {
+		case "":
+			service = values.String(values.StrWalletUnlocked)
+		case libwallet.ServiceOrderScheduler:
+			service = values.StringF(values.StrSchedulerJob, holder.SchedulerJobID)
+		default:
+			service = values.String(serviceTitles[holder.Service])
+		}
+
+		wallet := pg.Theme.Body2(holder.WalletName)
+		status := pg.Theme.Body2(service)
+		status.Color = pg.Theme.Color.GrayText2
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, wallet.Layout),
+					layout.Rigid(status.Layout),
+				)
+			})
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *AutoLockPage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	assetsManager := pg.WL.AssetsManager
+	if pg.timeout.Changed() && pg.startupSecuritySet {
+		assetsManager.SetAutoLockTimeout(autoLockTimeouts[pg.timeout.SelectedIndex()])
+	}
+
+	if pg.lockOnSleep.Changed() {
+		assetsManager.SetLockOnSleep(pg.lockOnSleep.IsChecked())
+	}
+
+	for service, keep := range pg.keepRunning {
+		if keep.Changed() {
+			assetsManager.SetKeepRunningWhenLocked(service, keep.IsChecked())
+		}
+	}
+
+	if time.Since(pg.lastRefresh) > keyHoldersRefreshInterval {
+		pg.refreshKeyHolders()
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *AutoLockPage) OnNavigatedFrom() {}
@@ -3,6 +3,7 @@ package settings
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"gioui.org/io/clipboard"
 	"gioui.org/layout"
@@ -44,6 +45,8 @@ type BTCAcctDetailsPage struct {
 	extendedKeyClickable    *cryptomaterial.Clickable
 	showExtendedKeyButton   *cryptomaterial.Clickable
 	isHiddenExtendedxPubkey bool
+	descriptors             string
+	descriptorsClickable    *cryptomaterial.Clickable
 	infoButton              cryptomaterial.IconButton
 }
 
@@ -63,6 +66,7 @@ func NewAcctBTCDetailsPage(l *load.Load, account *sharedW.Account) *BTCAcctDetai
 		renameAccount:           l.Theme.NewClickable(false),
 		extendedKeyClickable:    l.Theme.NewClickable(true),
 		showExtendedKeyButton:   l.Theme.NewClickable(false),
+		descriptorsClickable:    l.Theme.NewClickable(true),
 		isHiddenExtendedxPubkey: true,
 	}
 
@@ -86,6 +90,7 @@ func (pg *BTCAcctDetailsPage) OnNavigatedTo() {
 	pg.keys = values.StringF(values.StrAcctDetailsKey, ext, internal, imp)
 	_, pg.infoButton = components.SubpageHeaderButtons(pg.Load)
 	pg.loadExtendedPubKey()
+	pg.loadDescriptors()
 }
 
 // Layout draws the page UI components into the provided C
@@ -111,6 +116,9 @@ func (pg *BTCAcctDetailsPage) Layout(gtx C) D {
 		func(gtx C) D {
 			return layout.Inset{Bottom: m}.Layout(gtx, pg.extendedPubkey)
 		},
+		func(gtx C) D {
+			return layout.Inset{Bottom: m}.Layout(gtx, pg.outputDescriptors)
+		},
 	}
 	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
 		return pg.layoutMobile(gtx, widgets)
@@ -119,6 +127,9 @@ func (pg *BTCAcctDetailsPage) Layout(gtx C) D {
 }
 
 func (pg *BTCAcctDetailsPage) extendedPubkey(gtx C) D {
+	if pg.extendedKey == "" {
+		return D{}
+	}
 	return pg.pageSections(gtx, func(gtx C) D {
 		return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 			layout.Rigid(func(gtx C) D {
@@ -170,6 +181,32 @@ func (pg *BTCAcctDetailsPage) extendedPubkey(gtx C) D {
 	})
 }
 
+func (pg *BTCAcctDetailsPage) outputDescriptors(gtx C) D {
+	if pg.descriptors == "" {
+		return D{}
+	}
+	return pg.pageSections(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				leftTextLabel := pg.theme.Label(values.TextSize14, values.String(values.StrOutputDescriptors))
+				leftTextLabel.Color = pg.theme.Color.GrayText2
+				return leftTextLabel.Layout(gtx)
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return layout.E.Layout(gtx, func(gtx C) D {
+					if pg.descriptorsClickable.Clicked() {
+						clipboard.WriteOp{Text: pg.descriptors}.Add(gtx.Ops)
+						pg.Toast.Notify(values.String(values.StrDescriptorsCopied))
+					}
+					lbl := pg.Theme.Label(values.TextSize14, values.String(values.StrCopy))
+					lbl.Color = pg.Theme.Color.Primary
+					return pg.descriptorsClickable.Layout(gtx, lbl.Layout)
+				})
+			}),
+		)
+	})
+}
+
 func (pg *BTCAcctDetailsPage) layoutDesktop(gtx layout.Context, widgets []func(gtx C) D) layout.Dimensions {
 	body := func(gtx C) D {
 		sp := components.SubPage{
@@ -400,6 +437,10 @@ func (pg *BTCAcctDetailsPage) HandleUserInteractions() {
 }
 
 func (pg *BTCAcctDetailsPage) loadExtendedPubKey() {
+	// The key of an address watch wallet is not the key of its addresses.
+	if pg.WL.SelectedWallet.Wallet.IsAddressWatchWallet() {
+		return
+	}
 	xpub, err := pg.WL.SelectedWallet.Wallet.GetExtendedPubKey(pg.account.Number)
 	if err != nil {
 		pg.Toast.NotifyError(err.Error())
@@ -407,6 +448,26 @@ func (pg *BTCAcctDetailsPage) loadExtendedPubKey() {
 	pg.extendedKey = xpub
 }
 
+// loadDescriptors loads the receive and change output descriptors of the
+// account, one per line.
+func (pg *BTCAcctDetailsPage) loadDescriptors() {
+	if pg.wallet.IsAddressWatchWallet() {
+		return
+	}
+	asset, ok := pg.wallet.(interface {
+		AccountDescriptors(account int32) ([]string, error)
+	})
+	if !ok {
+		return
+	}
+	descriptors, err := asset.AccountDescriptors(pg.account.Number)
+	if err != nil {
+		log.Errorf("Error loading the account descriptors: %v", err)
+		return
+	}
+	pg.descriptors = strings.Join(descriptors, "\n")
+}
+
 // OnNavigatedFrom is called when the page is about to be removed from
 // the displayed window. This method should ideally be used to disable
 // features that are irrelevant when the page is NOT displayed.
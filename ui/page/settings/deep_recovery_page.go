@@ -0,0 +1,313 @@
+package settings
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/listeners"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	DeepRecoveryPageID = "DeepRecovery"
+
+	defaultGapLimit        = 20
+	defaultAccountGapLimit = 10
+)
+
+// DeepRecoveryPage discovers the used accounts and addresses of a wallet
+// again with the gap limits entered, and reports the ones found.
+type DeepRecoveryPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+	*listeners.SyncProgressListener
+
+	wallet sharedW.Asset
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	pageContainer *widget.List
+	backButton    cryptomaterial.IconButton
+
+	gapLimit        cryptomaterial.Editor
+	accountGapLimit cryptomaterial.Editor
+	passphrase      cryptomaterial.Editor
+	startButton     cryptomaterial.Button
+	errorLabel      cryptomaterial.Label
+
+	// The fields below are updated by the recovery goroutine.
+	cancelRecovery context.CancelFunc
+	progress       int32
+	report         *sharedW.DeepRecoveryReport
+}
+
+func NewDeepRecoveryPage(l *load.Load, wallet sharedW.Asset) *DeepRecoveryPage {
+	pg := &DeepRecoveryPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(DeepRecoveryPageID),
+		wallet:           wallet,
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		gapLimit:        l.Theme.Editor(new(widget.Editor), values.String(values.StrGapLimit)),
+		accountGapLimit: l.Theme.Editor(new(widget.Editor), values.String(values.StrAccountGapLimit)),
+		passphrase:      l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrDeepRecoveryPassHint)),
+		startButton:     l.Theme.Button(values.String(values.StrDiscoverAddressUsage)),
+		errorLabel:      l.Theme.ErrorLabel(""),
+	}
+	pg.gapLimit.Editor.SingleLine = true
+	pg.accountGapLimit.Editor.SingleLine = true
+	pg.passphrase.Editor.SingleLine = true
+	pg.gapLimit.Editor.SetText(strconv.Itoa(int(wallet.ReadInt32ConfigValueForKey(sharedW.GapLimitConfigKey, defaultGapLimit))))
+	pg.accountGapLimit.Editor.SetText(strconv.Itoa(int(wallet.ReadInt32ConfigValueForKey(sharedW.AccountGapLimitConfigKey, defaultAccountGapLimit))))
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *DeepRecoveryPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.listenForProgress()
+}
+
+// listenForProgress updates the progress of the recovery displayed with the
+// address discovery progress of the wallet.
+func (pg *DeepRecoveryPage) listenForProgress() {
+	pg.SyncProgressListener = listeners.NewSyncProgress()
+	err := pg.wallet.AddSyncProgressListener(pg.SyncProgressListener, DeepRecoveryPageID)
+	if err != nil {
+		log.Errorf("Error adding sync progress listener: %v", err)
+		return
+	}
+
+	go func() {
+		for {
+			select {
+			case n := <-pg.SyncStatusChan:
+				if t, ok := n.ProgressReport.(*sharedW.AddressDiscoveryProgressReport); ok {
+					pg.progress = t.AddressDiscoveryProgress
+					pg.ParentWindow().Reload()
+				}
+			case <-pg.ctx.Done():
+				pg.wallet.RemoveSyncProgressListener(DeepRecoveryPageID)
+				close(pg.SyncStatusChan)
+				pg.SyncProgressListener = nil
+				return
+			}
+		}
+	}()
+}
+
+// startRecovery starts the recovery with the gap limits entered.
+func (pg *DeepRecoveryPage) startRecovery() {
+	pg.errorLabel.Text = ""
+	pg.report = nil
+
+	gapLimit, err := strconv.ParseUint(strings.TrimSpace(pg.gapLimit.Editor.Text()), 10, 32)
+	if err != nil || gapLimit < 1 || gapLimit > 1000 {
+		pg.gapLimit.SetError(values.String(values.StrGapLimitInputErr))
+		return
+	}
+	accountGapLimit, err := strconv.ParseUint(strings.TrimSpace(pg.accountGapLimit.Editor.Text()), 10, 32)
+	if err != nil || accountGapLimit > 100 {
+		pg.accountGapLimit.SetError(values.String(values.StrAccountGapLimitInputErr))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pg.cancelRecovery = cancel
+	pg.progress = 0
+	passphrase := pg.passphrase.Editor.Text()
+	go func() {
+		defer cancel()
+		report, err := pg.wallet.DeepRecovery(ctx, uint32(gapLimit), uint32(accountGapLimit), passphrase)
+		pg.cancelRecovery = nil
+		if err != nil {
+			if ctx.Err() == nil {
+				pg.errorLabel.Text = values.TranslateErr(err.Error())
+			}
+		} else {
+			pg.report = report
+			pg.passphrase.Editor.SetText("")
+		}
+		pg.ParentWindow().Reload()
+	}()
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *DeepRecoveryPage) Layout(gtx C) D {
+	return layout.UniformInset(values.MarginPadding20).Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(pg.pageHeaderLayout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding20}.Layout(gtx, pg.pageContentLayout)
+			}),
+		)
+	})
+}
+
+func (pg *DeepRecoveryPage) pageHeaderLayout(gtx C) D {
+	return layout.W.Layout(gtx, func(gtx C) D {
+		return layout.Flex{}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{
+					Right: values.MarginPadding16,
+					Top:   values.MarginPaddingMinus2,
+				}.Layout(gtx, pg.backButton.Layout)
+			}),
+			layout.Rigid(pg.Theme.Label(values.TextSize20, values.String(values.StrDeepRecovery)).Layout),
+		)
+	})
+}
+
+func (pg *DeepRecoveryPage) pageContentLayout(gtx C) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.Center.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding550)
+		gtx.Constraints.Max.X = gtx.Constraints.Min.X
+		gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+		return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+			return layout.Inset{Right: values.MarginPadding2, Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+					return layout.UniformInset(values.MarginPadding15).Layout(gtx, pg.formLayout)
+				})
+			})
+		})
+	})
+}
+
+func (pg *DeepRecoveryPage) formLayout(gtx C) D {
+	desc := pg.Theme.Body2(values.String(values.StrDeepRecoveryDesc))
+	desc.Color = pg.Theme.Color.GrayText2
+	recovering := pg.cancelRecovery != nil
+	pg.startButton.Text = values.String(values.StrDiscoverAddressUsage)
+	if recovering {
+		pg.startButton.Text = values.String(values.StrCancel)
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(desc.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, pg.gapLimit.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.accountGapLimit.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			// Watch only wallets cannot derive new accounts.
+			if pg.wallet.IsWatchingOnlyWallet() {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.passphrase.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !recovering {
+				return D{}
+			}
+			status := values.StringF(values.StrDiscoveringAddresses, pg.progress)
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.Theme.Body1(status).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if pg.errorLabel.Text == "" {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.errorLabel.Layout)
+		}),
+		layout.Rigid(pg.reportLayout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.E.Layout(gtx, pg.startButton.Layout)
+			})
+		}),
+	)
+}
+
+// reportLayout lists the accounts and addresses found by the recovery.
+func (pg *DeepRecoveryPage) reportLayout(gtx C) D {
+	if pg.report == nil {
+		return D{}
+	}
+	if len(pg.report.Accounts) == 0 {
+		return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.Theme.Body1(values.String(values.StrDeepRecoveryNothingFound)).Layout)
+	}
+
+	rows := []layout.FlexChild{
+		layout.Rigid(pg.Theme.Body1(values.String(values.StrDeepRecoveryFound)).Layout),
+	}
+	for _, account := range pg.report.Accounts {
+		title := values.StringF(values.StrDiscoveredAccount, account.Name, len(account.Addresses))
+		if account.New {
+			title = values.StringF(values.StrDiscoveredNewAccount, account.Name, len(account.Addresses))
+		}
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.Theme.Body1(title).Layout)
+		}))
+		for _, address := range account.Addresses {
+			addressLabel := pg.Theme.Body2(address)
+			addressLabel.Color = pg.Theme.Color.GrayText2
+			rows = append(rows, layout.Rigid(addressLabel.Layout))
+		}
+	}
+	return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+	})
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *DeepRecoveryPage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	for _, editor := range []*cryptomaterial.Editor{&pg.gapLimit, &pg.accountGapLimit, &pg.passphrase} {
+		if _, isChanged := cryptomaterial.HandleEditorEvents(editor.Editor); isChanged {
+			editor.SetError("")
+		}
+	}
+
+	if pg.startButton.Clicked() {
+		if pg.cancelRecovery != nil {
+			pg.cancelRecovery()
+		} else {
+			pg.startRecovery()
+		}
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *DeepRecoveryPage) OnNavigatedFrom() {
+	if pg.cancelRecovery != nil {
+		pg.cancelRecovery()
+	}
+	pg.ctxCancel()
+}
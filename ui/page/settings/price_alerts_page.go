@@ -0,0 +1,318 @@
+package settings
+
+import (
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/pricealert"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const PriceAlertsPageID = "PriceAlerts"
+
+// alertRow holds the widgets of a stored price alert.
+type alertRow struct {
+	alert        *pricealert.Alert
+	enabled      *cryptomaterial.Switch
+	deleteButton cryptomaterial.IconButton
+}
+
+// PriceAlertsPage lists the user's price alerts and allows creating,
+// disabling and deleting them.
+type PriceAlertsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	pageContainer *widget.List
+	backButton    cryptomaterial.IconButton
+
+	kind       *cryptomaterial.SegmentedControl
+	asset      *cryptomaterial.SegmentedControl
+	assetTypes []libutils.AssetType
+	threshold  cryptomaterial.Editor
+	addButton  cryptomaterial.Button
+
+	rows []*alertRow
+}
+
+func NewPriceAlertsPage(l *load.Load) *PriceAlertsPage {
+	kinds := make([]string, 0, len(components.PriceAlertKinds))
+	for _, k := range components.PriceAlertKinds {
+		kinds = append(kinds, values.String(k.Title))
+	}
+
+	var assetTypes []libutils.AssetType
+	var assets []string
+	for _, assetType := range l.WL.AssetsManager.AllAssetTypes() {
+		if _, ok := values.AssetExchangeMarketValue[assetType]; ok {
+			assetTypes = append(assetTypes, assetType)
+			assets = append(assets, assetType.String())
+		}
+	}
+
+	pg := &PriceAlertsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(PriceAlertsPageID),
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		kind:       l.Theme.SegmentedControl(kinds),
+		asset:      l.Theme.SegmentedControl(assets),
+		assetTypes: assetTypes,
+		threshold:  l.Theme.Editor(new(widget.Editor), values.String(values.StrAlertPriceHint)),
+		addButton:  l.Theme.Button(values.String(values.StrAddAlert)),
+	}
+	pg.threshold.Editor.SingleLine, pg.threshold.Editor.Submit = true, true
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *PriceAlertsPage) OnNavigatedTo() {
+	pg.loadAlerts()
+}
+
+// loadAlerts recreates the alert rows from the stored alerts.
+func (pg *PriceAlertsPage) loadAlerts() {
+	alerts := pg.WL.AssetsManager.PriceAlerts.Alerts()
+	rows := make([]*alertRow, 0, len(alerts))
+	for _, alert := range alerts {
+		row := &alertRow{
+			alert:        alert,
+			enabled:      pg.Theme.Switch(),
+			deleteButton: pg.Theme.IconButton(pg.Theme.Icons.NavigationCancel),
+		}
+		row.enabled.SetChecked(alert.Enabled)
+		row.deleteButton.Size = values.MarginPadding20
+		row.deleteButton.Inset = layout.UniformInset(values.MarginPadding4)
+		rows = append(rows, row)
+	}
+	pg.rows = rows
+}
+
+// selectedKind returns the kind of alert selected in the form.
+func (pg *PriceAlertsPage) selectedKind() pricealert.Kind {
+	return components.PriceAlertKinds[pg.kind.SelectedIndex()].Kind
+}
+
+// selectedMarket returns the USDT market of the asset selected in the form.
+func (pg *PriceAlertsPage) selectedMarket() string {
+	i := pg.asset.SelectedIndex()
+	if i < 0 || i >= len(pg.assetTypes) {
+		return ""
+	}
+	return values.AssetExchangeMarketValue[pg.assetTypes[i]]
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *PriceAlertsPage) Layout(gtx C) D {
+	return layout.UniformInset(values.MarginPadding20).Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(pg.pageHeaderLayout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding20}.Layout(gtx, pg.pageContentLayout)
+			}),
+		)
+	})
+}
+
+func (pg *PriceAlertsPage) pageHeaderLayout(gtx C) D {
+	return layout.W.Layout(gtx, func(gtx C) D {
+		return layout.Flex{}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{
+					Right: values.MarginPadding16,
+					Top:   values.MarginPaddingMinus2,
+				}.Layout(gtx, pg.backButton.Layout)
+			}),
+			layout.Rigid(pg.Theme.Label(values.TextSize20, values.String(values.StrPriceAlerts)).Layout),
+		)
+	})
+}
+
+func (pg *PriceAlertsPage) pageContentLayout(gtx C) D {
+	pageContent := []func(gtx C) D{
+		pg.newAlertLayout,
+		pg.alertsLayout,
+	}
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.Center.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding550)
+		gtx.Constraints.Max.X = gtx.Constraints.Min.X
+		gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+		return pg.Theme.List(pg.pageContainer).Layout(gtx, len(pageContent), func(gtx C, i int) D {
+			return layout.Inset{Right: values.MarginPadding2, Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+					return layout.UniformInset(values.MarginPadding15).Layout(gtx, pageContent[i])
+				})
+			})
+		})
+	})
+}
+
+func (pg *PriceAlertsPage) newAlertLayout(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.kind.Layout),
+		layout.Rigid(func(gtx C) D {
+			if pg.selectedKind().IsPortfolio() {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.asset.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.threshold.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.E.Layout(gtx, pg.addButton.Layout)
+			})
+		}),
+	)
+}
+
+func (pg *PriceAlertsPage) alertsLayout(gtx C) D {
+	if len(pg.rows) == 0 {
+		txt := pg.Theme.Body2(values.String(values.StrNoPriceAlerts))
+		txt.Color = pg.Theme.Color.GrayText3
+		return layout.Center.Layout(gtx, txt.Layout)
+	}
+
+	rows := make([]layout.FlexChild, 0, len(pg.rows))
+	for i, row := range pg.rows {
+		i, row := i, row
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+						return pg.alertRowLayout(gtx, row)
+					})
+				}),
+				layout.Rigid(func(gtx C) D {
+					if i == len(pg.rows)-1 {
+						return D{}
+					}
+					return pg.Theme.Separator().Layout(gtx)
+				}),
+			)
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
+func (pg *PriceAlertsPage) alertRowLayout(gtx C, row *alertRow) D {
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Flexed(1, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.Theme.Body1(components.PriceAlertTitle(pg.Printer, row.alert)).Layout),
+				layout.Rigid(func(gtx C) D {
+					if row.alert.LastTriggeredAt == 0 {
+						return D{}
+					}
+					lastTriggered := components.TimeAgo(row.alert.LastTriggeredAt)
+					txt := pg.Theme.Caption(values.StringF(values.StrAlertLastTriggered, lastTriggered))
+					txt.Color = pg.Theme.Color.GrayText2
+					return txt.Layout(gtx)
+				}),
+			)
+		}),
+		layout.Rigid(row.enabled.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, row.deleteButton.Layout)
+		}),
+	)
+}
+
+// addAlert creates an alert from the form values.
+func (pg *PriceAlertsPage) addAlert() {
+	threshold, err := strconv.ParseFloat(strings.TrimSpace(pg.threshold.Editor.Text()), 64)
+	if err != nil {
+		pg.threshold.SetError(values.String(values.StrInvalidAmount))
+		return
+	}
+
+	_, err = pg.WL.AssetsManager.PriceAlerts.AddAlert(pg.selectedKind(), pg.selectedMarket(), threshold)
+	if err != nil {
+		pg.threshold.SetError(err.Error())
+		return
+	}
+
+	pg.threshold.Editor.SetText("")
+	pg.loadAlerts()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *PriceAlertsPage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	if pg.kind.Changed() {
+		hint := values.StrAlertPriceHint
+		switch {
+		case pg.selectedKind() == pricealert.PriceMove:
+			hint = values.StrAlertPercentHint
+		case pg.selectedKind().IsPortfolio():
+			hint = values.StrAlertValueHint
+		}
+		pg.threshold.Hint = values.String(hint)
+	}
+
+	isSubmit, isChanged := cryptomaterial.HandleEditorEvents(pg.threshold.Editor)
+	if isChanged {
+		pg.threshold.SetError("")
+	}
+
+	if pg.addButton.Clicked() || isSubmit {
+		pg.addAlert()
+	}
+
+	for _, row := range pg.rows {
+		if row.enabled.Changed() {
+			err := pg.WL.AssetsManager.PriceAlerts.SetAlertEnabled(row.alert.ID, row.enabled.IsChecked())
+			if err != nil {
+				pg.Toast.NotifyError(err.Error())
+			}
+			pg.loadAlerts()
+			break
+		}
+
+		if row.deleteButton.Button.Clicked() {
+			if err := pg.WL.AssetsManager.PriceAlerts.DeleteAlert(row.alert.ID); err != nil {
+				pg.Toast.NotifyError(err.Error())
+			}
+			pg.loadAlerts()
+			break
+		}
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *PriceAlertsPage) OnNavigatedFrom() {}
@@ -1,6 +1,10 @@
 package settings
 
 import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
 	"gioui.org/layout"
 	"gioui.org/widget"
 
@@ -43,8 +47,13 @@ type SettingPage struct {
 	wal           *wallet.Wallet
 
 	changeStartupPass       *cryptomaterial.Clickable
+	autoLock                *cryptomaterial.Clickable
 	language                *cryptomaterial.Clickable
 	currency                *cryptomaterial.Clickable
+	fiatCurrency            *cryptomaterial.Clickable
+	priceAlerts             *cryptomaterial.Clickable
+	backupMetadata          *cryptomaterial.Clickable
+	restoreMetadata         *cryptomaterial.Clickable
 	help                    *cryptomaterial.Clickable
 	about                   *cryptomaterial.Clickable
 	appearanceMode          *cryptomaterial.Clickable
@@ -62,8 +71,16 @@ type SettingPage struct {
 	vspAPI        *cryptomaterial.Switch
 	privacyActive *cryptomaterial.Switch
 
+	dbBackup          *cryptomaterial.Switch
+	dbBackupDir       *cryptomaterial.Clickable
+	dbBackupRetention *cryptomaterial.Clickable
+	backupDBNow       *cryptomaterial.Clickable
+	restoreDBBackup   *cryptomaterial.Clickable
+
 	isDarkModeOn      bool
 	isStartupPassword bool
+	isDBBackup        bool
+	isBackingUpDB     bool
 }
 
 func NewSettingsPage(l *load.Load) *SettingPage {
@@ -82,15 +99,25 @@ func NewSettingsPage(l *load.Load) *SettingPage {
 		feeRateAPI:              l.Theme.Switch(),
 		vspAPI:                  l.Theme.Switch(),
 		privacyActive:           l.Theme.Switch(),
+		dbBackup:                l.Theme.Switch(),
 
 		changeStartupPass: l.Theme.NewClickable(false),
+		autoLock:          l.Theme.NewClickable(false),
 		language:          l.Theme.NewClickable(false),
 		currency:          l.Theme.NewClickable(false),
+		fiatCurrency:      l.Theme.NewClickable(false),
+		priceAlerts:       l.Theme.NewClickable(false),
+		backupMetadata:    l.Theme.NewClickable(false),
+		restoreMetadata:   l.Theme.NewClickable(false),
 		help:              l.Theme.NewClickable(false),
 		about:             l.Theme.NewClickable(false),
 		appearanceMode:    l.Theme.NewClickable(false),
 		logLevel:          l.Theme.NewClickable(false),
 		viewLog:           l.Theme.NewClickable(false),
+		dbBackupDir:       l.Theme.NewClickable(false),
+		dbBackupRetention: l.Theme.NewClickable(false),
+		backupDBNow:       l.Theme.NewClickable(false),
+		restoreDBBackup:   l.Theme.NewClickable(false),
 	}
 
 	_, pg.networkInfoButton = components.SubpageHeaderButtons(l)
@@ -257,6 +284,30 @@ func (pg *SettingPage) general() layout.Widget {
 				layout.Rigid(func(gtx C) D {
 					return pg.subSectionSwitch(gtx, values.String(values.StrTxNotification), pg.transactionNotification)
 				}),
+				layout.Rigid(func(gtx C) D {
+					priceAlertsRow := row{
+						title:     values.String(values.StrPriceAlerts),
+						clickable: pg.priceAlerts,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, priceAlertsRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					backupRow := row{
+						title:     values.String(values.StrBackupMetadata),
+						clickable: pg.backupMetadata,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, backupRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					restoreRow := row{
+						title:     values.String(values.StrRestoreMetadata),
+						clickable: pg.restoreMetadata,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, restoreRow)
+				}),
 			)
 		})
 	}
@@ -279,6 +330,16 @@ func (pg *SettingPage) networkSettings() layout.Widget {
 					}
 					return pg.clickableRow(gtx, exchangeRate)
 				}),
+				layout.Rigid(func(gtx C) D {
+					lKey := pg.WL.AssetsManager.GetFiatCurrency()
+					l := preference.GetKeyValue(lKey, preference.FiatCurrencyOptions)
+					fiatCurrency := row{
+						title:     values.String(values.StrDisplayCurrency),
+						clickable: pg.fiatCurrency,
+						label:     pg.Theme.Body2(values.String(l)),
+					}
+					return pg.clickableRow(gtx, fiatCurrency)
+				}),
 				layout.Rigid(func(gtx C) D {
 					return pg.subSectionSwitch(gtx, values.String(values.StrGovernanceAPI), pg.governanceAPI)
 				}),
@@ -314,11 +375,74 @@ func (pg *SettingPage) security() layout.Widget {
 					}
 					return D{}
 				}),
+				layout.Rigid(func(gtx C) D {
+					if !pg.isStartupPassword {
+						return D{}
+					}
+					return pg.clickableRow(gtx, row{
+						title:     values.String(values.StrAutoLock),
+						clickable: pg.autoLock,
+						label:     pg.Theme.Body1(""),
+					})
+				}),
+				layout.Rigid(pg.dbBackupSection),
 			)
 		})
 	}
 }
 
+// dbBackupSection lays out the database backup settings, which are only
+// available with a startup password since it encrypts the backups.
+func (pg *SettingPage) dbBackupSection(gtx C) D {
+	if !pg.isStartupPassword {
+		return D{}
+	}
+
+	assetsManager := pg.WL.AssetsManager
+	rows := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			return pg.subSectionSwitch(gtx, values.String(values.StrDBBackups), pg.dbBackup)
+		}),
+	}
+	if pg.isDBBackup {
+		rows = append(rows,
+			layout.Rigid(func(gtx C) D {
+				return pg.clickableRow(gtx, row{
+					title:     values.String(values.StrDBBackupDir),
+					clickable: pg.dbBackupDir,
+					label:     pg.Theme.Body2(assetsManager.DBBackupDir()),
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				return pg.clickableRow(gtx, row{
+					title:     values.String(values.StrDBBackupRetention),
+					clickable: pg.dbBackupRetention,
+					label:     pg.Theme.Body2(strconv.Itoa(assetsManager.DBBackupRetention())),
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				lastBackup := ""
+				if last := assetsManager.LastDBBackup(); !last.IsZero() {
+					lastBackup = last.Format("2006-01-02 15:04")
+				}
+				return pg.clickableRow(gtx, row{
+					title:     values.String(values.StrBackupDBNow),
+					clickable: pg.backupDBNow,
+					label:     pg.Theme.Body2(lastBackup),
+				})
+			}),
+		)
+	}
+	rows = append(rows, layout.Rigid(func(gtx C) D {
+		return pg.clickableRow(gtx, row{
+			title:     values.String(values.StrRestoreDBBackup),
+			clickable: pg.restoreDBBackup,
+			label:     pg.Theme.Body1(""),
+		})
+	}))
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
 func (pg *SettingPage) info() layout.Widget {
 	return func(gtx C) D {
 		return pg.wrapSection(gtx, values.String(values.StrInfo), func(gtx C) D {
@@ -434,6 +558,16 @@ func (pg *SettingPage) HandleUserInteractions() {
 		break
 	}
 
+	for pg.fiatCurrency.Clicked() {
+		fiatCurrencySelectorModal := preference.NewListPreference(pg.Load,
+			sharedW.FiatCurrencyConfigKey, values.USDCurrency,
+			preference.FiatCurrencyOptions).
+			Title(values.StrDisplayCurrency).
+			UpdateValues(func(_ string) {})
+		pg.ParentWindow().ShowModal(fiatCurrencySelectorModal)
+		break
+	}
+
 	for pg.appearanceMode.Clicked() {
 		pg.isDarkModeOn = !pg.isDarkModeOn
 		pg.WL.AssetsManager.SetDarkMode(pg.isDarkModeOn)
@@ -478,6 +612,55 @@ func (pg *SettingPage) HandleUserInteractions() {
 		pg.ParentWindow().ShowModal(info)
 	}
 
+	if pg.autoLock.Clicked() {
+		pg.ParentNavigator().Display(NewAutoLockPage(pg.Load))
+	}
+
+	if pg.priceAlerts.Clicked() {
+		pg.ParentNavigator().Display(NewPriceAlertsPage(pg.Load))
+	}
+
+	if pg.backupMetadata.Clicked() {
+		pg.ParentWindow().ShowModal(components.BackupMetadataModal(pg.Load))
+	}
+
+	if pg.restoreMetadata.Clicked() {
+		pg.ParentWindow().ShowModal(components.RestoreMetadataModal(pg.Load, -1))
+	}
+
+	if pg.dbBackup.Changed() {
+		if err := pg.WL.AssetsManager.SetDBBackupEnabled(pg.dbBackup.IsChecked()); err != nil {
+			pg.Toast.NotifyError(err.Error())
+		}
+		pg.isDBBackup = pg.WL.AssetsManager.IsDBBackupEnabled()
+		pg.dbBackup.SetChecked(pg.isDBBackup)
+	}
+
+	if pg.dbBackupDir.Clicked() {
+		pg.showDBBackupDirModal()
+	}
+
+	if pg.dbBackupRetention.Clicked() {
+		pg.showDBBackupRetentionModal()
+	}
+
+	if pg.backupDBNow.Clicked() && !pg.isBackingUpDB {
+		pg.isBackingUpDB = true
+		go func() {
+			defer func() { pg.isBackingUpDB = false }()
+			path, err := pg.WL.AssetsManager.BackupDatabases()
+			if err != nil {
+				pg.Toast.NotifyError(err.Error())
+				return
+			}
+			pg.Toast.Notify(values.StringF(values.StrDBBackedUp, path), true)
+		}()
+	}
+
+	if pg.restoreDBBackup.Clicked() {
+		pg.showRestoreDBBackupModal()
+	}
+
 	if pg.help.Clicked() {
 		pg.ParentNavigator().Display(NewHelpPage(pg.Load))
 	}
@@ -515,7 +698,7 @@ func (pg *SettingPage) HandleUserInteractions() {
 				}
 				err := pg.wal.GetAssetsManager().VerifyStartupPassphrase(password)
 				if err != nil {
-					pm.SetError(err.Error())
+					pm.SetError(components.PassphraseError(err))
 					pm.SetLoading(false)
 					return false
 				}
@@ -589,7 +772,7 @@ func (pg *SettingPage) HandleUserInteractions() {
 				SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
 					err := pg.wal.GetAssetsManager().RemoveStartupPassphrase(password)
 					if err != nil {
-						pm.SetError(err.Error())
+						pm.SetError(components.PassphraseError(err))
 						pm.SetLoading(false)
 						return false
 					}
@@ -606,6 +789,78 @@ func (pg *SettingPage) HandleUserInteractions() {
 	}
 }
 
+func (pg *SettingPage) showDBBackupDirModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrDBBackupDir)).
+		SetText(pg.WL.AssetsManager.DBBackupDir()).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(dir string, tm *modal.TextInputModal) bool {
+			if err := pg.WL.AssetsManager.SetDBBackupDir(strings.TrimSpace(dir)); err != nil {
+				tm.SetError(err.Error())
+				tm.SetLoading(false)
+				return false
+			}
+			return true
+		})
+	textModal.Title(values.String(values.StrDBBackupDir)).
+		SetPositiveButtonText(values.String(values.StrSave))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+func (pg *SettingPage) showDBBackupRetentionModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrDBBackupRetention)).
+		SetText(strconv.Itoa(pg.WL.AssetsManager.DBBackupRetention())).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(text string, tm *modal.TextInputModal) bool {
+			retention, err := strconv.Atoi(strings.TrimSpace(text))
+			if err == nil {
+				err = pg.WL.AssetsManager.SetDBBackupRetention(retention)
+			}
+			if err != nil {
+				tm.SetError(values.String(values.StrInvalidDBBackupRetention))
+				tm.SetLoading(false)
+				return false
+			}
+			return true
+		})
+	textModal.Title(values.String(values.StrDBBackupRetention)).
+		SetPositiveButtonText(values.String(values.StrSave))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// showRestoreDBBackupModal restores the latest database backup with the
+// startup password it was made with.
+func (pg *SettingPage) showRestoreDBBackupModal() {
+	backups, err := pg.WL.AssetsManager.DBBackups()
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	if len(backups) == 0 {
+		pg.Toast.NotifyError(values.String(values.StrNoDBBackup))
+		return
+	}
+
+	latest := backups[0]
+	restoreModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrRestoreDBBackup)).
+		SetDescription(values.StringF(values.StrRestoreDBBackupDesc, filepath.Base(latest))).
+		PasswordHint(values.String(values.StrStartupPassword)).
+		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
+			if err := pg.WL.AssetsManager.RestoreDBBackup(latest, password); err != nil {
+				m.SetError(err.Error())
+				m.SetLoading(false)
+				return false
+			}
+			pg.showNoticeSuccess(values.String(values.StrDBRestoreStaged))
+			return true
+		})
+	pg.ParentWindow().ShowModal(restoreModal)
+}
+
 func (pg *SettingPage) showNoticeSuccess(title string) {
 	info := modal.NewSuccessModal(pg.Load, title, modal.DefaultClickFunc())
 	pg.ParentWindow().ShowModal(info)
@@ -620,6 +875,9 @@ func (pg *SettingPage) updateSettingOptions() {
 		pg.isStartupPassword = true
 	}
 
+	pg.isDBBackup = pg.WL.AssetsManager.IsDBBackupEnabled()
+	pg.setInitialSwitchStatus(pg.dbBackup, pg.isDBBackup)
+
 	pg.updatePrivacySettings()
 }
 
@@ -0,0 +1,298 @@
+package settings
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const SpendingPolicyPageID = "SpendingPolicy"
+
+// spendingPeriods are the periods of the spending limit, in the order they
+// are displayed.
+var spendingPeriods = []struct {
+	Period sharedW.SpendingPeriod
+	Title  string
+}{
+	{Period: sharedW.SpendingPeriodNone, Title: values.StrNoSpendingLimit},
+	{Period: sharedW.SpendingPeriodDaily, Title: values.StrDailyLimit},
+	{Period: sharedW.SpendingPeriodWeekly, Title: values.StrWeeklyLimit},
+}
+
+// SpendingPolicyPage edits the spending policy of a wallet.
+type SpendingPolicyPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	pageContainer *widget.List
+	backButton    cryptomaterial.IconButton
+
+	period           *cryptomaterial.SegmentedControl
+	limit            cryptomaterial.Editor
+	allowedAddresses cryptomaterial.Editor
+	delayThreshold   cryptomaterial.Editor
+	delay            cryptomaterial.Editor
+	saveButton       cryptomaterial.Button
+}
+
+func NewSpendingPolicyPage(l *load.Load, wallet sharedW.Asset) *SpendingPolicyPage {
+	periods := make([]string, 0, len(spendingPeriods))
+	for _, p := range spendingPeriods {
+		periods = append(periods, values.String(p.Title))
+	}
+
+	symbol := wallet.GetAssetType().String()
+	pg := &SpendingPolicyPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(SpendingPolicyPageID),
+		wallet:           wallet,
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		period:           l.Theme.SegmentedControl(periods),
+		limit:            l.Theme.Editor(new(widget.Editor), values.StringF(values.StrSpendingLimitHint, symbol)),
+		allowedAddresses: l.Theme.Editor(new(widget.Editor), values.String(values.StrAllowedAddressesHint)),
+		delayThreshold:   l.Theme.Editor(new(widget.Editor), values.StringF(values.StrSendDelayThresholdHint, symbol)),
+		delay:            l.Theme.Editor(new(widget.Editor), values.String(values.StrSendDelayHint)),
+		saveButton:       l.Theme.Button(values.String(values.StrSave)),
+	}
+	pg.limit.Editor.SingleLine = true
+	pg.delayThreshold.Editor.SingleLine = true
+	pg.delay.Editor.SingleLine = true
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *SpendingPolicyPage) OnNavigatedTo() {
+	policy, err := pg.wallet.SpendingPolicy()
+	if err != nil {
+		log.Errorf("Error reading the spending policy: %v", err)
+	}
+	if policy == nil {
+		policy = &sharedW.SpendingPolicy{}
+	}
+
+	for _, p := range spendingPeriods {
+		if p.Period == policy.Period {
+			pg.period.SetSelectedSegment(values.String(p.Title))
+		}
+	}
+	pg.limit.Editor.SetText(pg.formatAmount(policy.Limit))
+	pg.allowedAddresses.Editor.SetText(strings.Join(policy.AllowedAddresses, "\n"))
+	pg.delayThreshold.Editor.SetText(pg.formatAmount(policy.DelayThreshold))
+	pg.delay.Editor.SetText("")
+	if policy.Delay > 0 {
+		pg.delay.Editor.SetText(strconv.Itoa(int(policy.Delay / time.Hour)))
+	}
+}
+
+// formatAmount formats amount in coins, or returns an empty string if it is
+// zero.
+func (pg *SpendingPolicyPage) formatAmount(amount int64) string {
+	if amount == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(pg.wallet.ToAmount(amount).ToCoin(), 'f', -1, 64)
+}
+
+// parseAmount returns the amount in coins of editor in the smallest unit of
+// the wallet's asset. An empty editor is zero.
+func (pg *SpendingPolicyPage) parseAmount(editor *cryptomaterial.Editor) (int64, bool) {
+	text := strings.TrimSpace(editor.Editor.Text())
+	if text == "" {
+		return 0, true
+	}
+	coins, err := strconv.ParseFloat(text, 64)
+	if err != nil || coins < 0 {
+		editor.SetError(values.String(values.StrInvalidAmount))
+		return 0, false
+	}
+	return int64(math.Round(coins / pg.wallet.ToAmount(1).ToCoin())), true
+}
+
+// formPolicy returns the policy entered in the form, or false if a value is
+// invalid.
+func (pg *SpendingPolicyPage) formPolicy() (*sharedW.SpendingPolicy, bool) {
+	policy := &sharedW.SpendingPolicy{
+		Period: spendingPeriods[pg.period.SelectedIndex()].Period,
+	}
+
+	var ok bool
+	if policy.Limit, ok = pg.parseAmount(&pg.limit); !ok {
+		return nil, false
+	}
+	if policy.DelayThreshold, ok = pg.parseAmount(&pg.delayThreshold); !ok {
+		return nil, false
+	}
+
+	if text := strings.TrimSpace(pg.delay.Editor.Text()); text != "" {
+		hours, err := strconv.Atoi(text)
+		if err != nil || hours < 0 {
+			pg.delay.SetError(values.String(values.StrInvalidSendDelay))
+			return nil, false
+		}
+		policy.Delay = time.Duration(hours) * time.Hour
+	}
+
+	for _, address := range strings.Fields(pg.allowedAddresses.Editor.Text()) {
+		if !pg.wallet.IsAddressValid(address) {
+			pg.allowedAddresses.SetError(values.StringF(values.StrInvalidAllowedAddress, address))
+			return nil, false
+		}
+		policy.AllowedAddresses = append(policy.AllowedAddresses, address)
+	}
+	return policy, true
+}
+
+// savePolicy saves the policy entered in the form after the spending
+// passphrase is entered.
+func (pg *SpendingPolicyPage) savePolicy() {
+	policy, ok := pg.formPolicy()
+	if !ok {
+		return
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrSpendingPolicy)).
+		PasswordHint(values.String(values.StrSpendingPassword)).
+		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
+			if err := pg.wallet.SetSpendingPolicy(password, policy); err != nil {
+				m.SetError(values.TranslateErr(err.Error()))
+				m.SetLoading(false)
+				return false
+			}
+			pg.Toast.Notify(values.String(values.StrSpendingPolicySaved))
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *SpendingPolicyPage) Layout(gtx C) D {
+	return layout.UniformInset(values.MarginPadding20).Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(pg.pageHeaderLayout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding20}.Layout(gtx, pg.pageContentLayout)
+			}),
+		)
+	})
+}
+
+func (pg *SpendingPolicyPage) pageHeaderLayout(gtx C) D {
+	return layout.W.Layout(gtx, func(gtx C) D {
+		return layout.Flex{}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{
+					Right: values.MarginPadding16,
+					Top:   values.MarginPaddingMinus2,
+				}.Layout(gtx, pg.backButton.Layout)
+			}),
+			layout.Rigid(pg.Theme.Label(values.TextSize20, values.String(values.StrSpendingPolicy)).Layout),
+		)
+	})
+}
+
+func (pg *SpendingPolicyPage) pageContentLayout(gtx C) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.Center.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding550)
+		gtx.Constraints.Max.X = gtx.Constraints.Min.X
+		gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+		return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+			return layout.Inset{Right: values.MarginPadding2, Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+					return layout.UniformInset(values.MarginPadding15).Layout(gtx, pg.formLayout)
+				})
+			})
+		})
+	})
+}
+
+func (pg *SpendingPolicyPage) formLayout(gtx C) D {
+	desc := pg.Theme.Body2(values.String(values.StrSpendingPolicyDesc))
+	desc.Color = pg.Theme.Color.GrayText2
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(desc.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, pg.period.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if spendingPeriods[pg.period.SelectedIndex()].Period == sharedW.SpendingPeriodNone {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.limit.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.allowedAddresses.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.delayThreshold.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.delay.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.E.Layout(gtx, pg.saveButton.Layout)
+			})
+		}),
+	)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *SpendingPolicyPage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	for _, editor := range []*cryptomaterial.Editor{&pg.limit, &pg.allowedAddresses, &pg.delayThreshold, &pg.delay} {
+		if _, isChanged := cryptomaterial.HandleEditorEvents(editor.Editor); isChanged {
+			editor.SetError("")
+		}
+	}
+
+	if pg.saveButton.Clicked() {
+		pg.savePolicy()
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *SpendingPolicyPage) OnNavigatedFrom() {}
@@ -0,0 +1,311 @@
+package settings
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const SweepKeysPageID = "SweepKeys"
+
+// SweepKeysPage sweeps the funds of private keys into an account of a
+// wallet. The keys are only used to sign the sweeping transaction.
+type SweepKeysPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	pageContainer   *widget.List
+	backButton      cryptomaterial.IconButton
+	accountSelector *components.WalletAndAccountSelector
+
+	keys        cryptomaterial.Editor
+	startHeight cryptomaterial.Editor
+	scanButton  cryptomaterial.Button
+	sweepButton cryptomaterial.Button
+	errorLabel  cryptomaterial.Label
+
+	// The fields below are updated by the scan goroutine.
+	cancelScan    context.CancelFunc
+	scanHeight    int32
+	scanEndHeight int32
+	scan          *sharedW.SweepScan
+	sweeping      bool
+	swept         bool
+}
+
+func NewSweepKeysPage(l *load.Load, wallet sharedW.Asset) *SweepKeysPage {
+	pg := &SweepKeysPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(SweepKeysPageID),
+		wallet:           wallet,
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		keys:        l.Theme.Editor(new(widget.Editor), values.String(values.StrPrivateKeysHint)),
+		startHeight: l.Theme.Editor(new(widget.Editor), values.String(values.StrScanFromHeightHint)),
+		scanButton:  l.Theme.Button(values.String(values.StrScanForFunds)),
+		sweepButton: l.Theme.Button(values.String(values.StrSweep)),
+		errorLabel:  l.Theme.ErrorLabel(""),
+	}
+	pg.startHeight.Editor.SingleLine = true
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	pg.accountSelector = components.NewWalletAndAccountSelector(l).
+		Title(values.String(values.StrSelectAcc)).
+		AccountValidator(func(account *sharedW.Account) bool {
+			return account.Number != load.MaxInt32
+		})
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *SweepKeysPage) OnNavigatedTo() {
+	if err := pg.accountSelector.SelectFirstValidAccount(load.NewWalletMapping(pg.wallet)); err != nil {
+		log.Errorf("Error selecting the sweep account: %v", err)
+	}
+}
+
+// startScan scans the blocks from the entered height for the funds of the
+// entered keys.
+func (pg *SweepKeysPage) startScan() {
+	pg.errorLabel.Text = ""
+	pg.scan = nil
+
+	wifs := strings.Fields(pg.keys.Editor.Text())
+	if len(wifs) == 0 {
+		pg.keys.SetError(values.String(values.StrInvalidPrivateKey))
+		return
+	}
+
+	var startHeight int32
+	if text := strings.TrimSpace(pg.startHeight.Editor.Text()); text != "" {
+		height, err := strconv.ParseInt(text, 10, 32)
+		if err != nil || height < 0 || int32(height) > pg.wallet.GetBestBlockHeight() {
+			pg.startHeight.SetError(values.String(values.StrInvalidBlockHeight))
+			return
+		}
+		startHeight = int32(height)
+	}
+
+	if !pg.wallet.IsSynced() {
+		pg.errorLabel.Text = values.String(values.StrSyncBeforeSweep)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pg.cancelScan = cancel
+	pg.scanHeight = startHeight
+	pg.scanEndHeight = pg.wallet.GetBestBlockHeight()
+	go func() {
+		defer cancel()
+		scan, err := pg.wallet.ScanSweepKeys(ctx, wifs, startHeight, func(height int32) {
+			pg.scanHeight = height
+			pg.ParentWindow().Reload()
+		})
+		pg.cancelScan = nil
+		if err != nil {
+			if ctx.Err() == nil {
+				pg.errorLabel.Text = values.TranslateErr(err.Error())
+			}
+		} else {
+			pg.scan = scan
+		}
+		pg.ParentWindow().Reload()
+	}()
+}
+
+// sweep broadcasts the transaction sweeping the scanned funds to the selected
+// account.
+func (pg *SweepKeysPage) sweep() {
+	account := pg.accountSelector.SelectedAccount()
+	if pg.scan == nil || account == nil {
+		return
+	}
+
+	pg.sweeping = true
+	wifs := strings.Fields(pg.keys.Editor.Text())
+	go func() {
+		defer func() {
+			pg.sweeping = false
+			pg.ParentWindow().Reload()
+		}()
+
+		if _, err := pg.wallet.SweepKeys(wifs, pg.scan, account.Number); err != nil {
+			pg.errorLabel.Text = values.TranslateErr(err.Error())
+			return
+		}
+		pg.scan = nil
+		pg.swept = true
+		pg.Toast.Notify(values.StringF(values.StrFundsSwept, account.Name))
+	}()
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *SweepKeysPage) Layout(gtx C) D {
+	return layout.UniformInset(values.MarginPadding20).Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(pg.pageHeaderLayout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding20}.Layout(gtx, pg.pageContentLayout)
+			}),
+		)
+	})
+}
+
+func (pg *SweepKeysPage) pageHeaderLayout(gtx C) D {
+	return layout.W.Layout(gtx, func(gtx C) D {
+		return layout.Flex{}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{
+					Right: values.MarginPadding16,
+					Top:   values.MarginPaddingMinus2,
+				}.Layout(gtx, pg.backButton.Layout)
+			}),
+			layout.Rigid(pg.Theme.Label(values.TextSize20, values.String(values.StrSweepPrivateKeys)).Layout),
+		)
+	})
+}
+
+func (pg *SweepKeysPage) pageContentLayout(gtx C) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.Center.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding550)
+		gtx.Constraints.Max.X = gtx.Constraints.Min.X
+		gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+		return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+			return layout.Inset{Right: values.MarginPadding2, Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+					return layout.UniformInset(values.MarginPadding15).Layout(gtx, pg.formLayout)
+				})
+			})
+		})
+	})
+}
+
+func (pg *SweepKeysPage) formLayout(gtx C) D {
+	desc := pg.Theme.Body2(values.String(values.StrSweepPrivateKeysDesc))
+	desc.Color = pg.Theme.Color.GrayText2
+	scanning := pg.cancelScan != nil
+	pg.scanButton.Text = values.String(values.StrScanForFunds)
+	if scanning {
+		pg.scanButton.Text = values.String(values.StrCancel)
+	}
+	pg.sweepButton.SetEnabled(pg.scan != nil && !pg.sweeping)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(desc.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, pg.keys.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.startHeight.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return pg.accountSelector.Layout(pg.ParentWindow(), gtx)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			var status string
+			switch {
+			case scanning:
+				status = values.StringF(values.StrScanningBlock, pg.scanHeight, pg.scanEndHeight)
+			case pg.scan != nil:
+				status = values.StringF(values.StrSweepFound, len(pg.scan.Outputs),
+					pg.wallet.ToAmount(pg.scan.Total).String(), pg.wallet.ToAmount(pg.scan.Fee).String())
+			default:
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.Theme.Body1(status).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if pg.errorLabel.Text == "" {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.errorLabel.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.E.Layout(gtx, func(gtx C) D {
+					return layout.Flex{}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.scanButton.Layout)
+						}),
+						layout.Rigid(pg.sweepButton.Layout),
+					)
+				})
+			})
+		}),
+	)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *SweepKeysPage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	if pg.swept {
+		pg.swept = false
+		pg.keys.Editor.SetText("")
+	}
+
+	for _, editor := range []*cryptomaterial.Editor{&pg.keys, &pg.startHeight} {
+		if _, isChanged := cryptomaterial.HandleEditorEvents(editor.Editor); isChanged {
+			editor.SetError("")
+			// The scanned funds belong to the keys and range entered.
+			pg.scan = nil
+		}
+	}
+
+	if pg.scanButton.Clicked() {
+		if pg.cancelScan != nil {
+			pg.cancelScan()
+		} else {
+			pg.startScan()
+		}
+	}
+
+	if pg.sweepButton.Clicked() {
+		pg.sweep()
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *SweepKeysPage) OnNavigatedFrom() {
+	if pg.cancelScan != nil {
+		pg.cancelScan()
+	}
+}
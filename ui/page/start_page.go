@@ -48,6 +48,7 @@ type startPage struct {
 
 	loading          bool
 	isQuitting       bool
+	isLocked         bool
 	displayStartPage bool
 
 	currentPage int
@@ -74,6 +75,16 @@ func NewStartPage(l *load.Load, isShuttingDown ...bool) app.Page {
 	return sp
 }
 
+// NewLockedStartPage returns the start page displayed when the app is locked.
+// The wallets are already open, so only the startup passphrase is verified
+// before the home page is displayed again.
+func NewLockedStartPage(l *load.Load) app.Page {
+	sp := NewStartPage(l).(*startPage)
+	sp.isLocked = true
+	sp.displayStartPage = false
+	return sp
+}
+
 // OnNavigatedTo is called when the page is about to be displayed and
 // may be used to initialize page features that are only relevant when
 // the page is displayed.
@@ -88,6 +99,12 @@ func (sp *startPage) OnNavigatedTo() {
 		return
 	}
 
+	if sp.isLocked {
+		sp.currentPage = -1
+		sp.unlock()
+		return
+	}
+
 	if sp.WL.AssetsManager.LoadedWalletsCount() > 0 {
 		sp.currentPage = -1
 		sp.setLanguageSetting()
@@ -140,9 +157,14 @@ func (sp *startPage) unlock() {
 		SetCancelable(false).
 		SetPositiveButtonText(values.String(values.StrUnlock)).
 		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
-			err := sp.openWallets(password)
+			var err error
+			if sp.isLocked {
+				err = sp.unlockSession(password)
+			} else {
+				err = sp.openWallets(password)
+			}
 			if err != nil {
-				m.SetError(err.Error())
+				m.SetError(components.PassphraseError(err))
 				m.SetLoading(false)
 				return false
 			}
@@ -165,6 +187,17 @@ func (sp *startPage) openWallets(password string) error {
 	return nil
 }
 
+// unlockSession displays the home page again once the startup passphrase of
+// the locked app is verified.
+func (sp *startPage) unlockSession(password string) error {
+	if err := sp.WL.AssetsManager.VerifyStartupPassphrase(password); err != nil {
+		return err
+	}
+
+	sp.ParentNavigator().ClearStackAndDisplay(root.NewHomePage(sp.Load))
+	return nil
+}
+
 // HandleUserInteractions is called just before Layout() to determine
 // if any user interaction recently occurred on the page and may be
 // used to update the page's UI components shortly before they are
@@ -23,9 +23,25 @@ var (
 	ExchOptions = []ItemPreference{
 		{Key: values.BinanceExchange, Value: values.StrUsdBinance},
 		{Key: values.BittrexExchange, Value: values.StrUsdBittrex},
+		{Key: values.KrakenExchange, Value: values.StrUsdKraken},
+		{Key: values.CoinbaseExchange, Value: values.StrUsdCoinbase},
+		{Key: values.CoinGeckoExchange, Value: values.StrUsdCoinGecko},
+		{Key: values.AggregateExchange, Value: values.StrUsdAggregate},
 		{Key: values.DefaultExchangeValue, Value: values.StrNone},
 	}
 
+	// FiatCurrencyOptions holds the selectable display fiat currencies.
+	FiatCurrencyOptions = []ItemPreference{
+		{Key: values.USDCurrency, Value: values.StrCurrencyUSD},
+		{Key: values.EURCurrency, Value: values.StrCurrencyEUR},
+		{Key: values.GBPCurrency, Value: values.StrCurrencyGBP},
+		{Key: values.NGNCurrency, Value: values.StrCurrencyNGN},
+		{Key: values.BRLCurrency, Value: values.StrCurrencyBRL},
+		{Key: values.CADCurrency, Value: values.StrCurrencyCAD},
+		{Key: values.JPYCurrency, Value: values.StrCurrencyJPY},
+		{Key: values.INRCurrency, Value: values.StrCurrencyINR},
+	}
+
 	// LangOptions stores the configurable language options.
 	LangOptions = []ItemPreference{
 		{Key: localizable.ENGLISH, Value: values.StrEnglish},
@@ -96,6 +112,8 @@ func (lp *ListPreferenceModal) ReadPreferenceKeyedValue() string {
 	switch lp.preferenceKey {
 	case sharedW.CurrencyConversionConfigKey:
 		return lp.WL.AssetsManager.GetCurrencyConversionExchange()
+	case sharedW.FiatCurrencyConfigKey:
+		return lp.WL.AssetsManager.GetFiatCurrency()
 	case sharedW.LanguagePreferenceKey:
 		return lp.WL.AssetsManager.GetLanguagePreference()
 	case sharedW.LogLevelConfigKey:
@@ -110,6 +128,8 @@ func (lp *ListPreferenceModal) SavePreferenceKeyedValue() {
 	switch lp.preferenceKey {
 	case sharedW.CurrencyConversionConfigKey:
 		lp.WL.AssetsManager.SetCurrencyConversionExchange(val)
+	case sharedW.FiatCurrencyConfigKey:
+		lp.WL.AssetsManager.SetFiatCurrency(val)
 	case sharedW.LanguagePreferenceKey:
 		lp.WL.AssetsManager.SetLanguagePreference(val)
 	case sharedW.LogLevelConfigKey:
@@ -11,8 +11,10 @@ import (
 	"strings"
 	"time"
 
-	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/libwallet/passphrase"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/values"
 
 	"gioui.org/widget"
 	"golang.org/x/text/message"
@@ -83,8 +85,18 @@ func BreakBalance(p *message.Printer, balance string) (b1, b2 string) {
 	return
 }
 
-func FormatAsUSDString(p *message.Printer, usdAmt float64) string {
-	return p.Sprintf("$%.2f", usdAmt)
+// FormatAsFiatString formats amt as a value of the provided fiat currency.
+func FormatAsFiatString(p *message.Printer, currency string, amt float64) string {
+	return p.Sprintf("%s%.2f", FiatSymbol(currency), amt)
+}
+
+// FiatSymbol returns the symbol of the provided fiat currency. The currency
+// code is returned for currencies without a known symbol.
+func FiatSymbol(currency string) string {
+	if symbol, ok := values.FiatCurrencySymbols[currency]; ok {
+		return symbol
+	}
+	return currency + " "
 }
 
 func CryptoToUSD(exchangeRate, coin float64) float64 {
@@ -97,8 +109,12 @@ func USDToDCR(exchangeRate, usd float64) float64 {
 
 func ComputePasswordStrength(pb *cryptomaterial.ProgressBarStyle, th *cryptomaterial.Theme, editors ...*widget.Editor) {
 	password := editors[0]
-	strength := utils.ShannonEntropy(password.Text()) / 4.0
-	pb.Progress = float32(strength)
+	strength := passphrase.Estimate(password.Text())
+	pb.Progress = float32(strength.Score) / passphrase.MaxScore
+	if password.Len() > 0 && strength.Score == 0 {
+		// Show that the password was measured.
+		pb.Progress = 0.1
+	}
 
 	//set progress bar color
 	switch {
@@ -111,6 +127,15 @@ func ComputePasswordStrength(pb *cryptomaterial.ProgressBarStyle, th *cryptomate
 	}
 }
 
+// PassphrasePolicyError returns the message displayed for a passphrase that
+// does not comply with policy.
+func PassphrasePolicyError(policy *passphrase.Policy, err error) string {
+	if err.Error() == libutils.ErrPassphraseTooShort {
+		return values.StringF(values.StrPassphraseMinLength, policy.MinLength)
+	}
+	return values.TranslateErr(err.Error())
+}
+
 func HandleSubmitEvent(editors ...*widget.Editor) bool {
 	var submit bool
 	for _, editor := range editors {
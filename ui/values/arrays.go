@@ -16,8 +16,38 @@ const (
 	DefaultExchangeValue = "none"
 	BittrexExchange      = "bittrex"
 	BinanceExchange      = "binance"
+	KrakenExchange       = "kraken"
+	CoinbaseExchange     = "coinbase"
+	CoinGeckoExchange    = "coingecko"
+	// AggregateExchange uses the median rate across all other rate sources.
+	AggregateExchange = "aggregate"
 )
 
+// These are a list of supported display fiat currencies. Rates are fetched in
+// USD and converted using forex rates.
+const (
+	USDCurrency = "USD"
+	EURCurrency = "EUR"
+	GBPCurrency = "GBP"
+	NGNCurrency = "NGN"
+	BRLCurrency = "BRL"
+	CADCurrency = "CAD"
+	JPYCurrency = "JPY"
+	INRCurrency = "INR"
+)
+
+// FiatCurrencySymbols maps the supported fiat currencies to their symbols.
+var FiatCurrencySymbols = map[string]string{
+	USDCurrency: "$",
+	EURCurrency: "€",
+	GBPCurrency: "£",
+	NGNCurrency: "₦",
+	BRLCurrency: "R$",
+	CADCurrency: "CA$",
+	JPYCurrency: "¥",
+	INRCurrency: "₹",
+}
+
 // initialize an asset market value map
 var AssetExchangeMarketValue = map[utils.AssetType]string{
 	utils.DCRWalletAsset: DCRUSDTMarket,
@@ -23,6 +23,33 @@ func TranslateErr(errStr string) string {
 	case utils.ErrInsufficientBalance:
 		return String(StrInsufficentFund)
 
+	case utils.ErrSpendingLimitExceeded:
+		return String(StrSpendingLimitExceeded)
+
+	case utils.ErrDestinationNotAllowed:
+		return String(StrDestinationNotAllowed)
+
+	case utils.ErrPassphraseTooShort:
+		return String(StrPassphraseTooShort)
+
+	case utils.ErrWeakPassphrase:
+		return String(StrWeakPassphrase)
+
+	case utils.ErrPINNotNumeric:
+		return String(StrPINNotNumeric)
+
+	case utils.ErrTooManyAttempts:
+		return String(StrTooManyAttempts)
+
+	case utils.ErrInvalidPrivateKey:
+		return String(StrInvalidPrivateKey)
+
+	case utils.ErrNothingToSweep:
+		return String(StrNothingToSweep)
+
+	case utils.ErrAddressWatchWallet:
+		return String(StrAddressWatchNoNewAddress)
+
 	default:
 		if strings.Contains(errStr, "strconv.ParseFloat") {
 			return String((StrInvalidAmount))
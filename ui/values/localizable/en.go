@@ -8,7 +8,10 @@ const EN = `
 "abandoned" = "Abandoned"
 "about" = "About"
 "abstain" = "Abstain"
+"acceptOffer" = "Accept offer"
 "account" = "Account"
+"accountGapLimit" = "Account Gap Limit"
+"accountGapLimitInputErr" = "Invalid input: valid values (0-100)"
 "accountList" = "Account List"
 "accountMixer" = "AccountMixer"
 "accRenamed" = "Account renamed"
@@ -19,6 +22,7 @@ const EN = `
 "acctNum" = "Account Number"
 "acctRenamed" = "Account renamed"
 "addAcctWarn" = "%v Accounts %v cannot %v be deleted once created.%v"
+"addAlert" = "Add alert"
 "addDexServer" = "Add dex server"
 "addNewAccount" = "Add account"
 "address" = "Address"
@@ -26,13 +30,25 @@ const EN = `
 "addressDiscoveryInProgress" = "Address Discovery in Progress..."
 "addressDiscoveryStarted" = "Address discovery started successfully"
 "addressDiscoveryStartedBody"    = "See wallet information page for progress"
+"addressWatch" = "Address Watch"
+"addressWatchNoNewAddress" = "This wallet only watches a list of addresses and cannot derive new addresses"
 "addrNotOwned" = "Address not owned by any wallet"
 "addVSP" = "Add a new VSP..."
 "addWallet" = "Add wallet"
 "adminToTriggerVoting" = "Waiting for admin to trigger the start of voting"
 "agendas" = "Agendas"
 "ago" = "ago"
+"alertLastTriggered" = "Last triggered %s"
+"alertPercentHint" = "Change (%)"
+"alertPortfolioAbove" = "Portfolio above"
+"alertPortfolioBelow" = "Portfolio below"
+"alertPriceAbove" = "Price above"
+"alertPriceBelow" = "Price below"
+"alertPriceHint" = "Price (USD)"
+"alertPriceMove" = "24h move"
+"alertValueHint" = "Value (USD)"
 "all" = "All"
+"allowedAddressesHint" = "Allowed addresses, one per line. Leave empty to allow any address"
 "allowSpendingFromUnmixedAccount" = "Allow spending from unmixed account"
 "allowUnspendUnmixedAcct" = "%v Spendings from unmixed accounts could potentially be traced back to you %v Please type %v I am aware of the risks %v to allow spending from unmixed accounts.%v"
 "allTickets" = "All tickets"
@@ -43,8 +59,14 @@ const EN = `
 "appTitle" = "Cryptopower (%s)"
 "appWallet" = "Cryptopower Wallet"
 "askedEnterSeedWords" = "You will be asked to enter the seed phrase on the next screen."
+"atomicSwaps" = "Atomic Swaps"
 "authorToAuthorizeVoting" = "Waiting for author to authorize voting"
+"autoLock" = "Auto-lock"
+"autoLockDesc" = "Returns to the startup password screen when the app is idle or the system sleeps. The wallets are locked and the background services below are stopped unless they keep running."
+"autoLockNeedsStartupPassword" = "Set a startup password in the security settings to use the auto-lock. Without it, the app cannot be locked and these options have no effect."
 "automatic" = "Automatic"
+"autoRedeem" = "Auto redeem"
+"autoRedeemSwapMsg" = "Enter the spending password of %s to sign the redeem of the counterparty's contract. It is broadcast automatically once the secret is revealed."
 "autoSetUp" = "Auto Setup"
 "autoSyncInfo" = "Auto sync feature has been enable, and wallets are not synced.\nWould you like to start syncing your wallets now?"
 "autoTicketInfo" = "Cryptopower must remain running, for tickets to be automatically purchased"
@@ -53,9 +75,14 @@ const EN = `
 "backAndRename" = "Go back & rename"
 "backStaking" = "Back to staking"
 "backToWallets" = "Back to Wallets"
+"backupDBNow" = "Back up databases now"
+"backupFilePath" = "Backup file path"
 "backupInfo" = "%v No backup - no coins! %v In order not to lose your coins when your device is lost or broken, please make a wallet backup %v Now %v and keep it in %v a safe place! %v"
 "backupLater" = "Backup later"
+"backupMetadata" = "Backup metadata"
+"backupMetadataDesc" = "Account names, transaction labels, settings and exchange orders are not recovered from the seed. Back them up to an encrypted file."
 "backupNow" = "Backup now"
+"backupPassword" = "Backup password"
 "backupSeedPhrase" = "Back up seed phrase"
 "backupWarning" = "Wallet backup needed"
 "balance" = "Balance:"
@@ -67,6 +94,9 @@ const EN = `
 "bestBlockAge" = "Best block age"
 "bestBlocks" = "Best block"
 "bestBlockTimestamp" = "Best block timestamp"
+"bestQuoteSelected" = "%s offers the best rate: %.8f %s"
+"bestRate" = "Best rate"
+"bip39Seed" = "BIP39 seed phrase (12 to 24 words)"
 "currencyConverterRate" = "%s rate: 1 %s ~= %f %s"
 "blockHeaderFetched" = "Block header fetched"
 "blockHeaderFetchedCount" = "%d of %d"
@@ -88,6 +118,7 @@ const EN = `
 "checkGovernace" = "Check Governance page"
 "checkMixerStatus" = "Check mixer status"
 "checkStatistics" = "Check statistics"
+"checkWalletDB" = "Check wallet database"
 "checkWalletLog" = "Check wallet logs"
 "clear" = "Clear"
 "clearAll" = "Clear all"
@@ -98,6 +129,7 @@ const EN = `
 "complete" = "Completed"
 "confirm" = "Confirm"
 "confirmations" = "Confirmations"
+"confirmBackupPassword" = "Confirm backup password"
 "confirmDexReset" = "Confirm DEX Client Reset"
 "confirmed" = "Confirmed"
 "confirmNewSpendingPassword" = "Confirm new spending passphrase"
@@ -133,6 +165,7 @@ const EN = `
 "copy" = "Copy"
 "copyBlockLink" = "Copy block explorer link"
 "copyLink" = "Copy and paste the link below in your browser."
+"copyMessage" = "Copy message"
 "copyseed" = "Copy seed"
 "cost" = "Cost%v"
 "create" = "Create"
@@ -140,16 +173,26 @@ const EN = `
 "createNewAccount" = "Create new account"
 "createNewOrder" = "Create new order"
 "createNSetUpAccs" = "Create and setup the needed accounts for you."
+"createOffer" = "Create offer"
 "createOrder" = "Create Order"
 "createOrderPageInfo" = "To change the default source and destination wallet/account used for exchange, click the settings icon."
 "createStartupPassword" = "Create a startup password"
 "createWallet" = "Create wallet"
 "crossPlatform" = "Cross platform"
 "crossPlatformSubtext" = "Crytopower has cross platform apps for desktop and mobile."
+"currencyBRL" = "Brazilian Real (BRL)"
+"currencyCAD" = "Canadian Dollar (CAD)"
+"currencyEUR" = "Euro (EUR)"
+"currencyGBP" = "British Pound (GBP)"
+"currencyINR" = "Indian Rupee (INR)"
+"currencyJPY" = "Japanese Yen (JPY)"
+"currencyNGN" = "Nigerian Naira (NGN)"
+"currencyUSD" = "US Dollar (USD)"
 "currentSpendingPassword" = "Current spending passphrase"
 "currentStartupPass" = "Current startup password"
 "currentTotalBalance" = "Current Total Balance"
 "CustomUserAgent" = "Custom user agent"
+"dailyLimit" = "Daily limit"
 "dangerZone" = "Danger zone"
 "darkMode" = "Dark mode"
 "dateCreated" = "Date Created"
@@ -159,29 +202,46 @@ const EN = `
 "daysAgo" = "%d days ago"
 "daysToMiss" = "Days to miss"
 "daysToVote" = "Days to vote"
+"dbBackedUp" = "Databases backed up to %s"
+"dbBackupDir" = "Database backup directory"
+"dbBackupRetention" = "Database backups kept"
+"dbBackups" = "Automatic database backups"
+"dbRestoreStaged" = "Restart Cryptopower to complete the restore"
 "dcrCaps" = "DCR"
 "dcrDex" = "DCRDEX"
 "dcrReceived" = "You have received %s DCR"
 "debug" = "Debug"
+"deepRecovery" = "Deep Recovery"
+"deepRecoveryDesc" = "Discover the used accounts and addresses of this wallet again with a larger gap limit, for wallets restored from other software that left gaps between used addresses. The gap limit is the number of unused addresses in a row after which no more addresses are searched for, and the account gap limit the number of unused accounts. The wallet rescans the blocks for the transactions of the addresses found. Gap limits above 100 take a long time to scan."
+"deepRecoveryFound" = "The accounts and addresses below were found. The wallet is rescanning the blocks for their transactions."
+"deepRecoveryNothingFound" = "No accounts or addresses were found that the wallet did not track already"
+"deepRecoveryPassHint" = "Spending passphrase (required to discover accounts)"
 "default" = "default"
 "delete" = "Delete"
 "descriptionNote" = "Description Note"
+"descriptorsCopied" = "Output descriptors copied"
 "destAddr" = "Destination Address"
 "destination" = "Destination"
 "destinationMissing" = "destination address missing"
 "destinationModalInfo" = "A new receiving address will be automatically generated within the selected account."
+"destinationNotAllowed" = "The spending policy of the wallet does not allow sending to this address"
 "destinationWalletNotSynced" = "Destination wallet is not synced"
 "dex" = "Dex"
 "dexDataReset" = "DEX client data reset complete."
 "dexDataResetFalse" = "DEX client data reset failed. Check the logs."
 "dexResetInfo" = "You may need to restart cryptopower before you can use the DEX again. Proceed?"
 "dexStartupErr" = "Unable to start DEX client: %v"
+"diagnose" = "Diagnose"
 "disable" = "Disable"
 "disabled" = "disabled"
 "disconnect" = "Disconnect"
 "discoverAddressUsage" = "Discover Address Usage"
+"discoveredAccount" = "%s: %d new addresses"
+"discoveredNewAccount" = "%s (new account): %d new addresses"
+"discoveringAddresses" = "Discovering used addresses... %d%%"
 "discoveringWalletAddress" = "Discovering wallet address · %v%%"
 "discussions" = "Discussions:   %d comments"
+"displayCurrency" = "Display currency"
 "documentation" = "Documentation"
 "done" = "Done"
 "duration" = "%s (%d/%d blocks)"
@@ -194,6 +254,7 @@ const EN = `
 "enterAddressToSign" = "Enter an address and message to sign:"
 "enterHex"       = "Enter Hex"
 "enterSeedPhrase" = "Enter your seed phrase"
+"enterShares" = "Enter enough shares to restore the seed, one share per line"
 "enterSpendingPassword" = "Enter spending passphrase"
 "enterValidAddress" = "Please enter a valid address"
 "enterValidMsg" = "Please enter a valid message to sign"
@@ -220,10 +281,13 @@ const EN = `
 "expiredOn" = "Expired on"
 "expiresIn" = "Expires in "
 "explorerURL" = "Explorer URL for %v Asset"
+"exportCSV" = "Export CSV"
+"exportJSON" = "Export JSON"
 "extendedInfo" = "The Extended Public Key is used to import the wallet as a watch-only wallet"
 "extendedKey" = "Extended Public Key"
 "extendedKeyCopied" = "Extended Public Key copied"
 "extendedPubKey" = "Extended public key"
+"extendedPubKeyOrDescriptors" = "Extended public key or output descriptors"
 "external" = "External"
 "failed" = "Failed"
 "fee" = "Fee"
@@ -242,6 +306,9 @@ const EN = `
 "frequency" = "Frequency"
 "from" = "From"
 "functionUnavailable" = "This function is unavailable until sync is complete."
+"fundContract" = "Fund contract"
+"fundsSwept" = "Funds swept to %s"
+"fundSwapMsg" = "Enter the spending password of %s to fund the swap contract. It is refunded automatically if the swap does not complete."
 "gapLimit" = "Gap Limit"
 "gapLimitInputErr" = "Invalid input: valid values (1-1000)"
 "general" = "General"
@@ -292,10 +359,17 @@ const EN = `
 "invalidSignature" = "Invalid signature or message"
 "integratedExchange" = "Integrated exchange functionality"
 "integratedExchangeSubtext" = "Easily exchange coins within the app."
+"invalidAllowedAddress" = "%s is not a valid address"
+"invalidBlockHeight" = "Invalid block height"
+"invalidDBBackupRetention" = "Enter a number of backups of at least 1"
+"invalidPrivateKey" = "Invalid private key"
+"invalidSendDelay" = "Enter the delay in whole hours"
+"invalidSharesThreshold" = "Enter the shares needed and the total shares as N-of-M, e.g. 2-of-3"
 "ipAddress" = "IP address"
 "justNow" = "Just now"
 "keepAppOpen" = "Keep app open"
 "keepInMind" = "Keep in mind"
+"keepRunningWhenLocked" = "Keep running when locked"
 "key" = "Key"
 "labelSpendable" = "Spendable"
 "language" = "Language"
@@ -303,6 +377,9 @@ const EN = `
 "latestBlock" = "Latest block"
 "license" = "License"
 "lifeSpan" = "Life Span"
+"limitAmountHint" = "Total amount to swap (%s)"
+"limitDeadlineHint" = "Deadline in hours (optional)"
+"limitRateHint" = "Min. rate, %s per %s (optional)"
 "live" = "Live"
 "liveIn" = "Live in"
 "liveInfo" = "Waiting to be chosen to vote"
@@ -312,9 +389,11 @@ const EN = `
 "loading" = "Loading..."
 "loadingPrice" = "Loading price"
 "loadingVSP" = "Loading voting service provider"
+"lockAfterIdle" = "Lock after inactivity"
 "locked" = "Locked"
 "lockedByTickets" = "Locked By Tickets"
 "lockedin" = "Locked In"
+"lockOnSleep" = "Lock on sleep or screen lock"
 "logLevel" = "Log Level"
 "logLevelCritical"  = "Critical"
 "logLevelDebug"  = "Debug"
@@ -328,11 +407,14 @@ const EN = `
 "maturity" = "Maturity"
 "max" = "MAX"
 "message" = "Message"
+"metadataBackedUp" = "Metadata backed up to %s"
+"metadataRestored" = "Metadata restored"
 "minimumAssetType" = "Multiple coin types wallets are required for the exchange functionality."
 "minMax" = "Min: %f . Max: %f"
 "mins" = "Mins"
 "minuteAgo" = "%d minute ago"
 "minutesAgo" = "%d minutes ago"
+"minutesShort" = "%d min"
 "missedOn" = "Missed on"
 "missedTickets"="Missed Ticket"
 "mix" = "Mix"
@@ -357,25 +439,31 @@ const EN = `
 "myAcct" = "My account"
 "nConfirmations" = "%d Confirmations"
 "network" = "Network"
+"never" = "Never"
 "neverSynced" = "Never Synced"
 "newest" = "Newest"
 "newProposalUpdate" = "New update for proposal with Token: %s"
 "newSpendingPassword" = "New spending passphrase"
 "newStartupPass" = "New startup password"
+"newSwapOffer" = "New swap offer"
 "newWallet" = "New wallet"
 "next" = "Next"
 "no" = "No"
 "noActiveTickets" = "No active tickets"
 "noAgendaYet" = "No agendas yet"
+"noAtomicSwaps" = "No atomic swaps yet"
 "noConnectedPeer" = "no connected peers."
+"noDBBackup" = "No database backup found"
 "noExchangeOnTestnet" = "Exchange functionality is not available on the test network""
 "noInternet" = "no Internet Connectivity."
 "nonAccSelector" = "This widget isn't set to show accounts"
 "none" = "None"
 "noOrders" = "Orders you create will be shown here."
 "noPoliciesYet" = "No policies yet"
+"noPriceAlerts" = "No price alerts"
 "noProposal" = "No proposals %v"
 "noReward" = "Stakey sees no rewards"
+"noSpendingLimit" = "No limit"
 "noStaking" = "No recent Staking Activity"
 "notAllowed" = "%s API not allowed by current network settings."
 "notApplicable" = "N/A"
@@ -384,12 +472,15 @@ const EN = `
 "notConnected" = "Not connected to decred network"
 "note" = "Note"
 "notEnoughVotes" = "You don't have enough votes"
+"nothingToSweep" = "No unspent funds found for these keys"
 "noTickets" = "No tickets yet"
 "notifications" = "Notifications"
 "notOwned" = "Valid address not owned by you."
 "noTransactions" = "No transactions"
 "notSameAccoutMixUnmix" = "Cannot use same account for mixed & unmixed"
 "notSupported" = "%s is currently not suppported"
+"noUnlockedKeys" = "No wallet is unlocked"
+"noUsableQuote" = "No exchange server returned a usable quote"
 "noUTXOs" = "No UTXOs Available"
 "noValidAccountFound" = "no valid account found"
 "noValidWalletFound" = "no valid wallet found"
@@ -412,10 +503,13 @@ const EN = `
 "orderSendingFrom" = "From: %s (%s)"
 "orderSettingsSaved" = "Order Settings saved!"
 "orderSubmitted" = "Order Submitted"
+"outputDescriptors" = "Output descriptors"
 "overview" = "Overview"
 "owned" = "Valid address owned by you."
 "pageWarningNotSync" = "Page cannot be accessed because the wallet is not synced, please sync your wallet and try again"
 "pageWarningSync" = "Page cannot be accessed because the wallet sync is in progress, please wait for the sync to complete"
+"passphraseMinLength" = "Use at least %d characters"
+"passphraseTooShort" = "The password is too short"
 "passwordNotMatch" = "Passwords do not match"
 "pasteSeedWords" = "Paste Seed Words"
 "peer" = "Peer"
@@ -424,13 +518,22 @@ const EN = `
 "pending" = "Pending"
 "percentageMixed" = "%v%% Mixed"
 "piKey" = "Pi key"
+"pinNotNumeric" = "A PIN can only have digits"
 "policySetSuccessfully" = "Your treasury policy has been successfully updated!"
+"portfolioAboveNotif" = "Portfolio value rose above %s, now at %s"
+"portfolioBelowNotif" = "Portfolio value fell below %s, now at %s"
+"portfolioValue" = "Portfolio Value"
+"priceAboveNotif" = "%s rose above %s, now at %s"
+"priceAlerts" = "Price Alerts"
+"priceBelowNotif" = "%s fell below %s, now at %s"
+"priceMoveNotif" = "%s moved %s in the last 24h"
 "priority" = "Priority%v"
 "privacyInfo" = "%v When the mixer is activated, funds will be gradually transfered from the unmixed account to the mixed account. %v Important: keep this app open while mixer is running. %v The mixer routine will automatically stop when the unmixed balance is fully mixed.%v"
 "privacyModeActive" = "(Network Privacy Is Enabled)"
 "privacyModeInfo" = "Network Privacy Info"
 "privacyModeInfoDesc" = "When enabled, all HTTP API calls are disabled, with the exception of Network Check API that is used to check if a wallet has internet access."
 "privacySettings" = "Network Privacy"
+"privateKeysHint" = "Private keys, one per line"
 "propFetching" = "Proposals fetching %s. %s"
 "propNotif" = "Proposal notification"
 "propNotification" = "Proposal notification %s"
@@ -447,6 +550,7 @@ const EN = `
 "rate" = "Rate"
 "readyToMix" = "Ready to mix"
 "rebroadcast" = "Rebroadcast"
+"rebuildWalletDesc" = "The wallet is restored from its seed. Its account names, transaction labels, settings and exchange orders are kept."
 "receive" = "Receive"
 "received" = "Received"
 "receiveInfo" = "To protect your privacy, a new address is generated each time you receive a payment."
@@ -455,8 +559,12 @@ const EN = `
 "recentOrders" = "Recent Orders (%d)"
 "recentProposals" = "Recent Proposals"
 "recentTransactions" = "Recent Transactions"
+"recommendedRepair" = "Recommended repair: %s"
 "reconnect" = "Reconnect"
+"redeem" = "Redeem"
+"redeemSwapMsg" = "Enter the spending password of %s to redeem the counterparty's contract."
 "refresh" = "Refresh"
+"refund" = "Refund"
 "rejected" = "Rejected"
 "remove" = "Remove"
 "removePeer" = "Remove specific peer"
@@ -468,6 +576,11 @@ const EN = `
 "rename" = "Rename"
 "renameAcct" = "Rename account"
 "renameWalletSheetTitle" = "Rename wallet"
+"repair" = "Repair"
+"repairOnRestart" = "Restart Cryptopower to complete the repair"
+"repairRebuild" = "Rebuild from seed"
+"repairReindex" = "Reindex transactions"
+"repairRescan" = "Rescan transaction history"
 "republished" = "Republished unmined transactions to the %s network"
 "rescan" = "Rescan"
 "rescanBlockchain" = "Rescan blockchain"
@@ -476,10 +589,15 @@ const EN = `
 "rescanningHeaders" = "Rescanning headers · %v%%"
 "rescanProgressNotification" = "Check progress in overview."
 "restore" = "Restore"
+"restoreDBBackup" = "Restore latest database backup"
+"restoreDBBackupDesc" = "Enter the startup password %s was made with. The databases are replaced when Cryptopower restarts."
 "restoreExistingWallet" = "Restore existing wallet"
+"restoreMetadata" = "Restore metadata"
 "restoreWallet" = "Restore wallet"
 "restoreWithHex" = "Restore wallet using hex"
 "resumeAccountDiscoveryTitle" = "Unlock to resume restoration"
+"resumeScheduler" = "Resume Order Scheduler"
+"resumeSchedulerMsg" = "The %s to %s order scheduler was paused when Cryptopower restarted. Enter the spending password of %s to resume it."
 "retry" = "Retry"
 "revocation" = "Revocation"
 "revoke" = "Revoke"
@@ -490,7 +608,12 @@ const EN = `
 "reward" = "Reward"
 "rewardsEarned" = "Rewards Earned"
 "save" = "Save"
+"saveMessage" = "Save to file"
+"scanForFunds" = "Scan for funds"
+"scanFromHeightHint" = "Scan from block height (default: 0)"
+"scanningBlock" = "Scanning block %d of %d"
 "scheduler" = "Scheduler"
+"schedulerJob" = "Order Scheduler #%d"
 "schedulerRunning" = "Order Scheduler is running"
 "search" = "Search"
 "secs" = "Secs"
@@ -500,6 +623,7 @@ const EN = `
 "seeAll" = "See all"
 "seedAlreadyExist" = "A wallet with an identical seed already exists."
 "seedHex" = "Seed hex"
+"seedPassphrase" = "BIP39 passphrase (optional)"
 "seedPhraseToRestore" = "seed phrase is the only way to restore your wallet."
 "seedPhraseVerified" = "Your seed phrase backup is verified"
 "seedValidationFailed" = "Failed to verify. Please go through every wallet seed and try again."
@@ -523,6 +647,8 @@ const EN = `
 "selectWalletType" = "Select the type of wallet you want to create"
 "send" = "Send"
 "sendConfModalTitle" = "You're about to send"
+"sendDelayHint" = "Cooling-off delay in hours, 0 to disable"
+"sendDelayThresholdHint" = "Delay the sends above this amount (%s)"
 "sendInfo" = "Input or scan the destination wallet address and input the amount to send funds."
 "sending" = "Sending"
 "sendingAcct" = "Sending account"
@@ -542,6 +668,7 @@ const EN = `
 "setUpPrivacy" = "Using StakeShuffle increases the privacy of your wallet transactions."
 "setUpStakeShuffle" = "Set up StakeShuffle"
 "setupStartupPassword" = "Set up startup password"
+"sharesThresholdHint" = "Shares needed of total, e.g. 2-of-3"
 "signature" = "Signature"
 "signCopied" = "Signature copied"
 "signMessage" = "Sign message"
@@ -553,10 +680,18 @@ const EN = `
 "sourceWalletNotSynced" = "Source wallet is not synced"
 "spanish" = "Spanish"
 "spendableIn" = "Spendable in"
+"spendDelayed" = "This send is delayed by the spending policy of the wallet. Confirm it again after %s."
+"spendingLimitExceeded" = "This send exceeds the spending limit of the wallet"
+"spendingLimitHint" = "Maximum amount sent in the period (%s)"
 "spendingPassword" = "Spending passphrase"
 "spendingPasswordInfo" = "A spending password helps secure your wallet transactions."
 "spendingPasswordInfo2" = "This spending password is for the new wallet only"
 "spendingPasswordUpdated" = "Spending passphrase updated"
+"spendingPolicy" = "Spending policy"
+"spendingPolicyDesc" = "Limits the sends of this wallet. Sends to the wallet's own addresses are not limited. Changing the policy requires the spending passphrase."
+"spendingPolicySaved" = "Spending policy saved"
+"splitSeed" = "Split into shares"
+"splitSeedDesc" = "Split the seed into SLIP-39 shares. Any threshold of the shares restores the wallet, fewer reveal nothing about the seed."
 "stake" = "Stake"
 "stakeAge" = "Stake age"
 "staked" = "Staked"
@@ -572,12 +707,26 @@ const EN = `
 "status" = "Status"
 "step1" = "Step 1/2"
 "step2of2" = "Step 2/2"
+"stopScheduler" = "Stop scheduler"
 "submit" = "Submit"
 "summary" = "Summary"
 "sureToCancelMixer" = "Are you sure you want to cancel mixer action?"
 "sureToExitBackup" = "Are you sure you want to exit the seed backup process?"
 "sureToSafeStoreSeed" = "Be sure to store your seed phrase backup in a secure location."
+"swapMessageCopied" = "Swap message copied. Send it to your counterparty."
+"swapMessageHint" = "Paste a swap message or the path of a swap message file"
+"swapMessageImported" = "Swap message imported"
+"swapMessageSaved" = "Swap message saved to %s"
+"swapReceiveAmountHint" = "Amount you receive"
+"swapRefundableAt" = "Refundable after %s"
+"swapReportExported" = "Completed orders exported to %s"
+"swapSendAmountHint" = "Amount you send"
+"sweep" = "Sweep"
+"sweepFound" = "Found %d unspent outputs with %s in total. The fee is %s"
+"sweepPrivateKeys" = "Sweep private keys"
+"sweepPrivateKeysDesc" = "Move the funds of private keys or paper wallets into an account of this wallet. Enter the keys in WIF format, or paste the text of their QR codes, as QR codes cannot be scanned with a camera here. The keys only sign the sweeping transaction and are not saved."
 "sync" = "Sync"
+"syncBeforeSweep" = "The wallet must be synced to scan for funds"
 "syncCompTime" = "Est. sync completion time"
 "synced" = "Synced"
 "syncingProgress" = "Syncing progress"
@@ -601,6 +750,8 @@ const EN = `
 "timeLeft" = "%v left"
 "to" = "To"
 "token" = "Token:   %s"
+"tooManyAttempts" = "Too many failed attempts. Try again later"
+"tooManyAttemptsRetryAt" = "Too many failed attempts. Try again after %s"
 "total" = "Total"
 "totalAmount" = "Total Amount"
 "totalBalance" = "Total Balance"
@@ -632,6 +783,7 @@ const EN = `
 "underReview" = "Under Review"
 "unknown" = "Unknown"
 "unlock" = "Unlock"
+"unlockedKeys" = "What holds unlocked keys"
 "unlockWithPassword" = "Unlock with password"
 "unmined" = "Unmined"
 "unminedInfo" = "Broadcasted %v"
@@ -643,8 +795,13 @@ const EN = `
 "updatePreference" = "Update Preference"
 "updateVotePref" = "Update Voting Preference"
 "uptime" = "Uptime"
+"usdAggregate" = "USD (Median of all sources)"
 "usdBinance" = "USD (Binance)"
 "usdBittrex" = "USD (Bittrex)"
+"usdCoinbase" = "USD (Coinbase)"
+"usdCoinGecko" = "USD (CoinGecko)"
+"usdKraken" = "USD (Kraken)"
+"useBIP39Seed" = "Use a BIP39 seed phrase"
 "useMixer" = "How to use the mixer?"
 "userAgent" = "User agent"
 "userAgentDialogTitle" = "Set up user agent"
@@ -666,6 +823,7 @@ const EN = `
 "verifyMsgNote" = "Enter the address, signature, and message to verify:"
 "verifySeed" = "Verify Seed Phrase"
 "verifySeedInfo" = "Verify your seed phrase backup so you can recover your funds when needed."
+"verifyShares" = "Verify shares"
 "version" = "Version"
 "viewAllOrders" = "View all orders"
 "viewAppLog" = "View Application Log"
@@ -701,6 +859,8 @@ const EN = `
 "waitingForAuthor" = "Waiting for author to authorize voting"
 "waitingState" = "Waiting..."
 "walletCreated" = "Wallet created"
+"walletDBOK" = "No problems were found in the wallet"
+"walletDiagnosis" = "Wallet diagnosis"
 "walletDirectory" = "Wallet data directory"
 "walletExist" = "Wallet with name: %s already exist"
 "walletLengthError" = "Wallet name must be less than 20 characters"
@@ -712,6 +872,7 @@ const EN = `
 "walletRemoved" = "Wallet removed"
 "walletRemoveInfo" = "Make sure to have the seed phrase backed up before removing the wallet"
 "walletRenamed" = "Wallet renamed successfully"
+"walletRepaired" = "Wallet repaired"
 "walletRestored" = "Wallet restored"
 "walletRestoreMsg" = "You can restore this wallet from seed phrase after it is deleted."
 "wallets" = "Wallets"
@@ -720,20 +881,28 @@ const EN = `
 "walletStatus" = "Wallet Status:"
 "walletSyncing" = "Wallet is syncing, please wait"
 "walletToPurchaseFrom" = "Wallet to purchase from: %s"
+"walletUnlocked" = "Unlocked wallet"
 "warningVote" = "You cannot vote with a watch only wallet"
 "warningWatchWallet" = "You would be receiving to a read only wallet"
+"watchAddressesHint" = "To watch a list of addresses instead, enter them separated by spaces or commas. Address watch wallets cannot derive new addresses."
 "watchOnly" = "Watch-Only"
 "watchOnlyWalletImported" = "Watch only wallet imported"
 "watchOnlyWalletRemoveInfo" = "The watch-only wallet will be removed from your app"
 "watchOnlyWallets" = "Watch-only wallets"
+"weakPassphrase" = "The password is too easy to guess. Make it longer or add unrelated words"
 "webURL" = "Web URL"
 "weekAgo" = "%d week ago"
+"weeklyLimit" = "Weekly limit"
 "weeksAgo" = "%d weeks ago"
 "welcomeNote" = "Welcome to Cryptopower Wallet."
 "whatToCallWallet" = "What would you like to call your wallet?"
 "word" = "Word"
 "writeDownAll33Words" = "Write down all 33 words in the correct order."
+"writeDownAllWords" = "Write down all %d words in the correct order."
 "writeDownSeed" = "Write down seed phrase"
+"writeDownShare" = "Write down share %d of %d"
+"writeDownShareDesc" = "Write down the %d words of each share and store the shares in separate secure places. %d shares are needed to restore the wallet."
+"wroteAllShares" = "I have written down all the shares"
 "wroteAllWords" = "I have written down all 33 words"
 "xInputsConsumed" = "%d Inputs consumed"
 "xOutputCreated" = "%d Outputs created"
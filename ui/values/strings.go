@@ -119,7 +119,10 @@ const (
 	StrAbandoned                       = "abandoned"
 	StrAbout                           = "about"
 	StrAbstain                         = "abstain"
+	StrAcceptOffer                     = "acceptOffer"
 	StrAccount                         = "account"
+	StrAccountGapLimit                 = "accountGapLimit"
+	StrAccountGapLimitInputErr         = "accountGapLimitInputErr"
 	StrAccountList                     = "accountList"
 	StrAccountMixer                    = "accountMixer"
 	StrAcctCreated                     = "acctCreated"
@@ -128,6 +131,7 @@ const (
 	StrAcctNum                         = "acctNum"
 	StrAcctRenamed                     = "accRenamed"
 	StrAddAcctWarn                     = "addAcctWarn"
+	StrAddAlert                        = "addAlert"
 	StrAddDexServer                    = "addDexServer"
 	StrAddNewAccount                   = "addNewAccount"
 	StrAddress                         = "address"
@@ -135,13 +139,25 @@ const (
 	StrAddressDiscoveryInProgress      = "addressDiscoveryInProgress"
 	StrAddressDiscoveryStarted         = "addressDiscoveryStarted"
 	StrAddressDiscoveryStartedBody     = "addressDiscoveryStartedBody"
+	StrAddressWatch                    = "addressWatch"
+	StrAddressWatchNoNewAddress        = "addressWatchNoNewAddress"
 	StrAddrNotOwned                    = "addrNotOwned"
 	StrAddVSP                          = "addVSP"
 	StrAddWallet                       = "addWallet"
 	StrAdminToTriggerVoting            = "adminToTriggerVoting"
 	StrAgendas                         = "agendas"
 	StrAgo                             = "ago"
+	StrAlertLastTriggered              = "alertLastTriggered"
+	StrAlertPercentHint                = "alertPercentHint"
+	StrAlertPortfolioAbove             = "alertPortfolioAbove"
+	StrAlertPortfolioBelow             = "alertPortfolioBelow"
+	StrAlertPriceAbove                 = "alertPriceAbove"
+	StrAlertPriceBelow                 = "alertPriceBelow"
+	StrAlertPriceHint                  = "alertPriceHint"
+	StrAlertPriceMove                  = "alertPriceMove"
+	StrAlertValueHint                  = "alertValueHint"
 	StrAll                             = "all"
+	StrAllowedAddressesHint            = "allowedAddressesHint"
 	StrAllowSpendingFromUnmixedAccount = "allowSpendingFromUnmixedAccount"
 	StrAllowUnspendUnmixedAcct         = "allowUnspendUnmixedAcct"
 	StrAllTickets                      = "allTickets"
@@ -152,8 +168,14 @@ const (
 	StrAppTitle                        = "appTitle"
 	StrAppWallet                       = "appWallet"
 	StrAskedEnterSeedWords             = "askedEnterSeedWords"
+	StrAtomicSwaps                     = "atomicSwaps"
 	StrAuthorToAuthorizeVoting         = "authorToAuthorizeVoting"
+	StrAutoLock                        = "autoLock"
+	StrAutoLockDesc                    = "autoLockDesc"
+	StrAutoLockNeedsStartupPassword    = "autoLockNeedsStartupPassword"
 	StrAutomatic                       = "automatic"
+	StrAutoRedeem                      = "autoRedeem"
+	StrAutoRedeemSwapMsg               = "autoRedeemSwapMsg"
 	StrAutoSetUp                       = "autoSetUp"
 	StrAutoSync                        = "autoSync"
 	StrAutoTicketInfo                  = "autoTicketInfo"
@@ -163,9 +185,14 @@ const (
 	StrBackAndRename                   = "backAndRename"
 	StrBackStaking                     = "backStaking"
 	StrBackToWallets                   = "backToWallets"
+	StrBackupDBNow                     = "backupDBNow"
+	StrBackupFilePath                  = "backupFilePath"
 	StrBackupInfo                      = "backupInfo"
 	StrBackupLater                     = "backupLater"
+	StrBackupMetadata                  = "backupMetadata"
+	StrBackupMetadataDesc              = "backupMetadataDesc"
 	StrBackupNow                       = "backupNow"
+	StrBackupPassword                  = "backupPassword"
 	StrBackupSeedPhrase                = "backupSeedPhrase"
 	StrBackupWarning                   = "backupWarning"
 	StrBalance                         = "balance"
@@ -177,6 +204,9 @@ const (
 	StrBestBlockAge                    = "bestBlockAge"
 	StrBestBlocks                      = "bestBlocks"
 	StrBestBlockTimestamp              = "bestBlockTimestamp"
+	StrBestQuoteSelected               = "bestQuoteSelected"
+	StrBestRate                        = "bestRate"
+	StrBIP39Seed                       = "bip39Seed"
 	StrCurrencyConverterRate           = "currencyConverterRate"
 	StrBlockHeaderFetched              = "blockHeaderFetched"
 	StrBlockHeaderFetchedCount         = "blockHeaderFetchedCount"
@@ -198,6 +228,7 @@ const (
 	StrCheckGovernace                  = "checkGovernace"
 	StrCheckMixerStatus                = "checkMixerStatus"
 	StrCheckStatistics                 = "checkStatistics"
+	StrCheckWalletDB                   = "checkWalletDB"
 	StrCheckWalletLog                  = "checkWalletLog"
 	StrClear                           = "clear"
 	StrClearAll                        = "clearAll"
@@ -208,6 +239,7 @@ const (
 	StrComplete                        = "complete"
 	StrConfirm                         = "confirm"
 	StrConfirmations                   = "confirmations"
+	StrConfirmBackupPassword           = "confirmBackupPassword"
 	StrConfirmDexReset                 = "confirmDexReset"
 	StrConfirmed                       = "confirmed"
 	StrConfirmNewSpendingPassword      = "confirmNewSpendingPassword"
@@ -243,6 +275,7 @@ const (
 	StrCopy                            = "copy"
 	StrCopyBlockLink                   = "copyBlockLink"
 	StrCopyLink                        = "copyLink"
+	StrCopyMessage                     = "copyMessage"
 	StrCopySeed                        = "copyseed"
 	StrCost                            = "cost"
 	StrCreate                          = "create"
@@ -250,16 +283,26 @@ const (
 	StrCreateNewAccount                = "createNewAccount"
 	StrCreateNewOrder                  = "createNewOrder"
 	StrCreateNSetUpAccs                = "createNSetUpAccs"
+	StrCreateOffer                     = "createOffer"
 	StrCreateOrder                     = "createOrder"
 	StrCreateOrderPageInfo             = "createOrderPageInfo"
 	StrCreateStartupPassword           = "createStartupPassword"
 	StrCreateWallet                    = "createWallet"
 	StrCrossPlatform                   = "crossPlatform"
 	StrCrossPlatformSubtext            = "crossPlatformSubtext"
+	StrCurrencyBRL                     = "currencyBRL"
+	StrCurrencyCAD                     = "currencyCAD"
+	StrCurrencyEUR                     = "currencyEUR"
+	StrCurrencyGBP                     = "currencyGBP"
+	StrCurrencyINR                     = "currencyINR"
+	StrCurrencyJPY                     = "currencyJPY"
+	StrCurrencyNGN                     = "currencyNGN"
+	StrCurrencyUSD                     = "currencyUSD"
 	StrCurrentSpendingPassword         = "currentSpendingPassword"
 	StrCurrentStartupPass              = "currentStartupPass"
 	StrCurrentTotalBalance             = "currentTotalBalance"
 	StrCustomUserAgent                 = "CustomUserAgent"
+	StrDailyLimit                      = "dailyLimit"
 	StrDangerZone                      = "dangerZone"
 	StrDarkMode                        = "darkMode"
 	StrDateCreated                     = "dateCreated"
@@ -269,29 +312,46 @@ const (
 	StrDaysAgo                         = "daysAgo"
 	StrDaysToMiss                      = "daysToMiss"
 	StrDaysToVote                      = "daysToVote"
+	StrDBBackedUp                      = "dbBackedUp"
+	StrDBBackupDir                     = "dbBackupDir"
+	StrDBBackupRetention               = "dbBackupRetention"
+	StrDBBackups                       = "dbBackups"
+	StrDBRestoreStaged                 = "dbRestoreStaged"
 	StrDCRCaps                         = "dcrCaps"
 	StrDcrDex                          = "dcrDex"
 	StrDcrReceived                     = "dcrReceived"
 	StrDebug                           = "debug"
+	StrDeepRecovery                    = "deepRecovery"
+	StrDeepRecoveryDesc                = "deepRecoveryDesc"
+	StrDeepRecoveryFound               = "deepRecoveryFound"
+	StrDeepRecoveryNothingFound        = "deepRecoveryNothingFound"
+	StrDeepRecoveryPassHint            = "deepRecoveryPassHint"
 	StrDefault                         = "default"
 	StrDeleted                         = "delete"
 	StrDescriptionNote                 = "descriptionNote"
+	StrDescriptorsCopied               = "descriptorsCopied"
 	StrDestAddr                        = "destAddr"
 	StrDestination                     = "destination"
 	StrDestinationMissing              = "destinationMissing"
 	StrDestinationModalInfo            = "destinationModalInfo"
+	StrDestinationNotAllowed           = "destinationNotAllowed"
 	StrDestinationWalletNotSynced      = "destinationWalletNotSynced"
 	StrDex                             = "dex"
 	StrDexDataReset                    = "dexDataReset"
 	StrDexDataResetFalse               = "dexDataResetFalse"
 	StrDexResetInfo                    = "dexResetInfo"
 	StrDexStartupErr                   = "dexStartupErr"
+	StrDiagnose                        = "diagnose"
 	StrDisable                         = "disable"
 	StrDisabled                        = "disabled"
 	StrDisconnect                      = "disconnect"
 	StrDiscoverAddressUsage            = "discoverAddressUsage"
+	StrDiscoveredAccount               = "discoveredAccount"
+	StrDiscoveredNewAccount            = "discoveredNewAccount"
+	StrDiscoveringAddresses            = "discoveringAddresses"
 	StrDiscoveringWalletAddress        = "discoveringWalletAddress"
 	StrDiscussions                     = "discussions"
+	StrDisplayCurrency                 = "displayCurrency"
 	StrDocumentation                   = "documentation"
 	StrDone                            = "done"
 	StrDuration                        = "duration"
@@ -305,6 +365,7 @@ const (
 	StrEnterExtendedPubKey             = "enterXpubKey"
 	StrEnterHex                        = "enterHex"
 	StrEnterSeedPhrase                 = "enterSeedPhrase"
+	StrEnterShares                     = "enterShares"
 	StrEnterSpendingPassword           = "enterSpendingPassword"
 	StrEnterValidAddress               = "enterValidAddress"
 	StrEnterValidMsg                   = "enterValidMsg"
@@ -329,10 +390,13 @@ const (
 	StrExpiredOn                       = "expiredOn"
 	StrExpiresIn                       = "expiresIn"
 	StrExplorerURL                     = "explorerURL"
+	StrExportCSV                       = "exportCSV"
+	StrExportJSON                      = "exportJSON"
 	StrExtendedCopied                  = "extendedKeyCopied"
 	StrExtendedInfo                    = "extendedInfo"
 	StrExtendedKey                     = "extendedKey"
 	StrExtendedPubKey                  = "extendedPubKey"
+	StrExtendedPubKeyOrDescriptors     = "extendedPubKeyOrDescriptors"
 	StrExternal                        = "external"
 	StrFailed                          = "failed"
 	StrFee                             = "fee"
@@ -351,6 +415,9 @@ const (
 	StrFrequency                       = "frequency"
 	StrFrom                            = "from"
 	StrFunctionUnavailable             = "functionUnavailable"
+	StrFundContract                    = "fundContract"
+	StrFundsSwept                      = "fundsSwept"
+	StrFundSwapMsg                     = "fundSwapMsg"
 	StrGapLimit                        = "gapLimit"
 	StrGapLimitInputErr                = "gapLimitInputErr"
 	StrGeneral                         = "general"
@@ -400,10 +467,17 @@ const (
 	StrInvalidSignature                = "invalidSignature"
 	StrIntegratedExchange              = "integratedExchange"
 	StrIntegratedExchangeSubtext       = "integratedExchangeSubtext"
+	StrInvalidAllowedAddress           = "invalidAllowedAddress"
+	StrInvalidBlockHeight              = "invalidBlockHeight"
+	StrInvalidDBBackupRetention        = "invalidDBBackupRetention"
+	StrInvalidPrivateKey               = "invalidPrivateKey"
+	StrInvalidSendDelay                = "invalidSendDelay"
+	StrInvalidSharesThreshold          = "invalidSharesThreshold"
 	StrIPAddress                       = "ipAddress"
 	StrJustNow                         = "justNow"
 	StrKeepAppOpen                     = "keepAppOpen"
 	StrKeepInMind                      = "keepInMind"
+	StrKeepRunningWhenLocked           = "keepRunningWhenLocked"
 	StrKey                             = "key"
 	StrLabelSpendable                  = "labelSpendable"
 	StrLanguage                        = "language"
@@ -411,6 +485,9 @@ const (
 	StrLatestBlock                     = "latestBlock"
 	StrLicense                         = "license"
 	StrLifeSpan                        = "lifeSpan"
+	StrLimitAmountHint                 = "limitAmountHint"
+	StrLimitDeadlineHint               = "limitDeadlineHint"
+	StrLimitRateHint                   = "limitRateHint"
 	StrLive                            = "live"
 	StrLiveIn                          = "liveIn"
 	StrLiveInfo                        = "liveInfo"
@@ -420,9 +497,11 @@ const (
 	StrLoading                         = "loading"
 	StrLoadingPrice                    = "loadingPrice"
 	StrLoadingVSP                      = "loadingVSP"
+	StrLockAfterIdle                   = "lockAfterIdle"
 	StrLocked                          = "locked"
 	StrLockedByTickets                 = "lockedByTickets"
 	StrLockedIn                        = "lockedin"
+	StrLockOnSleep                     = "lockOnSleep"
 	StrLogLevel                        = "logLevel"
 	StrLogLevelCritical                = "logLevelCritical"
 	StrLogLevelDebug                   = "logLevelDebug"
@@ -436,11 +515,14 @@ const (
 	StrMaturity                        = "maturity"
 	StrMax                             = "max"
 	StrMessage                         = "message"
+	StrMetadataBackedUp                = "metadataBackedUp"
+	StrMetadataRestored                = "metadataRestored"
 	StrMinimumAssetType                = "minimumAssetType"
 	StrMinMax                          = "minMax"
 	StrMinuteAgo                       = "minuteAgo"
 	StrMinutes                         = "mins"
 	StrMinutesAgo                      = "minutesAgo"
+	StrMinutesShort                    = "minutesShort"
 	StrMissedOn                        = "missedOn"
 	StrMissedTickets                   = "missedTickets"
 	StrMix                             = "mix"
@@ -465,17 +547,21 @@ const (
 	StrMyAcct                          = "myAcct"
 	StrNConfirmations                  = "nConfirmations"
 	StrNetwork                         = "network"
+	StrNever                           = "never"
 	StrNeverSynced                     = "neverSynced"
 	StrNewest                          = "newest"
 	StrNewProposalUpdate               = "newProposalUpdate"
 	StrNewSpendingPassword             = "newSpendingPassword"
 	StrNewStartupPass                  = "newStartupPass"
+	StrNewSwapOffer                    = "newSwapOffer"
 	StrNewWallet                       = "newWallet"
 	StrNext                            = "next"
 	StrNo                              = "no"
 	StrNoActiveTickets                 = "noActiveTickets"
 	StrNoAgendaYet                     = "noAgendaYet"
+	StrNoAtomicSwaps                   = "noAtomicSwaps"
 	StrNoConnectedPeer                 = "noConnectedPeer"
+	StrNoDBBackup                      = "noDBBackup"
 	StrNoExchangeOnTestnet             = "noExchangeOnTestnet"
 	StrNoInternet                      = "noInternet"
 	StrNoMixable                       = "errNoMixable"
@@ -483,8 +569,10 @@ const (
 	StrNone                            = "none"
 	StrNoOrders                        = "noOrders"
 	StrNoPoliciesYet                   = "noPoliciesYet"
+	StrNoPriceAlerts                   = "noPriceAlerts"
 	StrNoProposals                     = "noProposal"
 	StrNoReward                        = "noReward"
+	StrNoSpendingLimit                 = "noSpendingLimit"
 	StrNoStaking                       = "noStaking"
 	StrNotAllowed                      = "notAllowed"
 	StrNotApplicable                   = "notApplicable"
@@ -493,12 +581,15 @@ const (
 	StrNotConnected                    = "notConnected"
 	StrNote                            = "note"
 	StrNotEnoughVotes                  = "notEnoughVotes"
+	StrNothingToSweep                  = "nothingToSweep"
 	StrNoTickets                       = "noTickets"
 	StrNotifications                   = "notifications"
 	StrNotOwned                        = "notOwned"
 	StrNoTransactions                  = "noTransactions"
 	StrNotSameAccoutMixUnmix           = "notSameAccoutMixUnmix"
 	StrNotSupported                    = "notSupported"
+	StrNoUnlockedKeys                  = "noUnlockedKeys"
+	StrNoUsableQuote                   = "noUsableQuote"
 	StrNoUTXOs                         = "noUTXOs"
 	StrNoValidAccountFound             = "noValidAccountFound"
 	StrnoValidWalletFound              = "noValidWalletFound"
@@ -522,10 +613,13 @@ const (
 	StrOrderSendingFrom                = "orderSendingFrom"
 	StrOrderSettingsSaved              = "orderSettingsSaved"
 	StrOrderSubmitted                  = "orderSubmitted"
+	StrOutputDescriptors               = "outputDescriptors"
 	StrOverview                        = "overview"
 	StrOwned                           = "owned"
 	StrPageWarningNotSync              = "pageWarningNotSync"
 	StrPageWarningSync                 = "pageWarningSync"
+	StrPassphraseMinLength             = "passphraseMinLength"
+	StrPassphraseTooShort              = "passphraseTooShort"
 	StrPasswordNotMatch                = "passwordNotMatch"
 	StrPasteSeedWords                  = "pasteSeedWords"
 	StrPeer                            = "peer"
@@ -534,13 +628,22 @@ const (
 	StrPending                         = "pending"
 	StrPercentageMixed                 = "percentageMixed"
 	StrPiKey                           = "piKey"
+	StrPINNotNumeric                   = "pinNotNumeric"
 	StrPolicySetSuccessful             = "policySetSuccessfully"
+	StrPortfolioAboveNotif             = "portfolioAboveNotif"
+	StrPortfolioBelowNotif             = "portfolioBelowNotif"
+	StrPortfolioValue                  = "portfolioValue"
+	StrPriceAboveNotif                 = "priceAboveNotif"
+	StrPriceAlerts                     = "priceAlerts"
+	StrPriceBelowNotif                 = "priceBelowNotif"
+	StrPriceMoveNotif                  = "priceMoveNotif"
 	StrPriority                        = "priority"
 	StrPrivacyInfo                     = "privacyInfo"
 	StrPrivacyModeActive               = "privacyModeActive"
 	StrPrivacyModeInfo                 = "privacyModeInfo"
 	StrPrivacyModeInfoDesc             = "privacyModeInfoDesc"
 	StrPrivacySettings                 = "privacySettings"
+	StrPrivateKeysHint                 = "privateKeysHint"
 	StrPropFetching                    = "propFetching"
 	StrPropNotif                       = "propNotif"
 	StrPropNotification                = "propNotification"
@@ -557,6 +660,7 @@ const (
 	StrRate                            = "rate"
 	StrReadyToMix                      = "readyToMix"
 	StrRebroadcast                     = "rebroadcast"
+	StrRebuildWalletDesc               = "rebuildWalletDesc"
 	StrReceive                         = "receive"
 	StrReceived                        = "received"
 	StrReceiveInfo                     = "receiveInfo"
@@ -565,8 +669,12 @@ const (
 	StrRecentOrders                    = "recentOrders"
 	StrRecentProposals                 = "recentProposals"
 	StrRecentTransactions              = "recentTransactions"
+	StrRecommendedRepair               = "recommendedRepair"
 	StrReconnect                       = "reconnect"
+	StrRedeem                          = "redeem"
+	StrRedeemSwapMsg                   = "redeemSwapMsg"
 	StrRefresh                         = "refresh"
+	StrRefund                          = "refund"
 	StrRejected                        = "rejected"
 	StrRemove                          = "remove"
 	StrRemovePeer                      = "removePeer"
@@ -578,6 +686,11 @@ const (
 	StrRename                          = "rename"
 	StrRenameAcct                      = "renameAcct"
 	StrRenameWalletSheetTitle          = "renameWalletSheetTitle"
+	StrRepair                          = "repair"
+	StrRepairOnRestart                 = "repairOnRestart"
+	StrRepairRebuild                   = "repairRebuild"
+	StrRepairReindex                   = "repairReindex"
+	StrRepairRescan                    = "repairRescan"
 	StrRepublished                     = "republished"
 	StrRescan                          = "rescan"
 	StrRescanBlockchain                = "rescanBlockchain"
@@ -586,10 +699,15 @@ const (
 	StrRescanningHeaders               = "rescanningHeaders"
 	StrRescanProgressNotification      = "rescanProgressNotification"
 	StrRestore                         = "restore"
+	StrRestoreDBBackup                 = "restoreDBBackup"
+	StrRestoreDBBackupDesc             = "restoreDBBackupDesc"
 	StrRestoreExistingWallet           = "restoreExistingWallet"
+	StrRestoreMetadata                 = "restoreMetadata"
 	StrRestoreWallet                   = "restoreWallet"
 	StrRestoreWithHex                  = "restoreWithHex"
 	StrResumeAccountDiscoveryTitle     = "resumeAccountDiscoveryTitle"
+	StrResumeScheduler                 = "resumeScheduler"
+	StrResumeSchedulerMsg              = "resumeSchedulerMsg"
 	StrRetry                           = "retry"
 	StrRevocation                      = "revocation"
 	StrRevoke                          = "revoke"
@@ -600,7 +718,12 @@ const (
 	StrReward                          = "reward"
 	StrRewardsEarned                   = "rewardsEarned"
 	StrSave                            = "save"
+	StrSaveMessage                     = "saveMessage"
+	StrScanForFunds                    = "scanForFunds"
+	StrScanFromHeightHint              = "scanFromHeightHint"
+	StrScanningBlock                   = "scanningBlock"
 	StrScheduler                       = "scheduler"
+	StrSchedulerJob                    = "schedulerJob"
 	StrSchedulerRunning                = "schedulerRunning"
 	StrSearch                          = "search"
 	StrSeconds                         = "secs"
@@ -610,6 +733,7 @@ const (
 	StrSeeAll                          = "seeAll"
 	StrSeedAlreadyExist                = "seedAlreadyExist"
 	StrSeedHex                         = "seedHex"
+	StrSeedPassphrase                  = "seedPassphrase"
 	StrSeedPhraseToRestore             = "seedPhraseToRestore"
 	StrSeedPhraseVerified              = "seedPhraseVerified"
 	StrSeedValidationFailed            = "seedValidationFailed"
@@ -633,6 +757,8 @@ const (
 	StrSelectWalletType                = "selectWalletType"
 	StrSend                            = "send"
 	StrSendConfModalTitle              = "sendConfModalTitle"
+	StrSendDelayHint                   = "sendDelayHint"
+	StrSendDelayThresholdHint          = "sendDelayThresholdHint"
 	StrSendInfo                        = "sendInfo"
 	StrSending                         = "sending"
 	StrSendingAcct                     = "sendingAcct"
@@ -652,6 +778,7 @@ const (
 	StrSetUpPrivacy                    = "setUpPrivacy"
 	StrSetupStakeShuffle               = "setUpStakeShuffle"
 	StrSetupStartupPassword            = "setupStartupPassword"
+	StrSharesThresholdHint             = "sharesThresholdHint"
 	StrSignature                       = "signature"
 	StrSignCopied                      = "signCopied"
 	StrSignMessage                     = "signMessage"
@@ -663,10 +790,18 @@ const (
 	StrSourceWalletNotSynced           = "sourceWalletNotSynced"
 	StrSpanish                         = "spanish"
 	StrSpendableIn                     = "spendableIn"
+	StrSpendDelayed                    = "spendDelayed"
+	StrSpendingLimitExceeded           = "spendingLimitExceeded"
+	StrSpendingLimitHint               = "spendingLimitHint"
 	StrSpendingPassword                = "spendingPassword"
 	StrSpendingPasswordInfo            = "spendingPasswordInfo"
 	StrSpendingPasswordInfo2           = "spendingPasswordInfo2"
 	StrSpendingPasswordUpdated         = "spendingPasswordUpdated"
+	StrSpendingPolicy                  = "spendingPolicy"
+	StrSpendingPolicyDesc              = "spendingPolicyDesc"
+	StrSpendingPolicySaved             = "spendingPolicySaved"
+	StrSplitSeed                       = "splitSeed"
+	StrSplitSeedDesc                   = "splitSeedDesc"
 	StrStake                           = "stake"
 	StrStakeAge                        = "stakeAge"
 	StrStaked                          = "staked"
@@ -682,12 +817,26 @@ const (
 	StrStatus                          = "status"
 	StrStep1                           = "step1"
 	StrStep2of2                        = "step2of2"
+	StrStopScheduler                   = "stopScheduler"
 	StrSubmit                          = "submit"
 	StrSummary                         = "summary"
 	StrSureToCancelMixer               = "sureToCancelMixer"
 	StrSureToExitBackup                = "sureToExitBackup"
 	StrSureToSafeStoreSeed             = "sureToSafeStoreSeed"
+	StrSwapMessageCopied               = "swapMessageCopied"
+	StrSwapMessageHint                 = "swapMessageHint"
+	StrSwapMessageImported             = "swapMessageImported"
+	StrSwapMessageSaved                = "swapMessageSaved"
+	StrSwapReceiveAmountHint           = "swapReceiveAmountHint"
+	StrSwapRefundableAt                = "swapRefundableAt"
+	StrSwapReportExported              = "swapReportExported"
+	StrSwapSendAmountHint              = "swapSendAmountHint"
+	StrSweep                           = "sweep"
+	StrSweepFound                      = "sweepFound"
+	StrSweepPrivateKeys                = "sweepPrivateKeys"
+	StrSweepPrivateKeysDesc            = "sweepPrivateKeysDesc"
 	StrSync                            = "sync"
+	StrSyncBeforeSweep                 = "syncBeforeSweep"
 	StrSyncCompTime                    = "syncCompTime"
 	StrSynced                          = "synced"
 	StrSyncingProgress                 = "syncingProgress"
@@ -711,6 +860,8 @@ const (
 	StrTimeLeft                        = "timeLeft"
 	StrTo                              = "to"
 	StrToken                           = "token"
+	StrTooManyAttempts                 = "tooManyAttempts"
+	StrTooManyAttemptsRetryAt          = "tooManyAttemptsRetryAt"
 	StrTotal                           = "total"
 	StrTotalAmount                     = "totalAmount"
 	StrTotalBalance                    = "totalBalance"
@@ -743,6 +894,7 @@ const (
 	StrUnderReview                     = "underReview"
 	StrUnknown                         = "unknown"
 	StrUnlock                          = "unlock"
+	StrUnlockedKeys                    = "unlockedKeys"
 	StrUnlockWithPassword              = "unlockWithPassword"
 	StrUnminedInfo                     = "unminedInfo"
 	StrUnmixed                         = "unmixed"
@@ -753,8 +905,13 @@ const (
 	StrUpdatePreference                = "updatePreference"
 	StrUpdatevotePref                  = "updateVotePref"
 	StrUptime                          = "uptime"
+	StrUsdAggregate                    = "usdAggregate"
 	StrUsdBinance                      = "usdBinance"
 	StrUsdBittrex                      = "usdBittrex"
+	StrUsdCoinbase                     = "usdCoinbase"
+	StrUsdCoinGecko                    = "usdCoinGecko"
+	StrUsdKraken                       = "usdKraken"
+	StrUseBIP39Seed                    = "useBIP39Seed"
 	StrUseMixer                        = "useMixer"
 	StrUserAgent                       = "userAgent"
 	StrUserAgentDialogTitle            = "userAgentDialogTitle"
@@ -776,6 +933,7 @@ const (
 	StrVerifyMsgNote                   = "verifyMsgNote"
 	StrVerifySeed                      = "verifySeed"
 	StrVerifySeedInfo                  = "verifySeedInfo"
+	StrVerifyShares                    = "verifyShares"
 	StrVersion                         = "version"
 	StrViewAllOrders                   = "viewAllOrders"
 	StrViewAppLog                      = "viewAppLog"
@@ -811,6 +969,8 @@ const (
 	StrWaitingForAdmin                 = "waitingForAdmin"
 	StrWaitingState                    = "waitingState"
 	StrWalletCreated                   = "walletCreated"
+	StrWalletDBOK                      = "walletDBOK"
+	StrWalletDiagnosis                 = "walletDiagnosis"
 	StrWalletDirectory                 = "walletDirectory"
 	StrWalletExist                     = "walletExist"
 	StrWalletLog                       = "walletLog"
@@ -822,6 +982,7 @@ const (
 	StrWalletRemoved                   = "walletRemoved"
 	StrWalletRemoveInfo                = "walletRemoveInfo"
 	StrWalletRenamed                   = "walletRenamed"
+	StrWalletRepaired                  = "walletRepaired"
 	StrWalletRestored                  = "walletRestored"
 	StrWalletRestoreMsg                = "walletRestoreMsg"
 	StrWallets                         = "wallets"
@@ -830,20 +991,28 @@ const (
 	StrWalletStatus                    = "walletStatus"
 	StrWalletSyncing                   = "walletSyncing"
 	StrWalletToPurchaseFrom            = "walletToPurchaseFrom"
+	StrWalletUnlocked                  = "walletUnlocked"
 	StrWarningVote                     = "warningVote"
 	StrWarningWatchWallet              = "warningWatchWallet"
+	StrWatchAddressesHint              = "watchAddressesHint"
 	StrWatchOnly                       = "watchOnly"
 	StrWatchOnlyWalletImported         = "watchOnlyWalletImported"
 	StrWatchOnlyWalletRemoveInfo       = "watchOnlyWalletRemoveInfo"
 	StrWatchOnlyWallets                = "watchOnlyWallets"
+	StrWeakPassphrase                  = "weakPassphrase"
 	StrWebURL                          = "webURL"
 	StrWeekAgo                         = "weekAgo"
+	StrWeeklyLimit                     = "weeklyLimit"
 	StrWeeksAgo                        = "weeksAgo"
 	StrWelcomeNote                     = "welcomeNote"
 	StrWhatToCallWallet                = "whatToCallWallet"
 	StrWord                            = "word"
 	StrWriteDownAll33Words             = "writeDownAll33Words"
+	StrWriteDownAllWords               = "writeDownAllWords"
 	StrWriteDownSeed                   = "writeDownSeed"
+	StrWriteDownShare                  = "writeDownShare"
+	StrWriteDownShareDesc              = "writeDownShareDesc"
+	StrWroteAllShares                  = "wroteAllShares"
 	StrWroteAllWords                   = "wroteAllWords"
 	StrXInputsConsumed                 = "xInputsConsumed"
 	StrXOutputCreated                  = "xOutputCreated"
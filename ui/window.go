@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"runtime"
 	"syscall"
+	"time"
 
 	giouiApp "gioui.org/app"
 	"gioui.org/io/key"
@@ -42,6 +43,11 @@ type Window struct {
 
 	walletAcctMixerStatus chan *wallet.AccountMixer
 
+	// lastActivity is when the user last interacted with the window and
+	// lastLockCheck when the idle time of the app was last checked.
+	lastActivity  time.Time
+	lastLockCheck time.Time
+
 	// Quit channel used to trigger background process to begin implementing the
 	// shutdown protocol.
 	Quit chan struct{}
@@ -81,6 +87,7 @@ func CreateWindow(wal *wallet.Wallet) (*Window, error) {
 		navigator:             app.NewSimpleWindowNavigator(giouiWindow.Invalidate),
 		wallet:                wal,
 		walletAcctMixerStatus: make(chan *wallet.AccountMixer),
+		lastActivity:          time.Now(),
 		Quit:                  make(chan struct{}, 1),
 		IsShutdown:            make(chan struct{}, 1),
 	}
@@ -187,12 +194,19 @@ func (win *Window) HandleEvents() {
 		win.Quit <- struct{}{}
 	}
 
+	autoLockTicker := time.NewTicker(autoLockCheckInterval)
+	defer autoLockTicker.Stop()
+
 	for {
-		// Select either the os interrupt or the window event, whichever becomes
-		// ready first.
+		// Select either the os interrupt, the window event or the auto-lock
+		// check, whichever becomes ready first.
 		select {
 		case <-done:
 			displayShutdownPage()
+		case <-autoLockTicker.C:
+			if !isShuttingDown {
+				win.checkAutoLock()
+			}
 		case <-win.IsShutdown:
 			// backend processes shutdown is complete, exit UI process too.
 			return
@@ -206,6 +220,11 @@ func (win *Window) HandleEvents() {
 				ops := win.handleFrameEvent(evt)
 				evt.Frame(ops)
 
+			case system.StageEvent:
+				if !isShuttingDown {
+					win.handleStageEvent(evt)
+				}
+
 			default:
 				log.Tracef("Unhandled window event %v\n", e)
 			}
@@ -224,6 +243,7 @@ func (win *Window) handleFrameEvent(evt system.FrameEvent) *op.Ops {
 		win.navigator.Display(page.NewStartPage(win.load))
 
 	default:
+		win.recordActivity(evt)
 		// The app window may have received some user interaction such as key
 		// presses, a button click, etc which triggered this FrameEvent. Handle
 		// such interactions before re-displaying the UI components. This
@@ -255,6 +275,7 @@ func (win *Window) handleRelevantKeyPresses(evt system.FrameEvent) {
 		}
 		for _, event := range evt.Queue.Events(tag) {
 			if keyEvent, isKeyEvent := event.(key.Event); isKeyEvent && keyEvent.State == key.Press {
+				win.lastActivity = time.Now()
 				handler.HandleKeyPress(&keyEvent)
 			}
 		}
@@ -307,6 +328,7 @@ func (win *Window) prepareToDisplayUI(evt system.FrameEvent) *op.Ops {
 		topModalLayout,
 		layout.Stacked(win.load.Toast.Layout),
 	)
+	win.addActivityInputOp(ops, evt.Size)
 
 	return ops
 }